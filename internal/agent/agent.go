@@ -0,0 +1,170 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+)
+
+// CommandExecutor abstracts exec.Command for testing.
+type CommandExecutor interface {
+	CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd
+}
+
+// DefaultExecutor uses the real exec.CommandContext.
+type DefaultExecutor struct{}
+
+// CommandContext creates a new exec.Cmd with the given context.
+func (e *DefaultExecutor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, name, args...)
+}
+
+// Options configures a CommandAgent.
+type Options struct {
+	// Stream receives the agent's stdout as it is produced (optional).
+	Stream io.Writer
+
+	// Executor for command creation (for testing).
+	Executor CommandExecutor
+}
+
+// CommandAgent implements loop.ClaudeClient by running an arbitrary CLI agent.
+type CommandAgent struct {
+	name   string
+	config *Config
+	parser OutputParser
+	opts   *Options
+}
+
+// NewCommandAgent creates an agent from a validated config.
+// If opts is nil, output is not streamed and the default executor is used.
+func NewCommandAgent(name string, config *Config, opts *Options) *CommandAgent {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if opts.Executor == nil {
+		opts.Executor = &DefaultExecutor{}
+	}
+	return &CommandAgent{
+		name:   name,
+		config: config,
+		parser: NewParser(config),
+		opts:   opts,
+	}
+}
+
+// Name returns the agent's name from the agents file.
+func (a *CommandAgent) Name() string {
+	return a.name
+}
+
+// Execute implements loop.ClaudeClient.
+// It runs the agent command with the prompt and parses its stdout.
+func (a *CommandAgent) Execute(ctx context.Context, prompt string) (*loop.IterationResult, error) {
+	startTime := time.Now()
+
+	if a.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.config.Timeout)
+		defer cancel()
+	}
+
+	args, cleanup, err := a.buildArgs(prompt)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	cmd := a.opts.Executor.CommandContext(ctx, a.config.Command, args...)
+	if a.config.Dir != "" {
+		cmd.Dir = a.config.Dir
+	}
+	if len(a.config.Env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range a.config.Env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+	if a.config.Prompt == PromptModeStdin {
+		cmd.Stdin = strings.NewReader(prompt)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	if a.opts.Stream != nil {
+		cmd.Stdout = io.MultiWriter(&stdout, a.opts.Stream)
+	}
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, &AgentError{Agent: a.name, Message: "timed out after " + a.config.Timeout.String(), Err: err}
+		}
+		return nil, &AgentError{
+			Agent:   a.name,
+			Message: "command failed",
+			Stderr:  strings.TrimSpace(stderr.String()),
+			Err:     err,
+		}
+	}
+
+	parsed := a.parser.Parse(stdout.String())
+	return &loop.IterationResult{
+		Output:       parsed.Text,
+		Cost:         parsed.Cost,
+		Duration:     time.Since(startTime),
+		InputTokens:  parsed.InputTokens,
+		OutputTokens: parsed.OutputTokens,
+	}, nil
+}
+
+// buildArgs substitutes the prompt into the configured arguments.
+// The returned cleanup removes any temporary prompt file.
+func (a *CommandAgent) buildArgs(prompt string) ([]string, func(), error) {
+	args := make([]string, len(a.config.Args))
+	copy(args, a.config.Args)
+	cleanup := func() {}
+
+	switch a.config.Prompt {
+	case PromptModeArgv:
+		args = substitute(args, PlaceholderPrompt, prompt)
+
+	case PromptModeFile:
+		f, err := os.CreateTemp("", "claude-loop-prompt-*.md")
+		if err != nil {
+			return nil, cleanup, &AgentError{Agent: a.name, Message: "failed to create prompt file", Err: err}
+		}
+		cleanup = func() { os.Remove(f.Name()) }
+		_, err = f.WriteString(prompt)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			cleanup()
+			return nil, func() {}, &AgentError{Agent: a.name, Message: "failed to write prompt file", Err: err}
+		}
+		args = substitute(args, PlaceholderPromptFile, f.Name())
+	}
+	return args, cleanup, nil
+}
+
+// substitute replaces placeholder in args with value, or appends value if no argument contains it.
+func substitute(args []string, placeholder, value string) []string {
+	found := false
+	for i, arg := range args {
+		if strings.Contains(arg, placeholder) {
+			args[i] = strings.ReplaceAll(arg, placeholder, value)
+			found = true
+		}
+	}
+	if !found {
+		args = append(args, value)
+	}
+	return args
+}
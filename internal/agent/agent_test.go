@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Compile-time check that CommandAgent implements loop.ClaudeClient.
+var _ loop.ClaudeClient = (*CommandAgent)(nil)
+
+func newTestAgent(t *testing.T, cfg *Config, opts *Options) *CommandAgent {
+	t.Helper()
+	require.NoError(t, cfg.Validate("test"))
+	return NewCommandAgent("test", cfg, opts)
+}
+
+func TestCommandAgent_PromptModes(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+	}{
+		{"argv placeholder", &Config{Command: "sh", Args: []string{"-c", `printf '%s' "$1"`, "_", "{prompt}"}}},
+		{"argv appended", &Config{Command: "sh", Args: []string{"-c", `printf '%s' "$1"`, "_"}}},
+		{"stdin", &Config{Command: "cat", Prompt: PromptModeStdin}},
+		{"file placeholder", &Config{Command: "cat", Args: []string{"{prompt_file}"}, Prompt: PromptModeFile}},
+		{"file appended", &Config{Command: "cat", Prompt: PromptModeFile}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestAgent(t, tt.cfg, nil).Execute(context.Background(), "fix the bug")
+			require.NoError(t, err)
+			assert.Equal(t, "fix the bug", result.Output)
+			assert.Greater(t, result.Duration, time.Duration(0))
+		})
+	}
+}
+
+func TestCommandAgent_ParsesOutput(t *testing.T) {
+	cfg := &Config{
+		Command:   "printf",
+		Args:      []string{`{"type":"text","text":"done"}\n{"usage":{"cost":0.25,"in":100,"out":7}}\n`},
+		Output:    OutputJSONL,
+		CostField: "usage.cost", InputTokensField: "usage.in", OutputTokensField: "usage.out",
+	}
+
+	result, err := newTestAgent(t, cfg, nil).Execute(context.Background(), "ignored")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.Output)
+	assert.Equal(t, 0.25, result.Cost)
+	assert.Equal(t, 100, result.InputTokens)
+	assert.Equal(t, 7, result.OutputTokens)
+}
+
+func TestCommandAgent_EnvAndStream(t *testing.T) {
+	var stream bytes.Buffer
+	cfg := &Config{
+		Command: "sh",
+		Args:    []string{"-c", `printf '%s' "$GREETING"`},
+		Prompt:  PromptModeStdin,
+		Env:     map[string]string{"GREETING": "hello"},
+		Dir:     t.TempDir(),
+	}
+
+	result, err := newTestAgent(t, cfg, &Options{Stream: &stream}).Execute(context.Background(), "p")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", result.Output)
+	assert.Equal(t, "hello", stream.String())
+}
+
+func TestCommandAgent_Errors(t *testing.T) {
+	t.Run("non-zero exit", func(t *testing.T) {
+		cfg := &Config{Command: "sh", Args: []string{"-c", "echo oops >&2; exit 3"}, Prompt: PromptModeStdin}
+
+		_, err := newTestAgent(t, cfg, nil).Execute(context.Background(), "p")
+		require.Error(t, err)
+		assert.True(t, IsAgentError(err))
+		assert.Contains(t, err.Error(), "agent test: command failed")
+		assert.Contains(t, err.Error(), "oops")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		cfg := &Config{Command: "sleep", Args: []string{"5"}, Prompt: PromptModeStdin, Timeout: 50 * time.Millisecond}
+
+		_, err := newTestAgent(t, cfg, nil).Execute(context.Background(), "p")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out after 50ms")
+	})
+
+	t.Run("missing command", func(t *testing.T) {
+		cfg := &Config{Command: "definitely-not-a-real-agent-binary"}
+
+		_, err := newTestAgent(t, cfg, nil).Execute(context.Background(), "p")
+		require.Error(t, err)
+		assert.True(t, IsAgentError(err))
+	})
+}
+
+func TestSubstitute(t *testing.T) {
+	assert.Equal(t, []string{"--message=hi", "-y"}, substitute([]string{"--message={prompt}", "-y"}, PlaceholderPrompt, "hi"))
+	assert.Equal(t, []string{"-y", "hi"}, substitute([]string{"-y"}, PlaceholderPrompt, "hi"))
+}
@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AgentError represents a misconfigured or failed command agent.
+type AgentError struct {
+	Agent   string // Agent name, or the agents file path for load errors
+	Message string
+	Stderr  string // stderr output from the agent, if any
+	Err     error
+}
+
+func (e *AgentError) Error() string {
+	msg := fmt.Sprintf("agent %s: %s", e.Agent, e.Message)
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+	if e.Stderr != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Stderr)
+	}
+	return msg
+}
+
+func (e *AgentError) Unwrap() error {
+	return e.Err
+}
+
+// IsAgentError checks if an error is an AgentError.
+func IsAgentError(err error) bool {
+	var ae *AgentError
+	return errors.As(err, &ae)
+}
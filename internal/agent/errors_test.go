@@ -0,0 +1,22 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgentError(t *testing.T) {
+	inner := errors.New("exit status 1")
+
+	assert.Equal(t, "agent aider: command is required", (&AgentError{Agent: "aider", Message: "command is required"}).Error())
+	assert.Equal(t, "agent aider: command failed: exit status 1: boom",
+		(&AgentError{Agent: "aider", Message: "command failed", Stderr: "boom", Err: inner}).Error())
+
+	wrapped := fmt.Errorf("iteration: %w", &AgentError{Agent: "a", Message: "x", Err: inner})
+	assert.True(t, IsAgentError(wrapped))
+	assert.ErrorIs(t, wrapped, inner)
+	assert.False(t, IsAgentError(inner))
+}
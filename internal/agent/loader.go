@@ -0,0 +1,103 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFile reads agent definitions from path.
+// A missing file yields an empty set, so only the built-in claude agent is available.
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &File{Agents: map[string]*Config{}, path: path}, nil
+		}
+		return nil, &AgentError{Agent: path, Message: "failed to read agents file", Err: err}
+	}
+
+	f := &File{path: path}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, &AgentError{Agent: path, Message: "invalid YAML syntax", Err: err}
+	}
+	if f.Agents == nil {
+		f.Agents = map[string]*Config{}
+	}
+
+	for _, name := range f.Names() {
+		if name == BuiltinClaude {
+			return nil, &AgentError{Agent: name, Message: "name is reserved for the built-in claude backend"}
+		}
+		if err := f.Agents[name].Validate(name); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// Names returns the defined agent names, sorted.
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.Agents))
+	for name := range f.Agents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the agent named name.
+func (f *File) Get(name string) (*Config, error) {
+	cfg, ok := f.Agents[name]
+	if !ok {
+		return nil, &AgentError{Agent: name, Message: fmt.Sprintf("not defined in %s", f.path)}
+	}
+	return cfg, nil
+}
+
+// Validate fills defaults and checks the configuration for the agent called name.
+func (c *Config) Validate(name string) error {
+	if c == nil {
+		return &AgentError{Agent: name, Message: "empty definition"}
+	}
+	if c.Command == "" {
+		return &AgentError{Agent: name, Message: "command is required"}
+	}
+
+	if c.Prompt == "" {
+		c.Prompt = PromptModeArgv
+	}
+	switch c.Prompt {
+	case PromptModeArgv, PromptModeStdin, PromptModeFile:
+	default:
+		return &AgentError{Agent: name, Message: fmt.Sprintf("invalid prompt mode %q (valid: argv, stdin, file)", c.Prompt)}
+	}
+
+	if c.Output == "" {
+		c.Output = OutputText
+	}
+	switch c.Output {
+	case OutputText, OutputJSONL:
+	case OutputRegex:
+		if c.CostPattern == "" {
+			return &AgentError{Agent: name, Message: "cost_pattern is required for regex output"}
+		}
+		re, err := regexp.Compile(c.CostPattern)
+		if err != nil {
+			return &AgentError{Agent: name, Message: "invalid cost_pattern", Err: err}
+		}
+		if re.NumSubexp() < 1 {
+			return &AgentError{Agent: name, Message: "cost_pattern needs a capture group for the cost"}
+		}
+	default:
+		return &AgentError{Agent: name, Message: fmt.Sprintf("invalid output format %q (valid: text, jsonl, regex)", c.Output)}
+	}
+
+	if c.Output == OutputJSONL && c.TextField == "" {
+		c.TextField = "text"
+	}
+	return nil
+}
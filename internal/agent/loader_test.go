@@ -0,0 +1,85 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAgentsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "agents.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writeAgentsFile(t, `
+agents:
+  aider:
+    command: aider
+    args: ["--message", "{prompt}", "--yes"]
+    output: regex
+    cost_pattern: 'Cost: \$([0-9.]+)'
+    timeout: 30m
+  local:
+    command: ollama-agent
+    prompt: stdin
+    output: jsonl
+    cost_field: usage.cost
+`)
+
+	f, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aider", "local"}, f.Names())
+	assert.Equal(t, path, f.Path())
+
+	aider, err := f.Get("aider")
+	require.NoError(t, err)
+	assert.Equal(t, PromptModeArgv, aider.Prompt)
+	assert.Equal(t, 30*time.Minute, aider.Timeout)
+
+	local, err := f.Get("local")
+	require.NoError(t, err)
+	assert.Equal(t, "text", local.TextField)
+
+	_, err = f.Get("missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "agent missing: not defined in "+path)
+}
+
+func TestLoadFile_Missing(t *testing.T) {
+	f, err := LoadFile(filepath.Join(t.TempDir(), "agents.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, f.Names())
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"bad yaml", "agents: [", "invalid YAML syntax"},
+		{"reserved name", "agents:\n  claude:\n    command: x\n", "reserved"},
+		{"missing command", "agents:\n  a:\n    args: [x]\n", "command is required"},
+		{"empty definition", "agents:\n  a:\n", "empty definition"},
+		{"bad prompt mode", "agents:\n  a:\n    command: x\n    prompt: pipe\n", `invalid prompt mode "pipe"`},
+		{"bad output", "agents:\n  a:\n    command: x\n    output: xml\n", `invalid output format "xml"`},
+		{"regex without pattern", "agents:\n  a:\n    command: x\n    output: regex\n", "cost_pattern is required"},
+		{"regex without group", "agents:\n  a:\n    command: x\n    output: regex\n    cost_pattern: 'cost'\n", "capture group"},
+		{"regex invalid", "agents:\n  a:\n    command: x\n    output: regex\n    cost_pattern: '('\n", "invalid cost_pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFile(writeAgentsFile(t, tt.content))
+			require.Error(t, err)
+			assert.True(t, IsAgentError(err))
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package agent
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ParsedOutput is what a parser extracts from an agent's stdout.
+type ParsedOutput struct {
+	Text         string
+	Cost         float64
+	InputTokens  int
+	OutputTokens int
+}
+
+// OutputParser extracts text and cost from an agent's stdout.
+type OutputParser interface {
+	Parse(stdout string) *ParsedOutput
+}
+
+// NewParser returns the parser selected by cfg.Output.
+// cfg must have been validated.
+func NewParser(cfg *Config) OutputParser {
+	switch cfg.Output {
+	case OutputJSONL:
+		return &JSONLParser{
+			TextField:         cfg.TextField,
+			CostField:         cfg.CostField,
+			InputTokensField:  cfg.InputTokensField,
+			OutputTokensField: cfg.OutputTokensField,
+		}
+	case OutputRegex:
+		return &RegexParser{CostPattern: regexp.MustCompile(cfg.CostPattern)}
+	default:
+		return &TextParser{}
+	}
+}
+
+// TextParser treats all of stdout as the output.
+type TextParser struct{}
+
+// Parse returns stdout unchanged with unknown cost.
+func (p *TextParser) Parse(stdout string) *ParsedOutput {
+	return &ParsedOutput{Text: stdout}
+}
+
+// RegexParser treats all of stdout as the output and captures the cost with a pattern.
+type RegexParser struct {
+	CostPattern *regexp.Regexp
+}
+
+// Parse returns stdout with the cost from the last match of CostPattern.
+func (p *RegexParser) Parse(stdout string) *ParsedOutput {
+	out := &ParsedOutput{Text: stdout}
+	matches := p.CostPattern.FindAllStringSubmatch(stdout, -1)
+	if len(matches) > 0 {
+		last := matches[len(matches)-1]
+		if cost, err := strconv.ParseFloat(strings.ReplaceAll(last[1], ",", ""), 64); err == nil {
+			out.Cost = cost
+		}
+	}
+	return out
+}
+
+// JSONLParser reads one JSON object per line.
+// Text fields from all lines are concatenated; numeric fields take the last value seen.
+// Lines that are not JSON objects are skipped.
+type JSONLParser struct {
+	TextField         string
+	CostField         string
+	InputTokensField  string
+	OutputTokensField string
+}
+
+// Parse extracts the configured fields from each line.
+func (p *JSONLParser) Parse(stdout string) *ParsedOutput {
+	out := &ParsedOutput{}
+	var text strings.Builder
+
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(line), &obj); err != nil {
+			continue
+		}
+
+		if s, ok := lookup(obj, p.TextField).(string); ok {
+			text.WriteString(s)
+		}
+		if v, ok := lookup(obj, p.CostField).(float64); ok {
+			out.Cost = v
+		}
+		if v, ok := lookup(obj, p.InputTokensField).(float64); ok {
+			out.InputTokens = int(v)
+		}
+		if v, ok := lookup(obj, p.OutputTokensField).(float64); ok {
+			out.OutputTokens = int(v)
+		}
+	}
+
+	out.Text = text.String()
+	return out
+}
+
+// lookup follows a dotted path such as "usage.cost" through nested objects.
+// Returns nil if path is empty or any segment is missing.
+func lookup(obj map[string]any, path string) any {
+	if path == "" {
+		return nil
+	}
+	var current any = obj
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+	return current
+}
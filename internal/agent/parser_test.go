@@ -0,0 +1,44 @@
+package agent
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextParser(t *testing.T) {
+	out := (&TextParser{}).Parse("all of it\n")
+	assert.Equal(t, "all of it\n", out.Text)
+	assert.Equal(t, 0.0, out.Cost)
+}
+
+func TestRegexParser(t *testing.T) {
+	p := &RegexParser{CostPattern: regexp.MustCompile(`Cost: \$([0-9.,]+)`)}
+
+	out := p.Parse("step 1\nCost: $0.10\nstep 2\nCost: $1,000.25\n")
+	assert.Equal(t, "step 1\nCost: $0.10\nstep 2\nCost: $1,000.25\n", out.Text)
+	assert.Equal(t, 1000.25, out.Cost)
+
+	assert.Equal(t, 0.0, p.Parse("no cost here").Cost)
+}
+
+func TestJSONLParser(t *testing.T) {
+	p := &JSONLParser{TextField: "message.content", CostField: "cost"}
+	stdout := `{"message":{"content":"Hello "}}
+not json
+{"message":{"content":"world"},"cost":0.1}
+{"cost":0.3}
+{"message":"not an object"}
+`
+
+	out := p.Parse(stdout)
+	assert.Equal(t, "Hello world", out.Text)
+	assert.Equal(t, 0.3, out.Cost)
+}
+
+func TestNewParser(t *testing.T) {
+	assert.IsType(t, &TextParser{}, NewParser(&Config{Output: OutputText}))
+	assert.IsType(t, &JSONLParser{}, NewParser(&Config{Output: OutputJSONL}))
+	assert.IsType(t, &RegexParser{}, NewParser(&Config{Output: OutputRegex, CostPattern: `(\d+)`}))
+}
@@ -0,0 +1,65 @@
+// Package agent runs arbitrary CLI coding agents behind the loop.ClaudeClient interface.
+package agent
+
+import "time"
+
+// DefaultFile is where command agents are defined.
+const DefaultFile = ".claude/agents.yaml"
+
+// BuiltinClaude names the built-in claude CLI backend. It needs no definition.
+const BuiltinClaude = "claude"
+
+// Placeholders substituted in Config.Args.
+const (
+	PlaceholderPrompt     = "{prompt}"
+	PlaceholderPromptFile = "{prompt_file}"
+)
+
+// PromptMode selects how the prompt is handed to the agent.
+type PromptMode string
+
+const (
+	PromptModeArgv  PromptMode = "argv"  // Substituted for {prompt} in args, or appended as the last argument
+	PromptModeStdin PromptMode = "stdin" // Written to the agent's stdin
+	PromptModeFile  PromptMode = "file"  // Written to a temporary file substituted for {prompt_file}, or appended
+)
+
+// OutputFormat selects how the agent's stdout is parsed.
+type OutputFormat string
+
+const (
+	OutputText  OutputFormat = "text"  // All of stdout is the output; cost is unknown
+	OutputJSONL OutputFormat = "jsonl" // One JSON object per line; text and cost read from fields
+	OutputRegex OutputFormat = "regex" // All of stdout is the output; cost captured by a regular expression
+)
+
+// Config defines one command agent.
+type Config struct {
+	Command string            `yaml:"command"`
+	Args    []string          `yaml:"args,omitempty"`
+	Prompt  PromptMode        `yaml:"prompt,omitempty"` // Default: argv
+	Output  OutputFormat      `yaml:"output,omitempty"` // Default: text
+	Env     map[string]string `yaml:"env,omitempty"`
+	Dir     string            `yaml:"dir,omitempty"`     // Working directory (default: current)
+	Timeout time.Duration     `yaml:"timeout,omitempty"` // 0 means no timeout
+
+	// jsonl output
+	TextField         string `yaml:"text_field,omitempty"`          // Dotted path to text (default: "text")
+	CostField         string `yaml:"cost_field,omitempty"`          // Dotted path to cost in USD; last value wins
+	InputTokensField  string `yaml:"input_tokens_field,omitempty"`  // Dotted path to input tokens; last value wins
+	OutputTokensField string `yaml:"output_tokens_field,omitempty"` // Dotted path to output tokens; last value wins
+
+	// regex output
+	CostPattern string `yaml:"cost_pattern,omitempty"` // First capture group is the cost in USD; last match wins
+}
+
+// File is the contents of the agents file.
+type File struct {
+	Agents map[string]*Config `yaml:"agents"`
+	path   string
+}
+
+// Path returns the file the agents were loaded from.
+func (f *File) Path() string {
+	return f.path
+}
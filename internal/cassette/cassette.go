@@ -0,0 +1,97 @@
+// Package cassette records Claude calls to disk and replays them offline.
+//
+// A cassette is a directory with one JSON file per call, numbered in send order
+// across all roles: 0001-iteration.json, 0002-reviewer.json, ... Calls made by a
+// specialised reviewer or council member carry its name: 0003-council-security.json.
+// Each file holds the prompt and the raw stream-json lines Claude printed,
+// so replay goes through the same parser as a live run.
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+)
+
+// Interaction is a single recorded call.
+type Interaction struct {
+	Seq        int           `json:"seq"`            // Position across all roles, starting at 1
+	Role       loop.Role     `json:"role"`           // Part of claude-loop that made the call
+	Name       string        `json:"name,omitempty"` // Specialised reviewer, council member or chair that made the call
+	Call       int           `json:"call"`           // Position among calls for Role and Name, starting at 1
+	Prompt     string        `json:"prompt"`
+	Lines      []string      `json:"lines"`           // Raw stream-json output
+	Error      string        `json:"error,omitempty"` // Error returned by the client, if any
+	Duration   time.Duration `json:"duration"`
+	RecordedAt time.Time     `json:"recorded_at"`
+}
+
+// FileName returns the cassette file name for the interaction.
+func (in *Interaction) FileName() string {
+	if in.Name != "" {
+		return fmt.Sprintf("%04d-%s-%s.json", in.Seq, in.Role, in.Name)
+	}
+	return fmt.Sprintf("%04d-%s.json", in.Seq, in.Role)
+}
+
+// caller identifies who made a call: a role, and within it the specialised
+// reviewer, council member or chair, if any.
+type caller struct {
+	role loop.Role
+	name string
+}
+
+// Save writes the interaction into dir and returns the file path.
+func Save(dir string, in *Interaction) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", &CassetteError{Path: dir, Message: "failed to create cassette directory", Err: err}
+	}
+	data, err := json.MarshalIndent(in, "", "  ")
+	if err != nil {
+		return "", &CassetteError{Message: "failed to encode interaction", Err: err}
+	}
+	path := filepath.Join(dir, in.FileName())
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", &CassetteError{Path: path, Message: "failed to write interaction", Err: err}
+	}
+	return path, nil
+}
+
+// Load reads every interaction in dir, ordered by Seq.
+func Load(dir string) ([]*Interaction, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, &CassetteError{Path: dir, Message: "failed to read cassette", Err: err}
+	}
+
+	var interactions []*Interaction
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, &CassetteError{Path: path, Message: "failed to read interaction", Err: err}
+		}
+		var in Interaction
+		if err := json.Unmarshal(data, &in); err != nil {
+			return nil, &CassetteError{Path: path, Message: "invalid interaction", Err: err}
+		}
+		interactions = append(interactions, &in)
+	}
+	if len(interactions) == 0 {
+		return nil, &CassetteError{Path: dir, Message: "no recorded interactions"}
+	}
+
+	sort.SliceStable(interactions, func(i, j int) bool {
+		return interactions[i].Seq < interactions[j].Seq
+	})
+	return interactions, nil
+}
@@ -0,0 +1,55 @@
+package cassette
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cassette")
+
+	second := &Interaction{Seq: 2, Role: loop.RoleReviewer, Call: 1, Prompt: "review", Duration: time.Second}
+	first := &Interaction{Seq: 1, Role: loop.RoleIteration, Call: 1, Prompt: "work", Lines: []string{`{"type":"result"}`}}
+
+	path, err := Save(dir, second)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "0002-reviewer.json"), path)
+	_, err = Save(dir, first)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.txt"), []byte("ignored"), 0644))
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, "work", loaded[0].Prompt)
+	assert.Equal(t, first.Lines, loaded[0].Lines)
+	assert.Equal(t, loop.RoleReviewer, loaded[1].Role)
+	assert.Equal(t, time.Second, loaded[1].Duration)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := Load(filepath.Join(t.TempDir(), "missing"))
+		assert.True(t, IsCassetteError(err))
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		_, err := Load(t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no recorded interactions")
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "0001-iteration.json"), []byte("{"), 0644))
+		_, err := Load(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid interaction")
+	})
+}
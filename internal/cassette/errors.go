@@ -0,0 +1,34 @@
+package cassette
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CassetteError represents a failure to record, load or replay a cassette.
+type CassetteError struct {
+	Path    string // Cassette file or directory involved, if any
+	Message string
+	Err     error
+}
+
+func (e *CassetteError) Error() string {
+	prefix := "cassette"
+	if e.Path != "" {
+		prefix = fmt.Sprintf("cassette %s", e.Path)
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", prefix, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", prefix, e.Message)
+}
+
+func (e *CassetteError) Unwrap() error {
+	return e.Err
+}
+
+// IsCassetteError checks if an error is a CassetteError.
+func IsCassetteError(err error) bool {
+	var ce *CassetteError
+	return errors.As(err, &ce)
+}
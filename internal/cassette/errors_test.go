@@ -0,0 +1,22 @@
+package cassette
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCassetteError(t *testing.T) {
+	inner := errors.New("permission denied")
+
+	assert.Equal(t, "cassette: no recorded call for reviewer #2", (&CassetteError{Message: "no recorded call for reviewer #2"}).Error())
+	assert.Equal(t, "cassette tapes/0001-iteration.json: failed to write interaction: permission denied",
+		(&CassetteError{Path: "tapes/0001-iteration.json", Message: "failed to write interaction", Err: inner}).Error())
+
+	wrapped := fmt.Errorf("replaying: %w", &CassetteError{Message: "x", Err: inner})
+	assert.True(t, IsCassetteError(wrapped))
+	assert.ErrorIs(t, wrapped, inner)
+	assert.False(t, IsCassetteError(inner))
+}
@@ -0,0 +1,146 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/claude"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+)
+
+// Player serves recorded interactions in place of live Claude calls.
+//
+// A call is matched to the first unused interaction of the same role and name
+// with an identical prompt. When prompts differ, for example because the notes
+// file or a template changed, it falls back to the interaction recorded at the
+// same position for that role and name, so the Nth reviewer call replays the Nth
+// recorded review. Specialised reviewers and council members, which run in
+// parallel, each replay their own recorded calls.
+type Player struct {
+	dir          string
+	interactions []*Interaction
+	handler      claude.StreamHandler
+
+	mu    sync.Mutex
+	used  map[*Interaction]bool
+	calls map[caller]int
+}
+
+// NewPlayer loads the cassette in dir.
+// Replayed output is streamed to handler when it is non-nil, as in a live run.
+func NewPlayer(dir string, handler claude.StreamHandler) (*Player, error) {
+	interactions, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Player{
+		dir:          dir,
+		interactions: interactions,
+		handler:      handler,
+		used:         make(map[*Interaction]bool),
+		calls:        make(map[caller]int),
+	}, nil
+}
+
+// Dir returns the cassette directory.
+func (p *Player) Dir() string {
+	return p.dir
+}
+
+// Len returns the number of recorded interactions.
+func (p *Player) Len() int {
+	return len(p.interactions)
+}
+
+// Remaining returns the number of interactions not replayed yet.
+func (p *Player) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.interactions) - len(p.used)
+}
+
+// Client returns a ClaudeClient that replays interactions recorded for role.
+func (p *Player) Client(role loop.Role) loop.ClaudeClient {
+	return p.ClientNamed(role, "")
+}
+
+// ClientNamed returns a ClaudeClient that replays the interactions recorded for
+// the specialised reviewer, council member or chair name of role.
+func (p *Player) ClientNamed(role loop.Role, name string) loop.ClaudeClient {
+	return &replayClient{player: p, caller: caller{role: role, name: name}}
+}
+
+// match finds the interaction for the next call of c with prompt and marks it used.
+func (p *Player) match(c caller, prompt string) (*Interaction, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls[c]++
+	call := p.calls[c]
+
+	var found *Interaction
+	for _, in := range p.interactions {
+		if !p.used[in] && in.Role == c.role && in.Name == c.name && in.Prompt == prompt {
+			found = in
+			break
+		}
+	}
+	if found == nil {
+		for _, in := range p.interactions {
+			if !p.used[in] && in.Role == c.role && in.Name == c.name && in.Call == call {
+				found = in
+				break
+			}
+		}
+	}
+	if found == nil {
+		who := string(c.role)
+		if c.name != "" {
+			who += " " + c.name
+		}
+		return nil, &CassetteError{Path: p.dir, Message: fmt.Sprintf("no recorded call for %s #%d", who, call)}
+	}
+
+	p.used[found] = true
+	return found, nil
+}
+
+// replayClient is the ClaudeClient returned by Player.Client.
+type replayClient struct {
+	player *Player
+	caller caller
+}
+
+// Execute returns the recorded result for prompt, parsed from the recorded stream.
+func (c *replayClient) Execute(ctx context.Context, prompt string) (*loop.IterationResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	in, err := c.player.match(c.caller, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := claude.NewParser(c.player.handler).Parse(strings.NewReader(strings.Join(in.Lines, "\n")))
+	if err != nil {
+		return nil, &CassetteError{Path: c.player.dir, Message: "failed to parse " + in.FileName(), Err: err}
+	}
+	if parsed.IsError {
+		return nil, &claude.ClaudeError{Message: "claude returned error", ResultText: parsed.ResultText}
+	}
+	if in.Error != "" {
+		return nil, errors.New(in.Error)
+	}
+
+	return &loop.IterationResult{
+		Output:       parsed.Output,
+		Cost:         parsed.TotalCostUSD,
+		Duration:     in.Duration,
+		InputTokens:  parsed.InputTokens,
+		OutputTokens: parsed.OutputTokens,
+	}, nil
+}
@@ -0,0 +1,171 @@
+package cassette
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/claude"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// textCollector records streamed text.
+type textCollector struct {
+	texts []string
+}
+
+func (c *textCollector) OnText(text string) {
+	c.texts = append(c.texts, text)
+}
+
+func resultLine(text string) []string {
+	return []string{
+		`{"type":"assistant","message":{"content":[{"type":"text","text":"` + text + `"}]}}`,
+		`{"type":"result","result":"` + text + `","total_cost_usd":0.1,"is_error":false}`,
+	}
+}
+
+func writeCassette(t *testing.T, interactions ...*Interaction) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, in := range interactions {
+		_, err := Save(dir, in)
+		require.NoError(t, err)
+	}
+	return dir
+}
+
+func TestPlayer_Execute(t *testing.T) {
+	dir := writeCassette(t,
+		&Interaction{Seq: 1, Role: loop.RoleIteration, Call: 1, Prompt: "first", Lines: resultLine("one"), Duration: 2 * time.Second},
+		&Interaction{Seq: 2, Role: loop.RoleReviewer, Call: 1, Prompt: "review", Lines: resultLine("reviewed")},
+		&Interaction{Seq: 3, Role: loop.RoleIteration, Call: 2, Prompt: "second", Lines: resultLine("two")},
+	)
+
+	t.Run("matches exact prompts", func(t *testing.T) {
+		player, err := NewPlayer(dir, nil)
+		require.NoError(t, err)
+		client := player.Client(loop.RoleIteration)
+
+		result, err := client.Execute(context.Background(), "second")
+		require.NoError(t, err)
+		assert.Equal(t, "two", result.Output)
+
+		result, err = client.Execute(context.Background(), "first")
+		require.NoError(t, err)
+		assert.Equal(t, "one", result.Output)
+		assert.Equal(t, 0.1, result.Cost)
+		assert.Equal(t, 2*time.Second, result.Duration)
+		assert.Equal(t, 1, player.Remaining())
+	})
+
+	t.Run("falls back to role and position", func(t *testing.T) {
+		player, err := NewPlayer(dir, nil)
+		require.NoError(t, err)
+
+		result, err := player.Client(loop.RoleReviewer).Execute(context.Background(), "review with new notes")
+		require.NoError(t, err)
+		assert.Equal(t, "reviewed", result.Output)
+
+		_, err = player.Client(loop.RoleReviewer).Execute(context.Background(), "review")
+		require.Error(t, err)
+		assert.True(t, IsCassetteError(err))
+		assert.Contains(t, err.Error(), "no recorded call for reviewer #2")
+	})
+
+	t.Run("streams replayed text", func(t *testing.T) {
+		collector := &textCollector{}
+		player, err := NewPlayer(dir, collector)
+		require.NoError(t, err)
+
+		_, err = player.Client(loop.RoleIteration).Execute(context.Background(), "first")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"one"}, collector.texts)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		player, err := NewPlayer(dir, nil)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = player.Client(loop.RoleIteration).Execute(ctx, "first")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 3, player.Remaining())
+	})
+}
+
+func TestPlayer_Named(t *testing.T) {
+	dir := writeCassette(t,
+		&Interaction{Seq: 1, Role: loop.RoleCouncil, Name: "security", Call: 1, Prompt: "as security", Lines: resultLine("secure")},
+		&Interaction{Seq: 2, Role: loop.RoleCouncil, Name: "product", Call: 1, Prompt: "as product", Lines: resultLine("ship")},
+		&Interaction{Seq: 3, Role: loop.RoleCouncil, Name: "chair", Call: 1, Prompt: "as chair", Lines: resultLine("decided")},
+	)
+
+	t.Run("each name replays its own calls whatever the order", func(t *testing.T) {
+		player, err := NewPlayer(dir, nil)
+		require.NoError(t, err)
+
+		// Prompts changed, so only the name and position can match
+		result, err := player.ClientNamed(loop.RoleCouncil, "product").Execute(context.Background(), "as product, new notes")
+		require.NoError(t, err)
+		assert.Equal(t, "ship", result.Output)
+		result, err = player.ClientNamed(loop.RoleCouncil, "security").Execute(context.Background(), "as security, new notes")
+		require.NoError(t, err)
+		assert.Equal(t, "secure", result.Output)
+	})
+
+	t.Run("a name does not replay another's calls", func(t *testing.T) {
+		player, err := NewPlayer(dir, nil)
+		require.NoError(t, err)
+
+		_, err = player.Client(loop.RoleCouncil).Execute(context.Background(), "as security")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no recorded call for council #1")
+
+		_, err = player.ClientNamed(loop.RoleCouncil, "cost").Execute(context.Background(), "as cost")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no recorded call for council cost #1")
+	})
+}
+
+func TestPlayer_RecordedErrors(t *testing.T) {
+	dir := writeCassette(t,
+		&Interaction{Seq: 1, Role: loop.RoleIteration, Call: 1, Prompt: "a",
+			Lines: []string{`{"type":"result","result":"API error occurred","is_error":true}`}},
+		&Interaction{Seq: 2, Role: loop.RoleIteration, Call: 2, Prompt: "b", Error: "claude: claude exited with error: exit status 1"},
+	)
+	player, err := NewPlayer(dir, nil)
+	require.NoError(t, err)
+	client := player.Client(loop.RoleIteration)
+
+	_, err = client.Execute(context.Background(), "a")
+	var claudeErr *claude.ClaudeError
+	require.ErrorAs(t, err, &claudeErr)
+	assert.Equal(t, "API error occurred", claudeErr.ResultText)
+
+	_, err = client.Execute(context.Background(), "b")
+	require.Error(t, err)
+	assert.Equal(t, "claude: claude exited with error: exit status 1", err.Error())
+}
+
+func TestRecordReplay_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	recorder := NewRecorder(dir)
+	live := &fakeRawClient{output: transcript}
+
+	recorded, err := recorder.Wrap(live, loop.RoleIteration).Execute(context.Background(), "work")
+	require.NoError(t, err)
+
+	player, err := NewPlayer(dir, nil)
+	require.NoError(t, err)
+	replayed, err := player.Client(loop.RoleIteration).Execute(context.Background(), "work")
+	require.NoError(t, err)
+
+	assert.Equal(t, recorded.Output, replayed.Output)
+	assert.Equal(t, recorded.Cost, replayed.Cost)
+	assert.Equal(t, 10, replayed.InputTokens)
+	assert.Equal(t, 3, replayed.OutputTokens)
+}
@@ -0,0 +1,138 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+)
+
+// RawClient is a ClaudeClient that can also hand out its raw stream-json output.
+// claude.Client implements it.
+type RawClient interface {
+	loop.ClaudeClient
+	ExecuteRaw(ctx context.Context, prompt string, raw io.Writer) (*loop.IterationResult, error)
+}
+
+// Recorder saves every call made through its wrapped clients to a cassette directory.
+type Recorder struct {
+	dir   string
+	mu    sync.Mutex
+	seq   int
+	calls map[caller]int
+}
+
+// NewRecorder creates a Recorder writing into dir.
+// The directory is created on the first write.
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{dir: dir, calls: make(map[caller]int)}
+}
+
+// Dir returns the cassette directory.
+func (r *Recorder) Dir() string {
+	return r.dir
+}
+
+// Wrap returns a ClaudeClient that records each call before returning its result.
+func (r *Recorder) Wrap(client loop.ClaudeClient, role loop.Role) loop.ClaudeClient {
+	return r.WrapNamed(client, role, "")
+}
+
+// WrapNamed is Wrap for the client of a specialised reviewer, council member or
+// chair. Its calls are recorded under name, so replay does not depend on the
+// order in which parallel calls were made.
+func (r *Recorder) WrapNamed(client loop.ClaudeClient, role loop.Role, name string) loop.ClaudeClient {
+	return &recordingClient{recorder: r, client: client, caller: caller{role: role, name: name}}
+}
+
+// next reserves the sequence numbers for a call, so files follow send order.
+func (r *Recorder) next(c caller) (seq, call int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	r.calls[c]++
+	return r.seq, r.calls[c]
+}
+
+// recordingClient is the ClaudeClient returned by Recorder.Wrap.
+type recordingClient struct {
+	recorder *Recorder
+	client   loop.ClaudeClient
+	caller   caller
+}
+
+// Execute runs the prompt and records it. Recording failures do not block execution.
+func (c *recordingClient) Execute(ctx context.Context, prompt string) (*loop.IterationResult, error) {
+	seq, call := c.recorder.next(c.caller)
+	in := &Interaction{Seq: seq, Role: c.caller.role, Name: c.caller.name, Call: call, Prompt: prompt, RecordedAt: time.Now()}
+
+	var result *loop.IterationResult
+	var err error
+	if raw, ok := c.client.(RawClient); ok {
+		var buf bytes.Buffer
+		result, err = raw.ExecuteRaw(ctx, prompt, &buf)
+		in.Lines = splitLines(buf.String())
+	} else {
+		result, err = c.client.Execute(ctx, prompt)
+		in.Lines = synthesizeLines(result)
+	}
+
+	in.Duration = time.Since(in.RecordedAt)
+	if result != nil && result.Duration > 0 {
+		in.Duration = result.Duration
+	}
+	if err != nil {
+		in.Error = err.Error()
+	}
+
+	_, _ = Save(c.recorder.dir, in)
+	return result, err
+}
+
+// splitLines returns the non-empty lines of output.
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// synthesizeLines builds stream-json lines equivalent to result, for clients
+// such as command agents that do not speak stream-json themselves.
+func synthesizeLines(result *loop.IterationResult) []string {
+	if result == nil {
+		return nil
+	}
+
+	assistant := map[string]any{
+		"type": "assistant",
+		"message": map[string]any{
+			"content": []map[string]any{{"type": "text", "text": result.Output}},
+		},
+	}
+	final := map[string]any{
+		"type":           "result",
+		"result":         result.Output,
+		"total_cost_usd": result.Cost,
+		"is_error":       false,
+		"usage": map[string]any{
+			"input_tokens":  result.InputTokens,
+			"output_tokens": result.OutputTokens,
+		},
+	}
+
+	var lines []string
+	for _, msg := range []any{assistant, final} {
+		data, _ := json.Marshal(msg)
+		lines = append(lines, string(data))
+	}
+	return lines
+}
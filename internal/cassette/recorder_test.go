@@ -0,0 +1,117 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRawClient prints a fixed stream-json transcript.
+type fakeRawClient struct {
+	output string
+	err    error
+}
+
+func (f *fakeRawClient) Execute(ctx context.Context, prompt string) (*loop.IterationResult, error) {
+	return f.ExecuteRaw(ctx, prompt, io.Discard)
+}
+
+func (f *fakeRawClient) ExecuteRaw(ctx context.Context, prompt string, raw io.Writer) (*loop.IterationResult, error) {
+	_, _ = io.WriteString(raw, f.output)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &loop.IterationResult{Output: "Hello!", Cost: 0.05}, nil
+}
+
+// fakeClient returns a fixed result without any stream-json output.
+type fakeClient struct {
+	result *loop.IterationResult
+}
+
+func (f *fakeClient) Execute(ctx context.Context, prompt string) (*loop.IterationResult, error) {
+	return f.result, nil
+}
+
+const transcript = `{"type":"assistant","message":{"content":[{"type":"text","text":"Hello!"}]}}
+{"type":"result","result":"Done","total_cost_usd":0.05,"is_error":false,"usage":{"input_tokens":10,"output_tokens":3}}
+`
+
+func TestRecorder(t *testing.T) {
+	t.Run("records raw stream lines in send order", func(t *testing.T) {
+		dir := t.TempDir()
+		recorder := NewRecorder(dir)
+		client := &fakeRawClient{output: transcript}
+
+		_, err := recorder.Wrap(client, loop.RoleIteration).Execute(context.Background(), "work")
+		require.NoError(t, err)
+		_, err = recorder.Wrap(client, loop.RoleReviewer).Execute(context.Background(), "review")
+		require.NoError(t, err)
+		_, err = recorder.Wrap(client, loop.RoleIteration).Execute(context.Background(), "work again")
+		require.NoError(t, err)
+
+		loaded, err := Load(dir)
+		require.NoError(t, err)
+		require.Len(t, loaded, 3)
+		assert.Equal(t, "0001-iteration.json", loaded[0].FileName())
+		assert.Len(t, loaded[0].Lines, 2)
+		assert.Equal(t, loop.RoleReviewer, loaded[1].Role)
+		assert.Equal(t, 1, loaded[1].Call)
+		assert.Equal(t, 2, loaded[2].Call)
+		assert.Equal(t, "work again", loaded[2].Prompt)
+	})
+
+	t.Run("counts calls per name", func(t *testing.T) {
+		dir := t.TempDir()
+		recorder := NewRecorder(dir)
+		client := &fakeRawClient{output: transcript}
+
+		_, err := recorder.WrapNamed(client, loop.RoleReviewer, "security").Execute(context.Background(), "check secrets")
+		require.NoError(t, err)
+		_, err = recorder.Wrap(client, loop.RoleReviewer).Execute(context.Background(), "review")
+		require.NoError(t, err)
+
+		loaded, err := Load(dir)
+		require.NoError(t, err)
+		require.Len(t, loaded, 2)
+		assert.Equal(t, "0001-reviewer-security.json", loaded[0].FileName())
+		assert.Equal(t, "security", loaded[0].Name)
+		assert.Equal(t, 1, loaded[0].Call)
+		assert.Equal(t, "", loaded[1].Name)
+		assert.Equal(t, 1, loaded[1].Call)
+	})
+
+	t.Run("records errors", func(t *testing.T) {
+		dir := t.TempDir()
+		client := &fakeRawClient{err: errors.New("claude exited with error")}
+
+		_, err := NewRecorder(dir).Wrap(client, loop.RoleCouncil).Execute(context.Background(), "decide")
+		require.Error(t, err)
+
+		loaded, err := Load(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "claude exited with error", loaded[0].Error)
+	})
+
+	t.Run("synthesizes stream lines for other clients", func(t *testing.T) {
+		dir := t.TempDir()
+		mock := &fakeClient{result: &loop.IterationResult{Output: "agent says hi", Cost: 0.2, InputTokens: 7, OutputTokens: 2}}
+
+		_, err := NewRecorder(dir).Wrap(mock, loop.RolePlanner).Execute(context.Background(), "plan")
+		require.NoError(t, err)
+
+		player, err := NewPlayer(dir, nil)
+		require.NoError(t, err)
+		result, err := player.Client(loop.RolePlanner).Execute(context.Background(), "plan")
+		require.NoError(t, err)
+		assert.Equal(t, "agent says hi", result.Output)
+		assert.Equal(t, 0.2, result.Cost)
+		assert.Equal(t, 7, result.InputTokens)
+		assert.Equal(t, 2, result.OutputTokens)
+	})
+}
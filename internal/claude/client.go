@@ -3,6 +3,7 @@ package claude
 import (
 	"bytes"
 	"context"
+	"io"
 	"os/exec"
 	"time"
 
@@ -28,9 +29,18 @@ type ClientOptions struct {
 	ClaudePath string
 
 	// AdditionalFlags are extra flags to pass to claude CLI.
-	// Default: ["--dangerously-skip-permissions", "--output-format", "stream-json", "--verbose"]
+	// Default: ["--output-format", "stream-json", "--verbose", "--include-partial-messages"]
 	AdditionalFlags []string
 
+	// Permissions selects the tool permissions granted to claude (default: ProfileFull).
+	Permissions PermissionProfile
+
+	// Publish also allows pushing and opening pull requests under a restricted profile.
+	Publish bool
+
+	// Model is passed to claude as --model, e.g. "haiku" (empty = claude's default).
+	Model string
+
 	// StreamHandler receives real-time text output (optional).
 	StreamHandler StreamHandler
 
@@ -43,12 +53,12 @@ func DefaultOptions() *ClientOptions {
 	return &ClientOptions{
 		ClaudePath: "claude",
 		AdditionalFlags: []string{
-			"--dangerously-skip-permissions",
 			"--output-format", "stream-json",
 			"--verbose",
 			"--include-partial-messages",
 		},
-		Executor: &DefaultExecutor{},
+		Permissions: ProfileFull,
+		Executor:    &DefaultExecutor{},
 	}
 }
 
@@ -75,6 +85,9 @@ func NewClient(opts *ClientOptions) *Client {
 	if opts.AdditionalFlags == nil {
 		opts.AdditionalFlags = defaults.AdditionalFlags
 	}
+	if opts.Permissions == "" {
+		opts.Permissions = defaults.Permissions
+	}
 
 	return &Client{
 		opts:   opts,
@@ -82,6 +95,45 @@ func NewClient(opts *ClientOptions) *Client {
 	}
 }
 
+// Model returns the model the client runs claude with (empty = claude's default).
+func (c *Client) Model() string {
+	return c.opts.Model
+}
+
+// modelFlags returns the claude CLI flags that select the model, if one is set.
+func (c *Client) modelFlags() []string {
+	if c.opts.Model == "" {
+		return nil
+	}
+	return []string{"--model", c.opts.Model}
+}
+
+// Permissions returns the permission profile the client runs claude with.
+func (c *Client) Permissions() PermissionProfile {
+	return c.opts.Permissions
+}
+
+// Publishes reports whether the client may push and open pull requests under its profile.
+func (c *Client) Publishes() bool {
+	return c.opts.Publish || c.opts.Permissions == ProfileFull
+}
+
+// Publisher returns a client with the same options that may also push and open
+// pull requests, for the pass that publishes committed work.
+func (c *Client) Publisher() *Client {
+	opts := *c.opts
+	opts.Publish = true
+	return NewClient(&opts)
+}
+
+// permissionFlags returns the claude CLI flags that apply the client's permissions.
+func (c *Client) permissionFlags() []string {
+	if c.opts.Publish {
+		return c.opts.Permissions.PublishFlags()
+	}
+	return c.opts.Permissions.Flags()
+}
+
 // execResult holds the result of running a command.
 type execResult struct {
 	parsed *ParsedResult
@@ -90,7 +142,8 @@ type execResult struct {
 
 // runCommand executes the claude CLI with the given arguments and returns the parsed result.
 // This is the common execution logic shared by Execute and ExecuteWithSession.
-func (c *Client) runCommand(ctx context.Context, args []string) (*execResult, error) {
+// If raw is non-nil, the unparsed stream-json output is copied to it.
+func (c *Client) runCommand(ctx context.Context, args []string, raw io.Writer) (*execResult, error) {
 	cmd := c.opts.Executor.CommandContext(ctx, c.opts.ClaudePath, args...)
 
 	stdout, err := cmd.StdoutPipe()
@@ -105,7 +158,12 @@ func (c *Client) runCommand(ctx context.Context, args []string) (*execResult, er
 		return nil, &ClaudeError{Message: "failed to start claude", Err: err}
 	}
 
-	parsed, parseErr := c.parser.Parse(stdout)
+	var output io.Reader = stdout
+	if raw != nil {
+		output = io.TeeReader(stdout, raw)
+	}
+
+	parsed, parseErr := c.parser.Parse(output)
 	cmdErr := cmd.Wait()
 	stderr := stderrBuf.String()
 
@@ -118,6 +176,10 @@ func (c *Client) runCommand(ctx context.Context, args []string) (*execResult, er
 	}
 
 	if err := c.checkExecutionError(ctx, cmdErr, parsed, stderr); err != nil {
+		if ctx.Err() != nil {
+			// Keep what the cancelled session reported so callers can count its spend
+			return &execResult{parsed: parsed, stderr: stderr}, err
+		}
 		return nil, err
 	}
 
@@ -159,22 +221,33 @@ func (c *Client) checkExecutionError(ctx context.Context, cmdErr error, parsed *
 // Execute implements loop.ClaudeClient interface.
 // It runs the claude CLI with the given prompt and returns the result.
 func (c *Client) Execute(ctx context.Context, prompt string) (*loop.IterationResult, error) {
+	return c.ExecuteRaw(ctx, prompt, nil)
+}
+
+// ExecuteRaw is Execute with the raw stream-json output copied to raw as it is read.
+// Used to record sessions for later replay.
+func (c *Client) ExecuteRaw(ctx context.Context, prompt string, raw io.Writer) (*loop.IterationResult, error) {
 	startTime := time.Now()
 
 	args := []string{"-p", prompt}
 	args = append(args, c.opts.AdditionalFlags...)
+	args = append(args, c.permissionFlags()...)
+	args = append(args, c.modelFlags()...)
 
-	result, err := c.runCommand(ctx, args)
-	if err != nil {
+	result, err := c.runCommand(ctx, args, raw)
+	if result == nil {
 		return nil, err
 	}
 
+	// A cancelled call returns its partial result with the error
 	return &loop.IterationResult{
 		Output:                result.parsed.Output,
 		Cost:                  result.parsed.TotalCostUSD,
 		Duration:              time.Since(startTime),
 		CompletionSignalFound: false, // Detected by loop package
-	}, nil
+		InputTokens:           result.parsed.InputTokens,
+		OutputTokens:          result.parsed.OutputTokens,
+	}, err
 }
 
 // ExecuteWithSession executes a prompt with optional session resume.
@@ -186,8 +259,10 @@ func (c *Client) ExecuteWithSession(ctx context.Context, prompt string, sessionI
 		args = append(args, "--resume", sessionID)
 	}
 	args = append(args, c.opts.AdditionalFlags...)
+	args = append(args, c.permissionFlags()...)
+	args = append(args, c.modelFlags()...)
 
-	result, err := c.runCommand(ctx, args)
+	result, err := c.runCommand(ctx, args, nil)
 	if err != nil {
 		return nil, err
 	}
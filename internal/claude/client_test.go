@@ -1,6 +1,7 @@
 package claude
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"os/exec"
@@ -84,6 +85,7 @@ func TestClient_NewClient(t *testing.T) {
 		assert.Equal(t, "claude", client.opts.ClaudePath)
 		assert.NotNil(t, client.opts.Executor)
 		assert.NotEmpty(t, client.opts.AdditionalFlags)
+		assert.Equal(t, ProfileFull, client.opts.Permissions)
 	})
 }
 
@@ -91,7 +93,7 @@ func TestClient_DefaultOptions(t *testing.T) {
 	opts := DefaultOptions()
 
 	assert.Equal(t, "claude", opts.ClaudePath)
-	assert.Contains(t, opts.AdditionalFlags, "--dangerously-skip-permissions")
+	assert.Equal(t, ProfileFull, opts.Permissions)
 	assert.Contains(t, opts.AdditionalFlags, "--output-format")
 	assert.Contains(t, opts.AdditionalFlags, "stream-json")
 	assert.Contains(t, opts.AdditionalFlags, "--verbose")
@@ -118,6 +120,36 @@ func TestClient_Execute_Success(t *testing.T) {
 	assert.Greater(t, result.Duration, time.Duration(0))
 }
 
+func TestClient_ExecuteRaw(t *testing.T) {
+	output := `{"type":"assistant","message":{"content":[{"type":"text","text":"Hello!"}]}}
+{"type":"result","result":"Done","total_cost_usd":0.05,"is_error":false}
+`
+	client := NewClient(&ClientOptions{Executor: &MockExecutor{Script: output}})
+
+	var raw bytes.Buffer
+	result, err := client.ExecuteRaw(context.Background(), "test prompt", &raw)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Hello!", result.Output)
+	assert.Equal(t, output, raw.String())
+}
+
+func TestClient_Execute_Model(t *testing.T) {
+	output := `{"type":"result","result":"Done","total_cost_usd":0.01,"is_error":false}
+`
+	executor := &argsExecutor{MockExecutor: MockExecutor{Script: output}}
+	client := NewClient(&ClientOptions{Executor: executor, Model: "haiku"})
+
+	assert.Equal(t, "haiku", client.Model())
+	_, err := client.Execute(context.Background(), "test prompt")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--model", "haiku"}, executor.args[len(executor.args)-2:])
+
+	_, err = NewClient(&ClientOptions{Executor: executor}).Execute(context.Background(), "test prompt")
+	require.NoError(t, err)
+	assert.NotContains(t, executor.args, "--model")
+}
+
 func TestClient_Execute_Error(t *testing.T) {
 	output := `{"type":"result","result":"API error occurred","total_cost_usd":0.01,"is_error":true}
 `
@@ -176,6 +208,29 @@ func TestClient_Execute_ContextCancellation(t *testing.T) {
 	assert.ErrorIs(t, err, context.Canceled)
 }
 
+// shellExecutor runs script with sh, ignoring the claude arguments.
+type shellExecutor struct {
+	script string
+}
+
+func (e *shellExecutor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "sh", "-c", e.script)
+}
+
+func TestClient_Execute_CancelledKeepsPartialResult(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(300*time.Millisecond, cancel)
+
+	client := NewClient(&ClientOptions{})
+	client.opts.Executor = &shellExecutor{script: `printf '%s\n' '{"type":"assistant","message":{"content":[{"type":"text","text":"Half done"}]}}'; exec sleep 10`}
+
+	result, err := client.Execute(ctx, "work")
+	require.ErrorIs(t, err, context.Canceled)
+	require.NotNil(t, result, "a cancelled call returns what it reported")
+	assert.Equal(t, "Half done", result.Output)
+}
+
 func TestClient_Execute_WithStreamHandler(t *testing.T) {
 	output := `{"type":"assistant","message":{"content":[{"type":"text","text":"Part1"}]}}
 {"type":"assistant","message":{"content":[{"type":"text","text":"Part2"}]}}
@@ -128,6 +128,10 @@ func (p *Parser) Parse(r io.Reader) (*ParsedResult, error) {
 			result.TotalCostUSD = msg.TotalCostUSD
 			result.IsError = msg.IsError
 			result.SessionID = msg.SessionID
+			if msg.Usage != nil {
+				result.InputTokens = msg.Usage.InputTokens + msg.Usage.CacheCreationInputTokens + msg.Usage.CacheReadInputTokens
+				result.OutputTokens = msg.Usage.OutputTokens
+			}
 
 		default:
 			// Store other message types (system, etc.)
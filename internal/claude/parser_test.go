@@ -55,6 +55,17 @@ func TestParser_ParseWithResult(t *testing.T) {
 	assert.False(t, result.IsError)
 }
 
+func TestParser_ParseUsage(t *testing.T) {
+	input := `{"type":"result","result":"ok","total_cost_usd":0.01,"usage":{"input_tokens":10,"cache_creation_input_tokens":100,"cache_read_input_tokens":1000,"output_tokens":42}}`
+
+	parser := NewParser(nil)
+	result, err := parser.Parse(strings.NewReader(input))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1110, result.InputTokens)
+	assert.Equal(t, 42, result.OutputTokens)
+}
+
 func TestParser_ParseErrorResult(t *testing.T) {
 	input := `{"type":"result","result":"Something went wrong","total_cost_usd":0.01,"is_error":true}`
 
@@ -0,0 +1,117 @@
+package claude
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PermissionProfile names a set of tool permissions passed to the claude CLI.
+type PermissionProfile string
+
+const (
+	// ProfileReadOnly allows reading files and inspecting git history.
+	ProfileReadOnly PermissionProfile = "read-only"
+	// ProfileEditOnly adds file edits, without shell access.
+	ProfileEditOnly PermissionProfile = "edit-only"
+	// ProfileEditTest adds build and test commands and local git commits.
+	ProfileEditTest PermissionProfile = "edit+test"
+	// ProfileFull skips all permission checks.
+	ProfileFull PermissionProfile = "full"
+)
+
+// PermissionProfiles lists the profiles from most to least restrictive.
+var PermissionProfiles = []PermissionProfile{ProfileReadOnly, ProfileEditOnly, ProfileEditTest, ProfileFull}
+
+// Tool sets granted by the profiles, in claude's --allowedTools syntax.
+var (
+	readTools = []string{
+		"Read", "Glob", "Grep", "LS", "TodoWrite",
+		"Bash(git status:*)", "Bash(git diff:*)", "Bash(git log:*)", "Bash(git show:*)",
+	}
+	editTools = []string{"Edit", "MultiEdit", "Write", "NotebookEdit"}
+	testTools = []string{
+		"Bash(git add:*)", "Bash(git commit:*)",
+		"Bash(go build:*)", "Bash(go test:*)", "Bash(go vet:*)",
+		"Bash(make:*)",
+		"Bash(npm test:*)", "Bash(npm run:*)", "Bash(yarn test:*)", "Bash(pnpm test:*)",
+		"Bash(pytest:*)", "Bash(python -m pytest:*)",
+		"Bash(cargo build:*)", "Bash(cargo test:*)",
+		"Bash(mvn test:*)", "Bash(gradle test:*)",
+	}
+	// publishTools let the pass after a commit push it and open the pull request.
+	publishTools = []string{"Bash(git push:*)", "Bash(gh pr:*)"}
+)
+
+// ParsePermissionProfile parses a profile name.
+func ParsePermissionProfile(s string) (PermissionProfile, error) {
+	for _, p := range PermissionProfiles {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+	names := make([]string, len(PermissionProfiles))
+	for i, p := range PermissionProfiles {
+		names[i] = string(p)
+	}
+	return "", fmt.Errorf("unknown permission profile %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// Flags returns the claude CLI flags that apply the profile.
+// An empty profile is treated as ProfileFull.
+func (p PermissionProfile) Flags() []string {
+	switch p {
+	case ProfileReadOnly:
+		return []string{
+			"--permission-mode", "default",
+			"--allowedTools", strings.Join(readTools, ","),
+			"--disallowedTools", strings.Join(editTools, ","),
+		}
+	case ProfileEditOnly:
+		return []string{
+			"--permission-mode", "acceptEdits",
+			"--allowedTools", strings.Join(concat(readTools, editTools), ","),
+		}
+	case ProfileEditTest:
+		return []string{
+			"--permission-mode", "acceptEdits",
+			"--allowedTools", strings.Join(concat(readTools, editTools, testTools), ","),
+		}
+	default:
+		return []string{"--dangerously-skip-permissions"}
+	}
+}
+
+// PublishFlags returns the profile's flags with pushing and opening pull requests
+// also allowed, for the pass that publishes work that is already committed.
+// ProfileFull allows them anyway.
+func (p PermissionProfile) PublishFlags() []string {
+	flags := p.Flags()
+	for i := 0; i+1 < len(flags); i++ {
+		if flags[i] == "--allowedTools" {
+			flags[i+1] += "," + strings.Join(publishTools, ",")
+		}
+	}
+	return flags
+}
+
+// ProfileForSecurityPosture returns the default profile for a security_posture principle value (1-10).
+// Postures up to 7 keep the unrestricted default; 8-9 limit the shell to builds, tests and
+// local commits; 10 removes shell access entirely.
+func ProfileForSecurityPosture(posture int) PermissionProfile {
+	switch {
+	case posture >= 10:
+		return ProfileEditOnly
+	case posture >= 8:
+		return ProfileEditTest
+	default:
+		return ProfileFull
+	}
+}
+
+func concat(lists ...[]string) []string {
+	var out []string
+	for _, l := range lists {
+		out = append(out, l...)
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package claude
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// argsExecutor records the arguments of each command before running the mock script.
+type argsExecutor struct {
+	MockExecutor
+	args []string
+}
+
+func (e *argsExecutor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	e.args = args
+	return e.MockExecutor.CommandContext(ctx, name, args...)
+}
+
+func TestParsePermissionProfile(t *testing.T) {
+	for _, p := range PermissionProfiles {
+		parsed, err := ParsePermissionProfile(string(p))
+		require.NoError(t, err)
+		assert.Equal(t, p, parsed)
+	}
+
+	_, err := ParsePermissionProfile("admin")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown permission profile "admin"`)
+	assert.Contains(t, err.Error(), "read-only, edit-only, edit+test, full")
+}
+
+func TestPermissionProfile_Flags(t *testing.T) {
+	t.Run("read-only", func(t *testing.T) {
+		flags := ProfileReadOnly.Flags()
+		assert.Equal(t, []string{"--permission-mode", "default"}, flags[:2])
+		assert.Contains(t, flags[3], "Read")
+		assert.NotContains(t, flags[3], "Edit")
+		assert.Equal(t, "--disallowedTools", flags[4])
+		assert.Contains(t, flags[5], "Write")
+	})
+
+	t.Run("edit-only", func(t *testing.T) {
+		flags := ProfileEditOnly.Flags()
+		assert.Equal(t, "acceptEdits", flags[1])
+		assert.Contains(t, flags[3], "Edit")
+		assert.NotContains(t, flags[3], "go test")
+	})
+
+	t.Run("edit+test", func(t *testing.T) {
+		flags := ProfileEditTest.Flags()
+		assert.Equal(t, "acceptEdits", flags[1])
+		assert.Contains(t, flags[3], "Bash(go test:*)")
+		assert.Contains(t, flags[3], "Bash(git commit:*)")
+		assert.NotContains(t, flags, "--dangerously-skip-permissions")
+	})
+
+	t.Run("full and empty", func(t *testing.T) {
+		assert.Equal(t, []string{"--dangerously-skip-permissions"}, ProfileFull.Flags())
+		assert.Equal(t, ProfileFull.Flags(), PermissionProfile("").Flags())
+	})
+}
+
+func TestPermissionProfile_PublishFlags(t *testing.T) {
+	flags := ProfileEditTest.PublishFlags()
+	assert.Contains(t, flags[3], "Bash(go test:*)")
+	assert.Contains(t, flags[3], "Bash(git push:*)")
+	assert.Contains(t, flags[3], "Bash(gh pr:*)")
+	assert.NotContains(t, ProfileEditTest.Flags()[3], "git push", "the profile itself is unchanged")
+	assert.Equal(t, ProfileFull.Flags(), ProfileFull.PublishFlags())
+}
+
+func TestProfileForSecurityPosture(t *testing.T) {
+	assert.Equal(t, ProfileFull, ProfileForSecurityPosture(0))
+	assert.Equal(t, ProfileFull, ProfileForSecurityPosture(7))
+	assert.Equal(t, ProfileEditTest, ProfileForSecurityPosture(8))
+	assert.Equal(t, ProfileEditTest, ProfileForSecurityPosture(9))
+	assert.Equal(t, ProfileEditOnly, ProfileForSecurityPosture(10))
+}
+
+func TestClient_Execute_PermissionFlags(t *testing.T) {
+	output := `{"type":"result","result":"Done","total_cost_usd":0.01,"is_error":false}
+`
+	t.Run("default skips permissions", func(t *testing.T) {
+		exec := &argsExecutor{MockExecutor: MockExecutor{Script: output}}
+		_, err := NewClient(&ClientOptions{Executor: exec}).Execute(context.Background(), "p")
+		require.NoError(t, err)
+		assert.Contains(t, exec.args, "--dangerously-skip-permissions")
+	})
+
+	t.Run("profile replaces skip flag", func(t *testing.T) {
+		exec := &argsExecutor{MockExecutor: MockExecutor{Script: output}}
+		client := NewClient(&ClientOptions{Executor: exec, Permissions: ProfileReadOnly})
+		assert.Equal(t, ProfileReadOnly, client.Permissions())
+		_, err := client.ExecuteWithSession(context.Background(), "p", "")
+		require.NoError(t, err)
+		assert.NotContains(t, exec.args, "--dangerously-skip-permissions")
+		assert.Contains(t, exec.args, "--allowedTools")
+	})
+
+	t.Run("publisher may push", func(t *testing.T) {
+		exec := &argsExecutor{MockExecutor: MockExecutor{Script: output}}
+		client := NewClient(&ClientOptions{Executor: exec, Permissions: ProfileEditTest})
+		assert.False(t, client.Publishes())
+
+		publisher := client.Publisher()
+		assert.True(t, publisher.Publishes())
+		assert.Equal(t, ProfileEditTest, publisher.Permissions())
+		_, err := publisher.Execute(context.Background(), "p")
+		require.NoError(t, err)
+		assert.Contains(t, exec.args, ProfileEditTest.PublishFlags()[3])
+	})
+}
@@ -12,6 +12,15 @@ type StreamMessage struct {
 	TotalCostUSD float64           `json:"total_cost_usd,omitempty"` // Present in result
 	IsError      bool              `json:"is_error,omitempty"`       // Present in result
 	SessionID    string            `json:"session_id,omitempty"`     // Session ID for resume capability
+	Usage        *Usage            `json:"usage,omitempty"`          // Token usage, present in result
+}
+
+// Usage reports token counts from the result message.
+type Usage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens,omitempty"`
 }
 
 // RawMessage is used to handle both assistant and user message formats.
@@ -78,6 +87,8 @@ type ParsedResult struct {
 	IsError      bool            // Whether the execution resulted in an error
 	RawMessages  []StreamMessage // All parsed messages (for debugging)
 	SessionID    string          // Session ID for resume capability
+	InputTokens  int             // Input tokens including cache reads and writes
+	OutputTokens int             // Output tokens
 }
 
 // SessionResult contains execution result with session info for resume.
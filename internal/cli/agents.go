@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/agent"
+	"github.com/DeukWoongWoo/claude-loop/internal/cassette"
+	"github.com/DeukWoongWoo/claude-loop/internal/claude"
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/DeukWoongWoo/claude-loop/internal/protected"
+	"github.com/DeukWoongWoo/claude-loop/internal/reviewer"
+)
+
+// agentClients holds the backend used for each role of a run.
+type agentClients struct {
+	Main     loop.ClaudeClient
+	Reviewer loop.ClaudeClient
+	Council  loop.ClaudeClient
+	Planner  loop.ClaudeClient
+
+	// CouncilMembers holds clients for council members and the chair that set
+	// their own model, keyed by member name or council.ChairName. With --record
+	// or --replay every member and the chair has one.
+	CouncilMembers map[string]loop.ClaudeClient
+
+	// Reviewers holds read-only clients for specialised reviewers, keyed by name.
+	// With --record or --replay every specialised reviewer has one.
+	Reviewers map[string]loop.ClaudeClient
+
+	// Publisher pushes committed changes and opens the pull request when the
+	// main profile does not allow it; nil when Main may do so itself.
+	Publisher loop.ClaudeClient
+}
+
+// newAgentClients resolves the --agent flags against the built-in claude backend
+// and the command agents defined in --agents-file.
+// Role flags that are not set fall back to the main agent. Built-in claude clients
+// get the role's permission profile; principles, when known, set the default profile
+// and the protected paths whose edits are flagged in the stream. Council members
+// with their own model get a client each when the council agent is claude.
+// Specialised reviewers get a read-only client each, with their own model when
+// they set one, when the reviewer agent is claude; otherwise they share it. They
+// run in parallel on the same tree, so they never get edit permissions. A
+// built-in main client whose profile cannot push gets a publisher for the pass
+// that pushes committed changes and opens the pull request.
+func newAgentClients(flags *Flags, principles *config.Principles, councilSettings *council.Settings,
+	reviewerSettings *reviewer.Settings) (*agentClients, error) {
+	agents, err := agent.LoadFile(flags.AgentsFile)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := parsePermissions(flags.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	permissions.applyPrinciples(principles)
+	protectedPaths, err := newProtectedMatcher(principles)
+	if err != nil {
+		return nil, err
+	}
+
+	resolve := func(name string, role loop.Role) (loop.ClaudeClient, error) {
+		if name == "" {
+			name = flags.Agent
+		}
+		if name == "" || name == agent.BuiltinClaude {
+			return newClaudeClient(flags, permissions.For(role), roleModel(flags, role), protectedPaths), nil
+		}
+		cfg, err := agents.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		var opts *agent.Options
+		if flags.Stream {
+			opts = &agent.Options{Stream: os.Stdout}
+		}
+		return agent.NewCommandAgent(name, cfg, opts), nil
+	}
+
+	clients := &agentClients{}
+	if clients.Main, err = resolve(flags.Agent, loop.RoleIteration); err != nil {
+		return nil, err
+	}
+	if main, ok := clients.Main.(*claude.Client); ok && !main.Publishes() {
+		clients.Publisher = main.Publisher()
+	}
+	if clients.Reviewer, err = resolve(flags.ReviewerAgent, loop.RoleReviewer); err != nil {
+		return nil, err
+	}
+	if _, ok := clients.Reviewer.(*claude.Client); ok && reviewerSettings != nil {
+		for _, r := range reviewerSettings.Reviewers {
+			model := r.Model
+			if model == "" {
+				model = flags.ReviewerModel
+			}
+			if clients.Reviewers == nil {
+				clients.Reviewers = make(map[string]loop.ClaudeClient)
+			}
+			clients.Reviewers[r.Name] = newClaudeClient(flags, claude.ProfileReadOnly, model, protectedPaths)
+		}
+	}
+	if clients.Council, err = resolve(flags.CouncilAgent, loop.RoleCouncil); err != nil {
+		return nil, err
+	}
+	if _, ok := clients.Council.(*claude.Client); ok && councilSettings != nil {
+		models := make(map[string]string)
+		for _, m := range councilSettings.Members {
+			models[m.Name] = m.Model
+		}
+		models[council.ChairName] = councilSettings.Chair.Model
+		for name, model := range models {
+			if model == "" {
+				continue
+			}
+			if clients.CouncilMembers == nil {
+				clients.CouncilMembers = make(map[string]loop.ClaudeClient)
+			}
+			clients.CouncilMembers[name] = newClaudeClient(flags, permissions.For(loop.RoleCouncil), model, protectedPaths)
+		}
+	}
+	if clients.Planner, err = resolve(flags.PlannerAgent, loop.RolePlanner); err != nil {
+		return nil, err
+	}
+
+	if permissions.Restricted() {
+		fmt.Printf("Permissions: %s\n", permissions.Summary())
+	}
+	return clients, nil
+}
+
+// roleModel returns the --reviewer-model or --council-model value for role.
+// Other roles use claude's default model.
+func roleModel(flags *Flags, role loop.Role) string {
+	switch role {
+	case loop.RoleReviewer:
+		return flags.ReviewerModel
+	case loop.RoleCouncil:
+		return flags.CouncilModel
+	default:
+		return ""
+	}
+}
+
+// newRunClients resolves the clients for every role of a run, then applies --record or --replay.
+func newRunClients(flags *Flags, run *runInfo, principles *config.Principles, councilSettings *council.Settings,
+	reviewerSettings *reviewer.Settings) (*agentClients, error) {
+	clients, err := newAgentClients(flags, principles, councilSettings, reviewerSettings)
+	if err != nil {
+		return nil, err
+	}
+	if err := clients.applyCassette(flags, run, councilSettings, reviewerSettings); err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// applyCassette swaps every role for a replay client with --replay,
+// or wraps every role with a recorder with --record. Specialised reviewers,
+// council members and the chair are recorded and replayed under their own
+// names, so their parallel calls replay the same whatever order they ran in.
+func (c *agentClients) applyCassette(flags *Flags, run *runInfo, councilSettings *council.Settings,
+	reviewerSettings *reviewer.Settings) error {
+	var reviewers, members []string
+	if reviewerSettings != nil {
+		for _, r := range reviewerSettings.Reviewers {
+			reviewers = append(reviewers, r.Name)
+		}
+	}
+	if councilSettings != nil {
+		for _, m := range councilSettings.Members {
+			members = append(members, m.Name)
+		}
+		members = append(members, council.ChairName)
+	}
+
+	switch {
+	case flags.Replay != "":
+		var handler claude.StreamHandler
+		if flags.Stream {
+			handler = NewConsoleStreamHandler()
+		}
+		player, err := cassette.NewPlayer(flags.Replay, handler)
+		if err != nil {
+			return err
+		}
+		c.Main = player.Client(loop.RoleIteration)
+		c.Publisher = nil
+		c.Reviewer = player.Client(loop.RoleReviewer)
+		c.Reviewers = replayNamed(player, loop.RoleReviewer, c.Reviewers, reviewers)
+		c.Council = player.Client(loop.RoleCouncil)
+		c.CouncilMembers = replayNamed(player, loop.RoleCouncil, c.CouncilMembers, members)
+		c.Planner = player.Client(loop.RolePlanner)
+		fmt.Printf("Replaying %d recorded calls from %s\n", player.Len(), player.Dir())
+
+	case flags.Record:
+		recorder := cassette.NewRecorder(run.cassetteDir())
+		c.Main = recorder.Wrap(c.Main, loop.RoleIteration)
+		if c.Publisher != nil {
+			c.Publisher = recorder.Wrap(c.Publisher, loop.RoleIteration)
+		}
+		c.Reviewers = recordNamed(recorder, loop.RoleReviewer, c.Reviewers, c.Reviewer, reviewers)
+		c.Reviewer = recorder.Wrap(c.Reviewer, loop.RoleReviewer)
+		c.CouncilMembers = recordNamed(recorder, loop.RoleCouncil, c.CouncilMembers, c.Council, members)
+		c.Council = recorder.Wrap(c.Council, loop.RoleCouncil)
+		c.Planner = recorder.Wrap(c.Planner, loop.RolePlanner)
+		fmt.Printf("Recording Claude calls to %s\n", recorder.Dir())
+	}
+	return nil
+}
+
+// recordNamed wraps the client of every name, and of every name in clients,
+// with a recorder. Names without a client of their own record calls made
+// through shared.
+func recordNamed(recorder *cassette.Recorder, role loop.Role, clients map[string]loop.ClaudeClient,
+	shared loop.ClaudeClient, names []string) map[string]loop.ClaudeClient {
+	named := make(map[string]loop.ClaudeClient)
+	for _, name := range names {
+		named[name] = recorder.WrapNamed(shared, role, name)
+	}
+	for name, client := range clients {
+		named[name] = recorder.WrapNamed(client, role, name)
+	}
+	if len(named) == 0 {
+		return nil
+	}
+	return named
+}
+
+// replayNamed returns a replay client for every name, and every name in clients.
+func replayNamed(player *cassette.Player, role loop.Role, clients map[string]loop.ClaudeClient,
+	names []string) map[string]loop.ClaudeClient {
+	named := make(map[string]loop.ClaudeClient)
+	for _, name := range names {
+		named[name] = player.ClientNamed(role, name)
+	}
+	for name := range clients {
+		named[name] = player.ClientNamed(role, name)
+	}
+	if len(named) == 0 {
+		return nil
+	}
+	return named
+}
+
+// newClaudeClient creates the built-in Claude Code client with optional streaming.
+// An empty model uses claude's default. With protected paths, edits to them are
+// reported on stderr as they happen.
+func newClaudeClient(flags *Flags, permissions claude.PermissionProfile, model string, protectedPaths *protected.Matcher) *claude.Client {
+	clientOpts := &claude.ClientOptions{Permissions: permissions, Model: model}
+	if flags.Stream {
+		clientOpts.StreamHandler = NewConsoleStreamHandler()
+	}
+	if !protectedPaths.Empty() {
+		clientOpts.StreamHandler = newProtectedWatcher(clientOpts.StreamHandler, protectedPaths)
+	}
+	return claude.NewClient(clientOpts)
+}
@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/agent"
+	"github.com/DeukWoongWoo/claude-loop/internal/cassette"
+	"github.com/DeukWoongWoo/claude-loop/internal/claude"
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/DeukWoongWoo/claude-loop/internal/reviewer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAgentsFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "agents.yaml")
+	content := `agents:
+  aider:
+    command: aider
+    args: ["--message", "{prompt}"]
+  local:
+    command: ./bin/agent
+    prompt: stdin
+    output: jsonl
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestNewAgentClients(t *testing.T) {
+	t.Run("defaults to claude for every role", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.AgentsFile = filepath.Join(t.TempDir(), "missing.yaml")
+
+		clients, err := newAgentClients(flags, nil, nil, nil)
+		require.NoError(t, err)
+		for _, client := range []loop.ClaudeClient{clients.Main, clients.Reviewer, clients.Council, clients.Planner} {
+			require.IsType(t, &claude.Client{}, client)
+			assert.Equal(t, claude.ProfileFull, client.(*claude.Client).Permissions())
+		}
+	})
+
+	t.Run("roles fall back to the main agent", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.AgentsFile = writeAgentsFile(t)
+		flags.Agent = "aider"
+		flags.ReviewerAgent = "local"
+		flags.PlannerAgent = "claude"
+
+		clients, err := newAgentClients(flags, nil, nil, nil)
+		require.NoError(t, err)
+		require.IsType(t, &agent.CommandAgent{}, clients.Main)
+		assert.Equal(t, "aider", clients.Main.(*agent.CommandAgent).Name())
+		assert.Equal(t, "local", clients.Reviewer.(*agent.CommandAgent).Name())
+		assert.Equal(t, "aider", clients.Council.(*agent.CommandAgent).Name())
+		assert.IsType(t, &claude.Client{}, clients.Planner)
+	})
+
+	t.Run("unknown agent", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.AgentsFile = writeAgentsFile(t)
+		flags.CouncilAgent = "codex"
+
+		_, err := newAgentClients(flags, nil, nil, nil)
+		require.Error(t, err)
+		assert.True(t, agent.IsAgentError(err))
+		assert.Contains(t, err.Error(), "codex")
+	})
+}
+
+// stubClient returns an empty result for every prompt.
+type stubClient struct{}
+
+func (stubClient) Execute(ctx context.Context, prompt string) (*loop.IterationResult, error) {
+	return &loop.IterationResult{Output: "ok"}, nil
+}
+
+func TestNewAgentClients_Permissions(t *testing.T) {
+	profiles := func(t *testing.T, clients *agentClients) []claude.PermissionProfile {
+		t.Helper()
+		var out []claude.PermissionProfile
+		for _, client := range []loop.ClaudeClient{clients.Main, clients.Reviewer, clients.Council, clients.Planner} {
+			out = append(out, client.(*claude.Client).Permissions())
+		}
+		return out
+	}
+
+	t.Run("default from security posture", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.AgentsFile = filepath.Join(t.TempDir(), "missing.yaml")
+		flags.Permissions = []string{"reviewer=read-only"}
+
+		clients, err := newAgentClients(flags, config.DefaultPrinciples(config.PresetEnterprise), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []claude.PermissionProfile{
+			claude.ProfileEditTest, claude.ProfileReadOnly, claude.ProfileEditTest, claude.ProfileEditTest,
+		}, profiles(t, clients))
+
+		// The enterprise main profile cannot push, so the commit pass gets a publisher
+		require.NotNil(t, clients.Publisher)
+		publisher := clients.Publisher.(*claude.Client)
+		assert.Equal(t, claude.ProfileEditTest, publisher.Permissions())
+		assert.True(t, publisher.Publishes())
+		assert.False(t, clients.Main.(*claude.Client).Publishes())
+	})
+
+	t.Run("explicit default overrides posture", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.AgentsFile = filepath.Join(t.TempDir(), "missing.yaml")
+		flags.Permissions = []string{"full", "council=read-only", "planner=edit-only"}
+
+		clients, err := newAgentClients(flags, config.DefaultPrinciples(config.PresetEnterprise), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []claude.PermissionProfile{
+			claude.ProfileFull, claude.ProfileFull, claude.ProfileReadOnly, claude.ProfileEditOnly,
+		}, profiles(t, clients))
+		assert.Nil(t, clients.Publisher, "a full main client pushes itself")
+	})
+}
+
+func TestNewAgentClients_Models(t *testing.T) {
+	flags := DefaultFlags()
+	flags.AgentsFile = filepath.Join(t.TempDir(), "missing.yaml")
+	flags.ReviewerModel = "haiku"
+	flags.CouncilModel = "sonnet"
+
+	clients, err := newAgentClients(flags, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, clients.Main.(*claude.Client).Model())
+	assert.Equal(t, "haiku", clients.Reviewer.(*claude.Client).Model())
+	assert.Equal(t, "sonnet", clients.Council.(*claude.Client).Model())
+	assert.Empty(t, clients.Planner.(*claude.Client).Model())
+}
+
+func TestNewAgentClients_CouncilModels(t *testing.T) {
+	settings := &council.Settings{
+		Members: []council.Member{{Name: "security", Model: "opus"}, {Name: "cost"}},
+		Chair:   council.Chair{Model: "sonnet"},
+	}
+
+	t.Run("members with a model get their own client", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.AgentsFile = filepath.Join(t.TempDir(), "missing.yaml")
+		flags.Permissions = []string{"council=read-only"}
+
+		clients, err := newAgentClients(flags, nil, settings, nil)
+		require.NoError(t, err)
+		require.Len(t, clients.CouncilMembers, 2)
+		assert.Equal(t, "opus", clients.CouncilMembers["security"].(*claude.Client).Model())
+		assert.Equal(t, "sonnet", clients.CouncilMembers[council.ChairName].(*claude.Client).Model())
+		assert.Equal(t, claude.ProfileReadOnly, clients.CouncilMembers["security"].(*claude.Client).Permissions())
+	})
+
+	t.Run("command agents ignore member models", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.AgentsFile = writeAgentsFile(t)
+		flags.CouncilAgent = "aider"
+
+		clients, err := newAgentClients(flags, nil, settings, nil)
+		require.NoError(t, err)
+		assert.Nil(t, clients.CouncilMembers)
+	})
+}
+
+func TestNewAgentClients_Reviewers(t *testing.T) {
+	settings := &reviewer.Settings{Reviewers: []reviewer.Spec{
+		{Name: "tests", Prompt: "Check the tests", Model: "haiku"},
+		{Name: "security", Prompt: "Check for vulnerabilities"},
+	}}
+
+	t.Run("each reviewer gets a read-only client", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.AgentsFile = filepath.Join(t.TempDir(), "missing.yaml")
+		flags.Permissions = []string{"full", "reviewer=edit+test"}
+		flags.ReviewerModel = "sonnet"
+
+		clients, err := newAgentClients(flags, nil, nil, settings)
+		require.NoError(t, err)
+		require.Len(t, clients.Reviewers, 2)
+		assert.Equal(t, "haiku", clients.Reviewers["tests"].(*claude.Client).Model())
+		assert.Equal(t, "sonnet", clients.Reviewers["security"].(*claude.Client).Model())
+		assert.Equal(t, claude.ProfileReadOnly, clients.Reviewers["security"].(*claude.Client).Permissions())
+		assert.Equal(t, claude.ProfileReadOnly, clients.Reviewers["tests"].(*claude.Client).Permissions(), "not the reviewer profile")
+		assert.Equal(t, claude.ProfileEditTest, clients.Reviewer.(*claude.Client).Permissions())
+		assert.Equal(t, claude.ProfileFull, clients.Main.(*claude.Client).Permissions())
+	})
+
+	t.Run("command agents are shared", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.AgentsFile = writeAgentsFile(t)
+		flags.ReviewerAgent = "aider"
+
+		clients, err := newAgentClients(flags, nil, nil, settings)
+		require.NoError(t, err)
+		assert.Nil(t, clients.Reviewers)
+	})
+}
+
+func TestLoadCouncilSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "council.yaml")
+	content := `members:
+  - name: security
+    principles: [security_posture]
+  - name: product
+    principles: [ux_philosophy]
+  - name: cost
+    principles: [cost_efficiency]
+max_cost: 0.5
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	flags := DefaultFlags()
+	flags.CouncilFile = path
+	flags.CouncilMembers = 2
+	settings, err := loadCouncilSettings(flags)
+	require.NoError(t, err)
+	require.Len(t, settings.Members, 2)
+	assert.Equal(t, "product", settings.Members[1].Name)
+	assert.Equal(t, 0.5, settings.MaxCost)
+
+	flags.CouncilMaxCost = 0.2
+	settings, err = loadCouncilSettings(flags)
+	require.NoError(t, err)
+	assert.Equal(t, 0.2, settings.MaxCost)
+
+	flags.CouncilFile = filepath.Join(t.TempDir(), "missing.yaml")
+	flags.CouncilMembers = 0
+	settings, err = loadCouncilSettings(flags)
+	require.NoError(t, err)
+	assert.Len(t, settings.Members, len(council.DefaultMembers()))
+}
+
+func TestAgentClients_ApplyCassette(t *testing.T) {
+	t.Run("record wraps every role", func(t *testing.T) {
+		run := &runInfo{ID: "run-1", Dir: t.TempDir()}
+		clients := &agentClients{Main: stubClient{}, Reviewer: stubClient{}, Council: stubClient{}, Planner: stubClient{},
+			Reviewers: map[string]loop.ClaudeClient{"tests": stubClient{}}}
+
+		flags := DefaultFlags()
+		flags.Record = true
+		require.NoError(t, clients.applyCassette(flags, run, nil, nil))
+
+		_, err := clients.Reviewer.Execute(context.Background(), "review")
+		require.NoError(t, err)
+		_, err = clients.Reviewers["tests"].Execute(context.Background(), "review tests")
+		require.NoError(t, err)
+		interactions, err := cassette.Load(run.cassetteDir())
+		require.NoError(t, err)
+		require.Len(t, interactions, 2)
+		assert.Equal(t, loop.RoleReviewer, interactions[0].Role)
+		assert.Equal(t, loop.RoleReviewer, interactions[1].Role)
+		assert.Equal(t, "tests", interactions[1].Name)
+	})
+
+	t.Run("members and reviewers sharing a client record under their names", func(t *testing.T) {
+		run := &runInfo{ID: "run-1", Dir: t.TempDir()}
+		clients := &agentClients{Main: stubClient{}, Reviewer: stubClient{}, Council: stubClient{}, Planner: stubClient{}}
+		councilSettings := &council.Settings{Members: council.DefaultMembers()}
+		reviewerSettings := &reviewer.Settings{Reviewers: []reviewer.Spec{{Name: "security"}}}
+
+		flags := DefaultFlags()
+		flags.Record = true
+		require.NoError(t, clients.applyCassette(flags, run, councilSettings, reviewerSettings))
+		require.Len(t, clients.CouncilMembers, len(councilSettings.Members)+1)
+		require.Contains(t, clients.Reviewers, "security")
+
+		member := councilSettings.Members[0].Name
+		_, err := clients.CouncilMembers[member].Execute(context.Background(), "vote")
+		require.NoError(t, err)
+		_, err = clients.CouncilMembers[council.ChairName].Execute(context.Background(), "decide")
+		require.NoError(t, err)
+		_, err = clients.Reviewers["security"].Execute(context.Background(), "check")
+		require.NoError(t, err)
+		interactions, err := cassette.Load(run.cassetteDir())
+		require.NoError(t, err)
+		require.Len(t, interactions, 3)
+		assert.Equal(t, member, interactions[0].Name)
+		assert.Equal(t, council.ChairName, interactions[1].Name)
+		assert.Equal(t, "security", interactions[2].Name)
+	})
+
+	t.Run("replay replaces every role", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := cassette.Save(dir, &cassette.Interaction{
+			Seq: 1, Role: loop.RolePlanner, Call: 1, Prompt: "plan", Duration: time.Second,
+			Lines: []string{`{"type":"result","result":"planned","total_cost_usd":0.3,"is_error":false}`},
+		})
+		require.NoError(t, err)
+
+		clients := &agentClients{Reviewers: map[string]loop.ClaudeClient{"tests": stubClient{}}}
+		flags := DefaultFlags()
+		flags.Replay = dir
+		councilSettings := &council.Settings{Members: council.DefaultMembers()}
+		require.NoError(t, clients.applyCassette(flags, &runInfo{}, councilSettings, nil))
+		require.Contains(t, clients.Reviewers, "tests")
+		_, isStub := clients.Reviewers["tests"].(stubClient)
+		assert.False(t, isStub, "specialised reviewers replay instead of calling their client")
+		assert.Len(t, clients.CouncilMembers, len(councilSettings.Members)+1)
+
+		result, err := clients.Planner.Execute(context.Background(), "plan")
+		require.NoError(t, err)
+		assert.Equal(t, 0.3, result.Cost)
+		_, err = clients.Main.Execute(context.Background(), "work")
+		assert.True(t, cassette.IsCassetteError(err))
+	})
+
+	t.Run("missing cassette", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.Replay = filepath.Join(t.TempDir(), "missing")
+		err := (&agentClients{}).applyCassette(flags, &runInfo{}, nil, nil)
+		assert.True(t, cassette.IsCassetteError(err))
+	})
+}
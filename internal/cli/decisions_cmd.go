@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/spf13/cobra"
+)
+
+// DecisionsOptions holds flag values for `decisions`.
+type DecisionsOptions struct {
+	File      string // --file: Structured decision log
+	Since     string // --since: First day to include (YYYY-MM-DD)
+	Until     string // --until: Last day to include (YYYY-MM-DD)
+	Principle string // --principle: Only decisions citing this principle
+	Council   bool   // --council: Only decisions the council made
+	Search    string // --search: Text to match in the decision, rationale or rule
+	Stats     bool   // --stats: Print summary stats instead of the list
+}
+
+var decisionsOpts = &DecisionsOptions{}
+
+// decisionsCmd lists and summarizes logged principle decisions.
+var decisionsCmd = &cobra.Command{
+	Use:   "decisions",
+	Short: "List and summarize logged principle decisions",
+	Long: `List the decisions recorded in .claude/principles-decisions.jsonl by runs with
+--log-decisions, oldest first. Filter by date with --since/--until (YYYY-MM-DD,
+inclusive), by cited principle with --principle, to council decisions with
+--council, and by text with --search. --stats shows which principles drive the
+most decisions instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := decisionsOpts.filter()
+		if err != nil {
+			return err
+		}
+		records, err := council.ReadDecisions(decisionsOpts.File)
+		if err != nil {
+			return err
+		}
+		records = council.FilterDecisions(records, filter)
+		if decisionsOpts.Stats {
+			writeDecisionStats(cmd.OutOrStdout(), council.ComputeDecisionStats(records))
+			return nil
+		}
+		writeDecisionList(cmd.OutOrStdout(), records)
+		return nil
+	},
+}
+
+func init() {
+	f := decisionsCmd.Flags()
+	f.StringVar(&decisionsOpts.File, "file", council.DefaultDecisionsFile, "Structured decision log")
+	f.StringVar(&decisionsOpts.Since, "since", "", "Only decisions made on or after this date (YYYY-MM-DD)")
+	f.StringVar(&decisionsOpts.Until, "until", "", "Only decisions made on or before this date (YYYY-MM-DD)")
+	f.StringVar(&decisionsOpts.Principle, "principle", "", "Only decisions citing this principle")
+	f.BoolVar(&decisionsOpts.Council, "council", false, "Only decisions the council made")
+	f.StringVar(&decisionsOpts.Search, "search", "", "Only decisions whose text or rule contains this")
+	f.BoolVar(&decisionsOpts.Stats, "stats", false, "Show which principles drive the most decisions")
+
+	decisionsCmd.SetHelpTemplate(subcommandHelpTemplate)
+	rootCmd.AddCommand(decisionsCmd)
+}
+
+// filter converts the flag values to a council.DecisionFilter.
+func (o *DecisionsOptions) filter() (*council.DecisionFilter, error) {
+	dates, err := (&HistoryFilterOptions{Since: o.Since, Until: o.Until}).filter()
+	if err != nil {
+		return nil, err
+	}
+	f := &council.DecisionFilter{Since: dates.Since, Until: dates.Until, CouncilOnly: o.Council, Text: o.Search}
+	if o.Principle != "" {
+		key, ok := config.NormalizePrincipleKey(o.Principle)
+		if !ok {
+			return nil, fmt.Errorf("unknown principle %q", o.Principle)
+		}
+		f.Principle = key
+	}
+	return f, nil
+}
+
+// writeDecisionList prints one line per decision.
+func writeDecisionList(w io.Writer, records []*council.DecisionRecord) {
+	if len(records) == 0 {
+		fmt.Fprintln(w, "No decisions recorded.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tRUN\tITER\tBY\tPRINCIPLES\tDECISION")
+	for _, r := range records {
+		run := r.RunID
+		if run == "" {
+			run = "-"
+		}
+		principles := make([]string, len(r.Principles))
+		for i, p := range r.Principles {
+			principles[i] = shortPrincipleKey(p)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			r.Timestamp.Local().Format("2006-01-02 15:04"),
+			run,
+			r.Iteration,
+			decisionSource(r),
+			strings.Join(principles, ","),
+			truncateString(r.Decision, 60),
+		)
+	}
+	tw.Flush()
+}
+
+// writeDecisionStats prints decision counts by source and by cited principle.
+func writeDecisionStats(w io.Writer, stats *council.DecisionStats) {
+	if stats.Decisions == 0 {
+		fmt.Fprintln(w, "No decisions recorded.")
+		return
+	}
+
+	fmt.Fprintf(w, "Decisions: %d (council %d, rules %d, logged %d)\n",
+		stats.Decisions, stats.Council, stats.Rules, stats.Decisions-stats.Council-stats.Rules)
+	fmt.Fprintf(w, "Council cost: $%.4f\n", stats.Cost)
+
+	if len(stats.ByRule) > 0 {
+		rules := make([]string, 0, len(stats.ByRule))
+		for rule := range stats.ByRule {
+			rules = append(rules, rule)
+		}
+		sort.Strings(rules)
+		parts := make([]string, len(rules))
+		for i, rule := range rules {
+			parts[i] = fmt.Sprintf("%s %d", rule, stats.ByRule[rule])
+		}
+		fmt.Fprintf(w, "Rules: %s\n", strings.Join(parts, ", "))
+	}
+
+	if len(stats.ByPrinciple) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nBy principle:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  PRINCIPLE\tDECISIONS\tCOUNCIL\tSHARE")
+	for _, pc := range stats.ByPrinciple {
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%.0f%%\n", pc.Principle, pc.Decisions, pc.Council,
+			float64(pc.Decisions)/float64(stats.Decisions)*100)
+	}
+	tw.Flush()
+}
+
+// decisionSource names who made a decision: a human, the council, a rule or the iteration.
+func decisionSource(r *council.DecisionRecord) string {
+	switch {
+	case r.Escalated:
+		return "human"
+	case r.CouncilInvoked:
+		return "council"
+	case r.Rule != "":
+		return r.Rule
+	default:
+		return "logged"
+	}
+}
+
+// shortPrincipleKey drops the layer prefix from a principle key.
+func shortPrincipleKey(key string) string {
+	_, name, found := strings.Cut(key, ".")
+	if !found {
+		return key
+	}
+	return name
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecisionsOptions_Filter(t *testing.T) {
+	t.Run("dates, principle and flags", func(t *testing.T) {
+		f, err := (&DecisionsOptions{Since: "2026-10-01", Until: "2026-10-31", Principle: "blast_radius",
+			Council: true, Search: "cache"}).filter()
+		require.NoError(t, err)
+
+		assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), f.Since)
+		assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local), f.Until)
+		assert.Equal(t, "layer1.blast_radius", f.Principle)
+		assert.True(t, f.CouncilOnly)
+		assert.Equal(t, "cache", f.Text)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		_, err := (&DecisionsOptions{Since: "yesterday"}).filter()
+		assert.ErrorContains(t, err, "invalid --since")
+
+		_, err = (&DecisionsOptions{Principle: "velocity"}).filter()
+		assert.ErrorContains(t, err, `unknown principle "velocity"`)
+	})
+}
+
+func testDecisionRecords() []*council.DecisionRecord {
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local)
+	return []*council.DecisionRecord{
+		{Timestamp: at, RunID: "run-20261001-090000", Iteration: 1, Decision: "Prioritize security_posture (9) over urgency_tiers (5)",
+			Principles: []string{"layer1.security_posture", "layer1.urgency_tiers"}, Rule: "R4"},
+		{Timestamp: at.Add(time.Hour), RunID: "run-20261001-090000", Iteration: 2, Decision: "Curate by hand",
+			Principles: []string{"layer0.curation_model", "layer1.security_posture"}, CouncilInvoked: true, Cost: 0.25},
+		{Timestamp: at.Add(2 * time.Hour), Iteration: 3, Decision: "Use sqlite"},
+	}
+}
+
+func TestWriteDecisionList(t *testing.T) {
+	var buf bytes.Buffer
+	writeDecisionList(&buf, testDecisionRecords())
+
+	out := buf.String()
+	assert.Contains(t, out, "TIME")
+	assert.Contains(t, out, "2026-10-01 09:00")
+	assert.Contains(t, out, "R4")
+	assert.Contains(t, out, "security_posture,urgency_tiers")
+	assert.Contains(t, out, "council")
+	assert.Contains(t, out, "logged")
+
+	buf.Reset()
+	writeDecisionList(&buf, []*council.DecisionRecord{{Decision: "Audit first", CouncilInvoked: true, Escalated: true}})
+	assert.Contains(t, buf.String(), "human")
+
+	buf.Reset()
+	writeDecisionList(&buf, nil)
+	assert.Equal(t, "No decisions recorded.\n", buf.String())
+}
+
+func TestWriteDecisionStats(t *testing.T) {
+	var buf bytes.Buffer
+	writeDecisionStats(&buf, council.ComputeDecisionStats(testDecisionRecords()))
+
+	out := buf.String()
+	assert.Contains(t, out, "Decisions: 3 (council 1, rules 1, logged 1)\n")
+	assert.Contains(t, out, "Council cost: $0.2500\n")
+	assert.Contains(t, out, "Rules: R4 1\n")
+	assert.Regexp(t, `layer1.security_posture\s+2\s+1\s+67%`, out)
+
+	buf.Reset()
+	writeDecisionStats(&buf, council.ComputeDecisionStats(nil))
+	assert.Equal(t, "No decisions recorded.\n", buf.String())
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/github"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+)
+
+// issueCreator opens GitHub issues; *github.IssueManager implements it.
+type issueCreator interface {
+	Create(ctx context.Context, title, body string) (string, error)
+}
+
+// escalator hands uncertain council outcomes to a human: it asks on a terminal
+// in auto mode, and otherwise writes an escalation file the human answers in,
+// opening a GitHub issue for it in issue mode.
+type escalator struct {
+	dir    string
+	ask    bool // Ask on stdin before writing a file
+	in     *bufio.Reader
+	out    io.Writer
+	issues issueCreator // nil = no issues
+}
+
+// newEscalator builds the Escalator for --escalation. Returns nil with "off".
+func newEscalator(flags *Flags) loop.Escalator {
+	mode := council.EscalationMode(flags.Escalation)
+	if mode == "" {
+		mode = council.EscalateAuto
+	}
+	if mode == council.EscalateOff {
+		return nil
+	}
+	e := &escalator{
+		dir: council.DefaultEscalationDir,
+		ask: mode == council.EscalateAuto && stdinInteractive(),
+		in:  bufio.NewReader(os.Stdin),
+		out: os.Stderr,
+	}
+	if mode == council.EscalateIssue {
+		var repo *github.RepoInfo
+		if flags.Owner != "" && flags.Repo != "" {
+			repo = &github.RepoInfo{Owner: flags.Owner, Repo: flags.Repo}
+		}
+		e.issues = github.NewIssueManager(nil, repo)
+	}
+	return e
+}
+
+// Escalate asks for a decision on esc, or leaves it pending in an escalation file.
+func (e *escalator) Escalate(ctx context.Context, esc *council.Escalation) error {
+	if e.ask {
+		if decision := e.prompt(esc); decision != "" {
+			esc.Decision = decision
+			return nil
+		}
+	}
+
+	if err := council.WriteEscalation(e.dir, esc); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.out, "Escalated a principle conflict (%s); write your decision in %s\n", esc.Reason, esc.Path)
+
+	if e.issues != nil {
+		title := "Principle conflict needs a decision"
+		if names := esc.Conflict.Names(); names != "" {
+			title += ": " + names
+		}
+		body := council.FormatEscalation(esc) + "\nAnswer by writing the decision in `" + esc.Path + "` of the working copy running claude-loop.\n"
+		url, err := e.issues.Create(ctx, title, body)
+		if err != nil {
+			fmt.Fprintf(e.out, "Warning: %v\n", err)
+			return nil
+		}
+		esc.URL = url
+		fmt.Fprintf(e.out, "Opened %s\n", url)
+	}
+	return nil
+}
+
+// Answer reads the decision written in a pending escalation's file.
+func (e *escalator) Answer(ctx context.Context, esc *council.Escalation) (string, error) {
+	if esc.Path == "" {
+		return "", nil
+	}
+	return council.ReadEscalationDecision(esc.Path)
+}
+
+// prompt asks for a decision on the terminal. An option number picks that
+// option, "a" accepts the council's proposal and other text is the decision.
+// Returns "" when the human decides later.
+func (e *escalator) prompt(esc *council.Escalation) string {
+	fmt.Fprintf(e.out, "\nA principle conflict needs your decision (%s)\n\n%s\n", esc.Reason, esc.Conflict)
+	if esc.Proposal != "" {
+		fmt.Fprintf(e.out, "Council proposal: %s\n", esc.Proposal)
+	}
+	choices := "Type your decision"
+	if len(esc.Conflict.Options) > 0 {
+		choices = fmt.Sprintf("Choose an option (1-%d) or type your decision", len(esc.Conflict.Options))
+	}
+	if esc.Proposal != "" {
+		choices += `, "a" to accept the proposal`
+	}
+	fmt.Fprintf(e.out, "%s, or press Enter to decide later: ", choices)
+
+	line, err := e.in.ReadString('\n')
+	if err != nil && line == "" {
+		return ""
+	}
+	answer := strings.TrimSpace(line)
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(esc.Conflict.Options) {
+		return esc.Conflict.Options[n-1]
+	}
+	if strings.EqualFold(answer, "a") && esc.Proposal != "" {
+		return esc.Proposal
+	}
+	return answer
+}
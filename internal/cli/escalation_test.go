@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeIssueCreator struct {
+	title, body string
+	err         error
+}
+
+func (f *fakeIssueCreator) Create(ctx context.Context, title, body string) (string, error) {
+	f.title, f.body = title, body
+	if f.err != nil {
+		return "", f.err
+	}
+	return "https://github.com/o/r/issues/7", nil
+}
+
+func testEscalation() *council.Escalation {
+	var conflict council.Conflict
+	conflict.Principles = [2]string{"layer1.speed_correctness", "layer1.security_posture"}
+	conflict.Options = []string{"Ship now", "Audit first"}
+	return council.NewEscalation("run-1", 2, conflict, "confidence 0.40 is below 0.60",
+		&council.Result{Resolution: "Audit first", Rationale: "Security"})
+}
+
+func TestNewEscalator(t *testing.T) {
+	t.Run("off", func(t *testing.T) {
+		assert.Nil(t, newEscalator(&Flags{Escalation: "off"}))
+	})
+
+	t.Run("auto asks on a terminal", func(t *testing.T) {
+		setStdinInteractive(t, true)
+		e := newEscalator(&Flags{Escalation: "auto"}).(*escalator)
+		assert.True(t, e.ask)
+		assert.Nil(t, e.issues)
+		assert.Equal(t, council.DefaultEscalationDir, e.dir)
+	})
+
+	t.Run("auto without a terminal", func(t *testing.T) {
+		setStdinInteractive(t, false)
+		assert.False(t, newEscalator(&Flags{Escalation: "auto"}).(*escalator).ask)
+	})
+
+	t.Run("file never asks", func(t *testing.T) {
+		setStdinInteractive(t, true)
+		assert.False(t, newEscalator(&Flags{Escalation: "file"}).(*escalator).ask)
+	})
+
+	t.Run("issue", func(t *testing.T) {
+		e := newEscalator(&Flags{Escalation: "issue", Owner: "o", Repo: "r"}).(*escalator)
+		assert.NotNil(t, e.issues)
+	})
+}
+
+func TestEscalator_Prompt(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "option number", input: "1\n", want: "Ship now"},
+		{name: "accept proposal", input: "a\n", want: "Audit first"},
+		{name: "own decision", input: "Ship behind a flag\n", want: "Ship behind a flag"},
+		{name: "no newline", input: "2", want: "Audit first"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			e := &escalator{dir: t.TempDir(), ask: true, in: bufio.NewReader(strings.NewReader(tt.input)), out: &out}
+			esc := testEscalation()
+
+			require.NoError(t, e.Escalate(context.Background(), esc))
+			assert.Equal(t, tt.want, esc.Decision)
+			assert.Empty(t, esc.Path, "no file when answered")
+			assert.Contains(t, out.String(), "A principle conflict needs your decision (confidence 0.40 is below 0.60)")
+			assert.Contains(t, out.String(), `Choose an option (1-2) or type your decision, "a" to accept the proposal`)
+		})
+	}
+}
+
+func TestEscalator_DecideLater(t *testing.T) {
+	var out bytes.Buffer
+	dir := t.TempDir()
+	e := &escalator{dir: dir, ask: true, in: bufio.NewReader(strings.NewReader("\n")), out: &out}
+	esc := testEscalation()
+
+	require.NoError(t, e.Escalate(context.Background(), esc))
+	assert.Empty(t, esc.Decision)
+	assert.Equal(t, filepath.Join(dir, "run-1-2.md"), esc.Path)
+	assert.Contains(t, out.String(), "write your decision in "+esc.Path)
+
+	decision, err := e.Answer(context.Background(), esc)
+	require.NoError(t, err)
+	assert.Empty(t, decision)
+
+	f, err := os.OpenFile(esc.Path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString("Audit first\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	decision, err = e.Answer(context.Background(), esc)
+	require.NoError(t, err)
+	assert.Equal(t, "Audit first", decision)
+}
+
+func TestEscalator_Issue(t *testing.T) {
+	t.Run("opens an issue", func(t *testing.T) {
+		issues := &fakeIssueCreator{}
+		e := &escalator{dir: t.TempDir(), out: &bytes.Buffer{}, issues: issues}
+		esc := testEscalation()
+
+		require.NoError(t, e.Escalate(context.Background(), esc))
+		assert.Equal(t, "https://github.com/o/r/issues/7", esc.URL)
+		assert.Equal(t, "Principle conflict needs a decision: speed_correctness vs security_posture", issues.title)
+		assert.Contains(t, issues.body, "## Council Proposal")
+		assert.Contains(t, issues.body, esc.Path)
+	})
+
+	t.Run("issue failure only warns", func(t *testing.T) {
+		var out bytes.Buffer
+		e := &escalator{dir: t.TempDir(), out: &out, issues: &fakeIssueCreator{err: errors.New("gh not found")}}
+		esc := testEscalation()
+
+		require.NoError(t, e.Escalate(context.Background(), esc))
+		assert.Empty(t, esc.URL)
+		assert.NotEmpty(t, esc.Path)
+		assert.Contains(t, out.String(), "Warning: gh not found")
+	})
+}
+
+func TestEscalator_Answer_NoFile(t *testing.T) {
+	decision, err := (&escalator{}).Answer(context.Background(), testEscalation())
+	require.NoError(t, err)
+	assert.Empty(t, decision)
+}
@@ -1,7 +1,11 @@
 // Package cli provides the command-line interface for claude-loop.
 package cli
 
-import "time"
+import (
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+)
 
 // Flags holds all CLI flag values for claude-loop.
 type Flags struct {
@@ -28,12 +32,48 @@ type Flags struct {
 
 	// Review & CI
 	ReviewPrompt   string // -r, --review-prompt: Reviewer pass prompt
+	ReviewersFile  string // --reviewers-file: Specialised reviewers and the diff size cap
 	DisableCIRetry bool   // --disable-ci-retry: Disable CI failure retry
 	CIRetryMax     int    // --ci-retry-max: Maximum CI fix attempts
 
 	// Shared state
 	NotesFile string // --notes-file: Shared notes file path
 
+	// Prompt customization
+	TemplatesDir string // --templates-dir: Directory of prompt template overrides
+
+	// Agent backends
+	Agent         string // --agent: Agent for main iterations (default: claude)
+	ReviewerAgent string // --reviewer-agent: Agent for reviewer passes (default: --agent)
+	CouncilAgent  string // --council-agent: Agent for council resolution (default: --agent)
+	PlannerAgent  string // --planner-agent: Agent for planning phases (default: --agent)
+	AgentsFile    string // --agents-file: Command agent definitions
+
+	// Permissions
+	Permissions []string // --permissions: Permission profile, or role=profile, for Claude calls
+
+	// Runtime policy
+	Verification  string   // --verification: relaxed, standard or strict (default from principles)
+	Verify        []string // --verify: Success criterion checked after each iteration, e.g. "go test"
+	DraftPRs      bool     // --draft-prs: Open pull requests as drafts (default from principles)
+	ReviewerModel string   // --reviewer-model: Model for reviewer passes (default from principles)
+	CouncilModel  string   // --council-model: Model for council resolution (default from principles)
+
+	// Council
+	CouncilFile    string  // --council-file: Council members, chair and cost cap
+	CouncilMembers int     // --council-members: Members to convene (0 = all, 1 = a single resolution call)
+	CouncilMaxCost float64 // --council-max-cost: Cost cap per council invocation in USD (0 = from the council file)
+
+	// Escalation
+	Escalation           string  // --escalation: How uncertain council outcomes reach a human: auto, file, issue, off
+	EscalationConfidence float64 // --escalation-confidence: Council confidence below which a decision is escalated
+
+	// Secret scanning
+	DisableSecretScan bool     // --disable-secret-scan: Skip scanning iteration changes for secrets
+	SecretPatterns    []string // --secret-pattern: Extra secret regex, optionally named (id=regex)
+	SecretsAllowlist  string   // --secrets-allowlist: File of allowlisted finding fingerprints
+	SecretAction      string   // --secret-action: What the commit check does with secrets: block, unstage, redact
+
 	// Worktree support
 	Worktree        string // --worktree: Git worktree name
 	WorktreeBaseDir string // --worktree-base-dir: Base directory for worktrees
@@ -41,13 +81,20 @@ type Flags struct {
 	ListWorktrees   bool   // --list-worktrees: List worktrees and exit
 
 	// Principles framework
-	ResetPrinciples bool   // --reset-principles: Force re-collection of principles
-	PrinciplesFile  string // --principles-file: Custom principles file path
-	LogDecisions    bool   // --log-decisions: Enable decision logging
+	ResetPrinciples    bool     // --reset-principles: Force re-collection of principles
+	PrinciplesFile     string   // --principles-file: Custom principles file path
+	Preset             string   // --preset: Preset for a missing principles file
+	PrincipleOverrides []string // --principle: Override a principle for this run (key=value, repeatable)
+	LogDecisions       bool     // --log-decisions: Enable decision logging
 
 	// Output control
-	Verbose bool // --verbose: Show detailed iteration summaries
-	Stream  bool // --stream: Stream Claude output in real-time
+	Verbose     bool // --verbose: Show detailed iteration summaries
+	Stream      bool // --stream: Stream Claude output in real-time
+	DumpPrompts bool // --dump-prompts: Save every prompt sent under the run directory
+
+	// Record & replay
+	Record bool   // --record: Save every Claude call as a cassette under the run directory
+	Replay string // --replay: Replay Claude calls from a cassette directory instead of calling Claude
 
 	// Update management
 	AutoUpdate     bool // --auto-update: Auto-install updates
@@ -57,6 +104,10 @@ type Flags struct {
 	Plan     bool   // --plan: Enable planning mode (PRD → Architecture → Tasks)
 	PlanOnly bool   // --plan-only: Generate plan without execution
 	Resume   string // --resume: Resume from saved plan ID
+
+	// presets holds the custom presets from config.PresetDirs, loaded before
+	// validation. Nil means only the built-in presets.
+	presets *config.PresetRegistry
 }
 
 // DefaultFlags returns a Flags struct with default values as defined in CLI_CONTRACT.md.
@@ -71,11 +122,29 @@ func DefaultFlags() *Flags {
 		CompletionThreshold: 3,
 
 		// Review & CI defaults
-		CIRetryMax: 1,
+		ReviewersFile: ".claude/reviewers.yaml",
+		CIRetryMax:    1,
 
 		// Shared state defaults
 		NotesFile: "SHARED_TASK_NOTES.md",
 
+		// Prompt customization defaults
+		TemplatesDir: ".claude/templates",
+
+		// Agent backend defaults
+		AgentsFile: ".claude/agents.yaml",
+
+		// Council defaults
+		CouncilFile: ".claude/council.yaml",
+
+		// Escalation defaults
+		Escalation:           "auto",
+		EscalationConfidence: 0.6,
+
+		// Secret scanning defaults
+		SecretsAllowlist: ".claude/secrets-allowlist",
+		SecretAction:     "block",
+
 		// Worktree defaults
 		WorktreeBaseDir: "../claude-loop-worktrees",
 
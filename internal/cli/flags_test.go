@@ -22,6 +22,15 @@ func TestDefaultFlags(t *testing.T) {
 	assert.Equal(t, "SHARED_TASK_NOTES.md", f.NotesFile)
 	assert.Equal(t, "../claude-loop-worktrees", f.WorktreeBaseDir)
 	assert.Equal(t, ".claude/principles.yaml", f.PrinciplesFile)
+	assert.Equal(t, ".claude/templates", f.TemplatesDir)
+	assert.Equal(t, ".claude/agents.yaml", f.AgentsFile)
+	assert.Equal(t, ".claude/secrets-allowlist", f.SecretsAllowlist)
+	assert.Equal(t, "block", f.SecretAction)
+	assert.Equal(t, ".claude/council.yaml", f.CouncilFile)
+	assert.Equal(t, ".claude/reviewers.yaml", f.ReviewersFile)
+	assert.Equal(t, "auto", f.Escalation)
+	assert.Equal(t, 0.6, f.EscalationConfidence)
+	assert.Empty(t, f.Agent)
 
 	// Boolean defaults should be false
 	assert.False(t, f.DisableCommits)
@@ -36,6 +45,7 @@ func TestDefaultFlags(t *testing.T) {
 	assert.False(t, f.Stream)
 	assert.False(t, f.AutoUpdate)
 	assert.False(t, f.DisableUpdates)
+	assert.False(t, f.DisableSecretScan)
 
 	// Planning mode defaults should be false/empty
 	assert.False(t, f.Plan)
@@ -179,6 +189,107 @@ func TestFlagParsing(t *testing.T) {
 				assert.Equal(t, "NOTES.md", globalFlags.NotesFile)
 			},
 		},
+		{
+			name: "templates-dir flag",
+			args: []string{"-p", "x", "-m", "1", "--templates-dir", "prompts"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, "prompts", globalFlags.TemplatesDir)
+			},
+		},
+		{
+			name: "permissions flag",
+			args: []string{"-p", "x", "-m", "1", "--permissions", "edit+test", "--permissions", "reviewer=read-only,council=read-only"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, []string{"edit+test", "reviewer=read-only", "council=read-only"}, globalFlags.Permissions)
+			},
+		},
+		{
+			name: "runtime policy flags",
+			args: []string{"-p", "x", "-m", "1", "--verification", "strict", "--draft-prs",
+				"--reviewer-model", "haiku", "--council-model", "sonnet"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, "strict", globalFlags.Verification)
+				assert.True(t, globalFlags.DraftPRs)
+				assert.Equal(t, "haiku", globalFlags.ReviewerModel)
+				assert.Equal(t, "sonnet", globalFlags.CouncilModel)
+			},
+		},
+		{
+			name: "verify flags",
+			args: []string{"-p", "x", "-m", "1", "--verify", "go build", "--verify", "go test, with race detector"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, []string{"go build", "go test, with race detector"}, globalFlags.Verify)
+			},
+		},
+		{
+			name: "council flags",
+			args: []string{"-p", "x", "-m", "1", "--council-file", "council.yaml", "--council-members", "3", "--council-max-cost", "0.25"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, "council.yaml", globalFlags.CouncilFile)
+				assert.Equal(t, 3, globalFlags.CouncilMembers)
+				assert.Equal(t, 0.25, globalFlags.CouncilMaxCost)
+			},
+		},
+		{
+			name: "reviewers file",
+			args: []string{"-p", "x", "-m", "1", "--reviewers-file", "reviewers.yaml"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, "reviewers.yaml", globalFlags.ReviewersFile)
+			},
+		},
+		{
+			name: "escalation flags",
+			args: []string{"-p", "x", "-m", "1", "--escalation", "issue", "--escalation-confidence", "0.75"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, "issue", globalFlags.Escalation)
+				assert.Equal(t, 0.75, globalFlags.EscalationConfidence)
+			},
+		},
+		{
+			name: "secret scanning flags",
+			args: []string{"-p", "x", "-m", "1", "--secret-pattern", "corp=corp_[a-z]{2,4}", "--secret-pattern", "x-[0-9]+",
+				"--secrets-allowlist", "allow.txt", "--disable-secret-scan", "--secret-action", "redact"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, []string{"corp=corp_[a-z]{2,4}", "x-[0-9]+"}, globalFlags.SecretPatterns)
+				assert.Equal(t, "allow.txt", globalFlags.SecretsAllowlist)
+				assert.True(t, globalFlags.DisableSecretScan)
+				assert.Equal(t, "redact", globalFlags.SecretAction)
+			},
+		},
+		{
+			name: "principle overrides",
+			args: []string{"-p", "x", "-m", "1", "--principle", "security_posture=9", "--principle", "layer1.blast_radius=7"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, []string{"security_posture=9", "layer1.blast_radius=7"}, globalFlags.PrincipleOverrides)
+			},
+		},
+		{
+			name: "preset",
+			args: []string{"-p", "x", "-m", "1", "--preset", "enterprise"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, "enterprise", globalFlags.Preset)
+			},
+		},
+		{
+			name: "record and replay flags",
+			args: []string{"-p", "x", "-m", "1", "--record", "--replay", "cassette"},
+			validate: func(t *testing.T) {
+				assert.True(t, globalFlags.Record)
+				assert.Equal(t, "cassette", globalFlags.Replay)
+			},
+		},
+		{
+			name: "agent flags",
+			args: []string{"-p", "x", "-m", "1", "--agent", "aider", "--reviewer-agent", "claude",
+				"--council-agent", "local", "--planner-agent", "local", "--agents-file", "agents.yaml"},
+			validate: func(t *testing.T) {
+				assert.Equal(t, "aider", globalFlags.Agent)
+				assert.Equal(t, "claude", globalFlags.ReviewerAgent)
+				assert.Equal(t, "local", globalFlags.CouncilAgent)
+				assert.Equal(t, "local", globalFlags.PlannerAgent)
+				assert.Equal(t, "agents.yaml", globalFlags.AgentsFile)
+			},
+		},
 		{
 			name: "update flags",
 			args: []string{"-p", "x", "-m", "1", "--auto-update"},
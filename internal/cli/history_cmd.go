@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/history"
+	"github.com/DeukWoongWoo/claude-loop/internal/report"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// historyDateLayout is the format accepted by --since and --until.
+const historyDateLayout = "2006-01-02"
+
+// HistoryFilterOptions holds the filter flags shared by `history` and `stats`.
+type HistoryFilterOptions struct {
+	Since  string // --since: First day to include (YYYY-MM-DD)
+	Until  string // --until: Last day to include (YYYY-MM-DD)
+	Prompt string // --prompt: Prompt hash prefix or text to match
+}
+
+var historyOpts = &HistoryFilterOptions{}
+
+// historyCmd lists past runs.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List past runs",
+	Long: `List summaries of past runs stored in .claude/history, oldest first.
+Filter by start date with --since/--until (YYYY-MM-DD, inclusive) and by
+prompt text or prompt hash prefix with --prompt.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := historyOpts.filter()
+		if err != nil {
+			return err
+		}
+		entries, err := history.NewStore(history.DefaultDir).List(filter)
+		if err != nil {
+			return err
+		}
+		writeHistoryList(cmd.OutOrStdout(), entries)
+		return nil
+	},
+}
+
+// historyShowCmd prints one run summary.
+var historyShowCmd = &cobra.Command{
+	Use:   "show <run-id>",
+	Short: "Show the summary of one run",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entry, err := history.NewStore(history.DefaultDir).Get(args[0])
+		if err != nil {
+			return err
+		}
+		writeHistoryEntry(cmd.OutOrStdout(), entry)
+		return nil
+	},
+}
+
+func init() {
+	historyOpts.register(historyCmd.Flags())
+
+	historyCmd.SetHelpTemplate(subcommandHelpTemplate)
+	historyCmd.AddCommand(historyShowCmd)
+	rootCmd.AddCommand(historyCmd)
+}
+
+// register adds the filter flags to fs.
+func (o *HistoryFilterOptions) register(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Since, "since", "", "Only runs started on or after this date (YYYY-MM-DD)")
+	fs.StringVar(&o.Until, "until", "", "Only runs started on or before this date (YYYY-MM-DD)")
+	fs.StringVar(&o.Prompt, "prompt", "", "Only runs whose prompt contains this text or whose prompt hash starts with it")
+}
+
+// filter converts the flag values to a history.Filter.
+func (o *HistoryFilterOptions) filter() (*history.Filter, error) {
+	f := &history.Filter{Prompt: o.Prompt}
+	if o.Since != "" {
+		since, err := time.ParseInLocation(historyDateLayout, o.Since, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --since %q: expected YYYY-MM-DD", o.Since)
+		}
+		f.Since = since
+	}
+	if o.Until != "" {
+		until, err := time.ParseInLocation(historyDateLayout, o.Until, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --until %q: expected YYYY-MM-DD", o.Until)
+		}
+		// Inclusive: keep runs started at any time on the until date
+		f.Until = until.AddDate(0, 0, 1)
+	}
+	return f, nil
+}
+
+// writeHistoryList prints one line per run.
+func writeHistoryList(w io.Writer, entries []*history.Entry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No runs recorded.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RUN ID\tSTARTED\tDURATION\tITERATIONS\tCOST\tSTOP REASON\tPROMPT")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d/%d\t$%.4f\t%s\t%s\n",
+			e.RunID,
+			e.StartedAt.Local().Format("2006-01-02 15:04"),
+			e.Duration().Round(time.Second),
+			e.SuccessfulIterations, e.TotalIterations,
+			e.Costs.Total,
+			e.StopReason,
+			truncateString(e.PromptPreview, 40),
+		)
+	}
+	tw.Flush()
+}
+
+// writeHistoryEntry prints every field of a run summary.
+func writeHistoryEntry(w io.Writer, e *history.Entry) {
+	fmt.Fprintf(w, "Run:          %s\n", e.RunID)
+	fmt.Fprintf(w, "Started:      %s\n", e.StartedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "Duration:     %s\n", e.Duration().Round(time.Second))
+	fmt.Fprintf(w, "Prompt:       %s\n", e.PromptPreview)
+	fmt.Fprintf(w, "Prompt hash:  %s\n", e.PromptHash)
+	fmt.Fprintf(w, "Stop reason:  %s\n", e.StopReason)
+	if e.DryRun {
+		fmt.Fprintln(w, "Mode:         dry run")
+	}
+	fmt.Fprintf(w, "Iterations:   %d successful / %d total\n", e.SuccessfulIterations, e.TotalIterations)
+	fmt.Fprintf(w, "Cost:         $%.4f (iterations $%.4f, reviewer $%.4f, council $%.4f)\n",
+		e.Costs.Total, e.Costs.Iterations, e.Costs.Reviewer, e.Costs.Council)
+
+	if len(e.Flags) > 0 {
+		names := make([]string, 0, len(e.Flags))
+		for name := range e.Flags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			parts = append(parts, fmt.Sprintf("--%s=%s", name, e.Flags[name]))
+		}
+		fmt.Fprintf(w, "Flags:        %s\n", strings.Join(parts, " "))
+	}
+	for _, pr := range e.PullRequests {
+		fmt.Fprintf(w, "Pull request: %s\n", pr)
+	}
+}
+
+// changedFlags returns the flags set explicitly on cmd's command line, excluding the prompt.
+func changedFlags(cmd *cobra.Command) map[string]string {
+	flags := make(map[string]string)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Name == "prompt" {
+			return
+		}
+		flags[f.Name] = f.Value.String()
+	})
+	return flags
+}
+
+// recordRunHistory appends the run summary to .claude/history. Dry runs are not recorded.
+// Failures are reported but do not change the exit status.
+func recordRunHistory(r *report.Report, flags map[string]string) {
+	if r.DryRun {
+		return
+	}
+	if _, err := history.NewStore(history.DefaultDir).Save(history.NewEntry(r, flags)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record run history: %v\n", err)
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/history"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryFilterOptions_Filter(t *testing.T) {
+	t.Run("until is inclusive", func(t *testing.T) {
+		f, err := (&HistoryFilterOptions{Since: "2026-10-01", Until: "2026-10-31", Prompt: "tests"}).filter()
+		require.NoError(t, err)
+
+		assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), f.Since)
+		assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local), f.Until)
+		assert.Equal(t, "tests", f.Prompt)
+	})
+
+	t.Run("invalid dates", func(t *testing.T) {
+		_, err := (&HistoryFilterOptions{Since: "10/01/2026"}).filter()
+		assert.ErrorContains(t, err, "invalid --since")
+
+		_, err = (&HistoryFilterOptions{Until: "yesterday"}).filter()
+		assert.ErrorContains(t, err, "invalid --until")
+	})
+}
+
+func testHistoryEntry() *history.Entry {
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local)
+	return &history.Entry{
+		RunID:                "run-20261001-090000",
+		StartedAt:            start,
+		FinishedAt:           start.Add(2 * time.Minute),
+		PromptHash:           "abcdef123456",
+		PromptPreview:        "Add tests",
+		Flags:                map[string]string{"max-runs": "3", "dry-run": "true"},
+		StopReason:           "max_runs_reached",
+		SuccessfulIterations: 3,
+		TotalIterations:      3,
+		Costs:                history.Costs{Iterations: 0.9, Reviewer: 0.1, Total: 1.0},
+		PullRequests:         []string{"https://github.com/acme/app/pull/9"},
+	}
+}
+
+func TestWriteHistoryList(t *testing.T) {
+	var buf bytes.Buffer
+	writeHistoryList(&buf, []*history.Entry{testHistoryEntry()})
+
+	assert.Contains(t, buf.String(), "RUN ID")
+	assert.Contains(t, buf.String(), "run-20261001-090000")
+	assert.Contains(t, buf.String(), "2026-10-01 09:00")
+	assert.Contains(t, buf.String(), "3/3")
+	assert.Contains(t, buf.String(), "$1.0000")
+
+	buf.Reset()
+	writeHistoryList(&buf, nil)
+	assert.Equal(t, "No runs recorded.\n", buf.String())
+}
+
+func TestWriteHistoryEntry(t *testing.T) {
+	var buf bytes.Buffer
+	writeHistoryEntry(&buf, testHistoryEntry())
+
+	assert.Contains(t, buf.String(), "Duration:     2m0s")
+	assert.Contains(t, buf.String(), "Cost:         $1.0000 (iterations $0.9000, reviewer $0.1000, council $0.0000)")
+	assert.Contains(t, buf.String(), "Flags:        --dry-run=true --max-runs=3")
+	assert.Contains(t, buf.String(), "Pull request: https://github.com/acme/app/pull/9")
+}
+
+func TestChangedFlags(t *testing.T) {
+	cmd := &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+	cmd.Flags().StringP("prompt", "p", "", "")
+	cmd.Flags().Int("max-runs", 0, "")
+	cmd.Flags().Bool("verbose", false, "")
+	cmd.SetArgs([]string{"-p", "secret goal", "--max-runs", "5"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, map[string]string{"max-runs": "5"}, changedFlags(cmd))
+}
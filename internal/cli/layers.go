@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+)
+
+// principleOverridesPath describes the --principle layer in sources.
+const principleOverridesPath = "--principle"
+
+// loadLayeredPrinciples loads the repository principles file at path and merges it
+// with the user-level file and --principle overrides. The layers used are reported to w.
+func loadLayeredPrinciples(w io.Writer, path string, overrides []string) (*config.Principles, error) {
+	repo, err := loadPrinciples(path)
+	if err != nil {
+		return nil, err
+	}
+	return layerPrinciples(w, config.PrincipleLayer{Source: config.SourceRepository, Path: path, Principles: repo}, overrides)
+}
+
+// layerPrinciples merges the user-level file, repo and --principle overrides.
+// Values ignored because of locked floors are reported on stderr.
+func layerPrinciples(w io.Writer, repo config.PrincipleLayer, overrides []string) (*config.Principles, error) {
+	eff, err := effectivePrinciples(repo, overrides)
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range eff.Notes {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", note)
+	}
+	if len(eff.Layers) > 1 {
+		fmt.Fprintf(w, "Principles: %s\n", describeLayers(eff.Layers))
+	}
+	return eff.Principles, nil
+}
+
+// effectivePrinciples merges the user-level file, repo and --principle overrides,
+// lowest precedence first.
+func effectivePrinciples(repo config.PrincipleLayer, overrides []string) (*config.EffectivePrinciples, error) {
+	user, err := loadUserPrinciples()
+	if err != nil {
+		return nil, err
+	}
+	cli := config.PrincipleLayer{Source: config.SourceCLI, Path: principleOverridesPath}
+	if len(overrides) > 0 {
+		if cli.Principles, err = config.ParsePrincipleAssignments(overrides); err != nil {
+			return nil, err
+		}
+	}
+	eff, err := config.MergePrinciples(user, repo, cli)
+	if err != nil {
+		return nil, fmt.Errorf("merging principles: %w", err)
+	}
+	return eff, nil
+}
+
+// loadUserPrinciples loads the user- or organisation-level principles file.
+// A missing default file is not an error; a missing $CLAUDE_LOOP_PRINCIPLES file is.
+func loadUserPrinciples() (config.PrincipleLayer, error) {
+	path, explicit := config.UserPrinciplesPath()
+	layer := config.PrincipleLayer{Source: config.SourceUser, Path: path}
+	if path == "" {
+		return layer, nil
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if explicit {
+			return layer, fmt.Errorf("%s points at %s, which does not exist", config.UserPrinciplesEnv, path)
+		}
+		return layer, nil
+	}
+	p, err := loadPrinciples(path)
+	if err != nil {
+		return layer, err
+	}
+	layer.Principles = p
+	return layer, nil
+}
+
+// describeLayers formats layers as "user (path) < repository (path)".
+func describeLayers(layers []config.PrincipleLayer) string {
+	parts := make([]string, len(layers))
+	for i, layer := range layers {
+		parts[i] = fmt.Sprintf("%s (%s)", layer.Source, layer.Path)
+	}
+	return strings.Join(parts, " < ")
+}
+
+// describeSource formats where an effective value came from, such as
+// "repository (.claude/principles.yaml), locked >= 8 by user".
+func describeSource(source config.ValueSource) string {
+	desc := fmt.Sprintf("%s (%s)", source.Source, source.Path)
+	if source.Floor > 0 {
+		desc += fmt.Sprintf(", locked >= %d by %s", source.Floor, source.LockedBy)
+	}
+	return desc
+}
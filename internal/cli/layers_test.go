@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestPrinciples saves principles for preset under dir and returns the path.
+func writeTestPrinciples(t *testing.T, dir string, preset config.Preset) string {
+	t.Helper()
+	p := config.DefaultPrinciples(preset)
+	p.CreatedAt = "2026-01-11"
+	path := filepath.Join(dir, "principles.yaml")
+	require.NoError(t, config.SaveToFile(path, p))
+	return path
+}
+
+// isolateUserPrinciples points the user-level principles file at path ("" for none).
+func isolateUserPrinciples(t *testing.T, path string) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(config.UserPrinciplesEnv, path)
+}
+
+const orgPrinciples = `layer1:
+  security_posture: 8
+locked:
+  - security_posture
+`
+
+func TestLoadLayeredPrinciples(t *testing.T) {
+	t.Run("repository only", func(t *testing.T) {
+		isolateUserPrinciples(t, "")
+		path := writeTestPrinciples(t, t.TempDir(), config.PresetStartup)
+
+		var buf bytes.Buffer
+		p, err := loadLayeredPrinciples(&buf, path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, config.DefaultPrinciples(config.PresetStartup).Layer1, p.Layer1)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("user floor and overrides", func(t *testing.T) {
+		org := filepath.Join(t.TempDir(), "org.yaml")
+		require.NoError(t, os.WriteFile(org, []byte(orgPrinciples), 0644))
+		isolateUserPrinciples(t, org)
+		path := writeTestPrinciples(t, t.TempDir(), config.PresetStartup)
+
+		var buf bytes.Buffer
+		p, err := loadLayeredPrinciples(&buf, path, []string{"blast_radius=9", "security_posture=2"})
+		require.NoError(t, err)
+		assert.Equal(t, 8, p.Layer1.SecurityPosture)
+		assert.Equal(t, 9, p.Layer1.BlastRadius)
+		assert.Equal(t, "Principles: user ("+org+") < repository ("+path+") < cli (--principle)\n", buf.String())
+	})
+
+	t.Run("missing explicit user file", func(t *testing.T) {
+		isolateUserPrinciples(t, filepath.Join(t.TempDir(), "missing.yaml"))
+		path := writeTestPrinciples(t, t.TempDir(), config.PresetStartup)
+
+		_, err := loadLayeredPrinciples(&bytes.Buffer{}, path, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), config.UserPrinciplesEnv+" points at")
+	})
+
+	t.Run("invalid override", func(t *testing.T) {
+		isolateUserPrinciples(t, "")
+		path := writeTestPrinciples(t, t.TempDir(), config.PresetStartup)
+
+		_, err := loadLayeredPrinciples(&bytes.Buffer{}, path, []string{"velocity=1"})
+		assert.Error(t, err)
+	})
+}
+
+func TestDescribeSource(t *testing.T) {
+	assert.Equal(t, "repository (p.yaml)", describeSource(config.ValueSource{Source: "repository", Path: "p.yaml"}))
+	assert.Equal(t, "user (org.yaml), locked >= 8 by user",
+		describeSource(config.ValueSource{Source: "user", Path: "org.yaml", Floor: 8, LockedBy: "user"}))
+}
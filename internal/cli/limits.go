@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+)
+
+// changeLimits returns the per-iteration change size limits from principles.
+// Without principles nothing is limited.
+func changeLimits(principles *config.Principles) config.ChangeLimits {
+	if principles == nil {
+		return config.ChangeLimits{}
+	}
+	return principles.EffectiveChangeLimits()
+}
+
+// printChangeSize reports an iteration that exceeded the change size limits on stderr.
+func printChangeSize(record *loop.ChangeSizeRecord) {
+	fmt.Fprintf(os.Stderr, "Change too large: %d files and %d lines changed (limit:%s)\n",
+		record.Files, record.Lines, formatChangeLimits(record.MaxFiles, record.MaxLines))
+	switch {
+	case record.Error != "":
+		fmt.Fprintf(os.Stderr, "Warning: failed to revert the oversized change: %s\n", record.Error)
+	case record.Action == config.ExceedRevert:
+		fmt.Fprintf(os.Stderr, "Reverted the iteration (%d files, %d commits dropped)\n", len(record.Reverted), record.Commits)
+	default:
+		fmt.Fprintln(os.Stderr, "The next iteration will be asked to split the change")
+	}
+}
+
+// formatChangeLimits formats the enabled limits, e.g. " 8 files, 300 lines".
+func formatChangeLimits(maxFiles, maxLines int) string {
+	s := ""
+	if maxFiles > 0 {
+		s += fmt.Sprintf(" %d files", maxFiles)
+	}
+	if maxLines > 0 {
+		if s != "" {
+			s += ","
+		}
+		s += fmt.Sprintf(" %d lines", maxLines)
+	}
+	return s
+}
@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeLimits(t *testing.T) {
+	assert.Equal(t, config.ChangeLimits{}, changeLimits(nil))
+
+	p := config.DefaultPrinciples(config.PresetEnterprise)
+	p.ChangeLimits = &config.ChangeLimits{MaxLines: -1, OnExceed: config.ExceedRevert}
+	assert.Equal(t, config.ChangeLimits{MaxFiles: 8, OnExceed: config.ExceedRevert}, changeLimits(p))
+}
+
+func TestFormatChangeLimits(t *testing.T) {
+	assert.Equal(t, " 8 files, 300 lines", formatChangeLimits(8, 300))
+	assert.Equal(t, " 300 lines", formatChangeLimits(0, 300))
+	assert.Equal(t, " 8 files", formatChangeLimits(8, 0))
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/claude"
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+)
+
+// permissionRoles maps the role names accepted by --permissions to loop roles.
+// Only roles the CLI builds a client for are listed; a profile for any other
+// role would silently do nothing.
+var permissionRoles = map[string]loop.Role{
+	"main":     loop.RoleIteration,
+	"reviewer": loop.RoleReviewer,
+	"council":  loop.RoleCouncil,
+	"planner":  loop.RolePlanner,
+}
+
+// permissionSettings holds the permission profile for each role.
+type permissionSettings struct {
+	Default claude.PermissionProfile               // Profile for roles without their own entry
+	Roles   map[loop.Role]claude.PermissionProfile // Per-role overrides
+}
+
+// parsePermissions parses --permissions values of the form "profile" or "role=profile".
+func parsePermissions(values []string) (*permissionSettings, error) {
+	s := &permissionSettings{Roles: make(map[loop.Role]claude.PermissionProfile)}
+	for _, value := range values {
+		roleName, profileName, scoped := strings.Cut(value, "=")
+		if !scoped {
+			profileName = roleName
+		}
+
+		profile, err := claude.ParsePermissionProfile(strings.TrimSpace(profileName))
+		if err != nil {
+			return nil, err
+		}
+		if !scoped {
+			s.Default = profile
+			continue
+		}
+
+		role, ok := permissionRoles[strings.TrimSpace(roleName)]
+		if !ok {
+			return nil, fmt.Errorf("unknown permission role %q (valid: %s)", roleName, strings.Join(permissionRoleNames(), ", "))
+		}
+		s.Roles[role] = profile
+	}
+	return s, nil
+}
+
+// applyPrinciples derives the default profile from security_posture
+// unless one was given explicitly.
+func (s *permissionSettings) applyPrinciples(p *config.Principles) {
+	if s.Default == "" && p != nil {
+		s.Default = claude.ProfileForSecurityPosture(p.Layer1.SecurityPosture)
+	}
+}
+
+// For returns the profile for role.
+func (s *permissionSettings) For(role loop.Role) claude.PermissionProfile {
+	if profile, ok := s.Roles[role]; ok {
+		return profile
+	}
+	if s.Default != "" {
+		return s.Default
+	}
+	return claude.ProfileFull
+}
+
+// Restricted reports whether any role runs with less than full permissions.
+func (s *permissionSettings) Restricted() bool {
+	for _, role := range permissionRoles {
+		if s.For(role) != claude.ProfileFull {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary describes the profile of every role, e.g. "main=edit+test, reviewer=read-only, ...".
+func (s *permissionSettings) Summary() string {
+	var parts []string
+	for _, name := range permissionRoleNames() {
+		parts = append(parts, fmt.Sprintf("%s=%s", name, s.For(permissionRoles[name])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// permissionRoleNames returns the role names accepted by --permissions, sorted.
+func permissionRoleNames() []string {
+	names := make([]string, 0, len(permissionRoles))
+	for name := range permissionRoles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/claude"
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePermissions(t *testing.T) {
+	t.Run("default and role overrides", func(t *testing.T) {
+		s, err := parsePermissions([]string{"edit+test", "reviewer=read-only", "planner = edit-only"})
+		require.NoError(t, err)
+		assert.Equal(t, claude.ProfileEditTest, s.For(loop.RoleIteration))
+		assert.Equal(t, claude.ProfileReadOnly, s.For(loop.RoleReviewer))
+		assert.Equal(t, claude.ProfileEditOnly, s.For(loop.RolePlanner))
+		assert.Equal(t, claude.ProfileEditTest, s.For(loop.RoleCouncil))
+	})
+
+	t.Run("nothing set keeps full permissions", func(t *testing.T) {
+		s, err := parsePermissions(nil)
+		require.NoError(t, err)
+		assert.Equal(t, claude.ProfileFull, s.For(loop.RoleCouncil))
+		assert.False(t, s.Restricted())
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := parsePermissions([]string{"main=root"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown permission profile "root"`)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		_, err := parsePermissions([]string{"tester=read-only"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown permission role "tester" (valid: council, main, planner, reviewer)`)
+	})
+
+	t.Run("ci-fix has no client to restrict", func(t *testing.T) {
+		_, err := parsePermissions([]string{"ci-fix=edit-only"})
+		assert.ErrorContains(t, err, `unknown permission role "ci-fix"`)
+	})
+}
+
+func TestPermissionSettings_ApplyPrinciples(t *testing.T) {
+	t.Run("posture sets the default", func(t *testing.T) {
+		s, err := parsePermissions([]string{"council=read-only"})
+		require.NoError(t, err)
+		s.applyPrinciples(config.DefaultPrinciples(config.PresetEnterprise))
+
+		assert.Equal(t, claude.ProfileEditTest, s.For(loop.RoleIteration))
+		assert.Equal(t, claude.ProfileReadOnly, s.For(loop.RoleCouncil))
+		assert.True(t, s.Restricted())
+		assert.Equal(t, "council=read-only, main=edit+test, planner=edit+test, reviewer=edit+test", s.Summary())
+	})
+
+	t.Run("low posture keeps full permissions", func(t *testing.T) {
+		s, err := parsePermissions(nil)
+		require.NoError(t, err)
+		s.applyPrinciples(config.DefaultPrinciples(config.PresetStartup))
+		assert.Equal(t, claude.ProfileFull, s.For(loop.RoleIteration))
+	})
+
+	t.Run("explicit default wins", func(t *testing.T) {
+		s, err := parsePermissions([]string{"full"})
+		require.NoError(t, err)
+		s.applyPrinciples(config.DefaultPrinciples(config.PresetEnterprise))
+		assert.Equal(t, claude.ProfileFull, s.For(loop.RoleReviewer))
+	})
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+)
+
+// Reviewer instructions used when the policy requires a reviewer and -r was not given.
+const (
+	defaultReviewPrompt = "Review the changes from the last iteration. " +
+		"Run the build and the tests for the changed code, and fix any failures."
+	strictReviewPrompt = "Review the changes from the last iteration. " +
+		"Run the full build, linters and test suite, and fix any failures. " +
+		"Check the changes for security problems such as injection, leaked secrets and missing input validation, and fix them."
+)
+
+// resolvePolicy derives the runtime policy from principles and applies it to flags.
+// Flags set on the command line, as reported by changed, override the policy and
+// are recorded as the reason for their setting.
+func resolvePolicy(f *Flags, principles *config.Principles, changed func(name string) bool) *config.Policy {
+	policy := config.DerivePolicy(principles)
+
+	if changed("verification") && f.Verification != "" {
+		policy.Verification = config.VerificationLevel(f.Verification)
+		policy.Reasons[config.SettingVerification] = "--verification"
+	}
+	f.Verification = string(policy.Verification)
+
+	if changed("review-prompt") {
+		policy.RequireReview = f.ReviewPrompt != ""
+		policy.Reasons[config.SettingReview] = "--review-prompt"
+	} else if policy.RequireReview {
+		f.ReviewPrompt = reviewPromptFor(policy.Verification)
+	}
+
+	if changed("draft-prs") {
+		policy.DraftPRs = f.DraftPRs
+		policy.Reasons[config.SettingDraftPRs] = "--draft-prs"
+	}
+	f.DraftPRs = policy.DraftPRs
+
+	if changed("disable-branches") && f.DisableBranches {
+		policy.DirectPush = true
+		policy.Reasons[config.SettingDirectPush] = "--disable-branches"
+	}
+
+	if changed("reviewer-model") {
+		policy.ReviewerModel = f.ReviewerModel
+		policy.Reasons[config.SettingReviewerModel] = "--reviewer-model"
+	}
+	f.ReviewerModel = policy.ReviewerModel
+
+	if changed("council-model") {
+		policy.CouncilModel = f.CouncilModel
+		policy.Reasons[config.SettingCouncilModel] = "--council-model"
+	}
+	f.CouncilModel = policy.CouncilModel
+
+	return policy
+}
+
+// reviewPromptFor returns the built-in reviewer instructions for a verification level.
+func reviewPromptFor(level config.VerificationLevel) string {
+	if level == config.VerificationStrict {
+		return strictReviewPrompt
+	}
+	return defaultReviewPrompt
+}
+
+// printPolicy prints the effective runtime policy.
+func printPolicy(policy *config.Policy) {
+	fmt.Println("Policy:")
+	for _, line := range policy.Summary() {
+		fmt.Printf("  %s\n", line)
+	}
+}
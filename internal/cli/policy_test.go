@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// changedSet reports the named flags as set on the command line.
+func changedSet(names ...string) func(string) bool {
+	return func(name string) bool {
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestResolvePolicy(t *testing.T) {
+	t.Run("principles set unspecified flags", func(t *testing.T) {
+		f := DefaultFlags()
+		p := config.DefaultPrinciples(config.PresetEnterprise)
+		p.Layer1.CostEfficiency = 8
+
+		policy := resolvePolicy(f, p, changedSet())
+		assert.True(t, policy.RequireReview)
+		assert.Equal(t, strictReviewPrompt, f.ReviewPrompt)
+		assert.Equal(t, "strict", f.Verification)
+		assert.True(t, f.DraftPRs)
+		assert.Equal(t, config.CheaperModel, f.ReviewerModel)
+		assert.Equal(t, config.CheaperModel, f.CouncilModel)
+	})
+
+	t.Run("explicit flags override", func(t *testing.T) {
+		f := DefaultFlags()
+		f.ReviewPrompt = ""
+		f.Verification = "relaxed"
+		f.DraftPRs = false
+		f.DisableBranches = true
+		f.ReviewerModel = "opus"
+
+		policy := resolvePolicy(f, config.DefaultPrinciples(config.PresetEnterprise),
+			changedSet("review-prompt", "verification", "draft-prs", "disable-branches", "reviewer-model"))
+		assert.False(t, policy.RequireReview)
+		assert.Empty(t, f.ReviewPrompt)
+		assert.Equal(t, config.VerificationRelaxed, policy.Verification)
+		assert.False(t, policy.DraftPRs)
+		assert.True(t, policy.DirectPush)
+		assert.Equal(t, "opus", policy.ReviewerModel)
+		assert.Equal(t, []string{
+			"review: optional (--review-prompt)",
+			"verification: relaxed (--verification)",
+			"draft_prs: no (--draft-prs)",
+			"direct_push: allowed (--disable-branches)",
+			"reviewer_model: opus (--reviewer-model)",
+			"council_model: default",
+		}, policy.Summary())
+	})
+
+	t.Run("user review prompt is kept", func(t *testing.T) {
+		f := DefaultFlags()
+		f.ReviewPrompt = "run make check"
+
+		policy := resolvePolicy(f, config.DefaultPrinciples(config.PresetEnterprise), changedSet("review-prompt"))
+		assert.True(t, policy.RequireReview)
+		assert.Equal(t, "run make check", f.ReviewPrompt)
+	})
+
+	t.Run("no principles changes nothing", func(t *testing.T) {
+		f := DefaultFlags()
+		policy := resolvePolicy(f, nil, changedSet())
+		assert.False(t, policy.RequireReview)
+		assert.Empty(t, f.ReviewPrompt)
+		assert.Equal(t, "standard", f.Verification)
+		assert.Empty(t, f.ReviewerModel)
+	})
+}
+
+func TestReviewPromptFor(t *testing.T) {
+	assert.Equal(t, strictReviewPrompt, reviewPromptFor(config.VerificationStrict))
+	assert.Equal(t, defaultReviewPrompt, reviewPromptFor(config.VerificationRelaxed))
+}
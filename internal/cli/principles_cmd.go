@@ -0,0 +1,683 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/agent"
+	"github.com/DeukWoongWoo/claude-loop/internal/claude"
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/DeukWoongWoo/claude-loop/internal/principles"
+	"github.com/spf13/cobra"
+)
+
+// PrinciplesInitOptions holds flag values for `principles init`.
+type PrinciplesInitOptions struct {
+	PrinciplesFile string   // --principles-file: Principles file to write
+	Preset         string   // --preset: Built-in or custom preset
+	Set            []string // --set: Principle values (key=value, repeatable)
+	NonInteractive bool     // --non-interactive: Write preset defaults without prompting
+	Force          bool     // --force: Overwrite an existing file
+}
+
+var principlesInitOpts = &PrinciplesInitOptions{}
+
+// PrinciplesWizardOptions holds flag values for `principles wizard`.
+type PrinciplesWizardOptions struct {
+	PrinciplesFile string // --principles-file: Principles file to create or edit
+	Preset         string // --preset: Starting preset for a new file
+}
+
+var principlesWizardOpts = &PrinciplesWizardOptions{}
+
+// PrinciplesSuggestOptions holds flag values for `principles suggest`.
+type PrinciplesSuggestOptions struct {
+	PrinciplesFile string   // --principles-file: Principles file to write
+	Set            []string // --set: Adjust suggested values (key=value, repeatable)
+	Yes            bool     // --yes: Write the suggestion without asking
+	Force          bool     // --force: Overwrite an existing file
+	Agent          string   // --agent: claude or a name from --agents-file
+	AgentsFile     string   // --agents-file: Command agent definitions
+	Model          string   // --model: Model for the built-in claude agent
+}
+
+var principlesSuggestOpts = &PrinciplesSuggestOptions{}
+
+// PrinciplesValidateOptions holds flag values for `principles validate`.
+type PrinciplesValidateOptions struct {
+	PrinciplesFile string // --principles-file: Principles file to validate
+}
+
+var principlesValidateOpts = &PrinciplesValidateOptions{}
+
+// PrinciplesDiffOptions holds flag values for `principles diff`.
+type PrinciplesDiffOptions struct {
+	PrinciplesFile string // --principles-file: Principles file to compare
+	Preset         string // --preset: Preset to compare against (default: the file's preset)
+}
+
+var principlesDiffOpts = &PrinciplesDiffOptions{}
+
+// PrinciplesExplainOptions holds flag values for `principles explain`.
+type PrinciplesExplainOptions struct {
+	PrinciplesFile string // --principles-file: Principles file for the current value
+}
+
+var principlesExplainOpts = &PrinciplesExplainOptions{}
+
+// PrinciplesMigrateOptions holds flag values for `principles migrate`.
+type PrinciplesMigrateOptions struct {
+	PrinciplesFile string // --principles-file: Principles file to migrate
+	DryRun         bool   // --dry-run: Report changes without writing
+}
+
+var principlesMigrateOpts = &PrinciplesMigrateOptions{}
+
+// PrinciplesShowOptions holds flag values for `principles show`.
+type PrinciplesShowOptions struct {
+	PrinciplesFile string   // --principles-file: Repository principles file
+	Effective      bool     // --effective: Merge all layers and show each value's source
+	Overrides      []string // --principle: Override a principle (key=value, repeatable)
+}
+
+var principlesShowOpts = &PrinciplesShowOptions{}
+
+// principlesCmd groups principles file commands.
+var principlesCmd = &cobra.Command{
+	Use:   "principles",
+	Short: "Manage the project principles file",
+}
+
+// principlesInitCmd creates the principles file.
+var principlesInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create the principles file from a preset",
+	Long: `Create the principles file from a preset.
+
+On a terminal, init asks the preset's follow-up questions (and the project type
+when --preset is not given). With --non-interactive, or when stdin is not a
+terminal, it writes the preset's defaults (startup unless --preset is given).
+--set key=value adjusts individual principles in either mode.`,
+	Example: `  claude-loop principles init
+  claude-loop principles init --preset enterprise --set security_posture=9 --non-interactive`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return initPrinciples(cmd.Context(), cmd.OutOrStdout(), cmd.InOrStdin(), loadPresets(cmd.ErrOrStderr()), principlesInitOpts)
+	},
+}
+
+// principlesWizardCmd walks through every principle.
+var principlesWizardCmd = &cobra.Command{
+	Use:   "wizard",
+	Short: "Set all 18 principles interactively, with explanations",
+	Long: `Walk through every principle, grouped by layer, with a description, examples
+for both ends of the scale and the preset default. Invalid answers are asked
+again; enter b to go back or s to skip the rest of a layer. A summary is shown
+before saving, where any principle can be changed again.
+
+An existing file is edited with its current values as defaults; --preset only
+applies to a new file.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPrinciplesWizard(cmd.Context(), cmd.OutOrStdout(), cmd.InOrStdin(), loadPresets(cmd.ErrOrStderr()), principlesWizardOpts)
+	},
+}
+
+// principlesSuggestCmd proposes principles from repository signals.
+var principlesSuggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Propose principles from repository signals",
+	Long: `Propose a preset and a value for each principle, with a rationale per key.
+
+Signals are gathered locally: README, LICENSE, CONTRIBUTING and similar files, CI
+workflows, test density, release tags and, through gh, default branch protection.
+Only their summary is sent to Claude. On a terminal you can adjust values with
+key=value before the file is written; otherwise pass --yes to write it.`,
+	Example: `  claude-loop principles suggest
+  claude-loop principles suggest --set blast_radius=9 --yes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := principlesSuggestOpts
+		client, err := newSuggestClient(opts)
+		if err != nil {
+			return err
+		}
+		signals, err := principles.GatherSignals(cmd.Context(), ".", nil)
+		if err != nil {
+			return err
+		}
+		presets := loadPresets(cmd.ErrOrStderr())
+		return suggestPrinciples(cmd.Context(), cmd.OutOrStdout(), cmd.InOrStdin(), principles.NewSuggester(client, presets), presets, signals, opts)
+	},
+}
+
+// principlesValidateCmd reports every problem in the principles file.
+var principlesValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the principles file and report every problem",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return validatePrinciples(cmd.OutOrStdout(), principlesValidateOpts)
+	},
+}
+
+// principlesDiffCmd compares the principles file with a preset.
+var principlesDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show where the principles differ from a preset",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return diffPrinciples(cmd.OutOrStdout(), loadPresets(cmd.ErrOrStderr()), principlesDiffOpts)
+	},
+}
+
+// principlesExplainCmd describes a principle.
+var principlesExplainCmd = &cobra.Command{
+	Use:     "explain <key>",
+	Short:   "Describe a principle and its 1-10 scale",
+	Example: "  claude-loop principles explain security_posture",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return explainPrinciple(cmd.OutOrStdout(), args[0], loadPresets(cmd.ErrOrStderr()), principlesExplainOpts)
+	},
+}
+
+// principlesPresetsCmd lists the presets --preset accepts.
+var principlesPresetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "List the built-in and custom presets",
+	Long: `List the presets --preset accepts.
+
+Custom presets are YAML files that extend a built-in preset. They are read from
+.claude/presets, each directory in $` + config.PresetsPathEnv + ` and
+~/.config/claude-loop/presets; the first definition of a name wins.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listPresets(cmd.OutOrStdout(), loadPresets(cmd.ErrOrStderr()))
+		return nil
+	},
+}
+
+// principlesMigrateCmd upgrades a principles file to the current schema version.
+var principlesMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade the principles file to the current schema version",
+	Long: `Upgrade the principles file to schema version ` + config.DefaultVersion + `, reporting each change.
+The original is kept as <file>.v<old-version>.bak. Comments are preserved.
+
+Use --dry-run to see the changes without writing anything.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return migratePrinciples(cmd.OutOrStdout(), principlesMigrateOpts)
+	},
+}
+
+// principlesShowCmd prints the principles file, or the effective principles.
+var principlesShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the principles, optionally merged across all layers",
+	Long: `Show the repository principles file.
+
+With --effective, merge the user-level file ($` + config.UserPrinciplesEnv + ` or
+~/.config/claude-loop/principles.yaml), the repository file and --principle
+overrides, and show where each value came from and which keys are locked.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showPrinciples(cmd.OutOrStdout(), principlesShowOpts)
+	},
+}
+
+func init() {
+	inf := principlesInitCmd.Flags()
+	inf.StringVar(&principlesInitOpts.PrinciplesFile, "principles-file", ".claude/principles.yaml", "Principles file path")
+	inf.StringVar(&principlesInitOpts.Preset, "preset", "", "Preset: startup, enterprise, opensource or a custom preset")
+	inf.StringArrayVar(&principlesInitOpts.Set, "set", nil, "Set a principle as key=value (repeatable)")
+	inf.BoolVar(&principlesInitOpts.NonInteractive, "non-interactive", false, "Write the preset defaults without prompting")
+	inf.BoolVar(&principlesInitOpts.Force, "force", false, "Overwrite an existing principles file")
+
+	wf := principlesWizardCmd.Flags()
+	wf.StringVar(&principlesWizardOpts.PrinciplesFile, "principles-file", ".claude/principles.yaml", "Principles file path")
+	wf.StringVar(&principlesWizardOpts.Preset, "preset", "", "Starting preset for a new file: startup, enterprise, opensource or a custom preset")
+
+	sgf := principlesSuggestCmd.Flags()
+	sgf.StringVar(&principlesSuggestOpts.PrinciplesFile, "principles-file", ".claude/principles.yaml", "Principles file path")
+	sgf.StringArrayVar(&principlesSuggestOpts.Set, "set", nil, "Adjust a suggested principle as key=value (repeatable)")
+	sgf.BoolVar(&principlesSuggestOpts.Yes, "yes", false, "Write the suggestion without asking")
+	sgf.BoolVar(&principlesSuggestOpts.Force, "force", false, "Overwrite an existing principles file")
+	sgf.StringVar(&principlesSuggestOpts.Agent, "agent", "", "Agent to ask: claude or a name from --agents-file")
+	sgf.StringVar(&principlesSuggestOpts.AgentsFile, "agents-file", agent.DefaultFile, "Command agent definitions")
+	sgf.StringVar(&principlesSuggestOpts.Model, "model", "", "Model for the built-in claude agent")
+
+	principlesValidateCmd.Flags().StringVar(&principlesValidateOpts.PrinciplesFile, "principles-file", ".claude/principles.yaml", "Principles file path")
+
+	df := principlesDiffCmd.Flags()
+	df.StringVar(&principlesDiffOpts.PrinciplesFile, "principles-file", ".claude/principles.yaml", "Principles file path")
+	df.StringVar(&principlesDiffOpts.Preset, "preset", "", "Preset to compare against (default: the file's preset)")
+
+	principlesExplainCmd.Flags().StringVar(&principlesExplainOpts.PrinciplesFile, "principles-file", ".claude/principles.yaml", "Principles file path (for the current value)")
+
+	sf := principlesShowCmd.Flags()
+	sf.StringVar(&principlesShowOpts.PrinciplesFile, "principles-file", ".claude/principles.yaml", "Principles file path")
+	sf.BoolVar(&principlesShowOpts.Effective, "effective", false, "Merge all layers and show where each value came from")
+	sf.StringArrayVar(&principlesShowOpts.Overrides, "principle", nil, "Override a principle as key=value (repeatable, with --effective)")
+
+	f := principlesMigrateCmd.Flags()
+	f.StringVar(&principlesMigrateOpts.PrinciplesFile, "principles-file", ".claude/principles.yaml", "Principles file path")
+	f.BoolVar(&principlesMigrateOpts.DryRun, "dry-run", false, "Show the changes without writing the file")
+
+	principlesCmd.SetHelpTemplate(subcommandHelpTemplate)
+	principlesCmd.AddCommand(principlesInitCmd)
+	principlesCmd.AddCommand(principlesWizardCmd)
+	principlesCmd.AddCommand(principlesSuggestCmd)
+	principlesCmd.AddCommand(principlesShowCmd)
+	principlesCmd.AddCommand(principlesValidateCmd)
+	principlesCmd.AddCommand(principlesDiffCmd)
+	principlesCmd.AddCommand(principlesExplainCmd)
+	principlesCmd.AddCommand(principlesMigrateCmd)
+	principlesCmd.AddCommand(principlesPresetsCmd)
+	rootCmd.AddCommand(principlesCmd)
+}
+
+// initPrinciples writes the principles file, asking questions on a terminal unless
+// opts.NonInteractive is set. in is the questionnaire input.
+func initPrinciples(ctx context.Context, w io.Writer, in io.Reader, presets *config.PresetRegistry, opts *PrinciplesInitOptions) error {
+	if _, err := os.Stat(opts.PrinciplesFile); err == nil && !opts.Force {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", opts.PrinciplesFile)
+	}
+	if opts.Preset != "" && !isPresetName(presets, opts.Preset) {
+		return fmt.Errorf("invalid preset %q (must be one of: %s)", opts.Preset, presetList(presets))
+	}
+	values, err := config.ParsePrincipleAssignments(opts.Set)
+	if err != nil {
+		return err
+	}
+
+	var p *config.Principles
+	if opts.NonInteractive || !stdinInteractive() {
+		preset := config.Preset(opts.Preset)
+		if preset == "" {
+			preset = config.PresetStartup
+		}
+		p = presets.Defaults(preset)
+		p.CreatedAt = time.Now().Format("2006-01-02")
+	} else {
+		collector := principles.NewCollectorWithReader(opts.PrinciplesFile, in, presets)
+		if opts.Preset != "" {
+			err = collector.CollectPreset(ctx, config.Preset(opts.Preset))
+		} else {
+			err = collector.Collect(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("collecting principles: %w", err)
+		}
+		if p, err = config.LoadFromFile(opts.PrinciplesFile); err != nil {
+			return err
+		}
+	}
+
+	changes := applyPrinciples(p, values)
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if err := config.SaveToFile(opts.PrinciplesFile, p); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Wrote %s (preset %s, schema %s)\n", opts.PrinciplesFile, p.Preset, p.Version)
+	for _, c := range changes {
+		fmt.Fprintf(w, "  %s: %d -> %d\n", c.Key, c.Baseline, c.Value)
+	}
+	return nil
+}
+
+// runPrinciplesWizard runs the wizard on a terminal. in answers its questions.
+func runPrinciplesWizard(ctx context.Context, w io.Writer, in io.Reader, presets *config.PresetRegistry, opts *PrinciplesWizardOptions) error {
+	if opts.Preset != "" && !isPresetName(presets, opts.Preset) {
+		return fmt.Errorf("invalid preset %q (must be one of: %s)", opts.Preset, presetList(presets))
+	}
+	if !stdinInteractive() {
+		return fmt.Errorf("the principles wizard needs a terminal; use 'claude-loop principles init --non-interactive' with --set key=value instead")
+	}
+	_, err := principles.NewWizard(opts.PrinciplesFile, in, w, presets).Run(ctx, config.Preset(opts.Preset))
+	return err
+}
+
+// suggestPrinciples shows the suggestion for signals, applies --set and, once
+// accepted, writes the principles file. in answers the adjustment prompt.
+func suggestPrinciples(ctx context.Context, w io.Writer, in io.Reader, suggester *principles.Suggester,
+	presets *config.PresetRegistry, signals *principles.Signals, opts *PrinciplesSuggestOptions) error {
+	if _, err := os.Stat(opts.PrinciplesFile); err == nil && !opts.Force {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", opts.PrinciplesFile)
+	}
+	adjustments, err := config.ParsePrincipleAssignments(opts.Set)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Repository signals:\n%s\n", signals.Summary())
+	suggestion, err := suggester.Suggest(ctx, signals)
+	if err != nil {
+		return err
+	}
+	p := suggestion.Principles
+	for _, c := range applyPrinciples(p, adjustments) {
+		suggestion.Rationale[c.Key] = "set with --set"
+	}
+	writeSuggestion(w, suggestion, presets)
+
+	if !opts.Yes {
+		if !stdinInteractive() {
+			fmt.Fprintf(w, "Not written. Re-run with --yes to write %s, adjusting values with --set key=value.\n", opts.PrinciplesFile)
+			return nil
+		}
+		accepted, err := adjustSuggestion(w, in, suggestion)
+		if err != nil || !accepted {
+			return err
+		}
+	}
+
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if err := config.SaveToFile(opts.PrinciplesFile, p); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Wrote %s (preset %s, schema %s)\n", opts.PrinciplesFile, p.Preset, p.Version)
+	return nil
+}
+
+// writeSuggestion prints each suggested value next to the preset default, with its rationale.
+func writeSuggestion(w io.Writer, s *principles.Suggestion, presets *config.PresetRegistry) {
+	defaults := presets.Defaults(s.Principles.Preset)
+	fmt.Fprintf(w, "Suggested preset: %s (cost $%.4f)\n", s.Principles.Preset, s.Cost)
+	for _, key := range config.PrincipleKeys() {
+		value, _ := s.Principles.Principle(key)
+		preset, _ := defaults.Principle(key)
+		marker := " "
+		if value != preset {
+			marker = "*"
+		}
+		rationale := s.Rationale[key]
+		if rationale == "" {
+			rationale = "preset default"
+		}
+		fmt.Fprintf(w, "  %-30s %2d %s (%s: %d)  %s\n", key, value, marker, s.Principles.Preset, preset, rationale)
+	}
+}
+
+// adjustSuggestion reads key=value adjustments until an empty line accepts the
+// suggestion or "n" rejects it. End of input accepts.
+func adjustSuggestion(w io.Writer, in io.Reader, s *principles.Suggestion) (bool, error) {
+	reader := bufio.NewReader(in)
+	for {
+		fmt.Fprint(w, "Adjust with key=value, press Enter to write, or n to cancel: ")
+		line, err := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			return true, nil
+		case strings.EqualFold(line, "n"):
+			fmt.Fprintln(w, "Cancelled; nothing written.")
+			return false, nil
+		}
+		if adjusted, perr := config.ParsePrincipleAssignments([]string{line}); perr != nil {
+			fmt.Fprintf(w, "  %s\n", perr)
+		} else {
+			for _, c := range applyPrinciples(s.Principles, adjusted) {
+				fmt.Fprintf(w, "  %s: %d -> %d\n", c.Key, c.Baseline, c.Value)
+				s.Rationale[c.Key] = "adjusted"
+			}
+		}
+		if err != nil {
+			return true, nil
+		}
+	}
+}
+
+// newSuggestClient returns the agent asked for suggestions: a read-only built-in
+// claude client unless --agent names a command agent.
+func newSuggestClient(opts *PrinciplesSuggestOptions) (principles.ClaudeClient, error) {
+	if opts.Agent == "" || opts.Agent == agent.BuiltinClaude {
+		return &suggestClientAdapter{client: claude.NewClient(&claude.ClientOptions{
+			Permissions: claude.ProfileReadOnly,
+			Model:       opts.Model,
+		})}, nil
+	}
+	agents, err := agent.LoadFile(opts.AgentsFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := agents.Get(opts.Agent)
+	if err != nil {
+		return nil, err
+	}
+	return &suggestClientAdapter{client: agent.NewCommandAgent(opts.Agent, cfg, nil)}, nil
+}
+
+// suggestClientAdapter adapts loop.ClaudeClient to principles.ClaudeClient.
+type suggestClientAdapter struct {
+	client loop.ClaudeClient
+}
+
+func (a *suggestClientAdapter) Execute(ctx context.Context, prompt string) (*principles.IterationResult, error) {
+	result, err := a.client.Execute(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return &principles.IterationResult{Output: result.Output, Cost: result.Cost}, nil
+}
+
+// applyPrinciples copies the principles set in values (non-zero) into p and returns
+// the changes, with the previous value as Baseline. values holds validated 1-10 values.
+func applyPrinciples(p, values *config.Principles) []config.PrincipleDiff {
+	var changes []config.PrincipleDiff
+	for _, key := range config.PrincipleKeys() {
+		value, _ := values.Principle(key)
+		old, _ := p.Principle(key)
+		if value == 0 || value == old {
+			continue
+		}
+		_ = p.SetPrinciple(key, value)
+		changes = append(changes, config.PrincipleDiff{Key: key, Value: value, Baseline: old})
+	}
+	return changes
+}
+
+// validatePrinciples reports every validation problem in the principles file.
+// It returns an error when the file is invalid.
+func validatePrinciples(w io.Writer, opts *PrinciplesValidateOptions) error {
+	p, err := loadPrinciples(opts.PrinciplesFile)
+	if err != nil {
+		return err
+	}
+	errs := p.ValidateAll()
+	if len(errs) == 0 {
+		fmt.Fprintf(w, "%s is valid (schema %s, preset %s)\n", opts.PrinciplesFile, p.Version, p.Preset)
+		return nil
+	}
+	for _, err := range errs {
+		fmt.Fprintf(w, "  %s\n", err)
+	}
+	problems := "problems"
+	if len(errs) == 1 {
+		problems = "problem"
+	}
+	return fmt.Errorf("%s has %d %s", opts.PrinciplesFile, len(errs), problems)
+}
+
+// diffPrinciples prints the principles that differ from a preset's defaults.
+func diffPrinciples(w io.Writer, presets *config.PresetRegistry, opts *PrinciplesDiffOptions) error {
+	p, err := loadPrinciples(opts.PrinciplesFile)
+	if err != nil {
+		return err
+	}
+	preset := opts.Preset
+	if preset == "" {
+		preset = string(p.Preset)
+	}
+	if !isPresetName(presets, preset) {
+		return fmt.Errorf("%s uses preset %q; choose one to compare against with --preset (%s)",
+			opts.PrinciplesFile, preset, presetList(presets))
+	}
+
+	diffs := config.DiffPrinciples(p, presets.Defaults(config.Preset(preset)))
+	if len(diffs) == 0 {
+		fmt.Fprintf(w, "%s matches the %s preset\n", opts.PrinciplesFile, preset)
+		return nil
+	}
+	fmt.Fprintf(w, "%s differs from the %s preset in %d principles:\n", opts.PrinciplesFile, preset, len(diffs))
+	for _, d := range diffs {
+		fmt.Fprintf(w, "  %-30s %2d  (%s: %d)\n", d.Key, d.Value, preset, d.Baseline)
+	}
+	return nil
+}
+
+// explainPrinciple describes a principle, its scale, the preset defaults and, when
+// the principles file exists, its current value.
+func explainPrinciple(w io.Writer, key string, presets *config.PresetRegistry, opts *PrinciplesExplainOptions) error {
+	info, err := config.DescribePrinciple(key)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s (%s)\n", info.Title, info.Key)
+	fmt.Fprintf(w, "  %s\n", info.Description)
+	fmt.Fprintf(w, "  Scale:    1-3 %s, 7-10 %s\n", info.Low, info.High)
+	fmt.Fprintf(w, "  Low:      %s\n", info.LowExample)
+	fmt.Fprintf(w, "  High:     %s\n", info.HighExample)
+	if info.Effects != "" {
+		fmt.Fprintf(w, "  Effects:  %s\n", info.Effects)
+	}
+
+	var defaults []string
+	for _, preset := range presets.Names() {
+		value, _ := presets.Defaults(preset).Principle(info.Key)
+		defaults = append(defaults, fmt.Sprintf("%s %d", preset, value))
+	}
+	fmt.Fprintf(w, "  Defaults: %s\n", strings.Join(defaults, ", "))
+
+	if _, err := os.Stat(opts.PrinciplesFile); err == nil {
+		p, err := loadPrinciples(opts.PrinciplesFile)
+		if err != nil {
+			return err
+		}
+		value, _ := p.Principle(info.Key)
+		fmt.Fprintf(w, "  Current:  %d (%s)\n", value, opts.PrinciplesFile)
+	}
+	return nil
+}
+
+// listPresets prints the built-in presets, then each custom preset with its base
+// preset and file.
+func listPresets(w io.Writer, presets *config.PresetRegistry) {
+	for _, preset := range config.BuiltinPresets {
+		fmt.Fprintf(w, "%-20s built-in\n", preset)
+	}
+	for _, d := range presets.Definitions() {
+		fmt.Fprintf(w, "%-20s extends %s (%s)\n", d.Name, d.Extends, d.Path)
+		if d.Description != "" {
+			fmt.Fprintf(w, "%-20s %s\n", "", d.Description)
+		}
+	}
+}
+
+// isPresetName reports whether name is a built-in or custom preset with defaults.
+func isPresetName(presets *config.PresetRegistry, name string) bool {
+	return presets.HasDefaults(config.Preset(name))
+}
+
+// presetList returns the names of the presets with defaults, comma-separated.
+func presetList(presets *config.PresetRegistry) string {
+	names := presets.Names()
+	list := make([]string, len(names))
+	for i, name := range names {
+		list[i] = string(name)
+	}
+	return strings.Join(list, ", ")
+}
+
+// showPrinciples prints each principle value, with its source when opts.Effective is set.
+func showPrinciples(w io.Writer, opts *PrinciplesShowOptions) error {
+	repo, err := loadPrinciples(opts.PrinciplesFile)
+	if err != nil {
+		return err
+	}
+	if !opts.Effective {
+		fmt.Fprintf(w, "%s (schema %s, preset %s)\n", opts.PrinciplesFile, repo.Version, repo.Preset)
+		for _, key := range config.PrincipleKeys() {
+			value, _ := repo.Principle(key)
+			fmt.Fprintf(w, "  %-30s %2d\n", key, value)
+		}
+		return nil
+	}
+
+	layer := config.PrincipleLayer{Source: config.SourceRepository, Path: opts.PrinciplesFile, Principles: repo}
+	eff, err := effectivePrinciples(layer, opts.Overrides)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Effective principles: %s\n", describeLayers(eff.Layers))
+	for _, key := range config.PrincipleKeys() {
+		value, _ := eff.Principles.Principle(key)
+		fmt.Fprintf(w, "  %-30s %2d  %s\n", key, value, describeSource(eff.Sources[key]))
+	}
+	if len(eff.Notes) > 0 {
+		fmt.Fprintln(w, "Ignored:")
+		for _, note := range eff.Notes {
+			fmt.Fprintf(w, "  %s\n", note)
+		}
+	}
+	return nil
+}
+
+// migratePrinciples migrates the principles file and reports what changed.
+func migratePrinciples(w io.Writer, opts *PrinciplesMigrateOptions) error {
+	result, err := config.MigrateFile(opts.PrinciplesFile, opts.DryRun)
+	if err != nil {
+		return err
+	}
+	if !result.Changed() {
+		fmt.Fprintf(w, "%s is already at schema version %s\n", result.Path, result.To)
+		return nil
+	}
+
+	verb := "Migrated"
+	if opts.DryRun {
+		verb = "Would migrate"
+	}
+	fmt.Fprintf(w, "%s %s from %s to %s\n", verb, result.Path, result.From, result.To)
+	writeMigrationSteps(w, result)
+	if result.Backup != "" {
+		fmt.Fprintf(w, "Backup: %s\n", result.Backup)
+	}
+	return nil
+}
+
+// writeMigrationSteps prints each applied migration and its changes.
+func writeMigrationSteps(w io.Writer, result *config.MigrationResult) {
+	for _, step := range result.Steps {
+		fmt.Fprintf(w, "  %s -> %s:\n", step.From, step.To)
+		for _, change := range step.Changes {
+			fmt.Fprintf(w, "    %s\n", change)
+		}
+	}
+}
+
+// loadPrinciples loads a principles file, migrating an older schema in memory.
+// The file is left as is; a warning points at `principles migrate`.
+func loadPrinciples(path string) (*config.Principles, error) {
+	p, result, err := config.LoadAndMigrate(path)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s uses principles schema %s; using it as %s. Run 'claude-loop principles migrate' to update the file.\n",
+			path, result.From, result.To)
+	}
+	return p, nil
+}
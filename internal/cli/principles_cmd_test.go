@@ -0,0 +1,512 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/principles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const principlesV20 = `version: "2.0"
+preset: startup
+created_at: "2025-03-01"
+layer0:
+  trust_architecture: 5
+  curation_model: 5
+  scope_philosophy: 5
+  monetization_model: 5
+  privacy_posture: 5
+  ux_philosophy: 5
+  authority_stance: 5
+  auditability: 5
+  interoperability: 5
+layer1:
+  speed_correctness: 3
+  innovation_stability: 3
+  blast_radius: 5
+  clarity_of_intent: 5
+  reversibility_priority: 5
+  security_posture: 5
+  urgency_tiers: 5
+  cost_efficiency: 5
+`
+
+func writeOldPrinciples(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "principles.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(principlesV20), 0644))
+	return path
+}
+
+func TestMigratePrinciples(t *testing.T) {
+	t.Run("migrates and writes backup", func(t *testing.T) {
+		path := writeOldPrinciples(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, migratePrinciples(&buf, &PrinciplesMigrateOptions{PrinciplesFile: path}))
+
+		out := buf.String()
+		assert.Contains(t, out, "Migrated "+path+" from 2.0 to "+config.DefaultVersion)
+		assert.Contains(t, out, "layer1.migration_burden: added as 5 (startup default)")
+		assert.Contains(t, out, "Backup: "+config.BackupPath(path, "2.0"))
+		assert.FileExists(t, config.BackupPath(path, "2.0"))
+
+		buf.Reset()
+		require.NoError(t, migratePrinciples(&buf, &PrinciplesMigrateOptions{PrinciplesFile: path}))
+		assert.Contains(t, buf.String(), "already at schema version "+config.DefaultVersion)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		path := writeOldPrinciples(t)
+
+		var buf bytes.Buffer
+		require.NoError(t, migratePrinciples(&buf, &PrinciplesMigrateOptions{PrinciplesFile: path, DryRun: true}))
+
+		assert.Contains(t, buf.String(), "Would migrate "+path)
+		assert.NotContains(t, buf.String(), "Backup:")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, principlesV20, string(data))
+	})
+
+	t.Run("newer schema", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`version: "9.9"`), 0644))
+
+		err := migratePrinciples(&bytes.Buffer{}, &PrinciplesMigrateOptions{PrinciplesFile: path})
+		assert.True(t, config.IsMigrationError(err))
+	})
+}
+
+func TestLoadPrinciples_MigratesInMemory(t *testing.T) {
+	path := writeOldPrinciples(t)
+
+	p, err := loadPrinciples(path)
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultVersion, p.Version)
+	assert.Equal(t, 5, p.Layer1.MigrationBurden)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, principlesV20, string(data))
+}
+
+func TestShowPrinciples(t *testing.T) {
+	t.Run("file values", func(t *testing.T) {
+		isolateUserPrinciples(t, "")
+		path := writeTestPrinciples(t, t.TempDir(), config.PresetEnterprise)
+
+		var buf bytes.Buffer
+		require.NoError(t, showPrinciples(&buf, &PrinciplesShowOptions{PrinciplesFile: path}))
+		out := buf.String()
+		assert.Contains(t, out, "(schema "+config.DefaultVersion+", preset enterprise)")
+		assert.Contains(t, out, "  layer1.security_posture         9\n")
+	})
+
+	t.Run("effective values with sources", func(t *testing.T) {
+		org := filepath.Join(t.TempDir(), "org.yaml")
+		require.NoError(t, os.WriteFile(org, []byte(orgPrinciples), 0644))
+		isolateUserPrinciples(t, org)
+		path := writeTestPrinciples(t, t.TempDir(), config.PresetStartup)
+
+		var buf bytes.Buffer
+		require.NoError(t, showPrinciples(&buf, &PrinciplesShowOptions{
+			PrinciplesFile: path,
+			Effective:      true,
+			Overrides:      []string{"cost_efficiency=9"},
+		}))
+		out := buf.String()
+		assert.Contains(t, out, "Effective principles: user ("+org+") < repository ("+path+") < cli (--principle)")
+		assert.Contains(t, out, "layer1.security_posture         8  user ("+org+"), locked >= 8 by user")
+		assert.Contains(t, out, "layer1.cost_efficiency          9  cli (--principle)")
+		assert.Contains(t, out, "layer1.blast_radius")
+		assert.Contains(t, out, "repository ("+path+")")
+		assert.Contains(t, out, "Ignored:\n  layer1.security_posture: repository value 7 ignored")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		isolateUserPrinciples(t, "")
+		err := showPrinciples(&bytes.Buffer{}, &PrinciplesShowOptions{PrinciplesFile: filepath.Join(t.TempDir(), "none.yaml")})
+		assert.True(t, config.IsLoadError(err))
+	})
+}
+
+// setStdinInteractive makes stdinInteractive report interactive for the test.
+func setStdinInteractive(t *testing.T, interactive bool) {
+	t.Helper()
+	orig := stdinInteractive
+	stdinInteractive = func() bool { return interactive }
+	t.Cleanup(func() { stdinInteractive = orig })
+}
+
+func TestInitPrinciples(t *testing.T) {
+	t.Run("non-interactive with overrides", func(t *testing.T) {
+		setStdinInteractive(t, true)
+		path := filepath.Join(t.TempDir(), ".claude", "principles.yaml")
+
+		var buf bytes.Buffer
+		opts := &PrinciplesInitOptions{PrinciplesFile: path, Preset: "enterprise", Set: []string{"security_posture=10"}, NonInteractive: true}
+		require.NoError(t, initPrinciples(context.Background(), &buf, strings.NewReader(""), nil, opts))
+
+		assert.Contains(t, buf.String(), "Wrote "+path+" (preset enterprise, schema "+config.DefaultVersion+")")
+		assert.Contains(t, buf.String(), "layer1.security_posture: 9 -> 10")
+		p, err := config.LoadFromFile(path)
+		require.NoError(t, err)
+		require.NoError(t, p.Validate())
+		assert.Equal(t, config.PresetEnterprise, p.Preset)
+		assert.Equal(t, 10, p.Layer1.SecurityPosture)
+	})
+
+	t.Run("stdin not a terminal uses startup defaults", func(t *testing.T) {
+		setStdinInteractive(t, false)
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+
+		require.NoError(t, initPrinciples(context.Background(), &bytes.Buffer{}, strings.NewReader(""), nil, &PrinciplesInitOptions{PrinciplesFile: path}))
+
+		p, err := config.LoadFromFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, config.DefaultPrinciples(config.PresetStartup).Layer1, p.Layer1)
+	})
+
+	t.Run("interactive asks the preset's questions", func(t *testing.T) {
+		setStdinInteractive(t, true)
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+
+		opts := &PrinciplesInitOptions{PrinciplesFile: path, Preset: "opensource", Set: []string{"ux_philosophy=2"}}
+		require.NoError(t, initPrinciples(context.Background(), &bytes.Buffer{}, strings.NewReader("9\n4\n"), nil, opts))
+
+		p, err := config.LoadFromFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, config.PresetOpenSource, p.Preset)
+		assert.Equal(t, 9, p.Layer0.CurationModel)
+		assert.Equal(t, 2, p.Layer0.UXPhilosophy)
+	})
+
+	t.Run("existing file needs force", func(t *testing.T) {
+		path := writeTestPrinciples(t, t.TempDir(), config.PresetStartup)
+		opts := &PrinciplesInitOptions{PrinciplesFile: path, Preset: "enterprise", NonInteractive: true}
+
+		err := initPrinciples(context.Background(), &bytes.Buffer{}, strings.NewReader(""), nil, opts)
+		assert.ErrorContains(t, err, "already exists; use --force")
+
+		opts.Force = true
+		require.NoError(t, initPrinciples(context.Background(), &bytes.Buffer{}, strings.NewReader(""), nil, opts))
+		p, err := config.LoadFromFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, config.PresetEnterprise, p.Preset)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+
+		err := initPrinciples(context.Background(), &bytes.Buffer{}, strings.NewReader(""), nil,
+			&PrinciplesInitOptions{PrinciplesFile: path, Preset: "custom", NonInteractive: true})
+		assert.ErrorContains(t, err, `invalid preset "custom"`)
+
+		err = initPrinciples(context.Background(), &bytes.Buffer{}, strings.NewReader(""), nil,
+			&PrinciplesInitOptions{PrinciplesFile: path, Set: []string{"security_posture=11"}, NonInteractive: true})
+		assert.ErrorContains(t, err, "must be between 1 and 10")
+		assert.NoFileExists(t, path)
+	})
+}
+
+func TestValidatePrinciples(t *testing.T) {
+	path := writeTestPrinciples(t, t.TempDir(), config.PresetStartup)
+
+	var buf bytes.Buffer
+	require.NoError(t, validatePrinciples(&buf, &PrinciplesValidateOptions{PrinciplesFile: path}))
+	assert.Contains(t, buf.String(), path+" is valid")
+
+	invalid := filepath.Join(t.TempDir(), "principles.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte(`version: "2.3"
+preset: startup
+created_at: "2026-01-11"
+layer0:
+  privacy_posture: 12
+`), 0644))
+
+	buf.Reset()
+	err := validatePrinciples(&buf, &PrinciplesValidateOptions{PrinciplesFile: invalid})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has 18 problems")
+	assert.Contains(t, buf.String(), "layer0.privacy_posture must be between 1 and 10")
+	assert.Contains(t, buf.String(), "layer1.migration_burden")
+}
+
+func TestDiffPrinciples(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestPrinciples(t, dir, config.PresetStartup)
+
+	var buf bytes.Buffer
+	require.NoError(t, diffPrinciples(&buf, nil, &PrinciplesDiffOptions{PrinciplesFile: path}))
+	assert.Equal(t, path+" matches the startup preset\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, diffPrinciples(&buf, nil, &PrinciplesDiffOptions{PrinciplesFile: path, Preset: "enterprise"}))
+	assert.Contains(t, buf.String(), "differs from the enterprise preset")
+	assert.Contains(t, buf.String(), "  layer1.security_posture         7  (enterprise: 9)\n")
+
+	custom := config.DefaultPrinciples(config.PresetStartup)
+	custom.Preset = config.PresetCustom
+	custom.CreatedAt = "2026-01-11"
+	require.NoError(t, config.SaveToFile(path, custom))
+	err := diffPrinciples(&buf, nil, &PrinciplesDiffOptions{PrinciplesFile: path})
+	assert.ErrorContains(t, err, "choose one to compare against with --preset")
+}
+
+func TestExplainPrinciple(t *testing.T) {
+	path := writeTestPrinciples(t, t.TempDir(), config.PresetEnterprise)
+
+	var buf bytes.Buffer
+	require.NoError(t, explainPrinciple(&buf, "security_posture", nil, &PrinciplesExplainOptions{PrinciplesFile: path}))
+
+	out := buf.String()
+	assert.Contains(t, out, "Security Posture (layer1.security_posture)")
+	assert.Contains(t, out, "Scale:    1-3 Basic, 7-10 Maximum security")
+	assert.Contains(t, out, "High:     Threat review, least privilege, strict checks")
+	assert.Contains(t, out, "Effects:  >= 8 requires a reviewer")
+	assert.Contains(t, out, "Defaults: startup 7, enterprise 9, opensource 8")
+	assert.Contains(t, out, "Current:  9 ("+path+")")
+
+	buf.Reset()
+	require.NoError(t, explainPrinciple(&buf, "layer0.auditability", nil, &PrinciplesExplainOptions{PrinciplesFile: filepath.Join(t.TempDir(), "none.yaml")}))
+	assert.NotContains(t, buf.String(), "Current:")
+	assert.NotContains(t, buf.String(), "Effects:")
+
+	err := explainPrinciple(&buf, "velocity", nil, &PrinciplesExplainOptions{})
+	assert.ErrorContains(t, err, `unknown principle "velocity"`)
+}
+
+func TestLoadOrCollectPrinciples_NonInteractive(t *testing.T) {
+	setStdinInteractive(t, false)
+	isolateUserPrinciples(t, "")
+	flags := &Flags{PrinciplesFile: filepath.Join(t.TempDir(), "principles.yaml")}
+
+	_, err := loadOrCollectPrinciples(context.Background(), flags)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stdin is not a terminal")
+	assert.Contains(t, err.Error(), "principles init --preset")
+	assert.NoFileExists(t, flags.PrinciplesFile)
+
+	flags.Preset = "regulated-fintech"
+	flags.presets = testPresets(t, "regulated-fintech", config.PresetEnterprise)
+	p, err := loadOrCollectPrinciples(context.Background(), flags)
+	require.NoError(t, err)
+	assert.Equal(t, config.Preset("regulated-fintech"), p.Preset)
+	assert.Equal(t, 10, p.Layer1.SecurityPosture)
+	assert.FileExists(t, flags.PrinciplesFile)
+}
+
+func TestLoadOrCollectPrinciples_DryRunPreset(t *testing.T) {
+	isolateUserPrinciples(t, "")
+	flags := &Flags{PrinciplesFile: filepath.Join(t.TempDir(), "principles.yaml"), DryRun: true, Preset: "opensource"}
+
+	p, err := loadOrCollectPrinciples(context.Background(), flags)
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultPrinciples(config.PresetOpenSource).Layer1, p.Layer1)
+	assert.NoFileExists(t, flags.PrinciplesFile)
+}
+
+func TestCustomPresetCommands(t *testing.T) {
+	presets := testPresets(t, "regulated-fintech", config.PresetEnterprise)
+	path := filepath.Join(t.TempDir(), "principles.yaml")
+
+	var buf bytes.Buffer
+	opts := &PrinciplesInitOptions{PrinciplesFile: path, Preset: "regulated-fintech", NonInteractive: true}
+	require.NoError(t, initPrinciples(context.Background(), &buf, strings.NewReader(""), presets, opts))
+	assert.Contains(t, buf.String(), "(preset regulated-fintech, schema ")
+
+	buf.Reset()
+	require.NoError(t, diffPrinciples(&buf, presets, &PrinciplesDiffOptions{PrinciplesFile: path}))
+	assert.Equal(t, path+" matches the regulated-fintech preset\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, diffPrinciples(&buf, presets, &PrinciplesDiffOptions{PrinciplesFile: path, Preset: "enterprise"}))
+	assert.Contains(t, buf.String(), "  layer1.security_posture        10  (enterprise: 9)\n")
+
+	buf.Reset()
+	require.NoError(t, explainPrinciple(&buf, "security_posture", presets, &PrinciplesExplainOptions{PrinciplesFile: path}))
+	assert.Contains(t, buf.String(), "Defaults: startup 7, enterprise 9, opensource 8, regulated-fintech 10")
+
+	err := initPrinciples(context.Background(), &buf, strings.NewReader(""), presets,
+		&PrinciplesInitOptions{PrinciplesFile: path, Preset: "agency", Force: true, NonInteractive: true})
+	assert.ErrorContains(t, err, `invalid preset "agency" (must be one of: startup, enterprise, opensource, regulated-fintech)`)
+}
+
+func TestCustomPresetFile_WithoutPresetDefinition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "principles.yaml")
+	opts := &PrinciplesInitOptions{PrinciplesFile: path, Preset: "regulated-fintech", NonInteractive: true}
+	presets := testPresets(t, "regulated-fintech", config.PresetEnterprise)
+	require.NoError(t, initPrinciples(context.Background(), &bytes.Buffer{}, strings.NewReader(""), presets, opts))
+
+	// A checkout without .claude/presets/regulated-fintech.yaml still uses the file
+	var buf bytes.Buffer
+	require.NoError(t, validatePrinciples(&buf, &PrinciplesValidateOptions{PrinciplesFile: path}))
+	assert.Contains(t, buf.String(), path+" is valid")
+
+	isolateUserPrinciples(t, "")
+	p, err := loadOrCollectPrinciples(context.Background(), &Flags{PrinciplesFile: path})
+	require.NoError(t, err)
+	assert.Equal(t, config.Preset("regulated-fintech"), p.Preset)
+	assert.Equal(t, 10, p.Layer1.SecurityPosture)
+
+	err = diffPrinciples(&buf, nil, &PrinciplesDiffOptions{PrinciplesFile: path})
+	assert.ErrorContains(t, err, `uses preset "regulated-fintech"; choose one to compare against with --preset`)
+}
+
+func TestLoadPresets(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "regulated-fintech.yaml"),
+		[]byte("extends: enterprise\nlayer1:\n  security_posture: 10\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("extends: agency\n"), 0644))
+	t.Setenv(config.PresetsPathEnv, dir)
+
+	var stderr bytes.Buffer
+	presets := loadPresets(&stderr)
+
+	assert.True(t, presets.HasDefaults("regulated-fintech"))
+	assert.Contains(t, stderr.String(), "Warning: skipping preset "+filepath.Join(dir, "broken.yaml")+": invalid preset")
+}
+
+// testPresets returns a registry with a custom preset that raises
+// security_posture to 10.
+func testPresets(t *testing.T, name string, extends config.Preset) *config.PresetRegistry {
+	t.Helper()
+	presets := config.NewPresetRegistry()
+	d := &config.PresetDefinition{Name: config.Preset(name), Extends: extends, Layer1: config.Layer1{SecurityPosture: 10}}
+	require.NoError(t, presets.Register(d))
+	return presets
+}
+
+// cannedSuggester returns a Suggester whose client always replies with output.
+func cannedSuggester(output string) *principles.Suggester {
+	return principles.NewSuggester(suggestClientFunc(func(ctx context.Context, prompt string) (*principles.IterationResult, error) {
+		return &principles.IterationResult{Output: output, Cost: 0.01}, nil
+	}), nil)
+}
+
+type suggestClientFunc func(ctx context.Context, prompt string) (*principles.IterationResult, error)
+
+func (f suggestClientFunc) Execute(ctx context.Context, prompt string) (*principles.IterationResult, error) {
+	return f(ctx, prompt)
+}
+
+const enterpriseSuggestion = "```yaml\npreset: enterprise\nprinciples:\n  auditability:\n    value: 10\n    rationale: SOC 2 mentioned in README\n```"
+
+func TestSuggestPrinciples(t *testing.T) {
+	signals := &principles.Signals{License: "MIT"}
+
+	t.Run("writes with --yes and --set", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+		opts := &PrinciplesSuggestOptions{PrinciplesFile: path, Yes: true, Set: []string{"blast_radius=5"}}
+
+		var buf bytes.Buffer
+		require.NoError(t, suggestPrinciples(context.Background(), &buf, strings.NewReader(""), cannedSuggester(enterpriseSuggestion), nil, signals, opts))
+
+		out := buf.String()
+		assert.Contains(t, out, "- License: MIT")
+		assert.Contains(t, out, "Suggested preset: enterprise (cost $0.0100)")
+		assert.Contains(t, out, "  layer0.auditability            10 * (enterprise: 9)  SOC 2 mentioned in README\n")
+		assert.Contains(t, out, "  layer1.blast_radius             5 * (enterprise: 9)  set with --set\n")
+		assert.Contains(t, out, "  layer1.security_posture         9   (enterprise: 9)  preset default\n")
+		assert.Contains(t, out, "Wrote "+path)
+
+		p, err := config.LoadFromFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, 10, p.Layer0.Auditability)
+		assert.Equal(t, 5, p.Layer1.BlastRadius)
+	})
+
+	t.Run("not written without a terminal", func(t *testing.T) {
+		setStdinInteractive(t, false)
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+
+		var buf bytes.Buffer
+		require.NoError(t, suggestPrinciples(context.Background(), &buf, strings.NewReader(""), cannedSuggester(enterpriseSuggestion), nil, signals,
+			&PrinciplesSuggestOptions{PrinciplesFile: path}))
+		assert.Contains(t, buf.String(), "Re-run with --yes")
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("interactive adjustments", func(t *testing.T) {
+		setStdinInteractive(t, true)
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+
+		var buf bytes.Buffer
+		in := strings.NewReader("velocity=3\nurgency_tiers=2\n\n")
+		require.NoError(t, suggestPrinciples(context.Background(), &buf, in, cannedSuggester(enterpriseSuggestion), nil, signals,
+			&PrinciplesSuggestOptions{PrinciplesFile: path}))
+
+		assert.Contains(t, buf.String(), `unknown principle "velocity"`)
+		assert.Contains(t, buf.String(), "layer1.urgency_tiers: 5 -> 2")
+		p, err := config.LoadFromFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, 2, p.Layer1.UrgencyTiers)
+	})
+
+	t.Run("interactive cancel", func(t *testing.T) {
+		setStdinInteractive(t, true)
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+
+		var buf bytes.Buffer
+		require.NoError(t, suggestPrinciples(context.Background(), &buf, strings.NewReader("n\n"), cannedSuggester(enterpriseSuggestion), nil, signals,
+			&PrinciplesSuggestOptions{PrinciplesFile: path}))
+		assert.Contains(t, buf.String(), "Cancelled; nothing written.")
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("existing file needs force", func(t *testing.T) {
+		path := writeTestPrinciples(t, t.TempDir(), config.PresetStartup)
+		err := suggestPrinciples(context.Background(), &bytes.Buffer{}, strings.NewReader(""), cannedSuggester(enterpriseSuggestion), nil, signals,
+			&PrinciplesSuggestOptions{PrinciplesFile: path, Yes: true})
+		assert.ErrorContains(t, err, "already exists; use --force")
+	})
+
+	t.Run("invalid reply", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+		err := suggestPrinciples(context.Background(), &bytes.Buffer{}, strings.NewReader(""), cannedSuggester("preset: agency"), nil, signals,
+			&PrinciplesSuggestOptions{PrinciplesFile: path, Yes: true})
+		assert.ErrorContains(t, err, `unknown preset "agency"`)
+	})
+}
+
+func TestRunPrinciplesWizard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "principles.yaml")
+
+	setStdinInteractive(t, false)
+	err := runPrinciplesWizard(context.Background(), &bytes.Buffer{}, strings.NewReader(""), nil, &PrinciplesWizardOptions{PrinciplesFile: path})
+	assert.ErrorContains(t, err, "needs a terminal")
+
+	setStdinInteractive(t, true)
+	err = runPrinciplesWizard(context.Background(), &bytes.Buffer{}, strings.NewReader(""), nil, &PrinciplesWizardOptions{PrinciplesFile: path, Preset: "agency"})
+	assert.ErrorContains(t, err, `invalid preset "agency"`)
+
+	var buf bytes.Buffer
+	require.NoError(t, runPrinciplesWizard(context.Background(), &buf, strings.NewReader("s\ns\n\n"), nil, &PrinciplesWizardOptions{PrinciplesFile: path, Preset: "opensource"}))
+	assert.Contains(t, buf.String(), "Wrote "+path)
+	p, err := config.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, config.PresetOpenSource, p.Preset)
+}
+
+func TestListPresets(t *testing.T) {
+	d := &config.PresetDefinition{Name: "regulated-fintech", Description: "Payments under PCI DSS",
+		Extends: config.PresetEnterprise, Path: ".claude/presets/regulated-fintech.yaml"}
+	presets := config.NewPresetRegistry()
+	require.NoError(t, presets.Register(d))
+
+	var buf bytes.Buffer
+	listPresets(&buf, presets)
+	assert.Equal(t, "startup              built-in\n"+
+		"enterprise           built-in\n"+
+		"opensource           built-in\n"+
+		"regulated-fintech    extends enterprise (.claude/presets/regulated-fintech.yaml)\n"+
+		"                     Payments under PCI DSS\n", buf.String())
+}
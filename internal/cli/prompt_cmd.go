@@ -0,0 +1,421 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+	"github.com/DeukWoongWoo/claude-loop/internal/planner"
+	"github.com/DeukWoongWoo/claude-loop/internal/prompt"
+	"github.com/DeukWoongWoo/claude-loop/internal/reviewer"
+	"github.com/spf13/cobra"
+)
+
+// Prompt roles accepted by `prompt render --role`.
+const (
+	renderRoleIteration    = "iteration"
+	renderRoleReviewer     = "reviewer"
+	renderRoleCouncil      = "council"
+	renderRoleCIFix        = "ci-fix"
+	renderRolePRD          = "prd"
+	renderRoleArchitecture = "architecture"
+	renderRoleTasks        = "tasks"
+	renderRoleAll          = "all"
+)
+
+// renderRoles lists every concrete role in render order.
+var renderRoles = []string{
+	renderRoleIteration,
+	renderRoleReviewer,
+	renderRoleCouncil,
+	renderRoleCIFix,
+	renderRolePRD,
+	renderRoleArchitecture,
+	renderRoleTasks,
+}
+
+// PromptRenderOptions holds flag values for `prompt render`.
+type PromptRenderOptions struct {
+	Role                 string   // --role: Which prompt to render (default: all)
+	Prompt               string   // -p, --prompt: User prompt
+	Iteration            int      // --iteration: Iteration number (also used as CI fix attempt)
+	CIRetryMax           int      // --ci-retry-max: Maximum CI fix attempts
+	CompletionSignal     string   // --completion-signal: Completion phrase
+	NotesFile            string   // --notes-file: Shared notes file
+	PrinciplesFile       string   // --principles-file: Principles file
+	TemplatesDir         string   // --templates-dir: Template overrides directory
+	ReviewPrompt         string   // -r, --review-prompt: Reviewer instructions
+	ConflictContext      string   // --conflict: Conflict block or context for the council
+	CouncilFile          string   // --council-file: Council members and chair
+	CouncilMembers       int      // --council-members: Members to convene (0 = all, 1 = a single resolution call)
+	Branch               string   // --branch: Branch exposed to templates
+	PlanID               string   // --plan-id: Saved plan used for architecture/tasks prompts
+	ReviewersFile        string   // --reviewers-file: Specialised reviewers (a reviewer gates commits)
+	DecisionsFile        string   // --decisions-file: Decision log offered as precedents
+	Verification         string   // --verification: relaxed, standard or strict (default from principles)
+	DisableCommits       bool     // --disable-commits: Leave commits to Claude
+	DisableSecretScan    bool     // --disable-secret-scan: Do not scan changes for secrets
+	DisableBranches      bool     // --disable-branches: Commit directly to the current branch
+	VerificationFailures []string // --verification-failure: Failed check reported by the previous iteration
+	BlockedCommit        []string // --blocked-commit: Reason the previous iteration was not committed
+	OutputDir            string   // --output: Write prompts to files instead of stdout
+	StatsOnly            bool     // --stats: Print sizes only
+}
+
+// renderedPrompt is a prompt produced by one of the builders.
+type renderedPrompt struct {
+	Role   string
+	Prompt string
+}
+
+var promptRenderOpts = &PromptRenderOptions{}
+
+// promptCmd groups prompt inspection commands.
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Inspect the prompts claude-loop sends to Claude",
+}
+
+// promptRenderCmd renders prompts without running Claude.
+var promptRenderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render the exact prompts without running Claude",
+	Long: `Render the exact prompts claude-loop would send for the given flags,
+and report their size and estimated tokens per section.
+
+Roles: iteration, reviewer, council, ci-fix, prd, architecture, tasks, all (default).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prompts, err := renderPrompts(promptRenderOpts)
+		if err != nil {
+			return err
+		}
+		return writeRenderedPrompts(cmd.OutOrStdout(), prompts, promptRenderOpts)
+	},
+}
+
+func init() {
+	f := promptRenderCmd.Flags()
+	o := promptRenderOpts
+	f.StringVar(&o.Role, "role", renderRoleAll, "Prompt to render: "+strings.Join(renderRoles, ", ")+", or all")
+	f.StringVarP(&o.Prompt, "prompt", "p", "", "The prompt/goal to render")
+	f.IntVar(&o.Iteration, "iteration", 1, "Iteration number to render")
+	f.IntVar(&o.CIRetryMax, "ci-retry-max", 1, "Maximum CI fix attempts per PR")
+	f.StringVar(&o.CompletionSignal, "completion-signal", "CONTINUOUS_CLAUDE_PROJECT_COMPLETE", "Completion signal phrase")
+	f.StringVar(&o.NotesFile, "notes-file", "SHARED_TASK_NOTES.md", "Shared notes file")
+	f.StringVar(&o.PrinciplesFile, "principles-file", ".claude/principles.yaml", "Principles file path")
+	f.StringVar(&o.TemplatesDir, "templates-dir", ".claude/templates", "Directory of prompt template overrides")
+	f.StringVarP(&o.ReviewPrompt, "review-prompt", "r", "", "Reviewer instructions")
+	f.StringVar(&o.ConflictContext, "conflict", "", "Conflict block or context for the council prompt")
+	f.StringVar(&o.CouncilFile, "council-file", council.DefaultFile, "Council members and chair")
+	f.IntVar(&o.CouncilMembers, "council-members", 0, "Council members to convene (0 = all, 1 = a single resolution call)")
+	f.StringVar(&o.Branch, "branch", "", "Branch name exposed to templates")
+	f.StringVar(&o.PlanID, "plan-id", "", "Saved plan ID used for architecture and tasks prompts")
+	f.StringVar(&o.ReviewersFile, "reviewers-file", reviewer.DefaultFile, "Specialised reviewers and the diff size cap")
+	f.StringVar(&o.DecisionsFile, "decisions-file", council.DefaultDecisionsFile, "Decision log offered as precedents")
+	f.StringVar(&o.Verification, "verification", "", "Verification level: relaxed, standard, strict (default: from principles)")
+	f.BoolVar(&o.DisableCommits, "disable-commits", false, "Render as if commits were left to Claude")
+	f.BoolVar(&o.DisableSecretScan, "disable-secret-scan", false, "Render as if changes were not scanned for secrets")
+	f.BoolVar(&o.DisableBranches, "disable-branches", false, "Render as if commits went directly to the current branch")
+	f.StringArrayVar(&o.VerificationFailures, "verification-failure", nil, "Failed check to report from the previous iteration (repeatable)")
+	f.StringArrayVar(&o.BlockedCommit, "blocked-commit", nil, "Reason the previous iteration was not committed (repeatable)")
+	f.StringVar(&o.OutputDir, "output", "", "Write each prompt to <output>/<role>.md instead of stdout")
+	f.BoolVar(&o.StatsOnly, "stats", false, "Print sizes and estimated tokens only")
+
+	promptCmd.SetHelpTemplate(subcommandHelpTemplate)
+	promptCmd.AddCommand(promptRenderCmd)
+	rootCmd.AddCommand(promptCmd)
+}
+
+// renderPrompts builds the prompts selected by opts.Role.
+func renderPrompts(opts *PromptRenderOptions) ([]renderedPrompt, error) {
+	roles := renderRoles
+	if opts.Role != renderRoleAll {
+		if !containsString(renderRoles, opts.Role) {
+			return nil, fmt.Errorf("unknown role %q (valid: %s, all)", opts.Role, strings.Join(renderRoles, ", "))
+		}
+		roles = []string{opts.Role}
+	}
+
+	templates, err := prompt.LoadTemplates(opts.TemplatesDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := templates.Validate(); err != nil {
+		return nil, err
+	}
+
+	// A missing principles file renders prompts without principles, as the loop would in dry-run
+	principles, err := loadLayeredPrinciples(io.Discard, opts.PrinciplesFile, nil)
+	if err != nil {
+		if !os.IsNotExist(errors.Unwrap(err)) {
+			return nil, err
+		}
+		principles = nil
+	}
+
+	var rendered []renderedPrompt
+	for _, role := range roles {
+		if role == renderRoleCouncil {
+			prompts, err := renderCouncil(opts, principles)
+			if err != nil {
+				return nil, fmt.Errorf("rendering %s prompt: %w", role, err)
+			}
+			rendered = append(rendered, prompts...)
+			continue
+		}
+		text, err := renderRole(role, opts, templates, principles)
+		if err != nil {
+			return nil, fmt.Errorf("rendering %s prompt: %w", role, err)
+		}
+		rendered = append(rendered, renderedPrompt{Role: role, Prompt: text})
+	}
+	return rendered, nil
+}
+
+// renderRole builds the prompt for a single role.
+func renderRole(role string, opts *PromptRenderOptions, templates *prompt.TemplateSet, principles *config.Principles) (string, error) {
+	switch role {
+	case renderRoleIteration:
+		buildCtx, err := iterationContext(context.Background(), opts, principles)
+		if err != nil {
+			return "", err
+		}
+		result, err := prompt.NewBuilderWithTemplates(templates).Build(buildCtx)
+		if err != nil {
+			return "", err
+		}
+		return result.Prompt, nil
+
+	case renderRoleReviewer:
+		reviewPrompt := opts.ReviewPrompt
+		if reviewPrompt == "" {
+			reviewPrompt = "<review instructions from -r>"
+		}
+		result, err := reviewer.NewPromptBuilderWithTemplates(templates).Build(reviewer.BuildContext{
+			UserReviewPrompt: reviewPrompt,
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.Prompt, nil
+
+	case renderRoleCIFix:
+		result, err := prompt.NewCIFixBuilderWithTemplates(templates).Build(prompt.CIFixContext{
+			FailureInfo: &prompt.CIFailureInfo{
+				RunID:        "<run-id>",
+				WorkflowName: "<workflow>",
+				JobName:      "<job>",
+				ErrorLogs:    "<error logs>",
+			},
+			BranchName:  opts.Branch,
+			Attempt:     opts.Iteration,
+			MaxAttempts: opts.CIRetryMax,
+		})
+		if err != nil {
+			return "", err
+		}
+		return result.Prompt, nil
+
+	case renderRolePRD:
+		return planner.NewPromptBuilder().BuildPRDPrompt(opts.Prompt), nil
+
+	case renderRoleArchitecture:
+		plan, err := loadRenderPlan(opts.PlanID)
+		if err != nil {
+			return "", err
+		}
+		prd := plan.PRD
+		if prd == nil {
+			prd = &planner.PRD{}
+		}
+		return planner.NewPromptBuilder().BuildArchitecturePrompt(prd)
+
+	case renderRoleTasks:
+		plan, err := loadRenderPlan(opts.PlanID)
+		if err != nil {
+			return "", err
+		}
+		arch := plan.Architecture
+		if arch == nil {
+			arch = &planner.Architecture{}
+		}
+		return planner.NewPromptBuilder().BuildTasksPrompt(arch)
+	}
+	return "", fmt.Errorf("unknown role %q", role)
+}
+
+// renderCouncil builds the council prompts as Resolve sends them: with two or
+// more members, one prompt per member (role council-<name>) and the chair's
+// prompt (role council-chair), whose votes are placeholders; otherwise the
+// single resolution prompt. Logged decisions relevant to the conflict are
+// offered as precedents.
+func renderCouncil(opts *PromptRenderOptions, principles *config.Principles) ([]renderedPrompt, error) {
+	if principles == nil {
+		principles = config.DefaultPrinciples(config.PresetStartup)
+	}
+	conflict, ok := council.ParseConflict(opts.ConflictContext)
+	if !ok {
+		conflict = council.Conflict{Context: opts.ConflictContext}
+	}
+	if conflict.Context == "" {
+		conflict.Context = "<context from the iteration's conflict block>"
+	}
+	settings, err := council.LoadSettings(opts.CouncilFile)
+	if err != nil {
+		return nil, err
+	}
+	settings.Limit(opts.CouncilMembers)
+
+	memory := council.NewDecisionMemory(loadPriorDecisions(opts.DecisionsFile))
+	bctx := council.BuildContext{
+		Conflict:   conflict,
+		Principles: principles,
+		Precedents: memory.Precedents(conflict.String()),
+	}
+	builder := council.NewPromptBuilder()
+	if len(settings.Members) < 2 {
+		result, err := builder.Build(bctx)
+		if err != nil {
+			return nil, err
+		}
+		return []renderedPrompt{{Role: renderRoleCouncil, Prompt: result.Prompt}}, nil
+	}
+
+	options := council.VoteOptions(conflict)
+	var rendered []renderedPrompt
+	votes := make([]council.Vote, len(settings.Members))
+	for i, m := range settings.Members {
+		result, err := builder.BuildMember(bctx, m, options)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, renderedPrompt{Role: renderRoleCouncil + "-" + m.Name, Prompt: result.Prompt})
+		votes[i] = council.Vote{Member: m.Name, Vote: "<vote>", Decision: "<decision>", Rationale: "<rationale>"}
+	}
+	chair := builder.BuildChair(bctx, settings.Chair, votes)
+	rendered = append(rendered, renderedPrompt{Role: renderRoleCouncil + "-" + council.ChairName, Prompt: chair.Prompt})
+	return rendered, nil
+}
+
+// iterationContext builds the iteration prompt's context as a run with the same
+// flags would: the policy is derived from principles, the review and commit gates
+// follow the review flags, the reviewers file and the commit settings, the commit
+// gate lists the checks applied before committing, and logged
+// decisions relevant to the prompt and notes are offered as precedents.
+func iterationContext(ctx context.Context, opts *PromptRenderOptions, principles *config.Principles) (prompt.BuildContext, error) {
+	flags := &Flags{
+		ReviewPrompt:      opts.ReviewPrompt,
+		Verification:      opts.Verification,
+		DisableCommits:    opts.DisableCommits,
+		DisableSecretScan: opts.DisableSecretScan,
+		DisableBranches:   opts.DisableBranches,
+	}
+	policy := resolvePolicy(flags, principles, func(name string) bool {
+		switch name {
+		case "review-prompt":
+			return opts.ReviewPrompt != ""
+		case "verification":
+			return opts.Verification != ""
+		case "disable-branches":
+			return opts.DisableBranches
+		}
+		return false
+	})
+
+	reviewers, err := reviewer.LoadSettings(opts.ReviewersFile)
+	if err != nil {
+		return prompt.BuildContext{}, err
+	}
+	reviewGate := flags.ReviewPrompt != "" || len(reviewers.Reviewers) > 0
+
+	// The council, and with it the precedents, only exists when principles are loaded
+	var precedents []string
+	if principles != nil {
+		notes, _, _ := prompt.NewFileNotesLoader().Load(opts.NotesFile)
+		memory := council.NewDecisionMemory(loadPriorDecisions(opts.DecisionsFile))
+		precedents = memory.Precedents(opts.Prompt + "\n" + notes)
+	}
+
+	// The checks are those a run applies before committing
+	commitGate := !reviewGate && isGitRepository(ctx) && commitsChanges(flags, policy)
+	var checks prompt.CommitChecks
+	if commitGate {
+		checks.Secrets = !flags.DisableSecretScan
+		checks.Verification = policy.Verification == config.VerificationStrict &&
+			len(verifyCriteria(flags, policy, projectRoot(ctx))) > 0
+		if !policy.DirectPush {
+			checks.BaseBranch = git.NewRepository(nil).GetDefaultBranch(ctx)
+		}
+	}
+
+	return prompt.BuildContext{
+		UserPrompt:           opts.Prompt,
+		Principles:           principles,
+		CompletionSignal:     opts.CompletionSignal,
+		NotesFile:            opts.NotesFile,
+		Iteration:            opts.Iteration,
+		Branch:               opts.Branch,
+		PriorDecisions:       precedents,
+		ReviewGate:           reviewGate,
+		CommitGate:           commitGate,
+		CommitChecks:         checks,
+		BlockedCommit:        opts.BlockedCommit,
+		Policy:               policy,
+		VerificationFailures: opts.VerificationFailures,
+	}, nil
+}
+
+// loadRenderPlan loads a saved plan, or returns an empty plan when planID is empty.
+func loadRenderPlan(planID string) (*planner.Plan, error) {
+	if planID == "" {
+		return planner.NewPlan("", ""), nil
+	}
+	persistence := planner.NewFilePersistence(planner.DefaultConfig().PlanDir)
+	return persistence.Load(persistence.DefaultPlanPath(planID))
+}
+
+// writeRenderedPrompts prints prompts and their statistics, or writes them to files.
+func writeRenderedPrompts(w io.Writer, prompts []renderedPrompt, opts *PromptRenderOptions) error {
+	for _, p := range prompts {
+		if opts.OutputDir != "" {
+			if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+				return err
+			}
+			path := filepath.Join(opts.OutputDir, p.Role+".md")
+			if err := os.WriteFile(path, []byte(p.Prompt), 0644); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "Wrote %s\n", path)
+		} else if !opts.StatsOnly {
+			fmt.Fprintf(w, "===== %s prompt =====\n%s\n\n", p.Role, p.Prompt)
+		}
+		writePromptStats(w, p)
+	}
+	return nil
+}
+
+// writePromptStats prints total and per-section sizes for a prompt.
+func writePromptStats(w io.Writer, p renderedPrompt) {
+	fmt.Fprintf(w, "--- %s: %d chars, ~%d tokens ---\n", p.Role, len(p.Prompt), prompt.EstimateTokens(p.Prompt))
+	for _, s := range prompt.SplitSections(p.Prompt) {
+		fmt.Fprintf(w, "  %-40s %7d chars  ~%6d tokens\n", truncateString(s.Name, 40), s.Chars, s.Tokens)
+	}
+	fmt.Fprintln(w)
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
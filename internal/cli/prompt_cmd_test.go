@@ -0,0 +1,337 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/DeukWoongWoo/claude-loop/internal/prompt"
+	"github.com/DeukWoongWoo/claude-loop/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRenderOptions(t *testing.T) *PromptRenderOptions {
+	t.Helper()
+	dir := t.TempDir()
+	return &PromptRenderOptions{
+		Role:             renderRoleAll,
+		Prompt:           "Add tests",
+		Iteration:        2,
+		CIRetryMax:       1,
+		CompletionSignal: "DONE",
+		NotesFile:        filepath.Join(dir, "NOTES.md"),
+		PrinciplesFile:   filepath.Join(dir, "principles.yaml"),
+		TemplatesDir:     filepath.Join(dir, "templates"),
+		CouncilFile:      filepath.Join(dir, "council.yaml"),
+	}
+}
+
+func TestRenderPrompts(t *testing.T) {
+	t.Run("all roles", func(t *testing.T) {
+		prompts, err := renderPrompts(testRenderOptions(t))
+		require.NoError(t, err)
+		var want []string
+		for _, role := range renderRoles {
+			if role != renderRoleCouncil {
+				want = append(want, role)
+				continue
+			}
+			for _, m := range council.DefaultMembers() {
+				want = append(want, "council-"+m.Name)
+			}
+			want = append(want, "council-chair")
+		}
+		var roles []string
+		for _, p := range prompts {
+			roles = append(roles, p.Role)
+			assert.NotEmpty(t, p.Prompt)
+		}
+		assert.Equal(t, want, roles)
+		assert.Contains(t, prompts[0].Prompt, "Add tests")
+		assert.Contains(t, prompts[0].Prompt, "DONE")
+	})
+
+	t.Run("single role", func(t *testing.T) {
+		opts := testRenderOptions(t)
+		opts.Role = renderRoleReviewer
+		opts.ReviewPrompt = "run go test"
+
+		prompts, err := renderPrompts(opts)
+		require.NoError(t, err)
+		require.Len(t, prompts, 1)
+		assert.Contains(t, prompts[0].Prompt, "run go test")
+	})
+
+	t.Run("council conflict block", func(t *testing.T) {
+		opts := testRenderOptions(t)
+		opts.Role = renderRoleCouncil
+		opts.ConflictContext = "<principle_conflict>\nprinciples: curation_model, monetization_model\ncontext: Rank the feed\n</principle_conflict>"
+
+		prompts, err := renderPrompts(opts)
+		require.NoError(t, err)
+		require.Len(t, prompts, len(council.DefaultMembers())+1)
+		for _, p := range prompts {
+			assert.Contains(t, p.Prompt, "Principles: layer0.curation_model vs layer0.monetization_model")
+			assert.Contains(t, p.Prompt, "Context: Rank the feed")
+		}
+		assert.Equal(t, "council-chair", prompts[len(prompts)-1].Role)
+		assert.Contains(t, prompts[len(prompts)-1].Prompt, council.DefaultChairPersona)
+	})
+
+	t.Run("single council call", func(t *testing.T) {
+		opts := testRenderOptions(t)
+		opts.Role = renderRoleCouncil
+		opts.CouncilMembers = 1
+
+		prompts, err := renderPrompts(opts)
+		require.NoError(t, err)
+		require.Len(t, prompts, 1)
+		assert.Equal(t, renderRoleCouncil, prompts[0].Role)
+	})
+
+	t.Run("ci fix attempts come from --ci-retry-max", func(t *testing.T) {
+		opts := testRenderOptions(t)
+		opts.Role = renderRoleCIFix
+		opts.CIRetryMax = 3
+
+		prompts, err := renderPrompts(opts)
+		require.NoError(t, err)
+		require.Len(t, prompts, 1)
+		assert.Contains(t, prompts[0].Prompt, "This is attempt 2 of 3.")
+	})
+
+	t.Run("template override is applied", func(t *testing.T) {
+		opts := testRenderOptions(t)
+		opts.Role = renderRoleIteration
+		require.NoError(t, os.MkdirAll(opts.TemplatesDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(opts.TemplatesDir, "workflow_context.tmpl"),
+			[]byte("## CUSTOM\niteration {{.Iteration}}"), 0644))
+
+		prompts, err := renderPrompts(opts)
+		require.NoError(t, err)
+		assert.Contains(t, prompts[0].Prompt, "iteration 2")
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		opts := testRenderOptions(t)
+		opts.Role = "bogus"
+
+		_, err := renderPrompts(opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown role "bogus"`)
+	})
+}
+
+func TestRenderPrompts_MatchesIteration(t *testing.T) {
+	opts := testRenderOptions(t)
+	opts.Role = renderRoleIteration
+	opts.Iteration = 1
+	opts.ReviewPrompt = "Run go test"
+	opts.Verification = "strict"
+	opts.DecisionsFile = filepath.Join(t.TempDir(), "decisions.jsonl")
+	require.NoError(t, config.SaveToFile(opts.PrinciplesFile, config.DefaultPrinciples(config.PresetStartup)))
+	line, err := json.Marshal(&council.DecisionRecord{Iteration: 2, Decision: "Add tests before features", CouncilInvoked: true})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(opts.DecisionsFile, append(line, '\n'), 0644))
+
+	prompts, err := renderPrompts(opts)
+	require.NoError(t, err)
+	require.Len(t, prompts, 1)
+
+	// Run one iteration with the same inputs, set up the way a run sets them up
+	flags := DefaultFlags()
+	flags.Prompt = opts.Prompt
+	flags.MaxRuns = 1
+	flags.CompletionSignal = opts.CompletionSignal
+	flags.NotesFile = opts.NotesFile
+	flags.ReviewPrompt = opts.ReviewPrompt
+	flags.Verification = opts.Verification
+	principles, err := loadLayeredPrinciples(io.Discard, opts.PrinciplesFile, nil)
+	require.NoError(t, err)
+	changed := func(name string) bool { return name == "review-prompt" || name == "verification" }
+
+	cfg := ConfigToLoopConfig(flags)
+	cfg.Policy = resolvePolicy(flags, principles, changed)
+	cfg.Principles = principles
+	cfg.PriorDecisions = loadPriorDecisions(opts.DecisionsFile)
+	client := mocks.NewConfigurableClaudeClient()
+	client.DefaultDelay = 0
+	_, err = loop.NewExecutor(cfg, client).Run(context.Background())
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, client.CallCount(), 1)
+	iterationPrompt := client.RecordedCalls[0].Prompt
+	assert.Equal(t, iterationPrompt, prompts[0].Prompt)
+	assert.Contains(t, iterationPrompt, "Add tests before features")
+}
+
+// councilRecorder records the prompt sent to one council member or the chair.
+type councilRecorder struct {
+	prompt string
+}
+
+func (r *councilRecorder) Execute(ctx context.Context, prompt string) (*council.IterationResult, error) {
+	r.prompt = prompt
+	return &council.IterationResult{Output: "**Vote**: both\n**Confidence**: 7\n**Decision**: Rank by quality"}, nil
+}
+
+func TestRenderPrompts_MatchesCouncil(t *testing.T) {
+	opts := testRenderOptions(t)
+	opts.Role = renderRoleCouncil
+	opts.ConflictContext = "<principle_conflict>\nprinciples: curation_model, monetization_model\ncontext: Rank the feed\n</principle_conflict>"
+	opts.DecisionsFile = filepath.Join(t.TempDir(), "decisions.jsonl")
+	line, err := json.Marshal(&council.DecisionRecord{Iteration: 3, Decision: "Rank the feed by quality", CouncilInvoked: true})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(opts.DecisionsFile, append(line, '\n'), 0644))
+
+	prompts, err := renderPrompts(opts)
+	require.NoError(t, err)
+
+	// Convene the default council on the same conflict, recording each member's prompt
+	members := council.DefaultMembers()
+	clients := make(map[string]council.ClaudeClient)
+	recorders := make(map[string]*councilRecorder)
+	for _, name := range append([]string{council.ChairName}, memberNames(members)...) {
+		recorders[name] = &councilRecorder{}
+		clients[name] = recorders[name]
+	}
+	cfg := council.DefaultConfig()
+	cfg.Principles = config.DefaultPrinciples(config.PresetStartup)
+	cfg.LogDecisions = false
+	cfg.Members = members
+	cfg.Clients = clients
+	cfg.PriorDecisions = loadPriorDecisions(opts.DecisionsFile)
+	conflict, ok := council.ParseConflict(opts.ConflictContext)
+	require.True(t, ok)
+	_, err = council.NewCouncil(cfg, &councilRecorder{}).Resolve(context.Background(), conflict)
+	require.NoError(t, err)
+
+	require.Len(t, prompts, len(members)+1)
+	for i, m := range members {
+		assert.Equal(t, "council-"+m.Name, prompts[i].Role)
+		assert.Equal(t, recorders[m.Name].prompt, prompts[i].Prompt)
+		assert.Contains(t, prompts[i].Prompt, "Rank the feed by quality")
+	}
+	assert.NotEmpty(t, recorders[council.ChairName].prompt)
+}
+
+// memberNames returns the members' names in order.
+func memberNames(members []council.Member) []string {
+	names := make([]string, len(members))
+	for i, m := range members {
+		names[i] = m.Name
+	}
+	return names
+}
+
+func TestIterationContext(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("previous iteration state", func(t *testing.T) {
+		opts := testRenderOptions(t)
+		opts.VerificationFailures = []string{"go test: exit status 1"}
+		opts.BlockedCommit = []string{"It failed verification"}
+
+		buildCtx, err := iterationContext(ctx, opts, nil)
+		require.NoError(t, err)
+		assert.Equal(t, opts.VerificationFailures, buildCtx.VerificationFailures)
+		assert.Equal(t, opts.BlockedCommit, buildCtx.BlockedCommit)
+		assert.Empty(t, buildCtx.PriorDecisions)
+	})
+
+	t.Run("review gate", func(t *testing.T) {
+		opts := testRenderOptions(t)
+		opts.ReviewPrompt = "Run go test"
+
+		buildCtx, err := iterationContext(ctx, opts, nil)
+		require.NoError(t, err)
+		assert.True(t, buildCtx.ReviewGate)
+		assert.False(t, buildCtx.CommitGate)
+	})
+
+	t.Run("commits left to Claude", func(t *testing.T) {
+		opts := testRenderOptions(t)
+		opts.DisableCommits = true
+
+		buildCtx, err := iterationContext(ctx, opts, nil)
+		require.NoError(t, err)
+		assert.False(t, buildCtx.ReviewGate)
+		assert.False(t, buildCtx.CommitGate)
+	})
+
+	t.Run("commit checks", func(t *testing.T) {
+		opts := testRenderOptions(t)
+
+		buildCtx, err := iterationContext(ctx, opts, nil)
+		require.NoError(t, err)
+		require.True(t, buildCtx.CommitGate)
+		assert.True(t, buildCtx.CommitChecks.Secrets)
+
+		opts.DisableSecretScan = true
+		opts.DisableBranches = true
+		opts.Verification = "strict"
+		buildCtx, err = iterationContext(ctx, opts, nil)
+		require.NoError(t, err)
+		require.True(t, buildCtx.CommitGate, "strict verification still has claude-loop commit")
+		assert.Equal(t, prompt.CommitChecks{Verification: true}, buildCtx.CommitChecks)
+	})
+
+	t.Run("policy from principles", func(t *testing.T) {
+		opts := testRenderOptions(t)
+		principles := config.DefaultPrinciples(config.PresetStartup)
+
+		buildCtx, err := iterationContext(ctx, opts, principles)
+		require.NoError(t, err)
+		assert.Equal(t, config.DerivePolicy(principles).Verification, buildCtx.Policy.Verification)
+	})
+}
+
+func TestCommitsChanges(t *testing.T) {
+	standard := &config.Policy{Verification: config.VerificationStandard}
+	strict := &config.Policy{Verification: config.VerificationStrict, DirectPush: true}
+	direct := &config.Policy{Verification: config.VerificationStandard, DirectPush: true}
+
+	assert.True(t, commitsChanges(&Flags{}, standard))
+	assert.False(t, commitsChanges(&Flags{DisableCommits: true}, standard))
+	assert.True(t, commitsChanges(&Flags{DisableSecretScan: true}, standard))
+	assert.True(t, commitsChanges(&Flags{DisableSecretScan: true}, strict))
+	assert.False(t, commitsChanges(&Flags{DisableSecretScan: true}, direct))
+}
+
+func TestWriteRenderedPrompts(t *testing.T) {
+	prompts := []renderedPrompt{{Role: "iteration", Prompt: "## GOAL\nship it\n"}}
+
+	t.Run("stdout with stats", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeRenderedPrompts(&buf, prompts, &PromptRenderOptions{}))
+		assert.Contains(t, buf.String(), "===== iteration prompt =====")
+		assert.Contains(t, buf.String(), "ship it")
+		assert.Contains(t, buf.String(), "--- iteration: 16 chars, ~4 tokens ---")
+		assert.Contains(t, buf.String(), "GOAL")
+	})
+
+	t.Run("stats only", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeRenderedPrompts(&buf, prompts, &PromptRenderOptions{StatsOnly: true}))
+		assert.NotContains(t, buf.String(), "ship it")
+		assert.Contains(t, buf.String(), "--- iteration:")
+	})
+
+	t.Run("output directory", func(t *testing.T) {
+		var buf bytes.Buffer
+		dir := filepath.Join(t.TempDir(), "out")
+		require.NoError(t, writeRenderedPrompts(&buf, prompts, &PromptRenderOptions{OutputDir: dir}))
+
+		data, err := os.ReadFile(filepath.Join(dir, "iteration.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "## GOAL\nship it\n", string(data))
+	})
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/claude"
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/DeukWoongWoo/claude-loop/internal/protected"
+)
+
+// newProtectedMatcher compiles the protected paths from principles.
+// Returns nil when principles are not loaded or protect nothing.
+func newProtectedMatcher(principles *config.Principles) (*protected.Matcher, error) {
+	if principles == nil || principles.Protected == nil || len(principles.Protected.Paths) == 0 {
+		return nil, nil
+	}
+	return protected.NewMatcher(principles.Protected.Paths)
+}
+
+// protectedRevertMode returns the configured revert mode, defaulting to files.
+func protectedRevertMode(principles *config.Principles) config.RevertMode {
+	if principles == nil || principles.Protected == nil || principles.Protected.Revert == "" {
+		return config.RevertFiles
+	}
+	return principles.Protected.Revert
+}
+
+// protectedWatcher warns as soon as Claude edits a protected path.
+// Edits are still reverted after the iteration; the warning only makes them visible early.
+// Other callbacks are forwarded to next, which may be nil.
+type protectedWatcher struct {
+	next    claude.StreamHandler
+	matcher *protected.Matcher
+	root    string
+	out     io.Writer
+}
+
+// newProtectedWatcher wraps next with a watcher that writes warnings to stderr.
+// File paths are resolved against the repository root, or the working directory outside git.
+func newProtectedWatcher(next claude.StreamHandler, matcher *protected.Matcher) *protectedWatcher {
+	root, err := git.NewRepository(nil).GetRootPath(context.Background())
+	if err != nil {
+		root, _ = os.Getwd()
+	}
+	return &protectedWatcher{next: next, matcher: matcher, root: root, out: os.Stderr}
+}
+
+// OnText forwards text to the wrapped handler.
+func (w *protectedWatcher) OnText(text string) {
+	if w.next != nil {
+		w.next.OnText(text)
+	}
+}
+
+// OnToolUse warns when a file-editing tool targets a protected path, then forwards the call.
+func (w *protectedWatcher) OnToolUse(name string, input string) {
+	if path := protected.ToolPath(name, input); path != "" {
+		rel := protected.RelativePath(w.root, path)
+		if pattern, ok := w.matcher.Match(rel); ok {
+			fmt.Fprintf(w.out, "Warning: Claude is editing protected path %s (protected by %q); the change will be reverted\n", rel, pattern)
+		}
+	}
+	if next, ok := w.next.(claude.ToolStreamHandler); ok {
+		next.OnToolUse(name, input)
+	}
+}
+
+// OnToolResult forwards tool results to the wrapped handler.
+func (w *protectedWatcher) OnToolResult(content string, isError bool) {
+	if next, ok := w.next.(claude.ToolStreamHandler); ok {
+		next.OnToolResult(content, isError)
+	}
+}
+
+// printProtectedRevert reports an iteration's protected-path violations on stderr.
+func printProtectedRevert(record *loop.ProtectedRecord) {
+	for _, v := range record.Violations {
+		fmt.Fprintf(os.Stderr, "Protected path changed: %s\n", v)
+	}
+	switch {
+	case record.Error != "":
+		fmt.Fprintf(os.Stderr, "Warning: failed to revert protected paths: %s\n", record.Error)
+	case record.Commits > 0:
+		fmt.Fprintf(os.Stderr, "Reverted the iteration (%d files, %d commits dropped)\n", len(record.Reverted), record.Commits)
+	default:
+		fmt.Fprintf(os.Stderr, "Reverted %d files\n", len(record.Reverted))
+	}
+}
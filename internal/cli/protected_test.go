@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/protected"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProtectedMatcher(t *testing.T) {
+	t.Run("nothing protected", func(t *testing.T) {
+		m, err := newProtectedMatcher(nil)
+		require.NoError(t, err)
+		assert.True(t, m.Empty())
+
+		m, err = newProtectedMatcher(config.DefaultPrinciples(config.PresetStartup))
+		require.NoError(t, err)
+		assert.True(t, m.Empty())
+		assert.Equal(t, config.RevertFiles, protectedRevertMode(nil))
+	})
+
+	t.Run("paths and revert mode from principles", func(t *testing.T) {
+		p := config.DefaultPrinciples(config.PresetStartup)
+		p.Protected = &config.ProtectedPaths{Paths: []string{"LICENSE", "migrations/"}, Revert: config.RevertIteration}
+
+		m, err := newProtectedMatcher(p)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"LICENSE", "migrations/"}, m.Patterns())
+		assert.Equal(t, config.RevertIteration, protectedRevertMode(p))
+	})
+
+	t.Run("invalid pattern fails client setup", func(t *testing.T) {
+		p := config.DefaultPrinciples(config.PresetStartup)
+		p.Protected = &config.ProtectedPaths{Paths: []string{"[abc"}}
+
+		flags := DefaultFlags()
+		flags.AgentsFile = filepath.Join(t.TempDir(), "missing.yaml")
+		_, err := newAgentClients(flags, p, nil, nil)
+		require.Error(t, err)
+		assert.True(t, protected.IsPolicyError(err))
+	})
+}
+
+// recordingHandler records the stream callbacks it receives.
+type recordingHandler struct {
+	texts []string
+	tools []string
+}
+
+func (h *recordingHandler) OnText(text string)                        { h.texts = append(h.texts, text) }
+func (h *recordingHandler) OnToolUse(name string, input string)       { h.tools = append(h.tools, name) }
+func (h *recordingHandler) OnToolResult(content string, isError bool) {}
+
+func TestProtectedWatcher(t *testing.T) {
+	matcher, err := protected.NewMatcher([]string{"*.lock", "/LICENSE"})
+	require.NoError(t, err)
+	root := filepath.FromSlash("/work/repo")
+
+	t.Run("warns on protected edits and forwards callbacks", func(t *testing.T) {
+		var out bytes.Buffer
+		next := &recordingHandler{}
+		w := &protectedWatcher{next: next, matcher: matcher, root: root, out: &out}
+
+		w.OnText("working")
+		w.OnToolUse("Edit", `{"file_path":"`+filepath.ToSlash(filepath.Join(root, "web", "yarn.lock"))+`"}`)
+		w.OnToolUse("Write", `{"file_path":"src/main.go"}`)
+		w.OnToolUse("Read", `{"file_path":"LICENSE"}`)
+		w.OnToolUse("Write", `{"file_path":"LICENSE"}`)
+
+		assert.Equal(t, []string{"working"}, next.texts)
+		assert.Equal(t, []string{"Edit", "Write", "Read", "Write"}, next.tools)
+		assert.Equal(t,
+			"Warning: Claude is editing protected path web/yarn.lock (protected by \"*.lock\"); the change will be reverted\n"+
+				"Warning: Claude is editing protected path LICENSE (protected by \"/LICENSE\"); the change will be reverted\n",
+			out.String())
+	})
+
+	t.Run("works without a wrapped handler", func(t *testing.T) {
+		var out bytes.Buffer
+		w := &protectedWatcher{matcher: matcher, root: root, out: &out}
+
+		w.OnText("ignored")
+		w.OnToolUse("MultiEdit", `{"file_path":"go.lock"}`)
+		w.OnToolResult("ok", false)
+		assert.Contains(t, out.String(), "go.lock")
+	})
+}
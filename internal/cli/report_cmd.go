@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/report"
+	"github.com/spf13/cobra"
+)
+
+// ReportOptions holds flag values for `report`.
+type ReportOptions struct {
+	OutputDir string // --output: Write report files here instead of the run directory
+	Stdout    bool   // --stdout: Print the Markdown report instead of writing files
+}
+
+var reportOpts = &ReportOptions{}
+
+// reportCmd regenerates the report for a past run.
+var reportCmd = &cobra.Command{
+	Use:   "report [run-id]",
+	Short: "Generate the Markdown and HTML report for a past run",
+	Long: `Regenerate report.md and report.html for a past run from its saved report.json.
+Without a run ID, the most recent run under .claude/runs is used.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runID := ""
+		if len(args) > 0 {
+			runID = args[0]
+		}
+		return generateReport(cmd.OutOrStdout(), DefaultRunsDir, runID, reportOpts)
+	},
+}
+
+func init() {
+	f := reportCmd.Flags()
+	f.StringVar(&reportOpts.OutputDir, "output", "", "Write report files to this directory instead of the run directory")
+	f.BoolVar(&reportOpts.Stdout, "stdout", false, "Print the Markdown report to stdout instead of writing files")
+
+	reportCmd.SetHelpTemplate(subcommandHelpTemplate)
+	rootCmd.AddCommand(reportCmd)
+}
+
+// generateReport loads the saved report for runID (or the latest run) and re-renders it.
+func generateReport(w io.Writer, runsDir, runID string, opts *ReportOptions) error {
+	if runID == "" {
+		latest, err := latestRunID(runsDir, report.JSONFile)
+		if err != nil {
+			return err
+		}
+		runID = latest
+	}
+
+	dir := filepath.Join(runsDir, runID)
+	r, err := report.Load(dir)
+	if err != nil {
+		return err
+	}
+
+	if opts.Stdout {
+		_, err := io.WriteString(w, report.Markdown(r))
+		return err
+	}
+
+	if opts.OutputDir != "" {
+		dir = opts.OutputDir
+	}
+	paths, err := report.Save(dir, r)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		fmt.Fprintf(w, "Wrote %s\n", path)
+	}
+	return nil
+}
+
+// writeRunReport saves the report for a finished run into its run directory.
+// Failures are reported but do not change the exit status; the loop's work is already done.
+func writeRunReport(w io.Writer, run *runInfo, r *report.Report) {
+	paths, err := report.Save(run.Dir, r)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write run report: %v\n", err)
+		return
+	}
+	fmt.Fprintf(w, "Report: %s\n", strings.Join(paths[1:], ", "))
+}
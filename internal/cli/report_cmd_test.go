@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/DeukWoongWoo/claude-loop/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// saveTestReport writes a report for runID under runsDir.
+func saveTestReport(t *testing.T, runsDir, runID string) {
+	t.Helper()
+	r := &report.Report{
+		RunID:      runID,
+		Prompt:     "Add tests",
+		StopReason: "max_runs_reached",
+		Iterations: []loop.IterationRecord{{Number: 1, Cost: 0.5}},
+	}
+	_, err := report.Save(filepath.Join(runsDir, runID), r)
+	require.NoError(t, err)
+}
+
+func TestGenerateReport(t *testing.T) {
+	t.Run("regenerates latest run", func(t *testing.T) {
+		runsDir := t.TempDir()
+		saveTestReport(t, runsDir, "run-20261001-000000")
+		saveTestReport(t, runsDir, "run-20261002-000000")
+		latestMD := filepath.Join(runsDir, "run-20261002-000000", report.MarkdownFile)
+		require.NoError(t, os.Remove(latestMD))
+
+		var buf bytes.Buffer
+		require.NoError(t, generateReport(&buf, runsDir, "", &ReportOptions{}))
+
+		assert.Contains(t, buf.String(), "Wrote "+latestMD)
+		assert.FileExists(t, latestMD)
+	})
+
+	t.Run("prints markdown to stdout", func(t *testing.T) {
+		runsDir := t.TempDir()
+		saveTestReport(t, runsDir, "run-20261001-000000")
+
+		var buf bytes.Buffer
+		require.NoError(t, generateReport(&buf, runsDir, "run-20261001-000000", &ReportOptions{Stdout: true}))
+		assert.Contains(t, buf.String(), "# claude-loop run report: run-20261001-000000")
+	})
+
+	t.Run("writes to output directory", func(t *testing.T) {
+		runsDir := t.TempDir()
+		saveTestReport(t, runsDir, "run-20261001-000000")
+		out := filepath.Join(t.TempDir(), "weekly")
+
+		var buf bytes.Buffer
+		require.NoError(t, generateReport(&buf, runsDir, "run-20261001-000000", &ReportOptions{OutputDir: out}))
+		assert.FileExists(t, filepath.Join(out, report.HTMLFile))
+	})
+
+	t.Run("unknown run", func(t *testing.T) {
+		err := generateReport(&bytes.Buffer{}, t.TempDir(), "run-missing", &ReportOptions{})
+		require.Error(t, err)
+		assert.True(t, report.IsReportError(err))
+	})
+}
+
+func TestWriteRunReport(t *testing.T) {
+	run := &runInfo{ID: "run-1", Dir: filepath.Join(t.TempDir(), "run-1")}
+	state := loop.NewState()
+	state.Iterations = []loop.IterationRecord{{Number: 1}}
+
+	r := report.New(run.ID, &loop.Config{Prompt: "p"}, &loop.LoopResult{State: state, StopReason: loop.StopReasonMaxRuns}, time.Now())
+
+	var buf bytes.Buffer
+	writeRunReport(&buf, run, r)
+
+	assert.Contains(t, buf.String(), "Report: "+filepath.Join(run.Dir, report.MarkdownFile))
+	loaded, err := report.Load(run.Dir)
+	require.NoError(t, err)
+	assert.Equal(t, "run-1", loaded.RunID)
+	assert.WithinDuration(t, time.Now(), loaded.FinishedAt, time.Minute)
+}
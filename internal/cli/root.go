@@ -5,6 +5,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"strings"
@@ -12,14 +13,17 @@ import (
 	"time"
 
 	"github.com/DeukWoongWoo/claude-loop/internal/architecture"
-	"github.com/DeukWoongWoo/claude-loop/internal/claude"
 	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
 	"github.com/DeukWoongWoo/claude-loop/internal/decomposer"
 	"github.com/DeukWoongWoo/claude-loop/internal/git"
 	"github.com/DeukWoongWoo/claude-loop/internal/loop"
 	"github.com/DeukWoongWoo/claude-loop/internal/planner"
 	"github.com/DeukWoongWoo/claude-loop/internal/prd"
 	"github.com/DeukWoongWoo/claude-loop/internal/principles"
+	"github.com/DeukWoongWoo/claude-loop/internal/prompt"
+	"github.com/DeukWoongWoo/claude-loop/internal/report"
+	"github.com/DeukWoongWoo/claude-loop/internal/reviewer"
 	"github.com/DeukWoongWoo/claude-loop/internal/update"
 	"github.com/DeukWoongWoo/claude-loop/internal/version"
 	"github.com/spf13/cobra"
@@ -86,6 +90,31 @@ OPTIONAL FLAGS:
     --git-branch-prefix <prefix>  Branch prefix for iterations (default: "claude-loop/")
     --merge-strategy <strategy>   PR merge strategy: squash, merge, or rebase (default: "squash")
     --notes-file <file>           Shared notes file for iteration context (default: "SHARED_TASK_NOTES.md")
+    --templates-dir <path>        Directory of prompt template overrides (default: ".claude/templates")
+    --agent <name>                Agent for main iterations: claude (default) or a name from --agents-file
+    --reviewer-agent <name>       Agent for reviewer passes (default: --agent)
+    --council-agent <name>        Agent for council resolution (default: --agent)
+    --planner-agent <name>        Agent for planning phases (default: --agent)
+    --agents-file <path>          Command agent definitions (default: ".claude/agents.yaml")
+    --permissions [role=]profile  Permission profile: read-only, edit-only, edit+test, full (repeatable;
+                                  roles: main, reviewer, council, planner; default from security_posture)
+    --verification <level>        Verification level: relaxed, standard, strict (default from principles)
+    --verify <criterion>          Check run after each iteration, e.g. "go test" (repeatable)
+    --draft-prs                   Open pull requests as drafts (default from reversibility_priority)
+    --reviewer-model <model>      Model for reviewer passes (default from cost_efficiency)
+    --council-model <model>       Model for council resolution (default from cost_efficiency)
+    --council-file <path>         Council members, chair and cost cap (default: ".claude/council.yaml")
+    --council-members <number>    Council members to convene (default: 0 = all; 1 = a single resolution call)
+    --council-max-cost <dollars>  Cost cap per council invocation (default from the council file; 0 = unlimited)
+    --escalation <mode>           Hand failed, split or unsure council outcomes to a human: auto (ask on a
+                                  terminal, else write a file), file, issue (file and GitHub issue), off
+                                  (default: auto)
+    --escalation-confidence <n>   Council confidence (0-1) below which a decision is escalated (default: 0.6)
+    --disable-secret-scan         Do not scan iteration changes for secrets
+    --secret-pattern [id=]<regex> Extra secret detector (repeatable)
+    --secrets-allowlist <path>    Fingerprints of findings that are not secrets (default: ".claude/secrets-allowlist")
+    --secret-action <action>      What the check before each commit does with secrets: block, unstage or
+                                  redact (default: block)
     --worktree <name>             Run in a git worktree for parallel execution (creates if needed)
     --worktree-base-dir <path>    Base directory for worktrees (default: "../claude-loop-worktrees")
     --cleanup-worktree            Remove worktree after completion
@@ -95,19 +124,39 @@ OPTIONAL FLAGS:
     --completion-threshold <num>  Number of consecutive signals to stop early (default: 3)
     -r, --review-prompt <text>    Run a reviewer pass after each iteration to validate changes
                                   (e.g., run build/lint/tests and fix any issues)
+    --reviewers-file <path>       Specialised reviewers run in parallel with -r (default: ".claude/reviewers.yaml")
     --disable-ci-retry            Disable automatic CI failure retry (enabled by default)
     --ci-retry-max <number>       Maximum CI fix attempts per PR (default: 1)
     --reset-principles            Force re-collection of principles
     --principles-file <path>      Custom principles file path (default: ".claude/principles.yaml")
+    --principle <key>=<value>     Override a principle for this run (repeatable)
+    --preset <name>               Preset for a missing principles file; without a terminal its defaults are written
     --log-decisions               Enable decision logging to .claude/principles-decisions.log
     --verbose                     Show detailed iteration summaries
     --stream                      Stream Claude output in real-time
+    --dump-prompts                Save every prompt sent to Claude under .claude/runs/<run-id>/prompts
+    --record                      Record every Claude call as a cassette under .claude/runs/<run-id>/cassette
+    --replay <dir>                Replay Claude calls from a recorded cassette instead of calling Claude
     --plan                        Enable planning mode (PRD → Architecture → Tasks)
     --plan-only                   Generate plan without execution (implies --plan)
     --resume <plan-id>            Resume from saved plan ID
 
 COMMANDS:
     update                        Check for and install the latest version
+    prompt render                 Render the exact prompts without running Claude
+    report [run-id]               Regenerate the Markdown/HTML report for a past run (default: latest)
+    history [show <run-id>]       List past runs (filter with --since, --until, --prompt)
+    decisions                     List logged decisions (--since, --until, --principle, --council, --search, --stats)
+    principles init               Create principles.yaml (--preset, --set key=value, --non-interactive)
+    principles wizard             Set all 18 principles interactively, or edit principles.yaml
+    principles suggest            Propose principles from README, LICENSE, CI, tests, tags and branch protection
+    principles show [--effective] Show principles; --effective merges user, repository and --principle layers
+    principles validate           Report every problem in principles.yaml
+    principles diff               Show where principles.yaml differs from a preset (--preset)
+    principles explain <key>      Describe a principle, its scale and the preset defaults
+    principles migrate            Upgrade principles.yaml to the current schema (--dry-run to preview)
+    principles presets            List the built-in presets and the custom presets from .claude/presets
+    stats                         Cost per day/week/month, cost per iteration, success rates (--csv)
 
 EXAMPLES:
     # Run 5 iterations to fix bugs
@@ -169,6 +218,9 @@ EXAMPLES:
     # Force re-collection of principles
     claude-loop -p "New project" -m 5 --reset-principles
 
+    # Start a new repository from a team preset in .claude/presets or $CLAUDE_LOOP_PRESETS_PATH
+    claude-loop -p "Add audit logging" -m 5 --preset regulated-fintech
+
     # Check for and install updates
     claude-loop update
 
@@ -188,6 +240,7 @@ For more information, visit: https://github.com/DeukWoongWoo/claude-loop`,
 		if err := parseDuration(); err != nil {
 			return err
 		}
+		globalFlags.presets = loadPresets(cmd.ErrOrStderr())
 		// Skip validation for --list-worktrees (standalone action)
 		if globalFlags.ListWorktrees {
 			return nil
@@ -245,6 +298,10 @@ func checkForUpdatesAtStartup(ctx context.Context, flags *Flags) {
 	manager := update.NewManager(&update.ManagerOptions{
 		AutoUpdate: flags.AutoUpdate,
 		OnPrompt: func(current, latest string) bool {
+			if !stdinInteractive() {
+				fmt.Printf("New version %s available (current: %s). Run claude-loop interactively or pass --auto-update to update.\n", latest, current)
+				return false
+			}
 			fmt.Printf("New version %s available (current: %s). Update? [Y/n]: ", latest, current)
 			reader := bufio.NewReader(os.Stdin)
 			response, err := reader.ReadString('\n')
@@ -279,6 +336,12 @@ func init() {
 	registerFlags(rootCmd)
 }
 
+// subcommandHelpTemplate shows a subcommand's description followed by its flags.
+// Subcommands set it explicitly because the root help template only prints Long.
+const subcommandHelpTemplate = `{{with or .Long .Short}}{{.}}{{end}}
+
+{{.UsageString}}`
+
 // configureCommand sets version and help templates on a command.
 func configureCommand(cmd *cobra.Command) {
 	cmd.SetVersionTemplate("claude-loop version {{.Version}}\n")
@@ -315,12 +378,48 @@ func registerFlags(cmd *cobra.Command) {
 
 	// Review & CI
 	flags.StringVarP(&f.ReviewPrompt, "review-prompt", "r", "", "Run a reviewer pass after each iteration")
+	flags.StringVar(&f.ReviewersFile, "reviewers-file", reviewer.DefaultFile, "Specialised reviewers run in parallel with -r")
 	flags.BoolVar(&f.DisableCIRetry, "disable-ci-retry", false, "Disable automatic CI failure retry")
 	flags.IntVar(&f.CIRetryMax, "ci-retry-max", 1, "Maximum CI fix attempts per PR")
 
 	// Shared state
 	flags.StringVar(&f.NotesFile, "notes-file", "SHARED_TASK_NOTES.md", "Shared notes file for iteration context")
 
+	// Prompt customization
+	flags.StringVar(&f.TemplatesDir, "templates-dir", ".claude/templates", "Directory of prompt template overrides")
+
+	// Agent backends
+	flags.StringVar(&f.Agent, "agent", "", "Agent for main iterations: claude or a name from --agents-file")
+	flags.StringVar(&f.ReviewerAgent, "reviewer-agent", "", "Agent for reviewer passes (default: --agent)")
+	flags.StringVar(&f.CouncilAgent, "council-agent", "", "Agent for council resolution (default: --agent)")
+	flags.StringVar(&f.PlannerAgent, "planner-agent", "", "Agent for planning phases (default: --agent)")
+	flags.StringVar(&f.AgentsFile, "agents-file", ".claude/agents.yaml", "Command agent definitions")
+
+	// Permissions
+	flags.StringSliceVar(&f.Permissions, "permissions", nil, "Permission profile, or role=profile (repeatable)")
+
+	// Runtime policy
+	flags.StringVar(&f.Verification, "verification", "", "Verification level: relaxed, standard or strict (default from principles)")
+	flags.StringArrayVar(&f.Verify, "verify", nil, "Check run after each iteration, e.g. \"go test\" (repeatable)")
+	flags.BoolVar(&f.DraftPRs, "draft-prs", false, "Open pull requests as drafts (default from principles)")
+	flags.StringVar(&f.ReviewerModel, "reviewer-model", "", "Model for reviewer passes (default from principles)")
+	flags.StringVar(&f.CouncilModel, "council-model", "", "Model for council resolution (default from principles)")
+
+	// Council
+	flags.StringVar(&f.CouncilFile, "council-file", council.DefaultFile, "Council members, chair and cost cap")
+	flags.IntVar(&f.CouncilMembers, "council-members", 0, "Council members to convene (0 = all, 1 = a single resolution call)")
+	flags.Float64Var(&f.CouncilMaxCost, "council-max-cost", 0, "Cost cap per council invocation in USD (default from the council file)")
+
+	// Escalation
+	flags.StringVar(&f.Escalation, "escalation", string(council.EscalateAuto), "How uncertain council outcomes reach a human: auto, file, issue or off")
+	flags.Float64Var(&f.EscalationConfidence, "escalation-confidence", council.DefaultEscalationConfidence, "Council confidence (0-1) below which a decision is escalated")
+
+	// Secret scanning
+	flags.BoolVar(&f.DisableSecretScan, "disable-secret-scan", false, "Do not scan iteration changes for secrets")
+	flags.StringArrayVar(&f.SecretPatterns, "secret-pattern", nil, "Extra secret regex, optionally named as id=regex (repeatable)")
+	flags.StringVar(&f.SecretsAllowlist, "secrets-allowlist", ".claude/secrets-allowlist", "File of allowlisted secret finding fingerprints")
+	flags.StringVar(&f.SecretAction, "secret-action", "block", "What the check before each commit does with secrets: block, unstage, redact")
+
 	// Worktree support
 	flags.StringVar(&f.Worktree, "worktree", "", "Run in a git worktree for parallel execution")
 	flags.StringVar(&f.WorktreeBaseDir, "worktree-base-dir", "../claude-loop-worktrees", "Base directory for worktrees")
@@ -330,11 +429,18 @@ func registerFlags(cmd *cobra.Command) {
 	// Principles framework
 	flags.BoolVar(&f.ResetPrinciples, "reset-principles", false, "Force re-collection of principles")
 	flags.StringVar(&f.PrinciplesFile, "principles-file", ".claude/principles.yaml", "Custom principles file path")
+	flags.StringVar(&f.Preset, "preset", "", "Preset for a missing principles file: startup, enterprise, opensource or a custom preset")
+	flags.StringArrayVar(&f.PrincipleOverrides, "principle", nil, "Override a principle for this run as key=value (repeatable)")
 	flags.BoolVar(&f.LogDecisions, "log-decisions", false, "Enable decision logging")
 
 	// Output control
 	flags.BoolVar(&f.Verbose, "verbose", false, "Show detailed iteration summaries")
 	flags.BoolVar(&f.Stream, "stream", false, "Stream Claude output in real-time")
+	flags.BoolVar(&f.DumpPrompts, "dump-prompts", false, "Save every prompt sent under the run directory")
+
+	// Record & replay
+	flags.BoolVar(&f.Record, "record", false, "Record every Claude call as a cassette under the run directory")
+	flags.StringVar(&f.Replay, "replay", "", "Replay Claude calls from a cassette directory instead of calling Claude")
 
 	// Update management
 	flags.BoolVar(&f.AutoUpdate, "auto-update", false, "Automatically install updates when available")
@@ -372,6 +478,7 @@ func ConfigToLoopConfig(f *Flags) *loop.Config {
 		DryRun:               f.DryRun,
 		NotesFile:            f.NotesFile,
 		ReviewPrompt:         f.ReviewPrompt,
+		MaxReviewFixes:       loop.DefaultMaxReviewFixes,
 		LogDecisions:         f.LogDecisions,
 	}
 }
@@ -391,15 +498,13 @@ func handleListWorktrees() {
 }
 
 // runPlanningMode executes the planning workflow (PRD → Architecture → Tasks).
-func runPlanningMode(ctx context.Context, flags *Flags) error {
-	// Create Claude client with optional streaming
-	var clientOpts *claude.ClientOptions
-	if flags.Stream {
-		clientOpts = &claude.ClientOptions{StreamHandler: NewConsoleStreamHandler()}
+func runPlanningMode(ctx context.Context, flags *Flags, run *runInfo, agents *agentClients) error {
+	claudeClient := agents.Planner
+	if dump := newPromptDump(flags, run); dump != nil {
+		claudeClient = dump.Wrap(claudeClient, loop.RolePlanner)
 	}
-	claudeClient := claude.NewClient(clientOpts)
 
-	// claude.Client implements loop.ClaudeClient, wrap with planner adapter
+	// The planner agent implements loop.ClaudeClient, wrap with planner adapter
 	adapter := planner.NewClaudeClientAdapter(claudeClient)
 
 	// Create Phase implementations
@@ -540,9 +645,22 @@ func runMainLoop(cmd *cobra.Command, args []string) {
 	// Check for updates at startup
 	checkForUpdatesAtStartup(ctx, globalFlags)
 
+	run := newRunInfo(time.Now())
+
 	// Check for planning mode
 	if globalFlags.Plan || globalFlags.PlanOnly || globalFlags.Resume != "" {
-		if err := runPlanningMode(ctx, globalFlags); err != nil {
+		// Planning never collects principles, but honors an existing file's security posture
+		existingPrinciples, err := loadExistingPrinciples(globalFlags)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		agents, err := newRunClients(globalFlags, run, existingPrinciples, nil, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := runPlanningMode(ctx, globalFlags, run, agents); err != nil {
 			fmt.Fprintf(os.Stderr, "Planning failed: %v\n", err)
 			os.Exit(1)
 		}
@@ -556,9 +674,83 @@ func runMainLoop(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	// Derive the runtime policy; flags given on the command line take precedence
+	policy := resolvePolicy(globalFlags, loadedPrinciples, cmd.Flags().Changed)
+	printPolicy(policy)
+
+	// Load and validate prompt template overrides before spending anything
+	templates, err := loadPromptTemplates(globalFlags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Load the council members, chair and cost cap
+	councilSettings, err := loadCouncilSettings(globalFlags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Load the specialised reviewers
+	reviewerSettings, err := reviewer.LoadSettings(globalFlags.ReviewersFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Resolve the agent backend and permissions for each role
+	agents, err := newRunClients(globalFlags, run, loadedPrinciples, councilSettings, reviewerSettings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Create loop config from flags
 	loopConfig := ConfigToLoopConfig(globalFlags)
 	loopConfig.Principles = loadedPrinciples
+	loopConfig.Policy = policy
+	loopConfig.Council = councilSettings
+	loopConfig.Reviewers = reviewerSettings
+	loopConfig.RunID = run.ID
+	loopConfig.PriorDecisions = loadPriorDecisions(council.DefaultDecisionsFile)
+	loopConfig.Escalator = newEscalator(globalFlags)
+	loopConfig.EscalationConfidence = globalFlags.EscalationConfidence
+	loopConfig.Templates = templates
+	loopConfig.Branch = currentBranch(ctx)
+	if isGitRepository(ctx) {
+		loopConfig.ChangeTracker = loop.NewGitChangeTracker(nil, artifactPaths()...)
+	}
+	// Patterns were already compiled for the clients above, so this cannot fail
+	loopConfig.ProtectedPaths, _ = newProtectedMatcher(loadedPrinciples)
+	loopConfig.ProtectedRevert = protectedRevertMode(loadedPrinciples)
+	loopConfig.ChangeLimits = changeLimits(loadedPrinciples)
+	loopConfig.SecretScanner, err = newSecretScanner(globalFlags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	// Verification and the base branch are enforced on the commits claude-loop makes itself
+	root := projectRoot(ctx)
+	loopConfig.VerifyCriteria = verifyCriteria(globalFlags, policy, root)
+	if len(loopConfig.VerifyCriteria) > 0 {
+		loopConfig.Verifier = newVerifier(root)
+		fmt.Printf("Verify: %s\n", strings.Join(loopConfig.VerifyCriteria, ", "))
+	}
+	loopConfig.RequireVerification = policy.Verification == config.VerificationStrict
+	if loopConfig.RequireVerification && len(loopConfig.VerifyCriteria) == 0 {
+		fmt.Fprintln(os.Stderr, "Warning: strict verification found no build or test command to run; name one with --verify")
+	}
+	if loopConfig.ChangeTracker != nil && commitsChanges(globalFlags, policy) {
+		committer := newCommitter(ctx, loopConfig.SecretScanner, globalFlags.SecretAction)
+		committer.Exclude(artifactPaths()...)
+		loopConfig.Committer = committer
+	}
+	if loopConfig.ChangeTracker != nil && !policy.DirectPush {
+		loopConfig.BaseBranch = git.NewRepository(nil).GetDefaultBranch(ctx)
+		loopConfig.BranchPrefix = globalFlags.GitBranchPrefix
+		loopConfig.Brancher = git.NewBranchManager(nil)
+	}
 
 	// Track previous cost for per-iteration cost calculation in verbose mode
 	var previousCost float64
@@ -597,17 +789,50 @@ func runMainLoop(cmd *cobra.Command, args []string) {
 			)
 		}
 		previousCost = state.TotalCost
+
+		if n := len(state.Iterations); n > 0 {
+			last := state.Iterations[n-1]
+			if last.Protected != nil {
+				printProtectedRevert(last.Protected)
+			}
+			if last.ChangeSize != nil {
+				printChangeSize(last.ChangeSize)
+			}
+			if len(last.Secrets) > 0 {
+				printSecretFindings(last.Secrets, globalFlags.SecretsAllowlist)
+			}
+			if last.Verification != nil && !last.Verification.Passed {
+				printVerificationFailures(last.Verification)
+			}
+		}
 	}
 
-	// Create Claude client for main loop
-	var clientOpts *claude.ClientOptions
-	if globalFlags.Stream {
-		clientOpts = &claude.ClientOptions{StreamHandler: NewConsoleStreamHandler()}
+	// Clients for the main loop and its auxiliary roles
+	claudeClient := agents.Main
+	roleClients := &loop.RoleClients{
+		Reviewer:       agents.Reviewer,
+		Reviewers:      agents.Reviewers,
+		Council:        agents.Council,
+		CouncilMembers: agents.CouncilMembers,
+		Publisher:      agents.Publisher,
+	}
+	if dump := newPromptDump(globalFlags, run); dump != nil {
+		if roleClients.Publisher != nil {
+			roleClients.Publisher = dump.Wrap(roleClients.Publisher, loop.RoleIteration)
+		}
+		roleClients.Reviewer = dump.Wrap(roleClients.Reviewer, loop.RoleReviewer)
+		for name, client := range roleClients.Reviewers {
+			roleClients.Reviewers[name] = dump.Wrap(client, loop.RoleReviewer)
+		}
+		roleClients.Council = dump.Wrap(roleClients.Council, loop.RoleCouncil)
+		for name, client := range roleClients.CouncilMembers {
+			roleClients.CouncilMembers[name] = dump.Wrap(client, loop.RoleCouncil)
+		}
+		claudeClient = dump.Wrap(claudeClient, loop.RoleIteration)
 	}
-	claudeClient := claude.NewClient(clientOpts)
 
 	// Create and run Executor
-	executor := loop.NewExecutor(loopConfig, claudeClient)
+	executor := loop.NewExecutorWithClients(loopConfig, claudeClient, roleClients)
 	result, err := executor.Run(ctx)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Loop failed: %v\n", err)
@@ -616,6 +841,10 @@ func runMainLoop(cmd *cobra.Command, args []string) {
 
 	// Display result
 	displayLoopResult(result)
+
+	runReport := report.New(run.ID, loopConfig, result, time.Now())
+	writeRunReport(os.Stdout, run, runReport)
+	recordRunHistory(runReport, changedFlags(cmd))
 }
 
 // newRootCmdWithRunner creates a new root command with the specified run function.
@@ -653,16 +882,41 @@ func NewRootCmdForFlagParsing() *cobra.Command {
 
 // loadOrCollectPrinciples loads existing principles or collects them interactively.
 func loadOrCollectPrinciples(ctx context.Context, flags *Flags) (*config.Principles, error) {
-	collector := principles.NewCollector(flags.PrinciplesFile)
+	collector := principles.NewCollector(flags.PrinciplesFile, flags.presets)
 
 	if !collector.NeedsCollection(flags.ResetPrinciples) {
-		return config.LoadFromFile(flags.PrinciplesFile)
+		return loadLayeredPrinciples(os.Stdout, flags.PrinciplesFile, flags.PrincipleOverrides)
 	}
 
+	preset := config.Preset(flags.Preset)
+
 	// In dry-run mode, use defaults instead of interactive collection
 	if flags.DryRun {
+		if preset == "" {
+			preset = config.PresetStartup
+		}
 		fmt.Println("Principles file not found. Using default principles for dry-run mode.")
-		return config.DefaultPrinciples(config.PresetStartup), nil
+		defaults := config.PrincipleLayer{
+			Source:     config.SourceDefault,
+			Path:       string(preset) + " preset",
+			Principles: flags.presets.Defaults(preset),
+		}
+		return layerPrinciples(os.Stdout, defaults, flags.PrincipleOverrides)
+	}
+
+	// The questionnaire would block on a closed or piped stdin
+	if !stdinInteractive() {
+		if preset == "" {
+			return nil, fmt.Errorf("principles file %s not found and stdin is not a terminal; "+
+				"pass --preset to write a preset's defaults, create it first with "+
+				"'claude-loop principles init --preset <name> --non-interactive', "+
+				"or use --dry-run to run with defaults", flags.PrinciplesFile)
+		}
+		if err := writePresetPrinciples(flags.PrinciplesFile, flags.presets.Defaults(preset)); err != nil {
+			return nil, err
+		}
+		fmt.Printf("Principles file not found. Wrote %s with the %s preset defaults.\n", flags.PrinciplesFile, preset)
+		return loadLayeredPrinciples(os.Stdout, flags.PrinciplesFile, flags.PrincipleOverrides)
 	}
 
 	// Run interactive collection
@@ -670,15 +924,127 @@ func loadOrCollectPrinciples(ctx context.Context, flags *Flags) (*config.Princip
 	fmt.Println("Please answer the following questions to configure project principles.")
 	fmt.Println()
 
-	if err := collector.Collect(ctx); err != nil {
+	var err error
+	if preset != "" {
+		err = collector.CollectPreset(ctx, preset)
+	} else {
+		err = collector.Collect(ctx)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("collecting principles: %w", err)
 	}
 
 	fmt.Println()
-	fmt.Println("Principles collected successfully. Continuing with main loop...")
+	fmt.Println("Principles collected successfully. Run 'claude-loop principles wizard' later to review all 18 principles.")
+	fmt.Println("Continuing with main loop...")
 	fmt.Println()
 
-	return config.LoadFromFile(flags.PrinciplesFile)
+	return loadLayeredPrinciples(os.Stdout, flags.PrinciplesFile, flags.PrincipleOverrides)
+}
+
+// loadPriorDecisions reads the decisions logged by earlier runs to path. A log
+// that cannot be read is reported and skipped.
+func loadPriorDecisions(path string) []*council.DecisionRecord {
+	records, err := council.ReadDecisions(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring prior decisions: %v\n", err)
+		return nil
+	}
+	return records
+}
+
+// commitsChanges reports whether claude-loop commits iteration changes itself
+// instead of leaving commits to Claude: it does so to scan them for secrets,
+// to require verification, or to keep them off the default branch.
+func commitsChanges(flags *Flags, policy *config.Policy) bool {
+	if flags.DisableCommits {
+		return false
+	}
+	return !flags.DisableSecretScan || policy.Verification == config.VerificationStrict || !policy.DirectPush
+}
+
+// loadCouncilSettings reads --council-file and applies --council-members and
+// --council-max-cost.
+func loadCouncilSettings(flags *Flags) (*council.Settings, error) {
+	settings, err := council.LoadSettings(flags.CouncilFile)
+	if err != nil {
+		return nil, err
+	}
+	settings.Limit(flags.CouncilMembers)
+	if flags.CouncilMaxCost > 0 {
+		settings.MaxCost = flags.CouncilMaxCost
+	}
+	return settings, nil
+}
+
+// writePresetPrinciples writes a preset's defaults to path.
+func writePresetPrinciples(path string, p *config.Principles) error {
+	p.CreatedAt = time.Now().Format("2006-01-02")
+	return config.SaveToFile(path, p)
+}
+
+// loadPresets loads the preset definitions found in config.PresetDirs. Files that
+// cannot be used are reported to w and skipped.
+func loadPresets(w io.Writer) *config.PresetRegistry {
+	presets, errs := config.LoadPresets(config.PresetDirs(".")...)
+	for _, err := range errs {
+		fmt.Fprintf(w, "Warning: skipping preset %v\n", err)
+	}
+	return presets
+}
+
+// stdinInteractive reports whether stdin is a terminal that can answer prompts.
+var stdinInteractive = func() bool {
+	return principles.IsTerminal(os.Stdin)
+}
+
+// loadExistingPrinciples loads the principles file if it exists, without collecting it.
+func loadExistingPrinciples(flags *Flags) (*config.Principles, error) {
+	if _, err := os.Stat(flags.PrinciplesFile); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return loadLayeredPrinciples(os.Stdout, flags.PrinciplesFile, flags.PrincipleOverrides)
+}
+
+// loadPromptTemplates loads template overrides from --templates-dir and
+// validates them by rendering each against sample data.
+func loadPromptTemplates(flags *Flags) (*prompt.TemplateSet, error) {
+	templates, err := prompt.LoadTemplates(flags.TemplatesDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading prompt templates: %w", err)
+	}
+	if err := templates.Validate(); err != nil {
+		return nil, fmt.Errorf("validating prompt templates: %w", err)
+	}
+	if names := templates.Names(); len(names) > 0 {
+		fmt.Printf("Using prompt template overrides from %s: %s\n", templates.Dir(), strings.Join(names, ", "))
+	}
+	return templates, nil
+}
+
+// newPromptDump returns a PromptDump for the run when --dump-prompts is set, or nil.
+func newPromptDump(flags *Flags, run *runInfo) *loop.PromptDump {
+	if !flags.DumpPrompts {
+		return nil
+	}
+	dump := loop.NewPromptDump(run.promptsDir())
+	fmt.Printf("Dumping prompts to %s\n", dump.Dir())
+	return dump
+}
+
+// currentBranch returns the current git branch, or "" outside a repository.
+func currentBranch(ctx context.Context) string {
+	branch, err := git.NewRepository(nil).GetCurrentBranch(ctx)
+	if err != nil {
+		return ""
+	}
+	return branch
+}
+
+// isGitRepository reports whether the working directory is inside a git repository.
+func isGitRepository(ctx context.Context) bool {
+	ok, err := git.NewRepository(nil).IsGitRepository(ctx)
+	return err == nil && ok
 }
 
 // Execute runs the root command.
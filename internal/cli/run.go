@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/history"
+)
+
+// DefaultRunsDir is where per-run artifacts such as dumped prompts are stored.
+const DefaultRunsDir = ".claude/runs"
+
+// artifactPaths lists what claude-loop itself writes into the working tree
+// during a run. They are not an iteration's changes: they are not counted,
+// reviewed, reverted or committed.
+func artifactPaths() []string {
+	return []string{
+		DefaultRunsDir,
+		council.DefaultEscalationDir,
+		council.DefaultLogFile,
+		council.DefaultDecisionsFile,
+		history.DefaultDir,
+	}
+}
+
+// runInfo identifies a single claude-loop invocation and its artifact directory.
+type runInfo struct {
+	ID  string
+	Dir string
+}
+
+// newRunInfo creates a run identifier from the start time.
+// IDs sort chronologically, so the newest run is always last in a listing.
+func newRunInfo(start time.Time) *runInfo {
+	id := "run-" + start.Format("20060102-150405")
+	return &runInfo{
+		ID:  id,
+		Dir: filepath.Join(DefaultRunsDir, id),
+	}
+}
+
+// promptsDir returns the directory for prompts saved with --dump-prompts.
+func (r *runInfo) promptsDir() string {
+	return filepath.Join(r.Dir, "prompts")
+}
+
+// cassetteDir returns the directory for Claude calls recorded with --record.
+func (r *runInfo) cassetteDir() string {
+	return filepath.Join(r.Dir, "cassette")
+}
+
+// listRunIDs returns the IDs of runs under runsDir that contain file, oldest first.
+func listRunIDs(runsDir, file string) ([]string, error) {
+	entries, err := os.ReadDir(runsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "run-") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(runsDir, entry.Name(), file)); err == nil {
+			ids = append(ids, entry.Name())
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// latestRunID returns the newest run under runsDir that contains file.
+func latestRunID(runsDir, file string) (string, error) {
+	ids, err := listRunIDs(runsDir, file)
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", fmt.Errorf("no runs with %s found in %s", file, runsDir)
+	}
+	return ids[len(ids)-1], nil
+}
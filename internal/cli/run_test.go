@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRunInfo(t *testing.T) {
+	run := newRunInfo(time.Date(2026, 10, 1, 9, 30, 5, 0, time.UTC))
+
+	assert.Equal(t, "run-20261001-093005", run.ID)
+	assert.Equal(t, filepath.Join(".claude", "runs", "run-20261001-093005"), run.Dir)
+	assert.Equal(t, filepath.Join(run.Dir, "prompts"), run.promptsDir())
+}
+
+func TestArtifactPaths(t *testing.T) {
+	assert.Equal(t, []string{
+		".claude/runs",
+		".claude/escalations",
+		".claude/principles-decisions.log",
+		".claude/principles-decisions.jsonl",
+		".claude/history",
+	}, artifactPaths())
+}
+
+func TestListRunIDs(t *testing.T) {
+	runsDir := t.TempDir()
+	for _, id := range []string{"run-20261002-000000", "run-20261001-000000", "run-20261003-000000"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(runsDir, id), 0755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(runsDir, "run-20261001-000000", "report.json"), []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(runsDir, "run-20261002-000000", "report.json"), []byte("{}"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(runsDir, "other"), 0755))
+
+	ids, err := listRunIDs(runsDir, "report.json")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run-20261001-000000", "run-20261002-000000"}, ids)
+
+	latest, err := latestRunID(runsDir, "report.json")
+	require.NoError(t, err)
+	assert.Equal(t, "run-20261002-000000", latest)
+}
+
+func TestLatestRunID_NoRuns(t *testing.T) {
+	ids, err := listRunIDs(filepath.Join(t.TempDir(), "missing"), "report.json")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	_, err = latestRunID(t.TempDir(), "report.json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no runs with report.json")
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+	"github.com/DeukWoongWoo/claude-loop/internal/secrets"
+)
+
+// newSecretScanner builds the scanner for iteration changes from the secret flags.
+// Returns nil with --disable-secret-scan.
+func newSecretScanner(flags *Flags) (*secrets.Scanner, error) {
+	if flags.DisableSecretScan {
+		return nil, nil
+	}
+	opts := &secrets.Options{}
+	for i, spec := range flags.SecretPatterns {
+		rule, err := secrets.ParseRule(spec, i+1)
+		if err != nil {
+			return nil, err
+		}
+		opts.Rules = append(opts.Rules, rule)
+	}
+	allowlist, err := secrets.LoadAllowlist(flags.SecretsAllowlist)
+	if err != nil {
+		return nil, err
+	}
+	opts.Allowlist = allowlist
+	return secrets.NewScanner(opts), nil
+}
+
+// newCommitter builds the commit manager the loop commits through. With a
+// scanner, a secrets guard applies action to the staged changes before every
+// commit. action was validated with the flags.
+func newCommitter(ctx context.Context, scanner *secrets.Scanner, action string) *git.CommitManager {
+	if scanner == nil {
+		return git.NewCommitManager(nil)
+	}
+	parsed, _ := secrets.ParseAction(action)
+	// Without a root, redaction rewrites files relative to the working directory
+	root, _ := git.NewRepository(nil).GetRootPath(ctx)
+	return git.NewCommitManagerWithCheck(nil, secrets.NewGuard(scanner, parsed, root).Check)
+}
+
+// printSecretFindings warns about possible secrets an iteration added.
+func printSecretFindings(findings []secrets.Finding, allowlistPath string) {
+	for _, f := range findings {
+		fmt.Fprintf(os.Stderr, "Warning: possible secret in %s\n", f)
+	}
+	fmt.Fprintf(os.Stderr, "Remove the secrets before they are pushed, or add the fingerprints of false positives to %s\n", allowlistPath)
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSecretScanner(t *testing.T) {
+	diff := "--- a/app.cfg\n+++ b/app.cfg\n@@ -0,0 +1 @@\n+token corp_abcd\n"
+
+	t.Run("custom patterns and allowlist", func(t *testing.T) {
+		allowlist := filepath.Join(t.TempDir(), "secrets-allowlist")
+		flags := DefaultFlags()
+		flags.SecretPatterns = []string{"corp=corp_[a-z]{4}"}
+		flags.SecretsAllowlist = allowlist
+
+		scanner, err := newSecretScanner(flags)
+		require.NoError(t, err)
+		findings := scanner.ScanDiff(diff)
+		require.Len(t, findings, 1)
+		assert.Equal(t, "corp", findings[0].Rule)
+
+		require.NoError(t, os.WriteFile(allowlist, []byte(findings[0].Fingerprint+"  app.cfg test fixture\n"), 0644))
+		scanner, err = newSecretScanner(flags)
+		require.NoError(t, err)
+		assert.Empty(t, scanner.ScanDiff(diff))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.DisableSecretScan = true
+		scanner, err := newSecretScanner(flags)
+		require.NoError(t, err)
+		assert.Nil(t, scanner)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		flags := DefaultFlags()
+		flags.SecretPatterns = []string{"("}
+		_, err := newSecretScanner(flags)
+		assert.True(t, secrets.IsScanError(err))
+	})
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/history"
+	"github.com/spf13/cobra"
+)
+
+// StatsOptions holds flag values for `stats`.
+type StatsOptions struct {
+	HistoryFilterOptions
+	Period string // --period: day, week or month
+	CSV    bool   // --csv: Print the per-period breakdown as CSV
+}
+
+var statsOpts = &StatsOptions{}
+
+// statsCmd aggregates run history into cost analytics.
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cost analytics for past runs",
+	Long: `Aggregate .claude/history into cost per day, week or month, average cost per
+successful iteration and success rate by stop reason. Use --csv to export the
+per-period breakdown.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		period, err := history.ParsePeriod(statsOpts.Period)
+		if err != nil {
+			return err
+		}
+		filter, err := statsOpts.filter()
+		if err != nil {
+			return err
+		}
+		entries, err := history.NewStore(history.DefaultDir).List(filter)
+		if err != nil {
+			return err
+		}
+
+		stats := history.Compute(entries, period)
+		if statsOpts.CSV {
+			return history.WriteCSV(cmd.OutOrStdout(), stats)
+		}
+		writeStats(cmd.OutOrStdout(), stats, period)
+		return nil
+	},
+}
+
+func init() {
+	f := statsCmd.Flags()
+	statsOpts.register(f)
+	f.StringVar(&statsOpts.Period, "period", string(history.PeriodDay), "Bucket costs by day, week or month")
+	f.BoolVar(&statsOpts.CSV, "csv", false, "Print the per-period breakdown as CSV")
+
+	statsCmd.SetHelpTemplate(subcommandHelpTemplate)
+	rootCmd.AddCommand(statsCmd)
+}
+
+// writeStats prints the totals, per-period costs and stop reason breakdown.
+func writeStats(w io.Writer, stats *history.Stats, period history.Period) {
+	if stats.Runs == 0 {
+		fmt.Fprintln(w, "No runs recorded.")
+		return
+	}
+
+	fmt.Fprintf(w, "Runs: %d\n", stats.Runs)
+	fmt.Fprintf(w, "Iterations: %d successful / %d total\n", stats.SuccessfulIterations, stats.TotalIterations)
+	fmt.Fprintf(w, "Total cost: $%.4f (iterations $%.4f, reviewer $%.4f, council $%.4f)\n",
+		stats.Costs.Total, stats.Costs.Iterations, stats.Costs.Reviewer, stats.Costs.Council)
+	fmt.Fprintf(w, "Average cost per successful iteration: $%.4f\n", stats.AvgCostPerSuccessfulIteration())
+
+	fmt.Fprintf(w, "\nCost per %s:\n", period)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  PERIOD\tRUNS\tSUCCESSFUL\tCOST")
+	for _, pc := range stats.ByPeriod {
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t$%.4f\n", pc.Period, pc.Runs, pc.SuccessfulIterations, pc.Costs.Total)
+	}
+	tw.Flush()
+
+	fmt.Fprintln(w, "\nBy stop reason:")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  STOP REASON\tRUNS\tITERATION SUCCESS RATE")
+	for _, rs := range stats.ByStopReason {
+		fmt.Fprintf(tw, "  %s\t%d\t%.0f%%\n", rs.StopReason, rs.Runs, rs.SuccessRate()*100)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/history"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteStats(t *testing.T) {
+	entries := []*history.Entry{testHistoryEntry()}
+	entries[0].TotalIterations = 4
+
+	var buf bytes.Buffer
+	writeStats(&buf, history.Compute(entries, history.PeriodMonth), history.PeriodMonth)
+
+	out := buf.String()
+	assert.Contains(t, out, "Runs: 1")
+	assert.Contains(t, out, "Average cost per successful iteration: $0.3333")
+	assert.Contains(t, out, "Cost per month:")
+	assert.Contains(t, out, "2026-10")
+	assert.Contains(t, out, "max_runs_reached")
+	assert.Contains(t, out, "75%")
+}
+
+func TestWriteStats_NoRuns(t *testing.T) {
+	var buf bytes.Buffer
+	writeStats(&buf, history.Compute(nil, history.PeriodDay), history.PeriodDay)
+	assert.Equal(t, "No runs recorded.\n", buf.String())
+}
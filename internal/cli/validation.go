@@ -3,6 +3,11 @@ package cli
 import (
 	"errors"
 	"fmt"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/secrets"
+	"github.com/DeukWoongWoo/claude-loop/internal/verifier"
 )
 
 // ValidationError represents a CLI validation error.
@@ -84,6 +89,108 @@ func (f *Flags) validatePlanningFlags() *ValidationError {
 	return nil
 }
 
+// validateRecordReplay checks that a run does not record and replay at the same time.
+func (f *Flags) validateRecordReplay() *ValidationError {
+	if f.Record && f.Replay != "" {
+		return &ValidationError{
+			Field:   "replay",
+			Message: "--record and --replay cannot be used together",
+		}
+	}
+	return nil
+}
+
+// validatePermissions checks --permissions profile and role names.
+func (f *Flags) validatePermissions() *ValidationError {
+	if _, err := parsePermissions(f.Permissions); err != nil {
+		return &ValidationError{
+			Field:   "permissions",
+			Message: err.Error(),
+		}
+	}
+	return nil
+}
+
+// validateSecretPatterns checks that every --secret-pattern compiles and that
+// --secret-action names a known action.
+func (f *Flags) validateSecretPatterns() *ValidationError {
+	for i, spec := range f.SecretPatterns {
+		if _, err := secrets.ParseRule(spec, i+1); err != nil {
+			return &ValidationError{
+				Field:   "secret-pattern",
+				Message: err.Error(),
+			}
+		}
+	}
+	if _, err := secrets.ParseAction(f.SecretAction); err != nil {
+		return &ValidationError{
+			Field:   "secret-action",
+			Message: err.Error(),
+		}
+	}
+	return nil
+}
+
+// validatePrincipleOverrides checks that every --principle is a known key=value.
+func (f *Flags) validatePrincipleOverrides() *ValidationError {
+	if _, err := config.ParsePrincipleAssignments(f.PrincipleOverrides); err != nil {
+		return &ValidationError{
+			Field:   "principle",
+			Message: err.Error(),
+		}
+	}
+	return nil
+}
+
+// validatePreset checks that --preset names a built-in or custom preset.
+func (f *Flags) validatePreset() *ValidationError {
+	if f.Preset == "" || f.presets.HasDefaults(config.Preset(f.Preset)) {
+		return nil
+	}
+	return &ValidationError{
+		Field:   "preset",
+		Message: fmt.Sprintf("preset must be one of: %s (got %q)", presetList(f.presets), f.Preset),
+	}
+}
+
+// validateVerification checks that --verification names a known level and that
+// a built-in check handles every --verify criterion.
+func (f *Flags) validateVerification() *ValidationError {
+	if f.Verification != "" && !config.IsValidVerificationLevel(config.VerificationLevel(f.Verification)) {
+		return &ValidationError{
+			Field:   "verification",
+			Message: fmt.Sprintf("verification must be relaxed, standard, or strict (got %q)", f.Verification),
+		}
+	}
+	registry := verifier.NewCheckerRegistry(nil)
+	for _, criterion := range f.Verify {
+		if registry.FindChecker(criterion) == nil {
+			return &ValidationError{
+				Field:   "verify",
+				Message: fmt.Sprintf("no built-in check handles verify criterion %q (use a build or test command such as \"go test\")", criterion),
+			}
+		}
+	}
+	return nil
+}
+
+// validateEscalation checks the --escalation mode and confidence threshold.
+func (f *Flags) validateEscalation() *ValidationError {
+	if f.Escalation != "" && !council.IsValidEscalationMode(f.Escalation) {
+		return &ValidationError{
+			Field:   "escalation",
+			Message: fmt.Sprintf("escalation must be auto, file, issue, or off (got %q)", f.Escalation),
+		}
+	}
+	if f.EscalationConfidence < 0 || f.EscalationConfidence > 1 {
+		return &ValidationError{
+			Field:   "escalation-confidence",
+			Message: fmt.Sprintf("escalation-confidence must be between 0 and 1 (got %g)", f.EscalationConfidence),
+		}
+	}
+	return nil
+}
+
 // validateNonNegative checks that numeric values are not negative.
 func (f *Flags) validateNonNegative() *ValidationError {
 	if f.MaxRuns < 0 {
@@ -116,6 +223,18 @@ func (f *Flags) validateNonNegative() *ValidationError {
 			Message: "completion-threshold cannot be negative",
 		}
 	}
+	if f.CouncilMembers < 0 {
+		return &ValidationError{
+			Field:   "council-members",
+			Message: "council-members cannot be negative",
+		}
+	}
+	if f.CouncilMaxCost < 0 {
+		return &ValidationError{
+			Field:   "council-max-cost",
+			Message: "council-max-cost cannot be negative",
+		}
+	}
 	return nil
 }
 
@@ -143,6 +262,27 @@ func (f *Flags) Validate() error {
 	if err := f.validateMergeStrategy(); err != nil {
 		return err
 	}
+	if err := f.validateRecordReplay(); err != nil {
+		return err
+	}
+	if err := f.validatePermissions(); err != nil {
+		return err
+	}
+	if err := f.validateSecretPatterns(); err != nil {
+		return err
+	}
+	if err := f.validateVerification(); err != nil {
+		return err
+	}
+	if err := f.validateEscalation(); err != nil {
+		return err
+	}
+	if err := f.validatePrincipleOverrides(); err != nil {
+		return err
+	}
+	if err := f.validatePreset(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -158,6 +298,18 @@ func (f *Flags) validateForPlanningMode() error {
 	if err := f.validatePlanningFlags(); err != nil {
 		return err
 	}
+	if err := f.validateRecordReplay(); err != nil {
+		return err
+	}
+	if err := f.validatePermissions(); err != nil {
+		return err
+	}
+	if err := f.validatePrincipleOverrides(); err != nil {
+		return err
+	}
+	if err := f.validatePreset(); err != nil {
+		return err
+	}
 
 	// --resume doesn't require --prompt
 	if f.Resume != "" {
@@ -210,6 +362,18 @@ func (f *Flags) ValidateAll() []error {
 		if err := f.validateMergeStrategy(); err != nil {
 			errs = append(errs, err)
 		}
+		if err := f.validateRecordReplay(); err != nil {
+			errs = append(errs, err)
+		}
+		if err := f.validatePermissions(); err != nil {
+			errs = append(errs, err)
+		}
+		if err := f.validatePrincipleOverrides(); err != nil {
+			errs = append(errs, err)
+		}
+		if err := f.validatePreset(); err != nil {
+			errs = append(errs, err)
+		}
 		return errs
 	}
 
@@ -225,6 +389,27 @@ func (f *Flags) ValidateAll() []error {
 	if err := f.validateMergeStrategy(); err != nil {
 		errs = append(errs, err)
 	}
+	if err := f.validateRecordReplay(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := f.validatePermissions(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := f.validateSecretPatterns(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := f.validateVerification(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := f.validateEscalation(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := f.validatePrincipleOverrides(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := f.validatePreset(); err != nil {
+		errs = append(errs, err)
+	}
 
 	return errs
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -58,6 +59,93 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "record with replay",
+			flags: &Flags{
+				Prompt:  "test",
+				MaxRuns: 5,
+				Record:  true,
+				Replay:  ".claude/runs/run-1/cassette",
+			},
+			wantErr: "--record and --replay cannot be used together",
+		},
+		{
+			name: "invalid permission profile",
+			flags: &Flags{
+				Prompt:      "test",
+				MaxRuns:     5,
+				Permissions: []string{"reviewer=admin"},
+			},
+			wantErr: `unknown permission profile "admin"`,
+		},
+		{
+			name: "invalid verification level",
+			flags: &Flags{
+				Prompt:       "test",
+				MaxRuns:      5,
+				Verification: "paranoid",
+			},
+			wantErr: `verification must be relaxed, standard, or strict (got "paranoid")`,
+		},
+		{
+			name: "unhandled verify criterion",
+			flags: &Flags{
+				Prompt:  "test",
+				MaxRuns: 5,
+				Verify:  []string{"go test", "the code is clean"},
+			},
+			wantErr: `no built-in check handles verify criterion "the code is clean"`,
+		},
+		{
+			name: "invalid principle override",
+			flags: &Flags{
+				Prompt:             "test",
+				MaxRuns:            5,
+				PrincipleOverrides: []string{"velocity=3"},
+			},
+			wantErr: `unknown principle "velocity"`,
+		},
+		{
+			name: "unknown preset",
+			flags: &Flags{
+				Prompt:  "test",
+				MaxRuns: 5,
+				Preset:  "agency",
+			},
+			wantErr: `preset must be one of: startup, enterprise, opensource (got "agency")`,
+		},
+		{
+			name: "custom preset from the registry",
+			flags: &Flags{
+				Prompt:  "test",
+				MaxRuns: 5,
+				Preset:  "agency",
+				presets: func() *config.PresetRegistry {
+					r := config.NewPresetRegistry()
+					_ = r.Register(&config.PresetDefinition{Name: "agency", Extends: config.PresetStartup})
+					return r
+				}(),
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid secret pattern",
+			flags: &Flags{
+				Prompt:         "test",
+				MaxRuns:        5,
+				SecretPatterns: []string{"corp=corp_(["},
+			},
+			wantErr: `invalid secret pattern "corp"`,
+		},
+		{
+			name: "invalid secret action",
+			flags: &Flags{
+				Prompt:       "test",
+				MaxRuns:      5,
+				SecretAction: "ignore",
+			},
+			wantErr: `unknown secrets action "ignore"`,
+		},
 		{
 			name: "invalid merge strategy",
 			flags: &Flags{
@@ -176,6 +264,43 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: "completion-threshold cannot be negative",
 		},
+		{
+			name: "negative council-members",
+			flags: &Flags{
+				Prompt:         "test",
+				MaxRuns:        5,
+				CouncilMembers: -1,
+			},
+			wantErr: "council-members cannot be negative",
+		},
+		{
+			name: "negative council-max-cost",
+			flags: &Flags{
+				Prompt:         "test",
+				MaxRuns:        5,
+				CouncilMaxCost: -0.5,
+			},
+			wantErr: "council-max-cost cannot be negative",
+		},
+		{
+			name: "invalid escalation mode",
+			flags: &Flags{
+				Prompt:     "test",
+				MaxRuns:    5,
+				Escalation: "slack",
+			},
+			wantErr: "escalation must be auto, file, issue, or off",
+		},
+		{
+			name: "escalation confidence above 1",
+			flags: &Flags{
+				Prompt:               "test",
+				MaxRuns:              5,
+				Escalation:           "file",
+				EscalationConfidence: 1.5,
+			},
+			wantErr: "escalation-confidence must be between 0 and 1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -228,6 +353,31 @@ func TestValidateAll(t *testing.T) {
 			flags:      &Flags{MergeStrategy: "invalid"},
 			wantErrors: 3,
 		},
+		{
+			name:       "record with replay",
+			flags:      &Flags{Prompt: "test", MaxRuns: 5, Record: true, Replay: "cassette"},
+			wantErrors: 1,
+		},
+		{
+			name:       "invalid verification level",
+			flags:      &Flags{Prompt: "test", MaxRuns: 5, Verification: "paranoid"},
+			wantErrors: 1,
+		},
+		{
+			name:       "invalid secret pattern",
+			flags:      &Flags{Prompt: "test", MaxRuns: 5, SecretPatterns: []string{"("}},
+			wantErrors: 1,
+		},
+		{
+			name:       "invalid principle override",
+			flags:      &Flags{Prompt: "test", MaxRuns: 5, PrincipleOverrides: []string{"security_posture=11"}},
+			wantErrors: 1,
+		},
+		{
+			name:       "unknown preset",
+			flags:      &Flags{Prompt: "test", MaxRuns: 5, Preset: "custom"},
+			wantErrors: 1,
+		},
 		{
 			name:       "list-worktrees bypasses validation",
 			flags:      &Flags{ListWorktrees: true},
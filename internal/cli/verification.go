@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/DeukWoongWoo/claude-loop/internal/verifier"
+)
+
+// verifyCriteria returns the checks to run after each iteration: the --verify
+// criteria, or with strict verification the build and test commands detected
+// for the project in dir.
+func verifyCriteria(flags *Flags, policy *config.Policy, dir string) []string {
+	if len(flags.Verify) > 0 || policy.Verification != config.VerificationStrict {
+		return flags.Verify
+	}
+	return verifier.DetectCriteria(dir)
+}
+
+// newVerifier builds the verifier for the verification criteria, running their
+// commands in dir.
+func newVerifier(dir string) *verifier.DefaultVerifier {
+	cfg := verifier.DefaultConfig()
+	cfg.WorkDir = dir
+	return verifier.NewVerifier(cfg, nil)
+}
+
+// projectRoot returns the repository root, or the working directory outside a repository.
+func projectRoot(ctx context.Context) string {
+	if root, err := git.NewRepository(nil).GetRootPath(ctx); err == nil {
+		return root
+	}
+	dir, _ := os.Getwd()
+	return dir
+}
+
+// printVerificationFailures warns about the checks an iteration failed.
+func printVerificationFailures(v *loop.VerificationRecord) {
+	for _, failure := range v.Failures {
+		fmt.Fprintf(os.Stderr, "Warning: verification failed: %s\n", failure)
+	}
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCriteria(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0644))
+	strict := &config.Policy{Verification: config.VerificationStrict}
+	standard := &config.Policy{Verification: config.VerificationStandard}
+
+	t.Run("--verify criteria are used as given", func(t *testing.T) {
+		f := DefaultFlags()
+		f.Verify = []string{"make test"}
+		assert.Equal(t, []string{"make test"}, verifyCriteria(f, strict, dir))
+		assert.Equal(t, []string{"make test"}, verifyCriteria(f, standard, dir))
+	})
+
+	t.Run("strict verification detects the build and tests", func(t *testing.T) {
+		assert.Equal(t, []string{"go build", "go test"}, verifyCriteria(DefaultFlags(), strict, dir))
+		assert.Empty(t, verifyCriteria(DefaultFlags(), strict, t.TempDir()), "unknown projects have nothing to detect")
+	})
+
+	t.Run("other levels only run --verify", func(t *testing.T) {
+		assert.Empty(t, verifyCriteria(DefaultFlags(), standard, dir))
+	})
+}
+
+func TestNewVerifier(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, dir, newVerifier(dir).Config().WorkDir, "checks run in the project root")
+}
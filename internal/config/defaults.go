@@ -4,6 +4,8 @@ package config
 const DefaultVersion = "2.3"
 
 // DefaultPrinciples returns a Principles struct with default values for the given preset.
+// Only built-in presets are known here (see PresetRegistry.Defaults for custom
+// presets); unknown presets get the startup defaults.
 // Note: CreatedAt is not set by default as it should be set to the actual creation time.
 // Callers must set CreatedAt before calling Validate().
 func DefaultPrinciples(preset Preset) *Principles {
@@ -14,9 +16,8 @@ func DefaultPrinciples(preset Preset) *Principles {
 		return enterpriseDefaults()
 	case PresetOpenSource:
 		return opensourceDefaults()
-	default:
-		return startupDefaults()
 	}
+	return startupDefaults()
 }
 
 func startupDefaults() *Principles {
@@ -0,0 +1,124 @@
+package config
+
+// PrincipleInfo describes what a principle means and how its scale reads.
+type PrincipleInfo struct {
+	Key         string // Full key, such as "layer1.security_posture"
+	Title       string
+	Low         string // Meaning of 1-3
+	High        string // Meaning of 7-10
+	Description string
+	LowExample  string // What a low value looks like in practice
+	HighExample string // What a high value looks like in practice
+	Effects     string // What the value changes at runtime, if anything
+}
+
+// principleInfos holds the description of every principle, keyed by full key.
+var principleInfos = map[string]PrincipleInfo{
+	"layer0.trust_architecture": {
+		Title: "Trust Architecture", Low: "Permissive", High: "Strict verification",
+		Description: "How much the product verifies users, content and integrations before trusting them.",
+		LowExample:  "Anyone can sign up and post immediately", HighExample: "Every account and webhook is verified before use",
+	},
+	"layer0.curation_model": {
+		Title: "Curation Model", Low: "Algorithm-driven", High: "Human judgment",
+		Description: "Whether content and decisions are shaped by automation or by people.",
+		LowExample:  "A ranking algorithm decides what users see", HighExample: "Editors review what gets featured",
+	},
+	"layer0.scope_philosophy": {
+		Title: "Scope Philosophy", Low: "MVP focus", High: "Feature-rich",
+		Description: "How broad the product should be; low values keep to the core feature set.",
+		LowExample:  "Ship one workflow that solves the core problem", HighExample: "Cover adjacent use cases and power features",
+	},
+	"layer0.monetization_model": {
+		Title: "Monetization Model", Low: "Free/freemium", High: "Premium/B2B",
+		Description: "Who pays, and how much polish and support that implies.",
+		LowExample:  "Free tier with ads or upsells", HighExample: "Paid contracts with SLAs",
+	},
+	"layer0.privacy_posture": {
+		Title: "Privacy Posture", Low: "Data collection", High: "Minimal data",
+		Description: "How much user data the product may collect and keep.",
+		LowExample:  "Collect analytics on every interaction", HighExample: "Store only what the feature cannot work without",
+	},
+	"layer0.ux_philosophy": {
+		Title: "UX Philosophy", Low: "Power users", High: "Simple-first",
+		Description: "Whether the interface favours flexibility or ease of use.",
+		LowExample:  "Dense screens, keyboard shortcuts, many options", HighExample: "One obvious path with sensible defaults",
+	},
+	"layer0.authority_stance": {
+		Title: "Authority Stance", Low: "User freedom", High: "Strong guidance",
+		Description: "How far the product lets users do things their own way.",
+		LowExample:  "Users configure everything themselves", HighExample: "Opinionated defaults that steer users",
+	},
+	"layer0.auditability": {
+		Title: "Auditability", Low: "Minimal logging", High: "Full audit trail",
+		Description: "How much of what happens must be recorded and traceable.",
+		LowExample:  "Basic error logs", HighExample: "Every change recorded with who, what and when",
+	},
+	"layer0.interoperability": {
+		Title: "Interoperability", Low: "Closed system", High: "Open integrations",
+		Description: "How much the product integrates with, and exposes itself to, other systems.",
+		LowExample:  "Proprietary formats, no public API", HighExample: "Open formats, public API and webhooks",
+	},
+	"layer1.speed_correctness": {
+		Title: "Speed vs Correctness", Low: "Speed first", High: "Correctness first",
+		Description: "Whether to ship quickly or to get every detail right first.",
+		LowExample:  "Ship behind a flag and fix forward", HighExample: "Tests and review before anything merges",
+		Effects: "<= 3 relaxes verification.",
+	},
+	"layer1.innovation_stability": {
+		Title: "Innovation vs Stability", Low: "New tech", High: "Proven tech",
+		Description: "Willingness to adopt new libraries, tools and patterns.",
+		LowExample:  "Try the new framework release", HighExample: "Stay on the LTS version",
+	},
+	"layer1.blast_radius": {
+		Title: "Blast Radius", Low: "Large changes", High: "Small changes",
+		Description: "How large a single change may be.",
+		LowExample:  "Refactor a whole module in one change", HighExample: "One small, focused change at a time",
+		Effects: "Sets the files and lines each iteration may change, unless change_limits overrides it.",
+	},
+	"layer1.clarity_of_intent": {
+		Title: "Clarity of Intent", Low: "Implicit", High: "Explicit",
+		Description: "How explicitly code, names and commits must state what they do and why.",
+		LowExample:  "Terse code and short commit messages", HighExample: "Descriptive names, comments and commit messages",
+	},
+	"layer1.reversibility_priority": {
+		Title: "Reversibility Priority", Low: "Permanent", High: "Easy rollback",
+		Description: "How easy it must be to undo a change.",
+		LowExample:  "One-way schema changes are fine", HighExample: "Every change can be rolled back",
+		Effects: ">= 7 opens draft PRs and disallows direct pushes.",
+	},
+	"layer1.security_posture": {
+		Title: "Security Posture", Low: "Basic", High: "Maximum security",
+		Description: "How much security review and hardening changes need.",
+		LowExample:  "Standard practices, no extra review", HighExample: "Threat review, least privilege, strict checks",
+		Effects: ">= 8 requires a reviewer and strict verification, and narrows the default permission profile (8-9 edit+test, 10 edit-only).",
+	},
+	"layer1.urgency_tiers": {
+		Title: "Urgency Tiers", Low: "All urgent", High: "Normal pace",
+		Description: "How much time pressure the work is under.",
+		LowExample:  "Everything is a hotfix", HighExample: "Work follows normal planning",
+	},
+	"layer1.cost_efficiency": {
+		Title: "Cost Efficiency", Low: "Build everything", High: "Use external tools",
+		Description: "Whether to build in-house or rely on existing tools and services, and how much to spend.",
+		LowExample:  "Write it in-house", HighExample: "Use a managed service or library",
+		Effects: ">= 8 selects a cheaper model for reviewer and council calls.",
+	},
+	"layer1.migration_burden": {
+		Title: "Migration Burden", Low: "Heavy migration OK", High: "Avoid migrations",
+		Description: "How much work a change may push onto users, such as data or API migrations.",
+		LowExample:  "Breaking changes with migration guides", HighExample: "Keep old behaviour working",
+	},
+}
+
+// DescribePrinciple returns the description of a principle given with or without
+// its layer prefix.
+func DescribePrinciple(key string) (PrincipleInfo, error) {
+	full, ok := NormalizePrincipleKey(key)
+	if !ok {
+		return PrincipleInfo{}, unknownPrincipleError(key)
+	}
+	info := principleInfos[full]
+	info.Key = full
+	return info, nil
+}
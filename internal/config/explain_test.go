@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribePrinciple(t *testing.T) {
+	info, err := DescribePrinciple("security_posture")
+	require.NoError(t, err)
+	assert.Equal(t, "layer1.security_posture", info.Key)
+	assert.Equal(t, "Security Posture", info.Title)
+	assert.Equal(t, "Basic", info.Low)
+	assert.Equal(t, "Maximum security", info.High)
+	assert.Contains(t, info.Effects, "requires a reviewer")
+
+	_, err = DescribePrinciple("velocity")
+	assert.True(t, IsValidationError(err))
+}
+
+func TestDescribePrinciple_AllKeys(t *testing.T) {
+	for _, key := range PrincipleKeys() {
+		info, err := DescribePrinciple(key)
+		require.NoError(t, err)
+		assert.NotEmpty(t, info.Title, key)
+		assert.NotEmpty(t, info.Low, key)
+		assert.NotEmpty(t, info.High, key)
+		assert.NotEmpty(t, info.Description, key)
+		assert.NotEmpty(t, info.LowExample, key)
+		assert.NotEmpty(t, info.HighExample, key)
+	}
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PrincipleKeys returns every principle key, such as "layer1.security_posture",
+// in schema order.
+func PrincipleKeys() []string {
+	var p Principles
+	fields := p.principleFields()
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = f.name
+	}
+	return keys
+}
+
+// NormalizePrincipleKey returns the full key for a principle given with or without
+// its layer prefix ("security_posture" -> "layer1.security_posture").
+func NormalizePrincipleKey(key string) (string, bool) {
+	var p Principles
+	f, ok := p.principleField(key)
+	return f.name, ok
+}
+
+// Principle returns the value of a principle by key. Unset principles are 0.
+func (p *Principles) Principle(key string) (int, error) {
+	f, ok := p.principleField(key)
+	if !ok {
+		return 0, unknownPrincipleError(key)
+	}
+	return *f.value, nil
+}
+
+// SetPrinciple sets a principle by key. The value must be within 1-10.
+func (p *Principles) SetPrinciple(key string, value int) error {
+	f, ok := p.principleField(key)
+	if !ok {
+		return unknownPrincipleError(key)
+	}
+	if err := validatePrincipleValue(f.name, value); err != nil {
+		return err
+	}
+	*f.value = value
+	return nil
+}
+
+// ParsePrincipleAssignments parses "key=value" assignments, such as
+// "security_posture=9", into Principles with only those principles set.
+func ParsePrincipleAssignments(assignments []string) (*Principles, error) {
+	p := &Principles{}
+	for _, a := range assignments {
+		key, raw, ok := strings.Cut(a, "=")
+		if !ok {
+			return nil, &ValidationError{
+				Field:   "principle",
+				Message: fmt.Sprintf("principle override must be key=value (got %q)", a),
+			}
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, &ValidationError{
+				Field:   "principle",
+				Message: fmt.Sprintf("principle override %q must have an integer value", a),
+			}
+		}
+		if err := p.SetPrinciple(strings.TrimSpace(key), value); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// PrincipleDiff is a principle whose value differs from a baseline.
+type PrincipleDiff struct {
+	Key      string
+	Value    int
+	Baseline int
+}
+
+// DiffPrinciples returns the principles in p whose values differ from baseline,
+// in schema order.
+func DiffPrinciples(p, baseline *Principles) []PrincipleDiff {
+	var diffs []PrincipleDiff
+	base := baseline.principleFields()
+	for i, f := range p.principleFields() {
+		if *f.value != *base[i].value {
+			diffs = append(diffs, PrincipleDiff{Key: f.name, Value: *f.value, Baseline: *base[i].value})
+		}
+	}
+	return diffs
+}
+
+// principleFields returns every principle in schema order.
+func (p *Principles) principleFields() []principleField {
+	return append(p.layer0Fields(), p.layer1Fields()...)
+}
+
+// principleField finds a principle by full or unprefixed key.
+func (p *Principles) principleField(key string) (principleField, bool) {
+	for _, f := range p.principleFields() {
+		if f.name == key || strings.TrimPrefix(strings.TrimPrefix(f.name, "layer0."), "layer1.") == key {
+			return f, true
+		}
+	}
+	return principleField{}, false
+}
+
+func unknownPrincipleError(key string) *ValidationError {
+	return &ValidationError{
+		Field:   "principle",
+		Message: fmt.Sprintf("unknown principle %q (expected a key such as layer1.security_posture)", key),
+	}
+}
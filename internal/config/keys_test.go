@@ -0,0 +1,94 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrincipleKeys(t *testing.T) {
+	keys := PrincipleKeys()
+	assert.Len(t, keys, 18)
+	assert.Equal(t, "layer0.trust_architecture", keys[0])
+	assert.Equal(t, "layer1.migration_burden", keys[17])
+}
+
+func TestNormalizePrincipleKey(t *testing.T) {
+	name, ok := NormalizePrincipleKey("security_posture")
+	assert.True(t, ok)
+	assert.Equal(t, "layer1.security_posture", name)
+
+	name, ok = NormalizePrincipleKey("layer0.privacy_posture")
+	assert.True(t, ok)
+	assert.Equal(t, "layer0.privacy_posture", name)
+
+	_, ok = NormalizePrincipleKey("layer0.security_posture")
+	assert.False(t, ok)
+}
+
+func TestPrinciples_SetPrinciple(t *testing.T) {
+	p := DefaultPrinciples(PresetStartup)
+
+	require.NoError(t, p.SetPrinciple("security_posture", 9))
+	assert.Equal(t, 9, p.Layer1.SecurityPosture)
+	value, err := p.Principle("layer1.security_posture")
+	require.NoError(t, err)
+	assert.Equal(t, 9, value)
+
+	err = p.SetPrinciple("security_posture", 11)
+	assert.True(t, IsValidationError(err))
+	assert.Contains(t, err.Error(), "layer1.security_posture must be between 1 and 10")
+
+	err = p.SetPrinciple("velocity", 5)
+	assert.Contains(t, err.Error(), `unknown principle "velocity"`)
+	_, err = p.Principle("velocity")
+	assert.Error(t, err)
+}
+
+func TestParsePrincipleAssignments(t *testing.T) {
+	p, err := ParsePrincipleAssignments([]string{"security_posture=9", "layer0.privacy_posture = 7"})
+	require.NoError(t, err)
+	assert.Equal(t, 9, p.Layer1.SecurityPosture)
+	assert.Equal(t, 7, p.Layer0.PrivacyPosture)
+	assert.Zero(t, p.Layer1.SpeedCorrectness)
+
+	tests := []struct {
+		assignment, wantErr string
+	}{
+		{"security_posture", "must be key=value"},
+		{"security_posture=high", "must have an integer value"},
+		{"security_posture=0", "must be between 1 and 10"},
+		{"nope=3", "unknown principle"},
+	}
+	for _, tt := range tests {
+		_, err := ParsePrincipleAssignments([]string{tt.assignment})
+		require.Error(t, err, tt.assignment)
+		assert.Contains(t, err.Error(), tt.wantErr)
+	}
+}
+
+func TestDiffPrinciples(t *testing.T) {
+	p := DefaultPrinciples(PresetEnterprise)
+	assert.Empty(t, DiffPrinciples(p, DefaultPrinciples(PresetEnterprise)))
+
+	require.NoError(t, p.SetPrinciple("privacy_posture", 3))
+	require.NoError(t, p.SetPrinciple("security_posture", 10))
+	diffs := DiffPrinciples(p, DefaultPrinciples(PresetEnterprise))
+	require.Len(t, diffs, 2)
+	assert.Equal(t, PrincipleDiff{Key: "layer0.privacy_posture", Value: 3, Baseline: 9}, diffs[0])
+	assert.Equal(t, "layer1.security_posture", diffs[1].Key)
+}
+
+func TestValidate_Locked(t *testing.T) {
+	p := DefaultPrinciples(PresetStartup)
+	p.CreatedAt = "2026-01-11"
+	p.Locked = []string{"security_posture", "layer0.privacy_posture"}
+	assert.NoError(t, p.Validate())
+
+	p.Locked = []string{"velocity"}
+	err := p.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `locked[0] must be a principle key`)
+	assert.Len(t, p.ValidateAll(), 1)
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// UserPrinciplesEnv names the environment variable pointing at the user- or
+// organisation-level principles file.
+const UserPrinciplesEnv = "CLAUDE_LOOP_PRINCIPLES"
+
+// Principle layer sources, lowest precedence first.
+const (
+	SourceUser       = "user"       // User- or organisation-level file
+	SourceDefault    = "default"    // Preset defaults used when the repository has no file (dry run)
+	SourceRepository = "repository" // The repository's principles file
+	SourceCLI        = "cli"        // --principle overrides
+)
+
+// UserPrinciplesPath returns the user-level principles file: $CLAUDE_LOOP_PRINCIPLES
+// when set, otherwise claude-loop/principles.yaml under the user config directory
+// (~/.config on Linux). explicit reports whether the environment variable was used;
+// path is empty when no config directory is available.
+func UserPrinciplesPath() (path string, explicit bool) {
+	if env := os.Getenv(UserPrinciplesEnv); env != "" {
+		return env, true
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", false
+	}
+	return filepath.Join(dir, "claude-loop", "principles.yaml"), false
+}
+
+// PrincipleLayer is one source of principles. Principles may be partial: unset
+// (zero) values inherit from lower layers.
+type PrincipleLayer struct {
+	Source     string
+	Path       string // File path, or a description such as "--principle"
+	Principles *Principles
+}
+
+// ValueSource records where an effective principle value came from.
+type ValueSource struct {
+	Source   string
+	Path     string
+	Floor    int    // Locked minimum (0 = not locked)
+	LockedBy string // Source of the layer that locked the key
+}
+
+// EffectivePrinciples is the result of merging principle layers.
+type EffectivePrinciples struct {
+	Principles *Principles
+	Layers     []PrincipleLayer       // Layers that contributed, lowest precedence first
+	Sources    map[string]ValueSource // Keyed by full principle key
+	// Notes describes values that were ignored because they were below a locked floor.
+	Notes []string
+}
+
+// MergePrinciples merges layers given lowest precedence first. For each principle the
+// highest layer that sets it wins, unless a lower layer locked the key and the value is
+// below the locked floor, in which case the value is ignored and reported in Notes.
+// Protected paths and rules accumulate across layers, with a higher layer's rules first;
+// other settings are taken from the highest layer that sets them. Layers without principles are skipped. The merged values and
+// settings are validated; version, preset and created_at are not required.
+func MergePrinciples(layers ...PrincipleLayer) (*EffectivePrinciples, error) {
+	merged := &Principles{}
+	eff := &EffectivePrinciples{Principles: merged, Sources: make(map[string]ValueSource)}
+	floors := make(map[string]ValueSource)
+
+	for _, layer := range layers {
+		p := layer.Principles
+		if p == nil {
+			continue
+		}
+		eff.Layers = append(eff.Layers, layer)
+
+		mergeSettings(merged, p)
+
+		targets := merged.principleFields()
+		for i, f := range p.principleFields() {
+			value := *f.value
+			if value == 0 {
+				continue
+			}
+			if floor, ok := floors[f.name]; ok && value < floor.Floor {
+				eff.Notes = append(eff.Notes, fmt.Sprintf("%s: %s value %d ignored; %s locks it at %d or above",
+					f.name, layer.Source, value, floor.LockedBy, floor.Floor))
+				continue
+			}
+			*targets[i].value = value
+			eff.Sources[f.name] = ValueSource{Source: layer.Source, Path: layer.Path}
+		}
+
+		for _, key := range p.Locked {
+			name, ok := NormalizePrincipleKey(key)
+			if !ok {
+				return nil, &LoadError{Path: layer.Path, Message: "invalid locked key", Err: unknownPrincipleError(key)}
+			}
+			value, _ := merged.Principle(name)
+			if value == 0 {
+				return nil, &LoadError{Path: layer.Path, Message: fmt.Sprintf("locked key %s has no value", name)}
+			}
+			floors[name] = ValueSource{Floor: value, LockedBy: layer.Source}
+			if !containsString(merged.Locked, name) {
+				merged.Locked = append(merged.Locked, name)
+			}
+		}
+	}
+
+	for name, floor := range floors {
+		source := eff.Sources[name]
+		source.Floor, source.LockedBy = floor.Floor, floor.LockedBy
+		eff.Sources[name] = source
+	}
+
+	for _, validate := range []func() *ValidationError{
+		merged.validateLayer0, merged.validateLayer1, merged.validateProtected,
+		merged.validateChangeLimits, merged.validateLocked, merged.validateRules,
+	} {
+		if err := validate(); err != nil {
+			return nil, err
+		}
+	}
+	return eff, nil
+}
+
+// mergeSettings applies the non-principle settings of p over merged.
+func mergeSettings(merged, p *Principles) {
+	if p.Version != "" {
+		merged.Version = p.Version
+	}
+	if p.Preset != "" {
+		merged.Preset = p.Preset
+	}
+	if p.CreatedAt != "" {
+		merged.CreatedAt = p.CreatedAt
+	}
+	if p.Protected != nil {
+		if merged.Protected == nil {
+			merged.Protected = &ProtectedPaths{}
+		}
+		for _, path := range p.Protected.Paths {
+			if !containsString(merged.Protected.Paths, path) {
+				merged.Protected.Paths = append(merged.Protected.Paths, path)
+			}
+		}
+		if p.Protected.Revert != "" {
+			merged.Protected.Revert = p.Protected.Revert
+		}
+	}
+	if len(p.Rules) > 0 {
+		merged.Rules = mergeRules(p.Rules, merged.Rules)
+	}
+	if p.ChangeLimits != nil {
+		if merged.ChangeLimits == nil {
+			merged.ChangeLimits = &ChangeLimits{}
+		}
+		if p.ChangeLimits.MaxFiles != 0 {
+			merged.ChangeLimits.MaxFiles = p.ChangeLimits.MaxFiles
+		}
+		if p.ChangeLimits.MaxLines != 0 {
+			merged.ChangeLimits.MaxLines = p.ChangeLimits.MaxLines
+		}
+		if p.ChangeLimits.OnExceed != "" {
+			merged.ChangeLimits.OnExceed = p.ChangeLimits.OnExceed
+		}
+	}
+}
+
+// mergeRules puts a higher layer's rules before a lower layer's, so they are checked
+// first. A higher rule replaces a lower rule with the same ID.
+func mergeRules(higher, lower []ResolutionRule) []ResolutionRule {
+	rules := append([]ResolutionRule(nil), higher...)
+	for _, r := range lower {
+		replaced := false
+		for _, h := range higher {
+			if h.ID == r.ID {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserPrinciplesPath(t *testing.T) {
+	t.Setenv(UserPrinciplesEnv, "/etc/claude-loop/org.yaml")
+	path, explicit := UserPrinciplesPath()
+	assert.Equal(t, "/etc/claude-loop/org.yaml", path)
+	assert.True(t, explicit)
+
+	t.Setenv(UserPrinciplesEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", "/home/dev/.config")
+	path, explicit = UserPrinciplesPath()
+	assert.False(t, explicit)
+	assert.True(t, strings.HasSuffix(path, filepath.Join("claude-loop", "principles.yaml")), path)
+}
+
+func TestMergePrinciples(t *testing.T) {
+	org := &Principles{
+		Layer1:    Layer1{SecurityPosture: 8, CostEfficiency: 9},
+		Protected: &ProtectedPaths{Paths: []string{".github/**"}},
+		Locked:    []string{"security_posture"},
+	}
+	repo := DefaultPrinciples(PresetStartup)
+	repo.CreatedAt = "2026-01-11"
+	repo.Protected = &ProtectedPaths{Paths: []string{"migrations/**"}, Revert: RevertIteration}
+
+	t.Run("higher layers win and record their source", func(t *testing.T) {
+		cli, err := ParsePrincipleAssignments([]string{"speed_correctness=2"})
+		require.NoError(t, err)
+
+		eff, err := MergePrinciples(
+			PrincipleLayer{Source: SourceUser, Path: "org.yaml", Principles: org},
+			PrincipleLayer{Source: SourceRepository, Path: ".claude/principles.yaml", Principles: repo},
+			PrincipleLayer{Source: SourceCLI, Path: "--principle", Principles: cli},
+		)
+		require.NoError(t, err)
+
+		p := eff.Principles
+		assert.Equal(t, 2, p.Layer1.SpeedCorrectness)
+		assert.Equal(t, repo.Layer1.CostEfficiency, p.Layer1.CostEfficiency)
+		assert.Equal(t, repo.Preset, p.Preset)
+		assert.Equal(t, []string{".github/**", "migrations/**"}, p.Protected.Paths)
+		assert.Equal(t, RevertIteration, p.Protected.Revert)
+		assert.Equal(t, []string{"layer1.security_posture"}, p.Locked)
+		assert.Len(t, eff.Layers, 3)
+
+		assert.Equal(t, ValueSource{Source: SourceCLI, Path: "--principle"}, eff.Sources["layer1.speed_correctness"])
+		assert.Equal(t, SourceRepository, eff.Sources["layer1.cost_efficiency"].Source)
+	})
+
+	t.Run("locked floor ignores lower values", func(t *testing.T) {
+		cli, err := ParsePrincipleAssignments([]string{"security_posture=3"})
+		require.NoError(t, err)
+
+		eff, err := MergePrinciples(
+			PrincipleLayer{Source: SourceUser, Path: "org.yaml", Principles: org},
+			PrincipleLayer{Source: SourceRepository, Path: ".claude/principles.yaml", Principles: repo},
+			PrincipleLayer{Source: SourceCLI, Path: "--principle", Principles: cli},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, 8, eff.Principles.Layer1.SecurityPosture)
+		assert.Equal(t, ValueSource{Source: SourceUser, Path: "org.yaml", Floor: 8, LockedBy: SourceUser},
+			eff.Sources["layer1.security_posture"])
+		require.Len(t, eff.Notes, 2)
+		assert.Contains(t, eff.Notes[0], "layer1.security_posture: repository value 7 ignored; user locks it at 8 or above")
+		assert.Contains(t, eff.Notes[1], "cli value 3 ignored")
+	})
+
+	t.Run("locked floor can be raised", func(t *testing.T) {
+		raised := DefaultPrinciples(PresetEnterprise)
+		eff, err := MergePrinciples(
+			PrincipleLayer{Source: SourceUser, Principles: org},
+			PrincipleLayer{Source: SourceRepository, Principles: raised},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, raised.Layer1.SecurityPosture, eff.Principles.Layer1.SecurityPosture)
+		assert.Empty(t, eff.Notes)
+	})
+
+	t.Run("missing values fail validation", func(t *testing.T) {
+		_, err := MergePrinciples(PrincipleLayer{Source: SourceUser, Principles: org})
+		require.Error(t, err)
+		assert.True(t, IsValidationError(err))
+	})
+
+	t.Run("locked key without a value", func(t *testing.T) {
+		_, err := MergePrinciples(PrincipleLayer{Source: SourceUser, Path: "org.yaml",
+			Principles: &Principles{Locked: []string{"blast_radius"}}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "locked key layer1.blast_radius has no value")
+	})
+
+	t.Run("rules from higher layers come first", func(t *testing.T) {
+		orgRules := &Principles{Rules: []ResolutionRule{
+			{ID: "org-1", When: RuleCondition{Types: []ConflictType{ConflictObjectives}}, Prefer: PreferHigher},
+			{ID: "shared", When: RuleCondition{Types: []ConflictType{ConflictConstraints}}, Prefer: PreferHigher},
+		}}
+		withRules := *repo
+		withRules.Rules = []ResolutionRule{{ID: "shared", When: RuleCondition{Types: []ConflictType{ConflictConstraints}}, Prefer: PreferPriority}}
+
+		eff, err := MergePrinciples(
+			PrincipleLayer{Source: SourceUser, Principles: orgRules},
+			PrincipleLayer{Source: SourceRepository, Principles: &withRules},
+		)
+		require.NoError(t, err)
+		require.Len(t, eff.Principles.Rules, 2)
+		assert.Equal(t, "shared", eff.Principles.Rules[0].ID)
+		assert.Equal(t, PreferPriority, eff.Principles.Rules[0].Prefer)
+		assert.Equal(t, "org-1", eff.Principles.Rules[1].ID)
+	})
+
+	t.Run("nil layers are skipped", func(t *testing.T) {
+		eff, err := MergePrinciples(PrincipleLayer{Source: SourceUser}, PrincipleLayer{Source: SourceRepository, Principles: repo})
+		require.NoError(t, err)
+		assert.Len(t, eff.Layers, 1)
+		assert.Equal(t, repo.Layer0, eff.Principles.Layer0)
+	})
+}
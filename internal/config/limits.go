@@ -0,0 +1,54 @@
+package config
+
+// blastRadiusLimits maps layer1.blast_radius (index) to the files and lines an
+// iteration may change. 1 allows sweeping changes; 10 allows only small ones.
+var blastRadiusLimits = [...]struct{ files, lines int }{
+	1:  {0, 0},
+	2:  {200, 10000},
+	3:  {100, 5000},
+	4:  {60, 3000},
+	5:  {40, 2000},
+	6:  {25, 1200},
+	7:  {15, 800},
+	8:  {10, 500},
+	9:  {8, 300},
+	10: {5, 150},
+}
+
+// BlastRadiusLimits returns the maximum files and lines changed per iteration for a
+// blast_radius value. Zero means unlimited, as does a value outside 1-10.
+func BlastRadiusLimits(radius int) (maxFiles, maxLines int) {
+	if radius < 1 || radius >= len(blastRadiusLimits) {
+		return 0, 0
+	}
+	l := blastRadiusLimits[radius]
+	return l.files, l.lines
+}
+
+// EffectiveChangeLimits returns the change limits enforced per iteration: the limits
+// derived from layer1.blast_radius with change_limits applied on top.
+// Zero limits in the result are unlimited, and OnExceed is always set.
+func (p *Principles) EffectiveChangeLimits() ChangeLimits {
+	limits := ChangeLimits{OnExceed: ExceedSplit}
+	limits.MaxFiles, limits.MaxLines = BlastRadiusLimits(p.Layer1.BlastRadius)
+	if o := p.ChangeLimits; o != nil {
+		limits.MaxFiles = overrideLimit(limits.MaxFiles, o.MaxFiles)
+		limits.MaxLines = overrideLimit(limits.MaxLines, o.MaxLines)
+		if o.OnExceed != "" {
+			limits.OnExceed = o.OnExceed
+		}
+	}
+	return limits
+}
+
+// overrideLimit applies a change_limits value: 0 keeps derived, -1 removes the limit.
+func overrideLimit(derived, override int) int {
+	switch {
+	case override < 0:
+		return 0
+	case override > 0:
+		return override
+	default:
+		return derived
+	}
+}
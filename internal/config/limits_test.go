@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlastRadiusLimits(t *testing.T) {
+	tests := []struct {
+		radius    int
+		wantFiles int
+		wantLines int
+	}{
+		{0, 0, 0},
+		{1, 0, 0},
+		{5, 40, 2000},
+		{9, 8, 300},
+		{10, 5, 150},
+		{11, 0, 0},
+	}
+	for _, tt := range tests {
+		files, lines := BlastRadiusLimits(tt.radius)
+		assert.Equal(t, tt.wantFiles, files, "radius %d", tt.radius)
+		assert.Equal(t, tt.wantLines, lines, "radius %d", tt.radius)
+	}
+}
+
+func TestBlastRadiusLimits_ShrinkAsRadiusGrows(t *testing.T) {
+	prevFiles, prevLines := BlastRadiusLimits(2)
+	for radius := 3; radius <= MaxPrincipleValue; radius++ {
+		files, lines := BlastRadiusLimits(radius)
+		assert.Less(t, files, prevFiles, "radius %d", radius)
+		assert.Less(t, lines, prevLines, "radius %d", radius)
+		prevFiles, prevLines = files, lines
+	}
+}
+
+func TestEffectiveChangeLimits(t *testing.T) {
+	t.Run("derived from blast_radius", func(t *testing.T) {
+		p := DefaultPrinciples(PresetEnterprise)
+		assert.Equal(t, ChangeLimits{MaxFiles: 8, MaxLines: 300, OnExceed: ExceedSplit}, p.EffectiveChangeLimits())
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		p := DefaultPrinciples(PresetEnterprise)
+		p.ChangeLimits = &ChangeLimits{MaxLines: 1000, OnExceed: ExceedRevert}
+		assert.Equal(t, ChangeLimits{MaxFiles: 8, MaxLines: 1000, OnExceed: ExceedRevert}, p.EffectiveChangeLimits())
+
+		p.ChangeLimits = &ChangeLimits{MaxFiles: -1}
+		assert.Equal(t, ChangeLimits{MaxLines: 300, OnExceed: ExceedSplit}, p.EffectiveChangeLimits())
+	})
+}
@@ -0,0 +1,324 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Migration upgrades a principles document from one schema version to the next.
+// Apply edits the document's top-level mapping in place and returns a description
+// of each change.
+type Migration struct {
+	From        string
+	To          string
+	Description string
+	Apply       func(doc *yaml.Node) ([]string, error)
+}
+
+// Migrations is the registry of schema upgrades, oldest first. Each migration's To
+// is the next one's From, and the last To is DefaultVersion.
+var Migrations = []Migration{
+	{
+		From:        "1.0",
+		To:          "2.0",
+		Description: "principle values move from a 1-5 to a 1-10 scale",
+		Apply:       rescalePrinciples,
+	},
+	{
+		From:        "2.0",
+		To:          DefaultVersion,
+		Description: "layer1.migration_burden is added",
+		Apply:       addMigrationBurden,
+	},
+}
+
+// MigrationError represents a failure to migrate a principles file.
+type MigrationError struct {
+	Path    string
+	Version string
+	Message string
+	Err     error
+}
+
+func (e *MigrationError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Path, e.Message)
+	if e.Version != "" {
+		msg = fmt.Sprintf("%s (schema %s): %s", e.Path, e.Version, e.Message)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *MigrationError) Unwrap() error {
+	return e.Err
+}
+
+// IsMigrationError checks if an error is a MigrationError.
+func IsMigrationError(err error) bool {
+	var me *MigrationError
+	return errors.As(err, &me)
+}
+
+// MigrationStep is one applied migration and what it changed.
+type MigrationStep struct {
+	From    string
+	To      string
+	Changes []string
+}
+
+// MigrationResult reports how a principles document was migrated.
+type MigrationResult struct {
+	Path  string
+	From  string          // Schema version before migration
+	To    string          // Schema version after migration
+	Steps []MigrationStep // Applied migrations, oldest first (empty when already current)
+	Data  []byte          // Migrated YAML, comments preserved
+	// Backup is the copy of the original file written by MigrateFile (empty on dry run
+	// or when nothing changed).
+	Backup string
+}
+
+// Changed reports whether any migration was applied.
+func (r *MigrationResult) Changed() bool {
+	return len(r.Steps) > 0
+}
+
+// NeedsMigration reports whether a schema version is older than DefaultVersion.
+// Unparseable versions are left to validation.
+func NeedsMigration(version string) bool {
+	cmp, ok := compareVersions(version, DefaultVersion)
+	return ok && cmp < 0
+}
+
+// Migrate upgrades a principles document to DefaultVersion.
+// path is used in errors only. Documents from a newer schema, or from a version
+// with no migration path, return a *MigrationError.
+func Migrate(data []byte, path string) (*MigrationResult, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, &MigrationError{Path: path, Message: "invalid YAML syntax", Err: err}
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, &MigrationError{Path: path, Message: "principles file must be a YAML mapping"}
+	}
+	root := doc.Content[0]
+
+	versionNode := mappingValue(root, "version")
+	if versionNode == nil || versionNode.Value == "" {
+		return nil, &MigrationError{Path: path, Message: "version is missing"}
+	}
+	result := &MigrationResult{Path: path, From: versionNode.Value, To: versionNode.Value, Data: data}
+
+	cmp, ok := compareVersions(result.From, DefaultVersion)
+	switch {
+	case !ok:
+		return nil, &MigrationError{Path: path, Version: result.From, Message: "version must be in X.Y format"}
+	case cmp > 0:
+		return nil, &MigrationError{Path: path, Version: result.From,
+			Message: fmt.Sprintf("written for a newer claude-loop (this version supports up to %s); upgrade claude-loop", DefaultVersion)}
+	case cmp == 0:
+		return result, nil
+	}
+
+	for result.To != DefaultVersion {
+		m := findMigration(result.To)
+		if m == nil {
+			return nil, &MigrationError{Path: path, Version: result.To, Message: "no migration path to " + DefaultVersion}
+		}
+		changes, err := m.Apply(root)
+		if err != nil {
+			return nil, &MigrationError{Path: path, Version: m.From, Message: "migration to " + m.To + " failed", Err: err}
+		}
+		result.Steps = append(result.Steps, MigrationStep{From: m.From, To: m.To, Changes: changes})
+		result.To = m.To
+	}
+	versionNode.Value = result.To
+	versionNode.Tag = "!!str"
+	versionNode.Style = yaml.DoubleQuotedStyle
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, &MigrationError{Path: path, Message: "failed to write migrated YAML", Err: err}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, &MigrationError{Path: path, Message: "failed to write migrated YAML", Err: err}
+	}
+	result.Data = buf.Bytes()
+	return result, nil
+}
+
+// MigrateFile upgrades the principles file at path to DefaultVersion. Unless dryRun is
+// set, the original is copied to BackupPath(path, from) and the file is rewritten.
+// A file that is already current is left untouched.
+func MigrateFile(path string, dryRun bool) (*MigrationResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &MigrationError{Path: path, Message: "failed to read file", Err: err}
+	}
+	result, err := Migrate(data, path)
+	if err != nil || !result.Changed() || dryRun {
+		return result, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, &MigrationError{Path: path, Message: "failed to read file", Err: err}
+	}
+	backup := BackupPath(path, result.From)
+	if err := os.WriteFile(backup, data, info.Mode().Perm()); err != nil {
+		return nil, &MigrationError{Path: path, Message: "failed to write backup", Err: err}
+	}
+	if err := os.WriteFile(path, result.Data, info.Mode().Perm()); err != nil {
+		return nil, &MigrationError{Path: path, Message: "failed to write migrated file", Err: err}
+	}
+	result.Backup = backup
+	return result, nil
+}
+
+// BackupPath returns where MigrateFile keeps the original of a file at a schema version,
+// e.g. ".claude/principles.yaml.v2.0.bak".
+func BackupPath(path, version string) string {
+	return fmt.Sprintf("%s.v%s.bak", path, version)
+}
+
+// LoadAndMigrate loads a principles file, migrating it in memory when it uses an older
+// schema. The file itself is not changed; the returned result reports what would change.
+func LoadAndMigrate(path string) (*Principles, *MigrationResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, &LoadError{Path: path, Message: "file not found", Err: err}
+		}
+		return nil, nil, &LoadError{Path: path, Message: "failed to read file", Err: err}
+	}
+	p, err := LoadFromBytes(data, path)
+	if err != nil || !NeedsMigration(p.Version) {
+		return p, nil, err
+	}
+	result, err := Migrate(data, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err = LoadFromBytes(result.Data, path)
+	return p, result, err
+}
+
+// findMigration returns the migration from version, or nil.
+func findMigration(version string) *Migration {
+	for i := range Migrations {
+		if Migrations[i].From == version {
+			return &Migrations[i]
+		}
+	}
+	return nil
+}
+
+// compareVersions compares two "X.Y" versions numerically.
+// ok is false when either version is not in X.Y format.
+func compareVersions(a, b string) (cmp int, ok bool) {
+	am, an, aok := parseVersion(a)
+	bm, bn, bok := parseVersion(b)
+	if !aok || !bok {
+		return 0, false
+	}
+	switch {
+	case am != bm:
+		return sign(am - bm), true
+	default:
+		return sign(an - bn), true
+	}
+}
+
+func parseVersion(v string) (major, minor int, ok bool) {
+	if !versionRegex.MatchString(v) {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(v, ".", 2)
+	major, _ = strconv.Atoi(parts[0])
+	minor, _ = strconv.Atoi(parts[1])
+	return major, minor, true
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// mappingValue returns the value for key in a mapping node, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// rescalePrinciples maps layer0 and layer1 values from 1-5 to 1-10, keeping both ends.
+func rescalePrinciples(root *yaml.Node) ([]string, error) {
+	var changes []string
+	for _, layer := range []string{"layer0", "layer1"} {
+		m := mappingValue(root, layer)
+		if m == nil {
+			continue
+		}
+		if m.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s must be a mapping", layer)
+		}
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			key, value := m.Content[i].Value, m.Content[i+1]
+			old, err := strconv.Atoi(value.Value)
+			if err != nil || old < 1 || old > 5 {
+				return nil, fmt.Errorf("%s.%s must be 1-5 (got %q)", layer, key, value.Value)
+			}
+			scaled := int(math.Round(1 + float64(old-1)*9/4))
+			value.Value = strconv.Itoa(scaled)
+			changes = append(changes, fmt.Sprintf("%s.%s: %d -> %d", layer, key, old, scaled))
+		}
+	}
+	return changes, nil
+}
+
+// addMigrationBurden adds layer1.migration_burden with the preset's default.
+func addMigrationBurden(root *yaml.Node) ([]string, error) {
+	layer1 := mappingValue(root, "layer1")
+	if layer1 == nil || mappingValue(layer1, "migration_burden") != nil {
+		return nil, nil
+	}
+	if layer1.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("layer1 must be a mapping")
+	}
+	preset := Preset("")
+	if node := mappingValue(root, "preset"); node != nil {
+		preset = Preset(node.Value)
+	}
+	value, source := 5, "neutral default"
+	if isBuiltinPreset(preset) {
+		value, source = DefaultPrinciples(preset).Layer1.MigrationBurden, string(preset)+" default"
+	}
+	layer1.Content = append(layer1.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "migration_burden"},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(value)},
+	)
+	return []string{fmt.Sprintf("layer1.migration_burden: added as %d (%s)", value, source)}, nil
+}
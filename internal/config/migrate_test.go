@@ -0,0 +1,207 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const principlesV20 = `# Team principles
+version: "2.0"
+preset: enterprise
+created_at: "2025-03-01"
+
+layer0:
+  trust_architecture: 9   # Strict
+  curation_model: 8
+  scope_philosophy: 5
+  monetization_model: 8
+  privacy_posture: 9
+  ux_philosophy: 6
+  authority_stance: 7
+  auditability: 9
+  interoperability: 6
+
+layer1:
+  speed_correctness: 8
+  innovation_stability: 9
+  blast_radius: 9
+  clarity_of_intent: 8
+  reversibility_priority: 9
+  security_posture: 9
+  urgency_tiers: 6
+  cost_efficiency: 5
+`
+
+const principlesV10 = `version: "1.0"
+preset: custom
+created_at: "2024-06-01"
+layer0:
+  trust_architecture: 1
+  curation_model: 2
+  scope_philosophy: 3
+  monetization_model: 4
+  privacy_posture: 5
+  ux_philosophy: 3
+  authority_stance: 3
+  auditability: 3
+  interoperability: 3
+layer1:
+  speed_correctness: 3
+  innovation_stability: 3
+  blast_radius: 3
+  clarity_of_intent: 3
+  reversibility_priority: 3
+  security_posture: 3
+  urgency_tiers: 3
+  cost_efficiency: 3
+`
+
+func TestMigrations_FormAChain(t *testing.T) {
+	require.NotEmpty(t, Migrations)
+	for i := 1; i < len(Migrations); i++ {
+		assert.Equal(t, Migrations[i-1].To, Migrations[i].From)
+	}
+	assert.Equal(t, DefaultVersion, Migrations[len(Migrations)-1].To)
+}
+
+func TestMigrate(t *testing.T) {
+	t.Run("adds migration_burden and keeps comments", func(t *testing.T) {
+		result, err := Migrate([]byte(principlesV20), "principles.yaml")
+		require.NoError(t, err)
+
+		assert.True(t, result.Changed())
+		assert.Equal(t, "2.0", result.From)
+		assert.Equal(t, DefaultVersion, result.To)
+		require.Len(t, result.Steps, 1)
+		assert.Equal(t, []string{"layer1.migration_burden: added as 7 (enterprise default)"}, result.Steps[0].Changes)
+
+		out := string(result.Data)
+		assert.Contains(t, out, "# Team principles")
+		assert.Contains(t, out, "trust_architecture: 9 # Strict")
+		assert.Contains(t, out, `version: "`+DefaultVersion+`"`)
+		assert.Contains(t, out, "\n  migration_burden: 7\n")
+
+		p, err := LoadFromBytes(result.Data, "principles.yaml")
+		require.NoError(t, err)
+		assert.NoError(t, p.Validate())
+	})
+
+	t.Run("rescales 1.0 values", func(t *testing.T) {
+		result, err := Migrate([]byte(principlesV10), "principles.yaml")
+		require.NoError(t, err)
+		require.Len(t, result.Steps, 2)
+		assert.Contains(t, result.Steps[0].Changes, "layer0.trust_architecture: 1 -> 1")
+		assert.Contains(t, result.Steps[0].Changes, "layer0.curation_model: 2 -> 3")
+		assert.Contains(t, result.Steps[0].Changes, "layer0.scope_philosophy: 3 -> 6")
+		assert.Contains(t, result.Steps[0].Changes, "layer0.privacy_posture: 5 -> 10")
+		assert.Equal(t, []string{"layer1.migration_burden: added as 5 (neutral default)"}, result.Steps[1].Changes)
+
+		p, err := LoadFromBytes(result.Data, "principles.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, 8, p.Layer0.MonetizationModel)
+		assert.NoError(t, p.Validate())
+	})
+
+	t.Run("current version is unchanged", func(t *testing.T) {
+		data := []byte(strings.Replace(principlesV20, `"2.0"`, `"`+DefaultVersion+`"`, 1))
+		result, err := Migrate(data, "principles.yaml")
+		require.NoError(t, err)
+		assert.False(t, result.Changed())
+		assert.Equal(t, data, result.Data)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name, data, wantErr string
+		}{
+			{"newer schema", `version: "9.0"`, "written for a newer claude-loop"},
+			{"no path", `version: "1.5"`, "(schema 1.5): no migration path"},
+			{"missing version", `preset: startup`, "version is missing"},
+			{"bad version", `version: "two"`, "version must be in X.Y format"},
+			{"out of range", "version: \"1.0\"\nlayer0:\n  trust_architecture: 7\n", "layer0.trust_architecture must be 1-5"},
+			{"not a mapping", "- a\n- b\n", "must be a YAML mapping"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := Migrate([]byte(tt.data), "p.yaml")
+				require.Error(t, err)
+				assert.True(t, IsMigrationError(err))
+				assert.Contains(t, err.Error(), tt.wantErr)
+			})
+		}
+	})
+}
+
+func TestMigrateFile(t *testing.T) {
+	t.Run("writes backup and migrated file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(principlesV20), 0644))
+
+		result, err := MigrateFile(path, false)
+		require.NoError(t, err)
+		assert.Equal(t, BackupPath(path, "2.0"), result.Backup)
+
+		backup, err := os.ReadFile(result.Backup)
+		require.NoError(t, err)
+		assert.Equal(t, principlesV20, string(backup))
+
+		p, err := LoadFromFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultVersion, p.Version)
+		assert.Equal(t, 7, p.Layer1.MigrationBurden)
+	})
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "principles.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(principlesV20), 0644))
+
+		result, err := MigrateFile(path, true)
+		require.NoError(t, err)
+		assert.True(t, result.Changed())
+		assert.Empty(t, result.Backup)
+		assert.NoFileExists(t, BackupPath(path, "2.0"))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, principlesV20, string(data))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := MigrateFile(filepath.Join(t.TempDir(), "missing.yaml"), false)
+		assert.True(t, IsMigrationError(err))
+	})
+}
+
+func TestLoadAndMigrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "principles.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(principlesV20), 0644))
+
+	p, result, err := LoadAndMigrate(path)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, "2.0", result.From)
+	assert.Equal(t, DefaultVersion, p.Version)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, principlesV20, string(data), "the file is not rewritten")
+
+	current := filepath.Join(t.TempDir(), "current.yaml")
+	require.NoError(t, SaveToFile(current, DefaultPrinciples(PresetStartup)))
+	_, result, err = LoadAndMigrate(current)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestNeedsMigration(t *testing.T) {
+	assert.True(t, NeedsMigration("1.0"))
+	assert.True(t, NeedsMigration("2.0"))
+	assert.False(t, NeedsMigration(DefaultVersion))
+	assert.False(t, NeedsMigration("2.10"))
+	assert.False(t, NeedsMigration("bad"))
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// VerificationLevel sets how thoroughly work is checked before it is committed.
+type VerificationLevel string
+
+const (
+	// VerificationRelaxed accepts a passing build and the tests for the changed code.
+	VerificationRelaxed VerificationLevel = "relaxed"
+	// VerificationStandard leaves verification to the agent's judgment (default).
+	VerificationStandard VerificationLevel = "standard"
+	// VerificationStrict requires the full build, linters and test suite to pass.
+	VerificationStrict VerificationLevel = "strict"
+)
+
+// VerificationLevels lists the valid verification levels.
+var VerificationLevels = []VerificationLevel{VerificationRelaxed, VerificationStandard, VerificationStrict}
+
+// CheaperModel is the model selected for reviewer and council calls when
+// cost_efficiency calls for saving money.
+const CheaperModel = "haiku"
+
+// Policy thresholds on principle values.
+const (
+	policyStrictSecurity     = 8 // security_posture at or above: reviewer and strict verification
+	policyRelaxedSpeed       = 3 // speed_correctness at or below: relaxed verification
+	policyDraftReversibility = 7 // reversibility_priority at or above: draft PRs, no direct pushes
+	policyCheaperCost        = 8 // cost_efficiency at or above: cheaper reviewer and council models
+)
+
+// Policy setting names, used as keys in Policy.Reasons.
+const (
+	SettingReview        = "review"
+	SettingVerification  = "verification"
+	SettingDraftPRs      = "draft_prs"
+	SettingDirectPush    = "direct_push"
+	SettingReviewerModel = "reviewer_model"
+	SettingCouncilModel  = "council_model"
+)
+
+// PolicySettings lists the setting names in display order.
+var PolicySettings = []string{
+	SettingReview, SettingVerification, SettingDraftPRs,
+	SettingDirectPush, SettingReviewerModel, SettingCouncilModel,
+}
+
+// Policy holds the runtime settings derived from principles.
+type Policy struct {
+	RequireReview bool              // Run a reviewer pass after each iteration
+	Verification  VerificationLevel // How thoroughly work is checked
+	DraftPRs      bool              // Open pull requests as drafts
+	DirectPush    bool              // Allow pushing directly to the working branch without a pull request
+	ReviewerModel string            // Model for reviewer passes (empty = agent default)
+	CouncilModel  string            // Model for council resolution (empty = agent default)
+
+	// Reasons maps a setting name to what decided it, such as "security_posture=9"
+	// or "--draft-prs". Settings left at their defaults have no reason.
+	Reasons map[string]string
+}
+
+// DefaultPolicy returns the policy used without principles.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Verification: VerificationStandard,
+		DirectPush:   true,
+		Reasons:      make(map[string]string),
+	}
+}
+
+// DerivePolicy derives the runtime policy from principles.
+// A nil Principles returns DefaultPolicy. When rules disagree, security wins over speed.
+func DerivePolicy(p *Principles) *Policy {
+	policy := DefaultPolicy()
+	if p == nil {
+		return policy
+	}
+	l := p.Layer1
+
+	if l.SpeedCorrectness <= policyRelaxedSpeed {
+		policy.Verification = VerificationRelaxed
+		policy.Reasons[SettingVerification] = principleReason("speed_correctness", l.SpeedCorrectness)
+	}
+	if l.SecurityPosture >= policyStrictSecurity {
+		reason := principleReason("security_posture", l.SecurityPosture)
+		policy.RequireReview = true
+		policy.Reasons[SettingReview] = reason
+		policy.Verification = VerificationStrict
+		policy.Reasons[SettingVerification] = reason
+	}
+	if l.ReversibilityPriority >= policyDraftReversibility {
+		reason := principleReason("reversibility_priority", l.ReversibilityPriority)
+		policy.DraftPRs = true
+		policy.Reasons[SettingDraftPRs] = reason
+		policy.DirectPush = false
+		policy.Reasons[SettingDirectPush] = reason
+	}
+	if l.CostEfficiency >= policyCheaperCost {
+		reason := principleReason("cost_efficiency", l.CostEfficiency)
+		policy.ReviewerModel = CheaperModel
+		policy.Reasons[SettingReviewerModel] = reason
+		policy.CouncilModel = CheaperModel
+		policy.Reasons[SettingCouncilModel] = reason
+	}
+	return policy
+}
+
+// Value returns a setting's value for display.
+func (p *Policy) Value(setting string) string {
+	switch setting {
+	case SettingReview:
+		return onOff(p.RequireReview, "required", "optional")
+	case SettingVerification:
+		return string(p.Verification)
+	case SettingDraftPRs:
+		return onOff(p.DraftPRs, "yes", "no")
+	case SettingDirectPush:
+		return onOff(p.DirectPush, "allowed", "pull requests only")
+	case SettingReviewerModel:
+		return modelName(p.ReviewerModel)
+	case SettingCouncilModel:
+		return modelName(p.CouncilModel)
+	default:
+		return ""
+	}
+}
+
+// Summary returns one line per setting, such as "review: required (security_posture=9)".
+func (p *Policy) Summary() []string {
+	lines := make([]string, 0, len(PolicySettings))
+	for _, setting := range PolicySettings {
+		line := fmt.Sprintf("%s: %s", setting, p.Value(setting))
+		if reason := p.Reasons[setting]; reason != "" {
+			line += " (" + reason + ")"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// IsValidVerificationLevel checks if a verification level is valid.
+func IsValidVerificationLevel(v VerificationLevel) bool {
+	for _, valid := range VerificationLevels {
+		if v == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func principleReason(name string, value int) string {
+	return name + "=" + strconv.Itoa(value)
+}
+
+func onOff(on bool, yes, no string) string {
+	if on {
+		return yes
+	}
+	return no
+}
+
+func modelName(model string) string {
+	if model == "" {
+		return "default"
+	}
+	return model
+}
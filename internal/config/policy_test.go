@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDerivePolicy(t *testing.T) {
+	t.Run("nil principles use defaults", func(t *testing.T) {
+		policy := DerivePolicy(nil)
+		assert.Equal(t, DefaultPolicy(), policy)
+		assert.False(t, policy.RequireReview)
+		assert.Equal(t, VerificationStandard, policy.Verification)
+		assert.True(t, policy.DirectPush)
+	})
+
+	t.Run("enterprise preset", func(t *testing.T) {
+		policy := DerivePolicy(DefaultPrinciples(PresetEnterprise))
+		assert.True(t, policy.RequireReview)
+		assert.Equal(t, VerificationStrict, policy.Verification)
+		assert.True(t, policy.DraftPRs)
+		assert.False(t, policy.DirectPush)
+		assert.Empty(t, policy.ReviewerModel, "cost_efficiency 5 keeps the default model")
+		assert.Equal(t, "security_posture=9", policy.Reasons[SettingReview])
+		assert.Equal(t, "reversibility_priority=9", policy.Reasons[SettingDirectPush])
+	})
+
+	t.Run("cost efficiency selects cheaper models", func(t *testing.T) {
+		p := DefaultPrinciples(PresetStartup)
+		p.Layer1.CostEfficiency = 9
+		policy := DerivePolicy(p)
+		assert.Equal(t, CheaperModel, policy.ReviewerModel)
+		assert.Equal(t, CheaperModel, policy.CouncilModel)
+		assert.Equal(t, "cost_efficiency=9", policy.Reasons[SettingCouncilModel])
+	})
+
+	t.Run("speed first relaxes verification", func(t *testing.T) {
+		p := DefaultPrinciples(PresetStartup)
+		p.Layer1.SpeedCorrectness = 2
+		policy := DerivePolicy(p)
+		assert.Equal(t, VerificationRelaxed, policy.Verification)
+		assert.Equal(t, "speed_correctness=2", policy.Reasons[SettingVerification])
+	})
+
+	t.Run("security wins over speed", func(t *testing.T) {
+		p := DefaultPrinciples(PresetStartup)
+		p.Layer1.SpeedCorrectness = 2
+		p.Layer1.SecurityPosture = 8
+		policy := DerivePolicy(p)
+		assert.Equal(t, VerificationStrict, policy.Verification)
+		assert.Equal(t, "security_posture=8", policy.Reasons[SettingVerification])
+	})
+}
+
+func TestPolicy_Summary(t *testing.T) {
+	assert.Equal(t, []string{
+		"review: required (security_posture=9)",
+		"verification: strict (security_posture=9)",
+		"draft_prs: yes (reversibility_priority=9)",
+		"direct_push: pull requests only (reversibility_priority=9)",
+		"reviewer_model: default",
+		"council_model: default",
+	}, DerivePolicy(DefaultPrinciples(PresetEnterprise)).Summary())
+}
+
+func TestIsValidVerificationLevel(t *testing.T) {
+	assert.True(t, IsValidVerificationLevel(VerificationStrict))
+	assert.False(t, IsValidVerificationLevel("paranoid"))
+	assert.False(t, IsValidVerificationLevel(""))
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PresetsPathEnv lists extra preset directories, separated like PATH, such as a
+// checkout of a team-wide presets repository.
+const PresetsPathEnv = "CLAUDE_LOOP_PRESETS_PATH"
+
+// BuiltinPresets are the presets with defaults defined in defaults.go.
+var BuiltinPresets = []Preset{PresetStartup, PresetEnterprise, PresetOpenSource}
+
+var presetNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// PresetDefinition is a preset loaded from YAML: a built-in preset with some
+// principles changed. Unset (zero) values inherit from Extends.
+type PresetDefinition struct {
+	Name        Preset `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Extends     Preset `yaml:"extends"`
+	Layer0      Layer0 `yaml:"layer0,omitempty"`
+	Layer1      Layer1 `yaml:"layer1,omitempty"`
+
+	Path string `yaml:"-"` // File the definition was loaded from
+}
+
+// Validate checks the definition's name, base preset and values.
+func (d *PresetDefinition) Validate() error {
+	switch {
+	case d.Name == "":
+		return &ValidationError{Field: "name", Message: "preset name is required"}
+	case !presetNameRegex.MatchString(string(d.Name)):
+		return &ValidationError{Field: "name",
+			Message: fmt.Sprintf("preset name %q must be lowercase letters, digits, '-' or '_'", d.Name)}
+	case isBuiltinPreset(d.Name) || d.Name == PresetCustom:
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("preset name %q is reserved", d.Name)}
+	case !isBuiltinPreset(d.Extends):
+		return &ValidationError{Field: "extends",
+			Message: fmt.Sprintf("preset %s: extends must be one of: startup, enterprise, opensource (got %q)", d.Name, d.Extends)}
+	}
+	p := Principles{Layer0: d.Layer0, Layer1: d.Layer1}
+	for _, f := range p.principleFields() {
+		if *f.value == 0 {
+			continue
+		}
+		if err := validatePrincipleValue(f.name, *f.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Principles returns the preset's full principles: the base preset's defaults with
+// the definition's values applied. CreatedAt is not set.
+func (d *PresetDefinition) Principles() *Principles {
+	p := DefaultPrinciples(d.Extends)
+	override := Principles{Layer0: d.Layer0, Layer1: d.Layer1}
+	fields := p.principleFields()
+	for i, f := range override.principleFields() {
+		if *f.value != 0 {
+			*fields[i].value = *f.value
+		}
+	}
+	p.Preset = d.Name
+	return p
+}
+
+// PresetRegistry holds custom preset definitions, keyed by name. A nil registry
+// knows only the built-in presets.
+type PresetRegistry struct {
+	defs map[Preset]*PresetDefinition
+}
+
+// NewPresetRegistry creates an empty registry.
+func NewPresetRegistry() *PresetRegistry {
+	return &PresetRegistry{defs: make(map[Preset]*PresetDefinition)}
+}
+
+// Register validates a definition and makes its name a preset with defaults. A
+// definition with the same name replaces the earlier one.
+func (r *PresetRegistry) Register(d *PresetDefinition) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	r.defs[d.Name] = d
+	return nil
+}
+
+// Get returns the registered definition for name.
+func (r *PresetRegistry) Get(name Preset) (*PresetDefinition, bool) {
+	if r == nil {
+		return nil, false
+	}
+	d, ok := r.defs[name]
+	return d, ok
+}
+
+// Definitions returns the registered definitions sorted by name.
+func (r *PresetRegistry) Definitions() []*PresetDefinition {
+	if r == nil {
+		return nil
+	}
+	defs := make([]*PresetDefinition, 0, len(r.defs))
+	for _, d := range r.defs {
+		defs = append(defs, d)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// Names returns every preset with defaults: the built-in presets, then the
+// registered ones by name.
+func (r *PresetRegistry) Names() []Preset {
+	names := append([]Preset{}, BuiltinPresets...)
+	for _, d := range r.Definitions() {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+// HasDefaults reports whether preset is a built-in or registered preset, that is,
+// one Defaults knows. "custom" has no defaults.
+func (r *PresetRegistry) HasDefaults(preset Preset) bool {
+	_, ok := r.Get(preset)
+	return ok || isBuiltinPreset(preset)
+}
+
+// Base returns the built-in preset a preset extends, or preset itself.
+func (r *PresetRegistry) Base(preset Preset) Preset {
+	if d, ok := r.Get(preset); ok {
+		return d.Extends
+	}
+	return preset
+}
+
+// Defaults returns the default principles for preset: a registered definition's
+// principles, or DefaultPrinciples for anything else.
+func (r *PresetRegistry) Defaults(preset Preset) *Principles {
+	if d, ok := r.Get(preset); ok {
+		return d.Principles()
+	}
+	return DefaultPrinciples(preset)
+}
+
+// PresetDirs returns where preset definitions are looked up, highest precedence
+// first: the repository's .claude/presets, each $CLAUDE_LOOP_PRESETS_PATH entry,
+// then the user config directory.
+func PresetDirs(repoDir string) []string {
+	dirs := []string{filepath.Join(repoDir, ".claude", "presets")}
+	for _, dir := range filepath.SplitList(os.Getenv(PresetsPathEnv)) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(dir, "claude-loop", "presets"))
+	}
+	return dirs
+}
+
+// LoadPresetFile reads a preset definition. A missing name defaults to the file name.
+func LoadPresetFile(path string) (*PresetDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &LoadError{Path: path, Message: "failed to read file", Err: err}
+	}
+	var d PresetDefinition
+	if err := yaml.Unmarshal(data, &d); err != nil {
+		return nil, &LoadError{Path: path, Message: "invalid YAML syntax", Err: err}
+	}
+	if d.Name == "" {
+		d.Name = Preset(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	}
+	d.Path = path
+	return &d, nil
+}
+
+// LoadPresets builds a registry from the *.yaml and *.yml definitions in dirs.
+// When several directories define a name, the first wins. Missing directories are
+// skipped; files that fail to load or validate are skipped and returned as errors.
+func LoadPresets(dirs ...string) (*PresetRegistry, []error) {
+	r := NewPresetRegistry()
+	var errs []error
+	for _, dir := range dirs {
+		var files []string
+		for _, pattern := range []string{"*.yaml", "*.yml"} {
+			matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+			files = append(files, matches...)
+		}
+		sort.Strings(files)
+		for _, path := range files {
+			d, err := LoadPresetFile(path)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if _, seen := r.Get(d.Name); seen {
+				continue
+			}
+			if err := r.Register(d); err != nil {
+				errs = append(errs, &LoadError{Path: path, Message: "invalid preset", Err: err})
+			}
+		}
+	}
+	return r, errs
+}
+
+// isBuiltinPreset reports whether preset is one of BuiltinPresets.
+func isBuiltinPreset(preset Preset) bool {
+	for _, p := range BuiltinPresets {
+		if p == preset {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fintechPreset = `name: regulated-fintech
+description: Payments services under PCI DSS
+extends: enterprise
+layer0:
+  privacy_posture: 10
+  auditability: 10
+layer1:
+  security_posture: 10
+  urgency_tiers: 7
+`
+
+// writePreset writes a preset definition into dir.
+func writePreset(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestPresetDefinition_Principles(t *testing.T) {
+	d := &PresetDefinition{Name: "regulated-fintech", Extends: PresetEnterprise,
+		Layer0: Layer0{PrivacyPosture: 10}, Layer1: Layer1{UrgencyTiers: 7}}
+
+	p := d.Principles()
+	base := DefaultPrinciples(PresetEnterprise)
+	assert.Equal(t, Preset("regulated-fintech"), p.Preset)
+	assert.Equal(t, 10, p.Layer0.PrivacyPosture)
+	assert.Equal(t, 7, p.Layer1.UrgencyTiers)
+	assert.Equal(t, base.Layer0.Auditability, p.Layer0.Auditability)
+	assert.Equal(t, base.Layer1.SecurityPosture, p.Layer1.SecurityPosture)
+}
+
+func TestPresetDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     PresetDefinition
+		wantErr string
+	}{
+		{"valid", PresetDefinition{Name: "fintech", Extends: PresetEnterprise}, ""},
+		{"missing name", PresetDefinition{Extends: PresetEnterprise}, "preset name is required"},
+		{"bad name", PresetDefinition{Name: "Fin Tech", Extends: PresetEnterprise}, "must be lowercase"},
+		{"reserved name", PresetDefinition{Name: "custom", Extends: PresetEnterprise}, `"custom" is reserved`},
+		{"builtin name", PresetDefinition{Name: "startup", Extends: PresetEnterprise}, `"startup" is reserved`},
+		{"missing extends", PresetDefinition{Name: "fintech"}, "extends must be one of"},
+		{"extends custom preset", PresetDefinition{Name: "fintech", Extends: "other"}, "extends must be one of"},
+		{"value out of range", PresetDefinition{Name: "fintech", Extends: PresetEnterprise, Layer1: Layer1{BlastRadius: 11}},
+			"layer1.blast_radius must be between 1 and 10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.def.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPresetRegistry(t *testing.T) {
+	d := &PresetDefinition{Name: "regulated-fintech", Extends: PresetEnterprise, Layer1: Layer1{SecurityPosture: 10}}
+	r := NewPresetRegistry()
+	assert.False(t, r.HasDefaults(d.Name))
+	require.NoError(t, r.Register(d))
+
+	assert.True(t, r.HasDefaults(d.Name))
+	assert.False(t, r.HasDefaults(PresetCustom))
+	assert.Equal(t, PresetEnterprise, r.Base(d.Name))
+	assert.Equal(t, PresetStartup, r.Base(PresetStartup))
+	assert.Equal(t, []Preset{PresetStartup, PresetEnterprise, PresetOpenSource, "regulated-fintech"}, r.Names())
+
+	p := r.Defaults(d.Name)
+	p.CreatedAt = "2026-01-11"
+	assert.NoError(t, p.Validate())
+	assert.Equal(t, 10, p.Layer1.SecurityPosture)
+	assert.Equal(t, DefaultPrinciples(PresetStartup).Layer1, DefaultPrinciples(d.Name).Layer1,
+		"registrations do not leak into the package defaults")
+
+	require.Error(t, r.Register(&PresetDefinition{Name: "startup", Extends: PresetEnterprise}))
+}
+
+func TestPresetRegistry_Nil(t *testing.T) {
+	var r *PresetRegistry
+	assert.Equal(t, BuiltinPresets, r.Names())
+	assert.Empty(t, r.Definitions())
+	assert.True(t, r.HasDefaults(PresetEnterprise))
+	assert.False(t, r.HasDefaults("regulated-fintech"))
+	assert.Equal(t, DefaultPrinciples(PresetOpenSource), r.Defaults(PresetOpenSource))
+}
+
+func TestLoadPresets(t *testing.T) {
+	repo := filepath.Join(t.TempDir(), "presets")
+	shared := filepath.Join(t.TempDir(), "shared")
+	writePreset(t, repo, "regulated-fintech.yaml", fintechPreset)
+	writePreset(t, repo, "broken.yaml", "name: broken\nextends: agency\n")
+	writePreset(t, shared, "fintech.yml", "name: regulated-fintech\nextends: startup\n")
+	writePreset(t, shared, "lean.yml", "extends: startup\nlayer0:\n  scope_philosophy: 1\n")
+
+	r, errs := LoadPresets(repo, shared, filepath.Join(t.TempDir(), "missing"))
+	require.Len(t, errs, 1)
+	assert.True(t, IsLoadError(errs[0]))
+	assert.Contains(t, errs[0].Error(), "broken.yaml: invalid preset")
+
+	require.Len(t, r.Definitions(), 2)
+	fintech, ok := r.Get("regulated-fintech")
+	require.True(t, ok)
+	assert.Equal(t, PresetEnterprise, fintech.Extends, "the repository definition shadows the shared one")
+	assert.Equal(t, "Payments services under PCI DSS", fintech.Description)
+	assert.Equal(t, filepath.Join(repo, "regulated-fintech.yaml"), fintech.Path)
+
+	lean, ok := r.Get("lean")
+	require.True(t, ok, "the name defaults to the file name")
+	assert.Equal(t, 1, r.Defaults(lean.Name).Layer0.ScopePhilosophy)
+}
+
+func TestPresetDirs(t *testing.T) {
+	t.Setenv(PresetsPathEnv, "/shared/a"+string(os.PathListSeparator)+"/shared/b")
+	t.Setenv("XDG_CONFIG_HOME", "/home/me/.config")
+	t.Setenv("HOME", "/home/me")
+
+	dirs := PresetDirs("/repo")
+	require.GreaterOrEqual(t, len(dirs), 3)
+	assert.Equal(t, []string{filepath.Join("/repo", ".claude", "presets"), "/shared/a", "/shared/b"}, dirs[:3])
+}
+
+func TestValidate_CustomPresetName(t *testing.T) {
+	p := DefaultPrinciples(PresetEnterprise)
+	p.CreatedAt = "2026-01-11"
+	p.Preset = "regulated-fintech"
+	assert.NoError(t, p.Validate(), "the file stores every value, so the preset need not be registered")
+
+	p.Preset = "Regulated Fintech"
+	assert.ErrorContains(t, p.Validate(), "or a custom preset name of lowercase letters")
+}
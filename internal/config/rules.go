@@ -0,0 +1,140 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConflictType classifies a conflict between two principles, following steps 1
+// and 2 of the R10 protocol.
+type ConflictType string
+
+const (
+	// ConflictCompatible principles govern different dimensions (breadth vs depth)
+	// and can both be satisfied.
+	ConflictCompatible ConflictType = "compatible"
+	// ConflictConstraintObjective pits a constraint against an objective.
+	ConflictConstraintObjective ConflictType = "constraint_objective"
+	// ConflictConstraints pits two constraints against each other.
+	ConflictConstraints ConflictType = "constraint_constraint"
+	// ConflictObjectives pits two objectives against each other.
+	ConflictObjectives ConflictType = "objective_objective"
+)
+
+// ConflictTypes lists the valid conflict types.
+var ConflictTypes = []ConflictType{ConflictCompatible, ConflictConstraintObjective, ConflictConstraints, ConflictObjectives}
+
+// Rule outcomes other than naming the winning principle.
+const (
+	PreferBoth       = "both"       // Satisfy both principles
+	PreferHigher     = "higher"     // The principle with the larger value
+	PreferConstraint = "constraint" // The constraint over the objective
+	PreferPriority   = "priority"   // The principle ranked higher in the priority hierarchy
+)
+
+// ResolutionRule is a Layer 2 rule: when a conflict matches its condition, the
+// preferred principle takes priority.
+type ResolutionRule struct {
+	ID          string        `yaml:"id"`
+	Description string        `yaml:"description"`
+	When        RuleCondition `yaml:"when"`
+	// Prefer names the winning principle, or one of both, higher, constraint, priority.
+	Prefer string `yaml:"prefer"`
+}
+
+// RuleCondition matches a conflict. Every set field must hold.
+type RuleCondition struct {
+	Conflict []string       `yaml:"conflict,omitempty"` // Principles the conflict must involve
+	Types    []ConflictType `yaml:"types,omitempty"`    // Conflict types, any of
+	Min      map[string]int `yaml:"min,omitempty"`      // Principle values at or above
+	Max      map[string]int `yaml:"max,omitempty"`      // Principle values at or below
+	MinGap   int            `yaml:"min_gap,omitempty"`  // Difference between the two principles' values, at least
+}
+
+// IsBuiltinRuleID reports whether id is reserved for the built-in rules R1-R10.
+func IsBuiltinRuleID(id string) bool {
+	for i := 1; i <= 10; i++ {
+		if strings.EqualFold(id, fmt.Sprintf("R%d", i)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Principles) validateRules() *ValidationError {
+	seen := make(map[string]bool)
+	for i, r := range p.Rules {
+		field := fmt.Sprintf("rules[%d]", i)
+		invalid := func(format string, args ...any) *ValidationError {
+			return &ValidationError{Field: field, Message: field + " " + fmt.Sprintf(format, args...)}
+		}
+		switch {
+		case r.ID == "":
+			return invalid("id is required")
+		case IsBuiltinRuleID(r.ID):
+			return invalid("id %q is reserved for a built-in rule", r.ID)
+		case seen[r.ID]:
+			return invalid("id %q is used more than once", r.ID)
+		}
+		seen[r.ID] = true
+
+		for _, key := range r.When.Conflict {
+			if _, ok := NormalizePrincipleKey(key); !ok {
+				return invalid("when.conflict has unknown principle %q", key)
+			}
+		}
+		if len(r.When.Conflict) > 2 {
+			return invalid("when.conflict may name at most two principles")
+		}
+		for _, t := range r.When.Types {
+			if !isValidConflictType(t) {
+				return invalid("when.types has unknown conflict type %q", t)
+			}
+		}
+		for name, bounds := range map[string]map[string]int{"min": r.When.Min, "max": r.When.Max} {
+			for key, value := range bounds {
+				if _, ok := NormalizePrincipleKey(key); !ok {
+					return invalid("when.%s has unknown principle %q", name, key)
+				}
+				if value < MinPrincipleValue || value > MaxPrincipleValue {
+					return invalid("when.%s.%s must be between %d and %d (got %d)", name, key, MinPrincipleValue, MaxPrincipleValue, value)
+				}
+			}
+		}
+		if r.When.MinGap < 0 || r.When.MinGap >= MaxPrincipleValue {
+			return invalid("when.min_gap must be between 0 and %d (got %d)", MaxPrincipleValue-1, r.When.MinGap)
+		}
+
+		switch r.Prefer {
+		case PreferBoth, PreferHigher, PreferConstraint, PreferPriority:
+		default:
+			name, ok := NormalizePrincipleKey(r.Prefer)
+			if !ok {
+				return invalid("prefer must be a principle or one of both, higher, constraint, priority (got %q)", r.Prefer)
+			}
+			if !ruleNamesPrinciple(r.When.Conflict, name) {
+				return invalid("prefer %q must be listed in when.conflict", r.Prefer)
+			}
+		}
+	}
+	return nil
+}
+
+// ruleNamesPrinciple reports whether keys contains the principle name.
+func ruleNamesPrinciple(keys []string, name string) bool {
+	for _, key := range keys {
+		if n, _ := NormalizePrincipleKey(key); n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidConflictType(t ConflictType) bool {
+	for _, valid := range ConflictTypes {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
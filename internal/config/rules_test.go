@@ -0,0 +1,75 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate_Rules(t *testing.T) {
+	valid := ResolutionRule{
+		ID:          "team-1",
+		Description: "Interop beats UX for our platform",
+		When: RuleCondition{
+			Conflict: []string{"interoperability", "ux_philosophy"},
+			Types:    []ConflictType{ConflictObjectives},
+			Min:      map[string]int{"interoperability": 6},
+			Max:      map[string]int{"layer0.ux_philosophy": 8},
+			MinGap:   1,
+		},
+		Prefer: "interoperability",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(r *ResolutionRule)
+		wantErr string
+	}{
+		{"valid", func(r *ResolutionRule) {}, ""},
+		{"keyword preference", func(r *ResolutionRule) { r.Prefer = PreferHigher }, ""},
+		{"missing id", func(r *ResolutionRule) { r.ID = "" }, "rules[0] id is required"},
+		{"built-in id", func(r *ResolutionRule) { r.ID = "r3" }, `id "r3" is reserved for a built-in rule`},
+		{"unknown conflict key", func(r *ResolutionRule) { r.When.Conflict = []string{"velocity"} }, `when.conflict has unknown principle "velocity"`},
+		{"three principles", func(r *ResolutionRule) {
+			r.When.Conflict = []string{"interoperability", "ux_philosophy", "auditability"}
+		}, "at most two principles"},
+		{"unknown type", func(r *ResolutionRule) { r.When.Types = []ConflictType{"hard"} }, `unknown conflict type "hard"`},
+		{"min out of range", func(r *ResolutionRule) { r.When.Min = map[string]int{"interoperability": 11} }, "when.min.interoperability must be between 1 and 10"},
+		{"max unknown key", func(r *ResolutionRule) { r.When.Max = map[string]int{"speed": 3} }, `when.max has unknown principle "speed"`},
+		{"gap out of range", func(r *ResolutionRule) { r.When.MinGap = 10 }, "when.min_gap must be between 0 and 9"},
+		{"unknown preference", func(r *ResolutionRule) { r.Prefer = "lower" }, `prefer must be a principle or one of both, higher, constraint, priority (got "lower")`},
+		{"preference outside conflict", func(r *ResolutionRule) { r.Prefer = "auditability" }, `prefer "auditability" must be listed in when.conflict`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := DefaultPrinciples(PresetStartup)
+			p.CreatedAt = "2026-01-11"
+			rule := valid
+			rule.When.Conflict = append([]string(nil), valid.When.Conflict...)
+			tt.modify(&rule)
+			p.Rules = []ResolutionRule{rule}
+
+			err := p.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, IsValidationError(err))
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	t.Run("duplicate id", func(t *testing.T) {
+		p := DefaultPrinciples(PresetStartup)
+		p.CreatedAt = "2026-01-11"
+		p.Rules = []ResolutionRule{valid, valid}
+		assert.Contains(t, p.Validate().Error(), `rules[1] id "team-1" is used more than once`)
+	})
+}
+
+func TestIsBuiltinRuleID(t *testing.T) {
+	assert.True(t, IsBuiltinRuleID("R1"))
+	assert.True(t, IsBuiltinRuleID("r10"))
+	assert.False(t, IsBuiltinRuleID("R11"))
+	assert.False(t, IsBuiltinRuleID("team-1"))
+}
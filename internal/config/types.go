@@ -21,6 +21,55 @@ type Principles struct {
 	CreatedAt string `yaml:"created_at"`
 	Layer0    Layer0 `yaml:"layer0"`
 	Layer1    Layer1 `yaml:"layer1"`
+
+	// Protected lists paths Claude must never modify (optional).
+	Protected *ProtectedPaths `yaml:"protected,omitempty"`
+
+	// ChangeLimits overrides the per-iteration change size limits derived from
+	// layer1.blast_radius (optional).
+	ChangeLimits *ChangeLimits `yaml:"change_limits,omitempty"`
+
+	// Locked lists principle keys whose value is a floor: layers with higher
+	// precedence may raise it but not lower it (optional).
+	Locked []string `yaml:"locked,omitempty"`
+
+	// Rules are custom Layer 2 resolution rules, checked before the built-in
+	// rules R1-R10 (optional).
+	Rules []ResolutionRule `yaml:"rules,omitempty"`
+}
+
+// RevertMode selects what is undone when an iteration touches a protected path.
+type RevertMode string
+
+const (
+	// RevertFiles restores only the protected files (default).
+	RevertFiles RevertMode = "files"
+	// RevertIteration restores every file the iteration changed and drops its commits.
+	RevertIteration RevertMode = "iteration"
+)
+
+// ProtectedPaths configures the protected-path policy.
+type ProtectedPaths struct {
+	Paths  []string   `yaml:"paths"`            // Glob patterns relative to the repository root
+	Revert RevertMode `yaml:"revert,omitempty"` // Default: files
+}
+
+// ExceedAction selects what happens when an iteration exceeds the change size limits.
+type ExceedAction string
+
+const (
+	// ExceedSplit keeps the changes and asks the next iteration to split them up (default).
+	ExceedSplit ExceedAction = "split"
+	// ExceedRevert restores every file the iteration changed and drops its commits.
+	ExceedRevert ExceedAction = "revert"
+)
+
+// ChangeLimits caps the size of each iteration's changes.
+// Zero limits are derived from layer1.blast_radius; -1 disables a limit.
+type ChangeLimits struct {
+	MaxFiles int          `yaml:"max_files,omitempty"` // Files changed per iteration
+	MaxLines int          `yaml:"max_lines,omitempty"` // Lines inserted plus deleted per iteration
+	OnExceed ExceedAction `yaml:"on_exceed,omitempty"` // Default: split
 }
 
 // Layer0 contains Product Principles (9 principles).
@@ -49,7 +98,7 @@ type Layer1 struct {
 	MigrationBurden       int `yaml:"migration_burden"`
 }
 
-// IsValidPreset checks if a preset value is valid.
+// IsValidPreset checks if a preset value is one of ValidPresets.
 func IsValidPreset(p Preset) bool {
 	for _, valid := range ValidPresets {
 		if p == valid {
@@ -44,6 +44,18 @@ func (p *Principles) Validate() error {
 	if err := p.validateLayer1(); err != nil {
 		return err
 	}
+	if err := p.validateProtected(); err != nil {
+		return err
+	}
+	if err := p.validateChangeLimits(); err != nil {
+		return err
+	}
+	if err := p.validateLocked(); err != nil {
+		return err
+	}
+	if err := p.validateRules(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -62,6 +74,18 @@ func (p *Principles) ValidateAll() []error {
 	}
 	errs = append(errs, p.validateLayer0Fields()...)
 	errs = append(errs, p.validateLayer1Fields()...)
+	if err := p.validateProtected(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := p.validateChangeLimits(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := p.validateLocked(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := p.validateRules(); err != nil {
+		errs = append(errs, err)
+	}
 
 	return errs
 }
@@ -89,10 +113,12 @@ func (p *Principles) validatePreset() *ValidationError {
 			Message: "preset is required",
 		}
 	}
-	if !IsValidPreset(p.Preset) {
+	// A file stores every value, so a custom preset name is informational and need
+	// not be registered on this machine.
+	if !IsValidPreset(p.Preset) && !presetNameRegex.MatchString(string(p.Preset)) {
 		return &ValidationError{
 			Field:   "preset",
-			Message: fmt.Sprintf("preset must be one of: startup, enterprise, opensource, custom (got %q)", p.Preset),
+			Message: fmt.Sprintf("preset must be one of: startup, enterprise, opensource, custom, or a custom preset name of lowercase letters, digits, '-' or '_' (got %q)", p.Preset),
 		}
 	}
 	return nil
@@ -114,6 +140,67 @@ func (p *Principles) validateCreatedAt() *ValidationError {
 	return nil
 }
 
+func (p *Principles) validateProtected() *ValidationError {
+	if p.Protected == nil {
+		return nil
+	}
+	for i, path := range p.Protected.Paths {
+		if path == "" {
+			return &ValidationError{
+				Field:   "protected.paths",
+				Message: fmt.Sprintf("protected.paths[%d] must not be empty", i),
+			}
+		}
+	}
+	switch p.Protected.Revert {
+	case "", RevertFiles, RevertIteration:
+		return nil
+	default:
+		return &ValidationError{
+			Field:   "protected.revert",
+			Message: fmt.Sprintf("protected.revert must be files or iteration (got %q)", p.Protected.Revert),
+		}
+	}
+}
+
+func (p *Principles) validateChangeLimits() *ValidationError {
+	if p.ChangeLimits == nil {
+		return nil
+	}
+	for _, f := range []principleField{
+		{"change_limits.max_files", &p.ChangeLimits.MaxFiles},
+		{"change_limits.max_lines", &p.ChangeLimits.MaxLines},
+	} {
+		if *f.value < -1 {
+			return &ValidationError{
+				Field:   f.name,
+				Message: fmt.Sprintf("%s must be positive, 0 (from blast_radius) or -1 (unlimited) (got %d)", f.name, *f.value),
+			}
+		}
+	}
+	switch p.ChangeLimits.OnExceed {
+	case "", ExceedSplit, ExceedRevert:
+		return nil
+	default:
+		return &ValidationError{
+			Field:   "change_limits.on_exceed",
+			Message: fmt.Sprintf("change_limits.on_exceed must be split or revert (got %q)", p.ChangeLimits.OnExceed),
+		}
+	}
+}
+
+func (p *Principles) validateLocked() *ValidationError {
+	for i, key := range p.Locked {
+		if _, ok := p.principleField(key); !ok {
+			return &ValidationError{
+				Field:   "locked",
+				Message: fmt.Sprintf("locked[%d] must be a principle key such as layer1.security_posture (got %q)", i, key),
+			}
+		}
+	}
+	return nil
+}
+
 func validatePrincipleValue(field string, value int) *ValidationError {
 	if value < MinPrincipleValue || value > MaxPrincipleValue {
 		return &ValidationError{
@@ -124,45 +211,45 @@ func validatePrincipleValue(field string, value int) *ValidationError {
 	return nil
 }
 
-// principleField represents a named principle value for validation.
+// principleField represents a named principle value.
 type principleField struct {
 	name  string
-	value int
+	value *int
 }
 
 func (p *Principles) layer0Fields() []principleField {
 	l := &p.Layer0
 	return []principleField{
-		{"layer0.trust_architecture", l.TrustArchitecture},
-		{"layer0.curation_model", l.CurationModel},
-		{"layer0.scope_philosophy", l.ScopePhilosophy},
-		{"layer0.monetization_model", l.MonetizationModel},
-		{"layer0.privacy_posture", l.PrivacyPosture},
-		{"layer0.ux_philosophy", l.UXPhilosophy},
-		{"layer0.authority_stance", l.AuthorityStance},
-		{"layer0.auditability", l.Auditability},
-		{"layer0.interoperability", l.Interoperability},
+		{"layer0.trust_architecture", &l.TrustArchitecture},
+		{"layer0.curation_model", &l.CurationModel},
+		{"layer0.scope_philosophy", &l.ScopePhilosophy},
+		{"layer0.monetization_model", &l.MonetizationModel},
+		{"layer0.privacy_posture", &l.PrivacyPosture},
+		{"layer0.ux_philosophy", &l.UXPhilosophy},
+		{"layer0.authority_stance", &l.AuthorityStance},
+		{"layer0.auditability", &l.Auditability},
+		{"layer0.interoperability", &l.Interoperability},
 	}
 }
 
 func (p *Principles) layer1Fields() []principleField {
 	l := &p.Layer1
 	return []principleField{
-		{"layer1.speed_correctness", l.SpeedCorrectness},
-		{"layer1.innovation_stability", l.InnovationStability},
-		{"layer1.blast_radius", l.BlastRadius},
-		{"layer1.clarity_of_intent", l.ClarityOfIntent},
-		{"layer1.reversibility_priority", l.ReversibilityPriority},
-		{"layer1.security_posture", l.SecurityPosture},
-		{"layer1.urgency_tiers", l.UrgencyTiers},
-		{"layer1.cost_efficiency", l.CostEfficiency},
-		{"layer1.migration_burden", l.MigrationBurden},
+		{"layer1.speed_correctness", &l.SpeedCorrectness},
+		{"layer1.innovation_stability", &l.InnovationStability},
+		{"layer1.blast_radius", &l.BlastRadius},
+		{"layer1.clarity_of_intent", &l.ClarityOfIntent},
+		{"layer1.reversibility_priority", &l.ReversibilityPriority},
+		{"layer1.security_posture", &l.SecurityPosture},
+		{"layer1.urgency_tiers", &l.UrgencyTiers},
+		{"layer1.cost_efficiency", &l.CostEfficiency},
+		{"layer1.migration_burden", &l.MigrationBurden},
 	}
 }
 
 func (p *Principles) validateLayer0() *ValidationError {
 	for _, f := range p.layer0Fields() {
-		if err := validatePrincipleValue(f.name, f.value); err != nil {
+		if err := validatePrincipleValue(f.name, *f.value); err != nil {
 			return err
 		}
 	}
@@ -172,7 +259,7 @@ func (p *Principles) validateLayer0() *ValidationError {
 func (p *Principles) validateLayer0Fields() []error {
 	var errs []error
 	for _, f := range p.layer0Fields() {
-		if err := validatePrincipleValue(f.name, f.value); err != nil {
+		if err := validatePrincipleValue(f.name, *f.value); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -181,7 +268,7 @@ func (p *Principles) validateLayer0Fields() []error {
 
 func (p *Principles) validateLayer1() *ValidationError {
 	for _, f := range p.layer1Fields() {
-		if err := validatePrincipleValue(f.name, f.value); err != nil {
+		if err := validatePrincipleValue(f.name, *f.value); err != nil {
 			return err
 		}
 	}
@@ -191,7 +278,7 @@ func (p *Principles) validateLayer1() *ValidationError {
 func (p *Principles) validateLayer1Fields() []error {
 	var errs []error
 	for _, f := range p.layer1Fields() {
-		if err := validatePrincipleValue(f.name, f.value); err != nil {
+		if err := validatePrincipleValue(f.name, *f.value); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -82,7 +82,7 @@ func TestValidate(t *testing.T) {
 			name: "invalid preset",
 			principles: &Principles{
 				Version:   "2.3",
-				Preset:    "invalid",
+				Preset:    "Not Valid!",
 				CreatedAt: "2026-01-11",
 				Layer0:    DefaultPrinciples(PresetStartup).Layer0,
 				Layer1:    DefaultPrinciples(PresetStartup).Layer1,
@@ -176,6 +176,60 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "valid protected paths",
+			principles: func() *Principles {
+				p := validPrinciples(PresetEnterprise)
+				p.Protected = &ProtectedPaths{Paths: []string{"migrations/**", "LICENSE"}, Revert: RevertIteration}
+				return p
+			}(),
+			wantErr: "",
+		},
+		{
+			name: "empty protected path",
+			principles: func() *Principles {
+				p := validPrinciples(PresetEnterprise)
+				p.Protected = &ProtectedPaths{Paths: []string{"LICENSE", ""}}
+				return p
+			}(),
+			wantErr: "protected.paths[1] must not be empty",
+		},
+		{
+			name: "invalid protected revert mode",
+			principles: func() *Principles {
+				p := validPrinciples(PresetEnterprise)
+				p.Protected = &ProtectedPaths{Paths: []string{"LICENSE"}, Revert: "commit"}
+				return p
+			}(),
+			wantErr: `protected.revert must be files or iteration (got "commit")`,
+		},
+		{
+			name: "valid change limits",
+			principles: func() *Principles {
+				p := validPrinciples(PresetEnterprise)
+				p.ChangeLimits = &ChangeLimits{MaxFiles: -1, MaxLines: 500, OnExceed: ExceedRevert}
+				return p
+			}(),
+			wantErr: "",
+		},
+		{
+			name: "negative change limit",
+			principles: func() *Principles {
+				p := validPrinciples(PresetEnterprise)
+				p.ChangeLimits = &ChangeLimits{MaxLines: -5}
+				return p
+			}(),
+			wantErr: "change_limits.max_lines must be positive, 0 (from blast_radius) or -1 (unlimited) (got -5)",
+		},
+		{
+			name: "invalid change limits action",
+			principles: func() *Principles {
+				p := validPrinciples(PresetEnterprise)
+				p.ChangeLimits = &ChangeLimits{OnExceed: "warn"}
+				return p
+			}(),
+			wantErr: `change_limits.on_exceed must be split or revert (got "warn")`,
+		},
 	}
 
 	for _, tt := range tests {
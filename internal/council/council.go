@@ -12,6 +12,8 @@ type DefaultCouncil struct {
 	detector      *ConflictDetector
 	promptBuilder *PromptBuilder
 	logger        *DecisionLogger
+	rules         *RuleEngine
+	memory        *DecisionMemory
 }
 
 // NewCouncil creates a new DefaultCouncil.
@@ -25,24 +27,38 @@ func NewCouncil(cfg *Config, client ClaudeClient) *DefaultCouncil {
 		detector:      NewConflictDetector(),
 		promptBuilder: NewPromptBuilder(),
 		logger:        NewDecisionLogger(cfg.LogFile, cfg.LogDecisions),
+		rules:         NewRuleEngine(cfg.Principles),
+		memory:        NewDecisionMemory(cfg.PriorDecisions),
 	}
 }
 
-// DetectConflict checks if output contains unresolved principle conflicts.
-func (c *DefaultCouncil) DetectConflict(output string) bool {
+// DetectConflict returns the principle conflict output asks to have resolved.
+func (c *DefaultCouncil) DetectConflict(output string) (Conflict, bool) {
 	return c.detector.Detect(output)
 }
 
-// Resolve invokes the LLM council for conflict resolution.
-func (c *DefaultCouncil) Resolve(ctx context.Context, conflictContext string) (*Result, error) {
+// Resolve resolves a conflict locally when a Layer 2 rule decides it, and otherwise
+// invokes the LLM council: the members vote and the chair decides, or with fewer
+// than two members a single call resolves it.
+func (c *DefaultCouncil) Resolve(ctx context.Context, conflict Conflict) (*Result, error) {
 	if c.config.Principles == nil {
 		return nil, ErrNoPrinciples
 	}
 
-	buildResult, err := c.promptBuilder.Build(BuildContext{
-		ConflictContext: conflictContext,
-		Principles:      c.config.Principles,
-	})
+	if result := c.ResolveLocally(conflict); result != nil {
+		return result, nil
+	}
+
+	bctx := BuildContext{
+		Conflict:   conflict,
+		Principles: c.config.Principles,
+		Precedents: c.memory.Precedents(conflict.String()),
+	}
+	if len(c.config.Members) >= 2 {
+		return c.convene(ctx, bctx)
+	}
+
+	buildResult, err := c.promptBuilder.Build(bctx)
 	if err != nil {
 		return nil, err
 	}
@@ -69,11 +85,39 @@ func (c *DefaultCouncil) Resolve(ctx context.Context, conflictContext string) (*
 	}, nil
 }
 
-// LogDecision logs a decision to the decision log file.
+// ResolveLocally resolves a conflict with Layer 2 rules, without calling Claude.
+// Returns nil when the principles in conflict are not identified or no rule decides it.
+func (c *DefaultCouncil) ResolveLocally(conflict Conflict) *Result {
+	if !conflict.Identified() {
+		return nil
+	}
+	res := c.rules.Resolve(conflict)
+	if res == nil {
+		return nil
+	}
+	return &Result{
+		Output:     res.Decision + "\n" + res.Rationale,
+		Resolution: res.Decision,
+		Rationale:  res.Rationale,
+		Rule:       res.Rule.ID,
+	}
+}
+
+// LogDecision logs a decision to the decision log file and remembers it as a
+// precedent, whether or not logging is enabled.
 func (c *DefaultCouncil) LogDecision(decision *Decision) error {
+	if decision != nil && decision.Decision != "" {
+		c.memory.Add(NewDecisionRecord(decision))
+	}
 	return c.logger.Log(decision)
 }
 
+// Precedents returns earlier decisions relevant to query, one per line, for
+// keeping later decisions consistent with them.
+func (c *DefaultCouncil) Precedents(query string) []string {
+	return c.memory.Precedents(query)
+}
+
 // Config returns the council's configuration.
 func (c *DefaultCouncil) Config() *Config {
 	return c.config
@@ -66,7 +66,7 @@ func TestDefaultCouncil_DetectConflict(t *testing.T) {
 	}{
 		{
 			name:     "detects conflict",
-			output:   "PRINCIPLE_CONFLICT_UNRESOLVED in the response",
+			output:   "<principle_conflict>\nprinciples: urgency_tiers, security_posture\n</principle_conflict>",
 			expected: true,
 		},
 		{
@@ -78,8 +78,8 @@ func TestDefaultCouncil_DetectConflict(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := council.DetectConflict(tt.output)
-			assert.Equal(t, tt.expected, result)
+			_, found := council.DetectConflict(tt.output)
+			assert.Equal(t, tt.expected, found)
 		})
 	}
 }
@@ -103,7 +103,7 @@ func TestDefaultCouncil_Resolve(t *testing.T) {
 		}
 		council := NewCouncil(cfg, client)
 
-		result, err := council.Resolve(ctx, "Conflict between speed and correctness")
+		result, err := council.Resolve(ctx, Conflict{Context: "Conflict between speed and correctness"})
 
 		require.NoError(t, err)
 		assert.NotNil(t, result)
@@ -114,6 +114,36 @@ func TestDefaultCouncil_Resolve(t *testing.T) {
 		assert.Contains(t, client.calls[0], "Conflict between speed and correctness")
 	})
 
+	t.Run("resolved by a rule without calling Claude", func(t *testing.T) {
+		client := &mockClaudeClient{}
+		principles := config.DefaultPrinciples(config.PresetEnterprise)
+		council := NewCouncil(&Config{Principles: principles}, client)
+
+		result, err := council.Resolve(ctx, conflictOf("urgency_tiers", "security_posture"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "R4", result.Rule)
+		assert.Equal(t, "Prioritize security_posture (9) over urgency_tiers (5)", result.Resolution)
+		assert.Equal(t, "R4 - Security posture outranks delivery pressure: security_posture=9 >= 7", result.Rationale)
+		assert.Zero(t, result.Cost)
+		assert.Empty(t, client.calls)
+	})
+
+	t.Run("falls back to Claude when no rule decides", func(t *testing.T) {
+		client := &mockClaudeClient{response: &IterationResult{Output: "**Decision**: Curate by hand"}}
+		principles := config.DefaultPrinciples(config.PresetEnterprise)
+		require.NoError(t, principles.SetPrinciple("curation_model", 6))
+		require.NoError(t, principles.SetPrinciple("monetization_model", 6))
+		council := NewCouncil(&Config{Principles: principles}, client)
+
+		result, err := council.Resolve(ctx, conflictOf("curation_model", "monetization_model"))
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Rule)
+		assert.Equal(t, "Curate by hand", result.Resolution)
+		assert.Len(t, client.calls, 1)
+	})
+
 	t.Run("error when no principles", func(t *testing.T) {
 		client := &mockClaudeClient{}
 		cfg := &Config{
@@ -121,7 +151,7 @@ func TestDefaultCouncil_Resolve(t *testing.T) {
 		}
 		council := NewCouncil(cfg, client)
 
-		result, err := council.Resolve(ctx, "Some conflict")
+		result, err := council.Resolve(ctx, Conflict{Context: "Some conflict"})
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -137,7 +167,7 @@ func TestDefaultCouncil_Resolve(t *testing.T) {
 		}
 		council := NewCouncil(cfg, client)
 
-		result, err := council.Resolve(ctx, "Some conflict")
+		result, err := council.Resolve(ctx, Conflict{Context: "Some conflict"})
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -168,6 +198,27 @@ func TestDefaultCouncil_LogDecision(t *testing.T) {
 		err := council.LogDecision(decision)
 		assert.NoError(t, err)
 	})
+
+	t.Run("remembers decisions as precedents when logging is off", func(t *testing.T) {
+		council := NewCouncil(&Config{}, &mockClaudeClient{})
+
+		require.NoError(t, council.LogDecision(&Decision{Iteration: 3, Decision: "Cache results in memory"}))
+		assert.Equal(t, []string{"Decided, iteration 3: Cache results in memory"}, council.Precedents("caching"))
+	})
+}
+
+func TestDefaultCouncil_Resolve_Precedents(t *testing.T) {
+	client := &mockClaudeClient{response: &IterationResult{Output: "**Decision**: Curate by hand"}}
+	council := NewCouncil(&Config{
+		Principles:     config.DefaultPrinciples(config.PresetStartup),
+		PriorDecisions: []*DecisionRecord{{Iteration: 2, Decision: "Rank the feed by hand", CouncilInvoked: true}},
+	}, client)
+
+	_, err := council.Resolve(context.Background(), conflictOf("curation_model", "monetization_model"))
+	require.NoError(t, err)
+	require.Len(t, client.calls, 1)
+	assert.Contains(t, client.calls[0], "## Precedents\n")
+	assert.Contains(t, client.calls[0], "- Council, iteration 2: Rank the feed by hand\n\n## Current Principles")
 }
 
 func TestDefaultCouncil_Config(t *testing.T) {
@@ -0,0 +1,204 @@
+package council
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+)
+
+// DefaultLogFile is the default human-readable decision log.
+const DefaultLogFile = ".claude/principles-decisions.log"
+
+// DefaultDecisionsFile is the structured decision log written next to the
+// default human-readable log.
+const DefaultDecisionsFile = ".claude/principles-decisions.jsonl"
+
+// DecisionRecord is one line of the structured decision log.
+type DecisionRecord struct {
+	Timestamp      time.Time     `json:"timestamp"`
+	RunID          string        `json:"run_id,omitempty"`
+	Iteration      int           `json:"iteration"`
+	Decision       string        `json:"decision"`
+	Rationale      string        `json:"rationale,omitempty"`
+	Principles     []string      `json:"principles,omitempty"` // Full keys of the principles cited
+	Preset         config.Preset `json:"preset,omitempty"`
+	CouncilInvoked bool          `json:"council_invoked"`
+	Rule           string        `json:"rule,omitempty"`
+	Votes          []Vote        `json:"votes,omitempty"`
+	Dissent        []string      `json:"dissent,omitempty"`
+	Confidence     float64       `json:"confidence,omitempty"`
+	Cost           float64       `json:"cost,omitempty"`
+	Escalated      bool          `json:"escalated,omitempty"`
+}
+
+// NewDecisionRecord converts a decision for the structured log. Without cited
+// principles, those mentioned in the decision and rationale are used.
+func NewDecisionRecord(d *Decision) *DecisionRecord {
+	principles := d.Principles
+	if len(principles) == 0 {
+		principles = MentionedPrinciples(d.Decision + "\n" + d.Rationale)
+	}
+	return &DecisionRecord{
+		Timestamp:      d.Timestamp.UTC(),
+		RunID:          d.RunID,
+		Iteration:      d.Iteration,
+		Decision:       d.Decision,
+		Rationale:      d.Rationale,
+		Principles:     principles,
+		Preset:         d.Preset,
+		CouncilInvoked: d.CouncilInvoked,
+		Rule:           d.Rule,
+		Votes:          d.Votes,
+		Dissent:        d.Dissent,
+		Confidence:     d.Confidence,
+		Cost:           d.Cost,
+		Escalated:      d.Escalated,
+	}
+}
+
+// DecisionsPath returns the structured log written alongside logFile: the same
+// path with a .jsonl extension.
+func DecisionsPath(logFile string) string {
+	return strings.TrimSuffix(logFile, filepath.Ext(logFile)) + ".jsonl"
+}
+
+// ReadDecisions reads the structured decision log at path, oldest first.
+// A missing file yields no records.
+func ReadDecisions(path string) ([]*DecisionRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, &CouncilError{Phase: "log", Message: "failed to read " + path, Err: err}
+	}
+	defer f.Close()
+
+	var records []*DecisionRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var r DecisionRecord
+		if err := json.Unmarshal([]byte(text), &r); err != nil {
+			return nil, &CouncilError{Phase: "log", Message: fmt.Sprintf("%s:%d: invalid record", path, line), Err: err}
+		}
+		records = append(records, &r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &CouncilError{Phase: "log", Message: "failed to read " + path, Err: err}
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Timestamp.Before(records[j].Timestamp) })
+	return records, nil
+}
+
+// DecisionFilter selects decision records.
+type DecisionFilter struct {
+	Since       time.Time // Zero means no lower bound
+	Until       time.Time // Exclusive; zero means no upper bound
+	Principle   string    // Full key of a principle the decision must cite
+	CouncilOnly bool      // Only decisions the council made
+	Text        string    // Case-insensitive substring of the decision, rationale or rule
+}
+
+// Match reports whether r passes the filter.
+func (f *DecisionFilter) Match(r *DecisionRecord) bool {
+	if f == nil {
+		return true
+	}
+	if !f.Since.IsZero() && r.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !r.Timestamp.Before(f.Until) {
+		return false
+	}
+	if f.Principle != "" && !containsKey(r.Principles, f.Principle) {
+		return false
+	}
+	if f.CouncilOnly && !r.CouncilInvoked {
+		return false
+	}
+	if f.Text != "" {
+		text := strings.ToLower(f.Text)
+		if !strings.Contains(strings.ToLower(r.Decision), text) &&
+			!strings.Contains(strings.ToLower(r.Rationale), text) &&
+			!strings.EqualFold(r.Rule, f.Text) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterDecisions returns the records that pass f.
+func FilterDecisions(records []*DecisionRecord, f *DecisionFilter) []*DecisionRecord {
+	var matched []*DecisionRecord
+	for _, r := range records {
+		if f.Match(r) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// PrincipleCount is how many decisions cited one principle.
+type PrincipleCount struct {
+	Principle string
+	Decisions int
+	Council   int // Decisions among them the council made
+}
+
+// DecisionStats summarizes a set of decision records.
+type DecisionStats struct {
+	Decisions   int
+	Council     int // Made by the council
+	Rules       int // Made by a Layer 2 rule
+	Cost        float64
+	ByPrinciple []PrincipleCount // Most cited first
+	ByRule      map[string]int
+}
+
+// ComputeDecisionStats counts decisions by source and by cited principle.
+func ComputeDecisionStats(records []*DecisionRecord) *DecisionStats {
+	stats := &DecisionStats{ByRule: make(map[string]int)}
+	index := make(map[string]int)
+	for _, r := range records {
+		stats.Decisions++
+		stats.Cost += r.Cost
+		if r.CouncilInvoked {
+			stats.Council++
+		}
+		if r.Rule != "" {
+			stats.Rules++
+			stats.ByRule[r.Rule]++
+		}
+		for _, p := range r.Principles {
+			i, ok := index[p]
+			if !ok {
+				i = len(stats.ByPrinciple)
+				index[p] = i
+				stats.ByPrinciple = append(stats.ByPrinciple, PrincipleCount{Principle: p})
+			}
+			stats.ByPrinciple[i].Decisions++
+			if r.CouncilInvoked {
+				stats.ByPrinciple[i].Council++
+			}
+		}
+	}
+	sort.SliceStable(stats.ByPrinciple, func(i, j int) bool {
+		if stats.ByPrinciple[i].Decisions != stats.ByPrinciple[j].Decisions {
+			return stats.ByPrinciple[i].Decisions > stats.ByPrinciple[j].Decisions
+		}
+		return stats.ByPrinciple[i].Principle < stats.ByPrinciple[j].Principle
+	})
+	return stats
+}
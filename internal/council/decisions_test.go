@@ -0,0 +1,115 @@
+package council
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecisionsPath(t *testing.T) {
+	assert.Equal(t, ".claude/principles-decisions.jsonl", DecisionsPath(".claude/principles-decisions.log"))
+	assert.Equal(t, "decisions.jsonl", DecisionsPath("decisions"))
+}
+
+func TestNewDecisionRecord(t *testing.T) {
+	t.Run("keeps cited principles", func(t *testing.T) {
+		r := NewDecisionRecord(&Decision{Decision: "Ship it", Principles: []string{"layer1.urgency_tiers"}})
+		assert.Equal(t, []string{"layer1.urgency_tiers"}, r.Principles)
+	})
+
+	t.Run("falls back to mentioned principles", func(t *testing.T) {
+		r := NewDecisionRecord(&Decision{Decision: "Keep the change small", Rationale: "blast radius and security posture"})
+		assert.Equal(t, []string{"layer1.blast_radius", "layer1.security_posture"}, r.Principles)
+	})
+}
+
+func TestReadDecisions(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		records, err := ReadDecisions(filepath.Join(t.TempDir(), "missing.jsonl"))
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("sorts by time and skips blank lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "decisions.jsonl")
+		content := `{"timestamp":"2026-02-02T10:00:00Z","iteration":2,"decision":"second","council_invoked":true}
+
+{"timestamp":"2026-02-01T10:00:00Z","iteration":1,"decision":"first","council_invoked":false}
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		records, err := ReadDecisions(path)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "first", records[0].Decision)
+		assert.True(t, records[1].CouncilInvoked)
+	})
+
+	t.Run("invalid line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "decisions.jsonl")
+		require.NoError(t, os.WriteFile(path, []byte("{}\nnot json\n"), 0644))
+
+		_, err := ReadDecisions(path)
+		require.Error(t, err)
+		assert.True(t, IsCouncilError(err))
+		assert.Contains(t, err.Error(), "decisions.jsonl:2")
+	})
+}
+
+func TestDecisionFilter_Match(t *testing.T) {
+	r := &DecisionRecord{
+		Timestamp:      time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC),
+		Decision:       "Add rate limiting",
+		Rationale:      "Protects the API",
+		Principles:     []string{"layer1.security_posture"},
+		CouncilInvoked: true,
+	}
+
+	tests := []struct {
+		name   string
+		filter *DecisionFilter
+		want   bool
+	}{
+		{"nil filter", nil, true},
+		{"since before", &DecisionFilter{Since: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}, true},
+		{"since after", &DecisionFilter{Since: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)}, false},
+		{"until is exclusive", &DecisionFilter{Until: r.Timestamp}, false},
+		{"cited principle", &DecisionFilter{Principle: "layer1.security_posture"}, true},
+		{"other principle", &DecisionFilter{Principle: "layer1.blast_radius"}, false},
+		{"council only", &DecisionFilter{CouncilOnly: true}, true},
+		{"text in rationale", &DecisionFilter{Text: "the api"}, true},
+		{"text missing", &DecisionFilter{Text: "caching"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(r))
+		})
+	}
+
+	assert.False(t, (&DecisionFilter{CouncilOnly: true}).Match(&DecisionRecord{}))
+}
+
+func TestComputeDecisionStats(t *testing.T) {
+	records := []*DecisionRecord{
+		{Principles: []string{"layer1.security_posture", "layer1.urgency_tiers"}, Rule: "R4"},
+		{Principles: []string{"layer1.security_posture"}, CouncilInvoked: true, Cost: 0.2},
+		{Principles: []string{"layer0.curation_model"}, CouncilInvoked: true, Cost: 0.1},
+		{},
+	}
+
+	stats := ComputeDecisionStats(records)
+	assert.Equal(t, 4, stats.Decisions)
+	assert.Equal(t, 2, stats.Council)
+	assert.Equal(t, 1, stats.Rules)
+	assert.InDelta(t, 0.3, stats.Cost, 0.0001)
+	assert.Equal(t, map[string]int{"R4": 1}, stats.ByRule)
+	assert.Equal(t, []PrincipleCount{
+		{Principle: "layer1.security_posture", Decisions: 2, Council: 1},
+		{Principle: "layer0.curation_model", Decisions: 1, Council: 1},
+		{Principle: "layer1.urgency_tiers", Decisions: 1},
+	}, stats.ByPrinciple)
+}
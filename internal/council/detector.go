@@ -5,13 +5,27 @@ import (
 	"strings"
 )
 
-// ConflictPatterns defines the regex patterns for detecting unresolved conflicts.
-// Matches bash implementation (claude_loop.sh lines 1203-1206).
-var ConflictPatterns = []*regexp.Regexp{
-	regexp.MustCompile(`(?i)PRINCIPLE_CONFLICT_UNRESOLVED`),
-	regexp.MustCompile(`(?i)cannot\s+resolve.*principle`),
-	regexp.MustCompile(`(?i)conflicting\s+principles.*unresolved`),
-}
+// ConflictBlockPattern matches the block an iteration emits when it cannot
+// resolve a principle conflict itself:
+//
+//	<principle_conflict>
+//	principles: speed_correctness, security_posture
+//	options:
+//	- Ship the fix now and add the audit log next iteration
+//	- Hold the fix until the audit log is in place
+//	context: The login fix touches session handling, which has no audit trail yet.
+//	</principle_conflict>
+var ConflictBlockPattern = regexp.MustCompile(`(?is)<principle_conflict>(.*?)</principle_conflict>`)
+
+// LegacyConflictPattern matches the one-line marker of the bash implementation,
+// "PRINCIPLE_CONFLICT_UNRESOLVED: <principle> vs <principle>", at the start of a line.
+var LegacyConflictPattern = regexp.MustCompile("(?im)^[ \\t>*`-]*PRINCIPLE_CONFLICT_UNRESOLVED[*`]*:[ \\t]*(.+)$")
+
+// conflictField matches a field line of a conflict block, such as "options:".
+var conflictField = regexp.MustCompile(`(?i)^(principles|options|context):\s*(.*)$`)
+
+// optionItem matches a list item: "- option", "* option", "1. option" or "1) option".
+var optionItem = regexp.MustCompile(`^(?:[-*]|\d+[.)])\s+(.*)$`)
 
 // DecisionPatterns for extracting decision info from output.
 var (
@@ -27,14 +41,70 @@ func NewConflictDetector() *ConflictDetector {
 	return &ConflictDetector{}
 }
 
-// Detect checks if the output contains any conflict patterns.
-func (d *ConflictDetector) Detect(output string) bool {
-	for _, pattern := range ConflictPatterns {
-		if pattern.MatchString(output) {
-			return true
+// Detect reports the principle conflict output asks to have resolved, if any.
+func (d *ConflictDetector) Detect(output string) (Conflict, bool) {
+	return ParseConflict(output)
+}
+
+// ParseConflict extracts the conflict an iteration reports. The first conflict
+// block is used; without one, a legacy PRINCIPLE_CONFLICT_UNRESOLVED line naming
+// two principles, with the lines of its paragraph as options and context.
+// A block whose principles cannot be identified is still a conflict, for the
+// council to weigh without rules; ok is false for an empty block.
+func ParseConflict(output string) (c Conflict, ok bool) {
+	if m := ConflictBlockPattern.FindStringSubmatch(output); m != nil {
+		c = parseConflictFields(strings.Split(m[1], "\n"), "")
+		return c, c.Identified() || len(c.Options) > 0 || c.Context != ""
+	}
+
+	loc := LegacyConflictPattern.FindStringSubmatchIndex(output)
+	if loc == nil {
+		return Conflict{}, false
+	}
+	rest := output[loc[1]:]
+	if end := strings.Index(rest, "\n\n"); end >= 0 {
+		rest = rest[:end]
+	}
+	c = parseConflictFields(strings.Split(rest, "\n"), "options")
+	c.setPrinciples(output[loc[2]:loc[3]])
+	return c, c.Identified()
+}
+
+// parseConflictFields reads the fields of a conflict block. Lines before the
+// first field belong to field; list items are options and other lines continue
+// the previous option, or are context when there is none.
+func parseConflictFields(lines []string, field string) Conflict {
+	var c Conflict
+	var principles, context []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := conflictField.FindStringSubmatch(line); m != nil {
+			field, line = strings.ToLower(m[1]), strings.TrimSpace(m[2])
+			if line == "" {
+				continue
+			}
+		}
+		switch field {
+		case "principles":
+			principles = append(principles, line)
+		case "options":
+			if m := optionItem.FindStringSubmatch(line); m != nil {
+				c.Options = append(c.Options, strings.TrimSpace(m[1]))
+			} else if n := len(c.Options); n > 0 {
+				c.Options[n-1] += " " + line
+			} else {
+				context = append(context, line)
+			}
+		default:
+			context = append(context, line)
 		}
 	}
-	return false
+	c.Context = strings.Join(context, "\n")
+	c.setPrinciples(strings.Join(principles, " "))
+	return c
 }
 
 // ExtractDecision extracts decision and rationale from output.
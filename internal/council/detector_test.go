@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConflictDetector_Detect(t *testing.T) {
@@ -15,29 +16,39 @@ func TestConflictDetector_Detect(t *testing.T) {
 		expected bool
 	}{
 		{
-			name:     "PRINCIPLE_CONFLICT_UNRESOLVED uppercase",
-			output:   "The task has PRINCIPLE_CONFLICT_UNRESOLVED status",
+			name:     "conflict block",
+			output:   "Stopped here.\n<principle_conflict>\nprinciples: speed_correctness, security_posture\n</principle_conflict>",
 			expected: true,
 		},
 		{
-			name:     "PRINCIPLE_CONFLICT_UNRESOLVED lowercase",
-			output:   "Found principle_conflict_unresolved in response",
+			name:     "conflict block with unknown principles",
+			output:   "<principle_conflict>\nprinciples: speed, safety\ncontext: Ship or audit first\n</principle_conflict>",
 			expected: true,
 		},
 		{
-			name:     "cannot resolve principle",
-			output:   "I cannot resolve this principle conflict",
-			expected: true,
+			name:     "empty conflict block",
+			output:   "<principle_conflict>\n</principle_conflict>",
+			expected: false,
 		},
 		{
-			name:     "Cannot Resolve Principle mixed case",
-			output:   "Cannot Resolve the Principle here",
+			name:     "legacy marker naming two principles",
+			output:   "PRINCIPLE_CONFLICT_UNRESOLVED: urgency_tiers vs security_posture",
 			expected: true,
 		},
 		{
-			name:     "conflicting principles unresolved",
+			name:     "legacy marker without principles",
+			output:   "The task has PRINCIPLE_CONFLICT_UNRESOLVED status",
+			expected: false,
+		},
+		{
+			name:     "prose about resolving principles",
+			output:   "I cannot resolve this principle conflict",
+			expected: false,
+		},
+		{
+			name:     "prose about unresolved principles",
 			output:   "There are conflicting principles that remain unresolved",
-			expected: true,
+			expected: false,
 		},
 		{
 			name:     "no conflict pattern",
@@ -49,11 +60,6 @@ func TestConflictDetector_Detect(t *testing.T) {
 			output:   "",
 			expected: false,
 		},
-		{
-			name:     "partial match - principle without conflict",
-			output:   "This is about principles in general",
-			expected: false,
-		},
 		{
 			name:     "partial match - conflict without principle",
 			output:   "There is a merge conflict here",
@@ -63,8 +69,88 @@ func TestConflictDetector_Detect(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := detector.Detect(tt.output)
-			assert.Equal(t, tt.expected, result)
+			_, found := detector.Detect(tt.output)
+			assert.Equal(t, tt.expected, found)
+		})
+	}
+}
+
+func TestParseConflict(t *testing.T) {
+	t.Run("conflict block", func(t *testing.T) {
+		output := `Implemented the session refresh.
+
+<principle_conflict>
+principles: layer1.speed_correctness, Security Posture
+options:
+- Ship the fix now
+  and add the audit log next iteration
+2. Hold the fix until the audit log is in place
+context: The login fix touches session handling,
+which has no audit trail yet.
+</principle_conflict>`
+
+		c, ok := ParseConflict(output)
+		require.True(t, ok)
+		assert.Equal(t, [2]string{"layer1.speed_correctness", "layer1.security_posture"}, c.Principles)
+		assert.Equal(t, Classify("layer1.speed_correctness", "layer1.security_posture"), c.Type)
+		assert.Equal(t, []string{
+			"Ship the fix now and add the audit log next iteration",
+			"Hold the fix until the audit log is in place",
+		}, c.Options)
+		assert.Equal(t, "The login fix touches session handling,\nwhich has no audit trail yet.", c.Context)
+	})
+
+	t.Run("block principles that are not known", func(t *testing.T) {
+		c, ok := ParseConflict("<principle_conflict>\nprinciples: speed, safety\ncontext: Ship or audit first\n</principle_conflict>")
+		require.True(t, ok)
+		assert.False(t, c.Identified())
+		assert.Equal(t, "Ship or audit first", c.Context)
+	})
+
+	t.Run("first block wins over a legacy marker", func(t *testing.T) {
+		output := "PRINCIPLE_CONFLICT_UNRESOLVED: ux_philosophy vs cost_efficiency\n\n" +
+			"<principle_conflict>\nprinciples: curation_model, monetization_model\n</principle_conflict>"
+		c, ok := ParseConflict(output)
+		require.True(t, ok)
+		assert.Equal(t, [2]string{"layer0.curation_model", "layer0.monetization_model"}, c.Principles)
+	})
+
+	tests := []struct {
+		name    string
+		output  string
+		want    [2]string
+		options []string
+		context string
+		ok      bool
+	}{
+		{
+			name:    "legacy marker with options",
+			output:  "Did some work.\n\nPRINCIPLE_CONFLICT_UNRESOLVED: layer1.speed_correctness vs security_posture\n- ship now\n- audit first\n\nDone.",
+			want:    [2]string{"layer1.speed_correctness", "layer1.security_posture"},
+			options: []string{"ship now", "audit first"},
+			ok:      true,
+		},
+		{
+			name:    "legacy marker with context",
+			output:  "**PRINCIPLE_CONFLICT_UNRESOLVED**: cost_efficiency vs scope_philosophy\nThe export feature doubles hosting cost.",
+			want:    [2]string{"layer1.cost_efficiency", "layer0.scope_philosophy"},
+			context: "The export feature doubles hosting cost.",
+			ok:      true,
+		},
+		{name: "legacy marker with one principle", output: "PRINCIPLE_CONFLICT_UNRESOLVED: security posture"},
+		{name: "legacy marker mid-sentence", output: "Nothing like PRINCIPLE_CONFLICT_UNRESOLVED: ux_philosophy vs cost_efficiency here"},
+		{name: "prose", output: "Cannot resolve the principle tension between Clarity of Intent and blast-radius here."},
+		{name: "no conflict", output: "security_posture and speed_correctness are both fine"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := ParseConflict(tt.output)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, c.Principles)
+				assert.Equal(t, tt.options, c.Options)
+				assert.Equal(t, tt.context, c.Context)
+			}
 		})
 	}
 }
@@ -0,0 +1,184 @@
+package council
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// EscalationMode selects how a conflict the council could not settle reaches a human.
+type EscalationMode string
+
+const (
+	EscalateAuto  EscalationMode = "auto"  // Ask on a terminal; otherwise write an escalation file
+	EscalateFile  EscalationMode = "file"  // Write an escalation file without asking
+	EscalateIssue EscalationMode = "issue" // Write an escalation file and open a GitHub issue for it
+	EscalateOff   EscalationMode = "off"   // The council's decision stands, however uncertain
+)
+
+// EscalationModes lists the valid escalation modes.
+var EscalationModes = []EscalationMode{EscalateAuto, EscalateFile, EscalateIssue, EscalateOff}
+
+// IsValidEscalationMode reports whether mode is one of EscalationModes.
+func IsValidEscalationMode(mode string) bool {
+	for _, m := range EscalationModes {
+		if string(m) == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultEscalationDir is where escalation files are written.
+const DefaultEscalationDir = ".claude/escalations"
+
+// DefaultEscalationConfidence is the council confidence below which a decision
+// is escalated.
+const DefaultEscalationConfidence = 0.6
+
+// Escalation is a principle conflict handed to a human because the council
+// failed, disagreed or was not confident enough.
+type Escalation struct {
+	ID        string    // Unique per run and iteration; names the escalation file
+	RunID     string    // Run the conflict arose in (may be empty)
+	Iteration int       // Iteration that reported the conflict
+	Created   time.Time // When the conflict was escalated
+	Conflict  Conflict
+	Reason    string  // Why the council's outcome was not trusted
+	Proposal  string  // The council's tentative decision (empty when it failed)
+	Rationale string  // The rationale for Proposal
+	Cost      float64 // What the council spent on the conflict in USD
+	Path      string  // Escalation file awaiting the decision (empty when answered at once)
+	URL       string  // GitHub issue opened for the escalation, if any
+	Decision  string  // The human's decision; empty while pending
+}
+
+// NewEscalation describes conflict for a human. result is the council's
+// outcome, or nil when the council failed.
+func NewEscalation(runID string, iteration int, conflict Conflict, reason string, result *Result) *Escalation {
+	now := time.Now()
+	prefix := runID
+	if prefix == "" {
+		prefix = now.Format("20060102-150405")
+	}
+	e := &Escalation{
+		ID:        fmt.Sprintf("%s-%d", prefix, iteration),
+		RunID:     runID,
+		Iteration: iteration,
+		Created:   now,
+		Conflict:  conflict,
+		Reason:    reason,
+	}
+	if result != nil {
+		e.Proposal, e.Rationale, e.Cost = result.Resolution, result.Rationale, result.Cost
+	}
+	return e
+}
+
+// EscalationReason returns why a council result should go to a human: it has
+// no decision, no majority, or a confidence below minConfidence. Returns "" when
+// the result can stand. Rule decisions and single-call results without votes
+// are only escalated when they lack a decision.
+func EscalationReason(r *Result, minConfidence float64) string {
+	switch {
+	case r.Rule != "":
+		return ""
+	case r.Resolution == "":
+		return "the council gave no decision"
+	case len(r.Votes) == 0:
+		return ""
+	}
+	voted := 0
+	for _, v := range r.Votes {
+		if v.Voted() {
+			voted++
+		}
+	}
+	if 2*len(r.Dissent) >= voted {
+		return fmt.Sprintf("no majority: %d of %d members dissent", len(r.Dissent), voted)
+	}
+	if r.Confidence < minConfidence {
+		return fmt.Sprintf("confidence %.2f is below %.2f", r.Confidence, minConfidence)
+	}
+	return ""
+}
+
+// Pending describes a pending escalation in one line for iteration prompts.
+func (e *Escalation) Pending() string {
+	var b strings.Builder
+	b.WriteString("Conflict")
+	if names := e.Conflict.Names(); names != "" {
+		b.WriteString(" between " + names)
+	}
+	fmt.Fprintf(&b, " from iteration %d", e.Iteration)
+	if len(e.Conflict.Options) > 0 {
+		fmt.Fprintf(&b, " (options: %s)", strings.Join(e.Conflict.Options, "; "))
+	}
+	if e.Conflict.Context != "" {
+		fmt.Fprintf(&b, ": %s", truncate(oneLine(e.Conflict.Context), 200))
+	}
+	return b.String()
+}
+
+// escalationDecisionHeading starts the section a human writes the decision in.
+const escalationDecisionHeading = "## Decision"
+
+// htmlComment matches the instructions left in the decision section.
+var htmlComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// FormatEscalation renders an escalation as the markdown of its file, ending
+// with an empty decision section for the human to fill in.
+func FormatEscalation(e *Escalation) string {
+	var b strings.Builder
+	b.WriteString("# Principle conflict escalation\n\n")
+	if e.RunID != "" {
+		fmt.Fprintf(&b, "- Run: %s\n", e.RunID)
+	}
+	fmt.Fprintf(&b, "- Iteration: %d\n", e.Iteration)
+	fmt.Fprintf(&b, "- Escalated: %s\n", e.Created.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Reason: %s\n\n", e.Reason)
+	fmt.Fprintf(&b, "## Conflict\n\n%s\n\n", e.Conflict)
+	if e.Proposal != "" {
+		fmt.Fprintf(&b, "## Council Proposal\n\n**Decision**: %s\n", e.Proposal)
+		if e.Rationale != "" {
+			fmt.Fprintf(&b, "**Rationale**: %s\n", e.Rationale)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(escalationDecisionHeading + "\n\n")
+	b.WriteString("<!-- Write your decision below. claude-loop reads it before the next iteration;\n")
+	b.WriteString("until then, iterations leave the work that depends on it alone. -->\n")
+	return b.String()
+}
+
+// WriteEscalation writes e to dir as <ID>.md and records the path in e.Path.
+func WriteEscalation(dir string, e *Escalation) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return &CouncilError{Phase: "escalate", Message: "failed to create " + dir, Err: err}
+	}
+	path := filepath.Join(dir, e.ID+".md")
+	if err := os.WriteFile(path, []byte(FormatEscalation(e)), 0644); err != nil {
+		return &CouncilError{Phase: "escalate", Message: "failed to write " + path, Err: err}
+	}
+	e.Path = path
+	return nil
+}
+
+// ReadEscalationDecision returns the decision a human wrote in the escalation
+// file at path, or "" while there is none.
+func ReadEscalationDecision(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", &CouncilError{Phase: "escalate", Message: "failed to read " + path, Err: err}
+	}
+	text := string(data)
+	i := strings.LastIndex(text, escalationDecisionHeading)
+	if i < 0 {
+		return "", nil
+	}
+	answer := htmlComment.ReplaceAllString(text[i+len(escalationDecisionHeading):], "")
+	return strings.TrimSpace(answer), nil
+}
@@ -0,0 +1,109 @@
+package council
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidEscalationMode(t *testing.T) {
+	for _, mode := range EscalationModes {
+		assert.True(t, IsValidEscalationMode(string(mode)))
+	}
+	assert.False(t, IsValidEscalationMode(""))
+	assert.False(t, IsValidEscalationMode("slack"))
+}
+
+func TestEscalationReason(t *testing.T) {
+	votes := func(dissent ...string) []Vote {
+		all := []Vote{{Member: "security", Vote: "both"}, {Member: "product", Vote: "both"}, {Member: "cost", Vote: "both"}, {Member: "delivery", Error: "timeout"}}
+		for i := range all {
+			for _, d := range dissent {
+				if all[i].Member == d {
+					all[i].Vote = "cost_efficiency"
+				}
+			}
+		}
+		return all
+	}
+
+	tests := []struct {
+		name   string
+		result *Result
+		want   string
+	}{
+		{name: "rule decision", result: &Result{Rule: "R4", Resolution: "Prioritize security"}},
+		{name: "single call", result: &Result{Resolution: "Ship it"}},
+		{name: "no decision", result: &Result{}, want: "the council gave no decision"},
+		{name: "confident majority", result: &Result{Resolution: "Do both", Votes: votes("cost"), Dissent: []string{"cost"}, Confidence: 0.8}},
+		{
+			name:   "no majority",
+			result: &Result{Resolution: "Do both", Votes: votes("cost", "product"), Dissent: []string{"cost", "product"}, Confidence: 0.9},
+			want:   "no majority: 2 of 3 members dissent",
+		},
+		{
+			name:   "low confidence",
+			result: &Result{Resolution: "Do both", Votes: votes(), Confidence: 0.4},
+			want:   "confidence 0.40 is below 0.60",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EscalationReason(tt.result, DefaultEscalationConfidence))
+		})
+	}
+}
+
+func TestNewEscalation(t *testing.T) {
+	conflict := conflictOf("speed_correctness", "security_posture")
+	conflict.Options = []string{"Ship now", "Audit first"}
+	conflict.Context = "The login fix has\nno audit trail."
+
+	e := NewEscalation("run-1", 3, conflict, "confidence 0.40 is below 0.60", &Result{Resolution: "Audit first", Rationale: "Security", Cost: 0.2})
+	assert.Equal(t, "run-1-3", e.ID)
+	assert.Equal(t, "Audit first", e.Proposal)
+	assert.Equal(t, 0.2, e.Cost)
+	assert.Equal(t, "Conflict between speed_correctness vs security_posture from iteration 3 (options: Ship now; Audit first): The login fix has no audit trail.", e.Pending())
+
+	failed := NewEscalation("", 2, Conflict{Context: "Cache or not"}, "the council failed: timeout", nil)
+	assert.True(t, strings.HasSuffix(failed.ID, "-2"))
+	assert.Empty(t, failed.Proposal)
+	assert.Equal(t, "Conflict from iteration 2: Cache or not", failed.Pending())
+}
+
+func TestWriteEscalation(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "escalations")
+	e := NewEscalation("run-1", 3, conflictOf("speed_correctness", "security_posture"), "no majority: 2 of 4 members dissent",
+		&Result{Resolution: "Audit first", Rationale: "Security"})
+
+	require.NoError(t, WriteEscalation(dir, e))
+	assert.Equal(t, filepath.Join(dir, "run-1-3.md"), e.Path)
+
+	data, err := os.ReadFile(e.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "- Reason: no majority: 2 of 4 members dissent\n")
+	assert.Contains(t, string(data), "## Conflict\n\nPrinciples: layer1.speed_correctness vs layer1.security_posture")
+	assert.Contains(t, string(data), "## Council Proposal\n\n**Decision**: Audit first\n**Rationale**: Security\n")
+
+	t.Run("no decision yet", func(t *testing.T) {
+		decision, err := ReadEscalationDecision(e.Path)
+		require.NoError(t, err)
+		assert.Empty(t, decision)
+	})
+
+	t.Run("decision written", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(e.Path, append(data, []byte("\nShip now, audit next sprint.\n")...), 0644))
+		decision, err := ReadEscalationDecision(e.Path)
+		require.NoError(t, err)
+		assert.Equal(t, "Ship now, audit next sprint.", decision)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ReadEscalationDecision(filepath.Join(dir, "missing.md"))
+		assert.True(t, IsCouncilError(err))
+	})
+}
@@ -1,6 +1,7 @@
 package council
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,7 +23,8 @@ func NewDecisionLogger(logFile string, enabled bool) *DecisionLogger {
 	}
 }
 
-// Log writes a decision entry to the log file.
+// Log writes a decision entry to the log file, and a record of it to the
+// structured log at DecisionsPath.
 // Returns nil if logging is disabled, decision is nil, or decision is empty.
 func (l *DecisionLogger) Log(decision *Decision) error {
 	if !l.enabled {
@@ -72,6 +74,15 @@ council_invoked: %t
 		escapeYAMLString(decision.Rationale),
 		decision.Preset,
 		decision.CouncilInvoked)
+	if decision.Rule != "" {
+		entry += fmt.Sprintf("rule: \"%s\"\n", escapeYAMLString(decision.Rule))
+	}
+	if len(decision.Votes) > 0 {
+		entry += formatVotes(decision)
+	}
+	if decision.Escalated {
+		entry += "escalated: true\n"
+	}
 
 	if _, err := f.WriteString(entry); err != nil {
 		return &CouncilError{
@@ -81,6 +92,23 @@ council_invoked: %t
 		}
 	}
 
+	return l.logRecord(decision)
+}
+
+// logRecord appends the decision to the structured log next to the log file.
+func (l *DecisionLogger) logRecord(decision *Decision) error {
+	data, err := json.Marshal(NewDecisionRecord(decision))
+	if err != nil {
+		return &CouncilError{Phase: "log", Message: "failed to encode decision", Err: err}
+	}
+	f, err := os.OpenFile(DecisionsPath(l.logFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return &CouncilError{Phase: "log", Message: "failed to open decisions file", Err: err}
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return &CouncilError{Phase: "log", Message: "failed to write decision record", Err: err}
+	}
 	return nil
 }
 
@@ -89,6 +117,29 @@ func (l *DecisionLogger) IsEnabled() bool {
 	return l.enabled
 }
 
+// formatVotes renders the council's confidence, each member's vote and the dissent.
+func formatVotes(decision *Decision) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "confidence: %.2f\nvotes:\n", decision.Confidence)
+	for _, v := range decision.Votes {
+		fmt.Fprintf(&b, "  - member: \"%s\"\n", escapeYAMLString(v.Member))
+		if !v.Voted() {
+			fmt.Fprintf(&b, "    error: \"%s\"\n", escapeYAMLString(v.Error))
+			continue
+		}
+		fmt.Fprintf(&b, "    vote: \"%s\"\n    confidence: %d\n", escapeYAMLString(v.Vote), v.Confidence)
+		if v.Rationale != "" {
+			fmt.Fprintf(&b, "    rationale: \"%s\"\n", escapeYAMLString(v.Rationale))
+		}
+	}
+	dissent := make([]string, len(decision.Dissent))
+	for i, member := range decision.Dissent {
+		dissent[i] = fmt.Sprintf("\"%s\"", escapeYAMLString(member))
+	}
+	fmt.Fprintf(&b, "dissent: [%s]\n", strings.Join(dissent, ", "))
+	return b.String()
+}
+
 // escapeYAMLString escapes special characters for YAML string values.
 func escapeYAMLString(s string) string {
 	var b strings.Builder
@@ -41,6 +41,98 @@ func TestDecisionLogger_Log(t *testing.T) {
 		assert.Contains(t, contentStr, `rationale: "Speed is prioritized"`)
 		assert.Contains(t, contentStr, `preset: "startup"`)
 		assert.Contains(t, contentStr, "council_invoked: true")
+		assert.NotContains(t, contentStr, "rule:")
+	})
+
+	t.Run("cites the rule that decided", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "decisions.log")
+		logger := NewDecisionLogger(logFile, true)
+
+		require.NoError(t, logger.Log(&Decision{
+			Decision:  "Prioritize cost_efficiency (6) over scope_philosophy (4)",
+			Rationale: "R3 - Prioritize cost efficiency for non-critical features",
+			Rule:      "R3",
+		}))
+
+		content, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "council_invoked: false\nrule: \"R3\"\n")
+	})
+
+	t.Run("marks decisions a human made", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "decisions.log")
+		logger := NewDecisionLogger(logFile, true)
+
+		require.NoError(t, logger.Log(&Decision{
+			Decision:       "Audit first",
+			Rationale:      "Escalated to a human: confidence 0.40 is below 0.60",
+			CouncilInvoked: true,
+			Escalated:      true,
+		}))
+
+		content, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "council_invoked: true\nescalated: true\n")
+
+		records, err := ReadDecisions(filepath.Join(filepath.Dir(logFile), "decisions.jsonl"))
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.True(t, records[0].Escalated)
+	})
+
+	t.Run("writes a structured record alongside", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "decisions.log")
+		logger := NewDecisionLogger(logFile, true)
+
+		require.NoError(t, logger.Log(&Decision{
+			Timestamp: time.Date(2026, 1, 14, 10, 30, 0, 0, time.UTC),
+			RunID:     "20260114-103000-abcd",
+			Iteration: 2,
+			Decision:  "Prioritize cost_efficiency (6) over scope_philosophy (4)",
+			Rule:      "R3",
+		}))
+
+		records, err := ReadDecisions(filepath.Join(filepath.Dir(logFile), "decisions.jsonl"))
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, "20260114-103000-abcd", records[0].RunID)
+		assert.Equal(t, 2, records[0].Iteration)
+		assert.Equal(t, "R3", records[0].Rule)
+		assert.Equal(t, []string{"layer1.cost_efficiency", "layer0.scope_philosophy"}, records[0].Principles)
+	})
+
+	t.Run("records the council's votes and dissent", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "decisions.log")
+		logger := NewDecisionLogger(logFile, true)
+
+		require.NoError(t, logger.Log(&Decision{
+			Decision:       "Curate by hand",
+			CouncilInvoked: true,
+			Confidence:     0.7,
+			Votes: []Vote{
+				{Member: "security", Vote: "curation_model", Confidence: 8, Rationale: "Editors catch abuse"},
+				{Member: "cost", Vote: "monetization_model", Confidence: 6},
+				{Member: "delivery", Error: "exit 1"},
+			},
+			Dissent: []string{"cost"},
+		}))
+
+		content, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `council_invoked: true
+confidence: 0.70
+votes:
+  - member: "security"
+    vote: "curation_model"
+    confidence: 8
+    rationale: "Editors catch abuse"
+  - member: "cost"
+    vote: "monetization_model"
+    confidence: 6
+  - member: "delivery"
+    error: "exit 1"
+dissent: ["cost"]
+`)
 	})
 
 	t.Run("returns nil when disabled", func(t *testing.T) {
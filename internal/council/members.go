@@ -0,0 +1,129 @@
+package council
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+)
+
+// DefaultFile is where council members are configured.
+const DefaultFile = ".claude/council.yaml"
+
+// ChairName is the key of the chair's client in Config.Clients.
+const ChairName = "chair"
+
+// Member is a council member: a persona that weighs a conflict from the
+// principles it is responsible for.
+type Member struct {
+	Name       string   `yaml:"name"`
+	Persona    string   `yaml:"persona"`
+	Principles []string `yaml:"principles"`      // Principle keys, with or without the layer prefix
+	Model      string   `yaml:"model,omitempty"` // Model for this member (empty = the council model)
+}
+
+// Chair synthesises the members' answers into one decision.
+type Chair struct {
+	Persona string `yaml:"persona,omitempty"` // Replaces the default chair instructions
+	Model   string `yaml:"model,omitempty"`   // Model for the chair (empty = the council model)
+}
+
+// Settings is the council file: who sits on the council and what an
+// invocation may cost.
+type Settings struct {
+	Members []Member `yaml:"members"`
+	Chair   Chair    `yaml:"chair,omitempty"`
+	MaxCost float64  `yaml:"max_cost,omitempty"` // Cost cap per invocation in USD (0 = unlimited)
+}
+
+// DefaultMembers returns the built-in council: one member per principle group,
+// together covering all 18 principles.
+func DefaultMembers() []Member {
+	return []Member{
+		{
+			Name:    "security",
+			Persona: "You protect users and the business from security, privacy and compliance risk.",
+			Principles: []string{"layer1.security_posture", "layer0.privacy_posture", "layer0.trust_architecture",
+				"layer0.auditability", "layer1.reversibility_priority"},
+		},
+		{
+			Name:    "product",
+			Persona: "You speak for the people who use the product: its scope, experience and openness.",
+			Principles: []string{"layer0.scope_philosophy", "layer0.ux_philosophy", "layer0.curation_model",
+				"layer0.authority_stance", "layer0.interoperability"},
+		},
+		{
+			Name:    "delivery",
+			Persona: "You care about shipping: pace, change size, clarity and the cost of migrations.",
+			Principles: []string{"layer1.speed_correctness", "layer1.urgency_tiers", "layer1.blast_radius",
+				"layer1.clarity_of_intent", "layer1.migration_burden"},
+		},
+		{
+			Name:       "cost",
+			Persona:    "You weigh what a choice costs to build, run and maintain.",
+			Principles: []string{"layer1.cost_efficiency", "layer0.monetization_model", "layer1.innovation_stability"},
+		},
+	}
+}
+
+// DefaultSettings returns the settings used when there is no council file.
+func DefaultSettings() *Settings {
+	return &Settings{Members: DefaultMembers()}
+}
+
+// LoadSettings reads the council file at path. A missing file yields DefaultSettings.
+func LoadSettings(path string) (*Settings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultSettings(), nil
+		}
+		return nil, &CouncilError{Phase: "config", Message: "failed to read " + path, Err: err}
+	}
+	var s Settings
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, &CouncilError{Phase: "config", Message: "invalid YAML in " + path, Err: err}
+	}
+	if err := s.Validate(); err != nil {
+		return nil, &CouncilError{Phase: "config", Message: path, Err: err}
+	}
+	return &s, nil
+}
+
+// Validate checks member names and principle keys and the cost cap.
+// Principle keys are normalized to their full form.
+func (s *Settings) Validate() error {
+	if s.MaxCost < 0 {
+		return fmt.Errorf("max_cost cannot be negative")
+	}
+	seen := make(map[string]bool)
+	for i := range s.Members {
+		m := &s.Members[i]
+		switch {
+		case m.Name == "":
+			return fmt.Errorf("member %d has no name", i+1)
+		case m.Name == ChairName:
+			return fmt.Errorf("member name %q is reserved", ChairName)
+		case seen[m.Name]:
+			return fmt.Errorf("duplicate member %q", m.Name)
+		}
+		seen[m.Name] = true
+		for j, key := range m.Principles {
+			full, ok := config.NormalizePrincipleKey(key)
+			if !ok {
+				return fmt.Errorf("member %s: unknown principle %q", m.Name, key)
+			}
+			m.Principles[j] = full
+		}
+	}
+	return nil
+}
+
+// Limit keeps the first n members. n <= 0 keeps them all.
+func (s *Settings) Limit(n int) {
+	if n > 0 && n < len(s.Members) {
+		s.Members = s.Members[:n]
+	}
+}
@@ -0,0 +1,83 @@
+package council
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultMembers_CoverEveryPrinciple(t *testing.T) {
+	covered := make(map[string]bool)
+	for _, m := range DefaultMembers() {
+		for _, key := range m.Principles {
+			covered[key] = true
+		}
+	}
+	assert.Len(t, covered, 18)
+	assert.NoError(t, DefaultSettings().Validate())
+}
+
+func TestLoadSettings(t *testing.T) {
+	t.Run("missing file uses the defaults", func(t *testing.T) {
+		s, err := LoadSettings(filepath.Join(t.TempDir(), "council.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, DefaultSettings(), s)
+	})
+
+	t.Run("reads members, chair and cap", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "council.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`max_cost: 0.5
+chair:
+  model: opus
+members:
+  - name: compliance
+    persona: You answer to the regulator.
+    principles: [auditability, layer0.privacy_posture]
+    model: sonnet
+  - name: delivery
+    persona: You ship.
+`), 0644))
+
+		s, err := LoadSettings(path)
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, s.MaxCost)
+		assert.Equal(t, "opus", s.Chair.Model)
+		require.Len(t, s.Members, 2)
+		assert.Equal(t, []string{"layer0.auditability", "layer0.privacy_posture"}, s.Members[0].Principles)
+		assert.Equal(t, "sonnet", s.Members[0].Model)
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		tests := []struct {
+			name, content, wantErr string
+		}{
+			{"yaml", "members: [", "invalid YAML"},
+			{"unnamed member", "members:\n  - persona: x\n", "member 1 has no name"},
+			{"reserved name", "members:\n  - name: chair\n", `member name "chair" is reserved`},
+			{"duplicate", "members:\n  - name: a\n  - name: a\n", `duplicate member "a"`},
+			{"unknown principle", "members:\n  - name: a\n    principles: [velocity]\n", `member a: unknown principle "velocity"`},
+			{"negative cap", "max_cost: -1\n", "max_cost cannot be negative"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "council.yaml")
+				require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+				_, err := LoadSettings(path)
+				require.Error(t, err)
+				assert.True(t, IsCouncilError(err))
+				assert.Contains(t, err.Error(), tt.wantErr)
+			})
+		}
+	})
+}
+
+func TestSettings_Limit(t *testing.T) {
+	s := DefaultSettings()
+	s.Limit(0)
+	assert.Len(t, s.Members, 4)
+	s.Limit(2)
+	assert.Equal(t, []string{"security", "product"}, []string{s.Members[0].Name, s.Members[1].Name})
+}
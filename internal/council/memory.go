@@ -0,0 +1,187 @@
+package council
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Limits on the precedents offered to a prompt.
+const (
+	MaxPrecedents      = 5    // Most decisions offered at once
+	MaxPrecedentsChars = 1500 // Most characters the formatted decisions may take
+	recentDecisions    = 3    // The latest decisions are always candidates
+)
+
+// DecisionMemory remembers the decisions made so far, including those read from
+// earlier runs, and selects the ones relevant to a prompt as precedents.
+type DecisionMemory struct {
+	mu      sync.Mutex
+	records []*DecisionRecord // Oldest first
+}
+
+// NewDecisionMemory creates a DecisionMemory holding records, oldest first.
+func NewDecisionMemory(records []*DecisionRecord) *DecisionMemory {
+	return &DecisionMemory{records: append([]*DecisionRecord(nil), records...)}
+}
+
+// Add remembers a decision.
+func (m *DecisionMemory) Add(r *DecisionRecord) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records = append(m.records, r)
+}
+
+// Len returns the number of decisions remembered.
+func (m *DecisionMemory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.records)
+}
+
+// Relevant selects up to MaxPrecedents decisions for a prompt about query:
+// the most recent ones, those the council made, and those sharing keywords with
+// query, best match first. A decision repeated later is offered once, as its
+// latest record.
+func (m *DecisionMemory) Relevant(query string) []*DecisionRecord {
+	m.mu.Lock()
+	records := append([]*DecisionRecord(nil), m.records...)
+	m.mu.Unlock()
+
+	keywords := keywordSet(query)
+	type candidate struct {
+		record *DecisionRecord
+		score  int
+		order  int
+	}
+	var candidates []candidate
+	seen := make(map[string]bool)
+	for i := len(records) - 1; i >= 0; i-- {
+		r := records[i]
+		key := strings.ToLower(strings.TrimSpace(r.Decision))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		score := 0
+		for word := range keywordSet(r.Decision + " " + r.Rationale + " " + strings.Join(r.Principles, " ")) {
+			if keywords[word] {
+				score += 2
+			}
+		}
+		if r.CouncilInvoked {
+			score++
+		}
+		recent := len(candidates) < recentDecisions
+		if score == 0 && !recent {
+			continue
+		}
+		if recent {
+			score++
+		}
+		candidates = append(candidates, candidate{record: r, score: score, order: len(candidates)})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].order < candidates[j].order
+	})
+
+	var selected []*DecisionRecord
+	for _, c := range candidates {
+		if len(selected) == MaxPrecedents {
+			break
+		}
+		selected = append(selected, c.record)
+	}
+	return selected
+}
+
+// Precedents returns the decisions relevant to query formatted one per line,
+// dropping the least relevant ones beyond MaxPrecedentsChars.
+func (m *DecisionMemory) Precedents(query string) []string {
+	var lines []string
+	size := 0
+	for _, r := range m.Relevant(query) {
+		line := FormatPrecedent(r)
+		if size+len(line) > MaxPrecedentsChars {
+			break
+		}
+		size += len(line)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// FormatPrecedent describes a decision in one line: who made it, when, what was
+// decided and why.
+func FormatPrecedent(r *DecisionRecord) string {
+	var b strings.Builder
+	switch {
+	case r.Escalated:
+		b.WriteString("Human")
+	case r.CouncilInvoked:
+		b.WriteString("Council")
+	case r.Rule != "":
+		b.WriteString("Rule " + r.Rule)
+	default:
+		b.WriteString("Decided")
+	}
+	if r.Iteration > 0 {
+		fmt.Fprintf(&b, ", iteration %d", r.Iteration)
+	}
+	if r.RunID != "" {
+		fmt.Fprintf(&b, " of %s", r.RunID)
+	}
+	fmt.Fprintf(&b, ": %s", truncate(oneLine(r.Decision), 200))
+	if r.Rationale != "" {
+		fmt.Fprintf(&b, " (because: %s)", truncate(oneLine(r.Rationale), 160))
+	}
+	return b.String()
+}
+
+// stopWords are frequent words that say nothing about what a decision is about.
+var stopWords = map[string]bool{
+	"about": true, "after": true, "also": true, "because": true, "before": true, "being": true,
+	"code": true, "does": true, "each": true, "every": true, "from": true, "have": true,
+	"into": true, "just": true, "make": true, "more": true, "must": true, "only": true,
+	"over": true, "should": true, "some": true, "than": true, "that": true, "their": true,
+	"them": true, "then": true, "there": true, "these": true, "they": true, "this": true,
+	"those": true, "under": true, "until": true, "using": true, "when": true,
+	"where": true, "which": true, "while": true, "will": true, "with": true, "without": true,
+	"would": true, "your": true, "layer0": true, "layer1": true,
+}
+
+// keywordSet returns the lowercase words of text that are four letters or
+// longer and not stop words. Principle keys are split into their words.
+func keywordSet(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		if len(w) >= 4 && !stopWords[w] {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+// oneLine collapses whitespace, including newlines, to single spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncate shortens s to at most n characters, marking the cut with "...".
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:n-3])) + "..."
+}
@@ -0,0 +1,110 @@
+package council
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decisions(r ...*DecisionRecord) []string {
+	out := make([]string, len(r))
+	for i, d := range r {
+		out[i] = d.Decision
+	}
+	return out
+}
+
+func TestDecisionMemory_Relevant(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, NewDecisionMemory(nil).Relevant("anything"))
+	})
+
+	t.Run("recent, council and keyword matches", func(t *testing.T) {
+		m := NewDecisionMemory([]*DecisionRecord{
+			{Decision: "Store sessions in Redis", Rationale: "Sessions must survive restarts"},
+			{Decision: "Skip the admin dashboard"},
+			{Decision: "Log every payment", CouncilInvoked: true},
+			{Decision: "Write docs later"},
+			{Decision: "Use table tests"},
+			{Decision: "Pin dependencies"},
+			{Decision: "Keep the CLI flags stable"},
+		})
+		m.Add(&DecisionRecord{Decision: "Prefer small PRs"})
+
+		got := decisions(m.Relevant("Fix session handling after restarts")...)
+		assert.Equal(t, []string{
+			"Store sessions in Redis", // Matches "restarts"
+			"Prefer small PRs",        // Recent
+			"Keep the CLI flags stable",
+			"Pin dependencies",
+			"Log every payment", // Council
+		}, got)
+		assert.Equal(t, 8, m.Len())
+	})
+
+	t.Run("repeated decisions are offered once", func(t *testing.T) {
+		m := NewDecisionMemory([]*DecisionRecord{
+			{Iteration: 1, Decision: "Use SQLite"},
+			{Iteration: 4, Decision: "use sqlite "},
+		})
+		relevant := m.Relevant("")
+		require.Len(t, relevant, 1)
+		assert.Equal(t, 4, relevant[0].Iteration)
+	})
+}
+
+func TestDecisionMemory_Precedents(t *testing.T) {
+	long := strings.Repeat("word ", 60)
+	var records []*DecisionRecord
+	for i := 0; i < MaxPrecedents; i++ {
+		records = append(records, &DecisionRecord{Decision: long + string(rune('a'+i)), Rationale: long})
+	}
+	lines := NewDecisionMemory(records).Precedents("")
+
+	size := 0
+	for _, line := range lines {
+		size += len(line)
+	}
+	assert.Less(t, len(lines), MaxPrecedents)
+	assert.LessOrEqual(t, size, MaxPrecedentsChars)
+}
+
+func TestFormatPrecedent(t *testing.T) {
+	tests := []struct {
+		name   string
+		record *DecisionRecord
+		want   string
+	}{
+		{
+			name:   "council",
+			record: &DecisionRecord{RunID: "run-20261001-090000", Iteration: 3, Decision: "Curate by hand", Rationale: "Quality\nfirst", CouncilInvoked: true},
+			want:   "Council, iteration 3 of run-20261001-090000: Curate by hand (because: Quality first)",
+		},
+		{
+			name:   "human",
+			record: &DecisionRecord{Iteration: 2, Decision: "Audit first", CouncilInvoked: true, Escalated: true},
+			want:   "Human, iteration 2: Audit first",
+		},
+		{
+			name:   "rule",
+			record: &DecisionRecord{Iteration: 1, Decision: "Prioritize security_posture (9) over urgency_tiers (5)", Rule: "R4"},
+			want:   "Rule R4, iteration 1: Prioritize security_posture (9) over urgency_tiers (5)",
+		},
+		{
+			name:   "logged",
+			record: &DecisionRecord{Decision: "Use SQLite"},
+			want:   "Decided: Use SQLite",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatPrecedent(tt.record))
+		})
+	}
+
+	long := FormatPrecedent(&DecisionRecord{Decision: strings.Repeat("é", 300)})
+	assert.True(t, strings.HasSuffix(long, "..."))
+	assert.Len(t, []rune(long), len("Decided: ")+200)
+}
@@ -2,6 +2,7 @@ package council
 
 import (
 	"fmt"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
@@ -42,8 +43,31 @@ func NewPromptBuilder() *PromptBuilder {
 
 // BuildContext contains inputs for building a council prompt.
 type BuildContext struct {
-	ConflictContext string             // The conflict context from Claude's output
-	Principles      *config.Principles // Current principles
+	Conflict   Conflict           // The conflict the iteration reported
+	Principles *config.Principles // Current principles
+	Precedents []string           // Earlier decisions to stay consistent with (may be empty)
+}
+
+// TemplatePrecedents introduces earlier decisions after the conflict.
+const TemplatePrecedents = `
+
+## Precedents
+Earlier decisions on related trade-offs. Stay consistent with them unless this
+conflict differs, and say why when you depart from one:
+`
+
+// conflictSection returns the conflict followed by any precedents.
+func (ctx BuildContext) conflictSection() string {
+	if len(ctx.Precedents) == 0 {
+		return ctx.Conflict.String()
+	}
+	var b strings.Builder
+	b.WriteString(ctx.Conflict.String())
+	b.WriteString(TemplatePrecedents)
+	for _, p := range ctx.Precedents {
+		fmt.Fprintf(&b, "- %s\n", p)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
 }
 
 // BuildResult contains the built prompt.
@@ -66,7 +90,115 @@ func (b *PromptBuilder) Build(ctx BuildContext) (*BuildResult, error) {
 		}
 	}
 
-	prompt := fmt.Sprintf(TemplateCouncilResolution, ctx.ConflictContext, string(principlesYAML))
+	prompt := fmt.Sprintf(TemplateCouncilResolution, ctx.conflictSection(), string(principlesYAML))
+
+	return &BuildResult{Prompt: prompt}, nil
+}
+
+// TemplateMemberVote is the prompt a council member answers independently.
+const TemplateMemberVote = `You are the %s member of a council resolving a principle conflict.
+%s
+
+## Conflict Context
+%s
+
+## Your Principles
+%s
+## All Principles
+` + "```yaml" + `
+%s
+` + "```" + `
+
+## Instructions
+Weigh the conflict from your perspective; the other members weigh it from theirs.
+Vote for the option you support: %s.
+
+## Response Format
+**Vote**: <option>
+**Confidence**: <1-10>
+**Decision**: <your recommendation>
+**Rationale**: <which principle(s) applied and why>`
+
+// TemplateChairSynthesis is the prompt the chair answers once the members have voted.
+const TemplateChairSynthesis = `%s
+
+## Conflict Context
+%s
+
+## Member Answers
+%s
+## Tally
+%s
+## Instructions
+Synthesise one decision. Follow the majority unless a member raised a risk the
+others missed, and say so in the rationale. Name any dissent that remains.
+
+## Response Format
+**Vote**: <the option the council adopts>
+**Confidence**: <1-10>
+**Decision**: <the council's decision>
+**Rationale**: <why, including how the dissent was weighed>`
+
+// DefaultChairPersona introduces the chair when Chair.Persona is empty.
+const DefaultChairPersona = "You chair a council whose members have each weighed a principle conflict from their own perspective."
+
+// BuildMember constructs the prompt for one council member. options lists what
+// the member may vote for; when empty, the member names the option itself.
+func (b *PromptBuilder) BuildMember(ctx BuildContext, m Member, options []string) (*BuildResult, error) {
+	if ctx.Principles == nil {
+		return nil, ErrNoPrinciples
+	}
+	principlesYAML, err := yaml.Marshal(ctx.Principles)
+	if err != nil {
+		return nil, &CouncilError{Phase: "prompt", Message: "failed to marshal principles", Err: err}
+	}
+
+	var focus strings.Builder
+	for _, key := range m.Principles {
+		value, _ := ctx.Principles.Principle(key)
+		info, _ := config.DescribePrinciple(key)
+		fmt.Fprintf(&focus, "- %s: %d (1 = %s, 10 = %s)\n", key, value, info.Low, info.High)
+	}
+	if focus.Len() == 0 {
+		focus.WriteString("- (all principles)\n")
+	}
 
+	choices := "a short name for the option"
+	if len(options) > 0 {
+		quoted := make([]string, len(options))
+		for i, o := range options {
+			quoted[i] = "`" + o + "`"
+		}
+		choices = "one of " + strings.Join(quoted, ", ")
+	}
+
+	prompt := fmt.Sprintf(TemplateMemberVote, m.Name, m.Persona, ctx.conflictSection(),
+		focus.String(), string(principlesYAML), choices)
 	return &BuildResult{Prompt: prompt}, nil
 }
+
+// BuildChair constructs the chair's prompt from the members' votes.
+func (b *PromptBuilder) BuildChair(ctx BuildContext, chair Chair, votes []Vote) *BuildResult {
+	persona := chair.Persona
+	if persona == "" {
+		persona = DefaultChairPersona
+	}
+
+	var answers strings.Builder
+	for _, v := range votes {
+		if !v.Voted() {
+			fmt.Fprintf(&answers, "### %s (did not vote)\n\n", v.Member)
+			continue
+		}
+		fmt.Fprintf(&answers, "### %s (vote: %s, confidence %d)\n", v.Member, v.Vote, v.Confidence)
+		fmt.Fprintf(&answers, "**Decision**: %s\n**Rationale**: %s\n\n", v.Decision, v.Rationale)
+	}
+
+	var tally strings.Builder
+	for _, t := range tallyVotes(votes) {
+		fmt.Fprintf(&tally, "- %s: %d (%s)\n", t.Vote, len(t.Members), strings.Join(t.Members, ", "))
+	}
+
+	prompt := fmt.Sprintf(TemplateChairSynthesis, persona, ctx.conflictSection(), answers.String(), tally.String())
+	return &BuildResult{Prompt: prompt}
+}
@@ -1,6 +1,7 @@
 package council
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/DeukWoongWoo/claude-loop/internal/config"
@@ -13,7 +14,7 @@ func TestPromptBuilder_Build(t *testing.T) {
 
 	t.Run("success with valid principles", func(t *testing.T) {
 		ctx := BuildContext{
-			ConflictContext: "Cannot decide between speed and correctness",
+			Conflict: Conflict{Context: "Cannot decide between speed and correctness"},
 			Principles: &config.Principles{
 				Version: "2.3",
 				Preset:  config.PresetStartup,
@@ -41,8 +42,8 @@ func TestPromptBuilder_Build(t *testing.T) {
 
 	t.Run("error with nil principles", func(t *testing.T) {
 		ctx := BuildContext{
-			ConflictContext: "Some conflict",
-			Principles:      nil,
+			Conflict:   Conflict{Context: "Some conflict"},
+			Principles: nil,
 		}
 
 		result, err := builder.Build(ctx)
@@ -54,7 +55,7 @@ func TestPromptBuilder_Build(t *testing.T) {
 
 	t.Run("includes YAML-formatted principles", func(t *testing.T) {
 		ctx := BuildContext{
-			ConflictContext: "Test conflict",
+			Conflict: Conflict{Context: "Test conflict"},
 			Principles: &config.Principles{
 				Version: "2.3",
 				Preset:  config.PresetEnterprise,
@@ -88,3 +89,33 @@ func TestTemplateCouncilResolution(t *testing.T) {
 	assert.Contains(t, TemplateCouncilResolution, "Priority Resolution")
 	assert.Contains(t, TemplateCouncilResolution, "Response Format")
 }
+
+func TestPromptBuilder_BuildMember(t *testing.T) {
+	b := NewPromptBuilder()
+	m := Member{Name: "cost", Persona: "You weigh cost.", Principles: []string{"layer1.cost_efficiency"}}
+	bctx := BuildContext{Conflict: Conflict{Context: "Build or buy the queue?"}, Principles: config.DefaultPrinciples(config.PresetStartup)}
+
+	result, err := b.BuildMember(bctx, m, nil)
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "You are the cost member of a council resolving a principle conflict.\nYou weigh cost.")
+	assert.Contains(t, result.Prompt, "Build or buy the queue?")
+	assert.Contains(t, result.Prompt, "- layer1.cost_efficiency: ")
+	assert.Contains(t, result.Prompt, "Vote for the option you support: a short name for the option.")
+	assert.Contains(t, result.Prompt, "**Confidence**: <1-10>")
+
+	_, err = b.BuildMember(BuildContext{}, m, nil)
+	assert.Equal(t, ErrNoPrinciples, err)
+}
+
+func TestPromptBuilder_BuildChair(t *testing.T) {
+	votes := []Vote{
+		{Member: "security", Vote: "both", Confidence: 7, Decision: "Do both", Rationale: "No trade-off"},
+		{Member: "cost", Error: "exit 1"},
+	}
+	result := NewPromptBuilder().BuildChair(BuildContext{Conflict: Conflict{Context: "conflict"}}, Chair{Persona: "You chair the fintech council."}, votes)
+
+	assert.True(t, strings.HasPrefix(result.Prompt, "You chair the fintech council.\n"))
+	assert.Contains(t, result.Prompt, "### security (vote: both, confidence 7)\n**Decision**: Do both\n**Rationale**: No trade-off")
+	assert.Contains(t, result.Prompt, "### cost (did not vote)")
+	assert.Contains(t, result.Prompt, "## Tally\n- both: 1 (security)\n")
+}
@@ -0,0 +1,449 @@
+package council
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+)
+
+// principleTraits classifies a principle for the R10 protocol.
+type principleTraits struct {
+	constraint bool   // Constraint (satisfy first) rather than objective (optimize within)
+	dimension  string // "breadth" or "depth"; empty when it has no clear dimension
+	rank       int    // Priority hierarchy class, 1 = highest
+}
+
+// Priority hierarchy classes:
+// Legal/Regulatory > Security > User Intent > Data Integrity > Quality > Speed > UX.
+const (
+	rankLegal = iota + 1
+	rankSecurity
+	rankIntent
+	rankIntegrity
+	rankQuality
+	rankSpeed
+	rankUX
+)
+
+// rankNames names the hierarchy classes for rationales.
+var rankNames = map[int]string{
+	rankLegal:     "legal/regulatory",
+	rankSecurity:  "security",
+	rankIntent:    "user intent",
+	rankIntegrity: "data integrity",
+	rankQuality:   "quality",
+	rankSpeed:     "speed",
+	rankUX:        "UX",
+}
+
+// traits maps each principle to its R10 classification.
+var traits = map[string]principleTraits{
+	"layer0.trust_architecture":     {dimension: "depth", rank: rankSecurity},
+	"layer0.curation_model":         {rank: rankUX},
+	"layer0.scope_philosophy":       {constraint: true, dimension: "breadth", rank: rankUX},
+	"layer0.monetization_model":     {rank: rankUX},
+	"layer0.privacy_posture":        {constraint: true, rank: rankLegal},
+	"layer0.ux_philosophy":          {dimension: "breadth", rank: rankUX},
+	"layer0.authority_stance":       {rank: rankIntent},
+	"layer0.auditability":           {rank: rankLegal},
+	"layer0.interoperability":       {dimension: "breadth", rank: rankUX},
+	"layer1.speed_correctness":      {dimension: "depth", rank: rankQuality},
+	"layer1.innovation_stability":   {rank: rankQuality},
+	"layer1.blast_radius":           {rank: rankIntegrity},
+	"layer1.clarity_of_intent":      {rank: rankIntent},
+	"layer1.reversibility_priority": {rank: rankIntegrity},
+	"layer1.security_posture":       {constraint: true, rank: rankSecurity},
+	"layer1.urgency_tiers":          {constraint: true, rank: rankSpeed},
+	"layer1.cost_efficiency":        {constraint: true, rank: rankUX},
+	"layer1.migration_burden":       {rank: rankIntegrity},
+}
+
+// BuiltinRules are the Layer 2 resolution rules R1-R10, checked in order after any
+// custom rules from principles.yaml.
+var BuiltinRules = []config.ResolutionRule{
+	{
+		ID:          "R1",
+		Description: "Privacy obligations come first",
+		When:        config.RuleCondition{Conflict: []string{"privacy_posture"}, Min: map[string]int{"privacy_posture": 7}},
+		Prefer:      "privacy_posture",
+	},
+	{
+		ID:          "R2",
+		Description: "Audit requirements are not traded away",
+		When:        config.RuleCondition{Conflict: []string{"auditability"}, Min: map[string]int{"auditability": 8}},
+		Prefer:      "auditability",
+	},
+	{
+		ID:          "R3",
+		Description: "Prioritize cost efficiency for non-critical features",
+		When: config.RuleCondition{Conflict: []string{"scope_philosophy", "cost_efficiency"},
+			Max: map[string]int{"scope_philosophy": 5}},
+		Prefer: "cost_efficiency",
+	},
+	{
+		ID:          "R4",
+		Description: "Security posture outranks delivery pressure",
+		When:        config.RuleCondition{Conflict: []string{"security_posture"}, Min: map[string]int{"security_posture": 7}},
+		Prefer:      "security_posture",
+	},
+	{
+		ID:          "R5",
+		Description: "Keep changes small when blast radius is tight",
+		When:        config.RuleCondition{Conflict: []string{"blast_radius"}, Min: map[string]int{"blast_radius": 8}},
+		Prefer:      "blast_radius",
+	},
+	{
+		ID:          "R6",
+		Description: "Keep changes reversible when rollback matters",
+		When: config.RuleCondition{Conflict: []string{"reversibility_priority"},
+			Min: map[string]int{"reversibility_priority": 8}},
+		Prefer: "reversibility_priority",
+	},
+	{
+		ID:          "R7",
+		Description: "Correctness before urgency",
+		When: config.RuleCondition{Conflict: []string{"speed_correctness", "urgency_tiers"},
+			Min: map[string]int{"speed_correctness": 7}},
+		Prefer: "speed_correctness",
+	},
+	{
+		ID:          "R8",
+		Description: "Principles about different dimensions are compatible",
+		When:        config.RuleCondition{Types: []config.ConflictType{config.ConflictCompatible}},
+		Prefer:      config.PreferBoth,
+	},
+	{
+		ID:          "R9",
+		Description: "Satisfy constraints before optimizing objectives",
+		When:        config.RuleCondition{Types: []config.ConflictType{config.ConflictConstraintObjective}},
+		Prefer:      config.PreferConstraint,
+	},
+	{
+		ID:          "R10",
+		Description: "Priority hierarchy, or the clearly weightier principle",
+		When:        config.RuleCondition{Types: []config.ConflictType{config.ConflictConstraints, config.ConflictObjectives}},
+		Prefer:      config.PreferPriority,
+	},
+}
+
+// Conflict is a conflict between two principles, as reported by an iteration.
+type Conflict struct {
+	Principles [2]string // Full principle keys, such as "layer1.security_posture"; empty when not identified
+	Type       config.ConflictType
+	Options    []string // Options the iteration considered
+	Context    string   // What the iteration was doing and why the principles collide
+}
+
+// Identified reports whether both principles in conflict are known.
+func (c Conflict) Identified() bool {
+	return c.Principles[0] != "" && c.Principles[1] != ""
+}
+
+// Overlaps reports whether c may be the same conflict as other: both name the
+// same principles, in either order, or either leaves them unidentified.
+func (c Conflict) Overlaps(other Conflict) bool {
+	if !c.Identified() || !other.Identified() {
+		return true
+	}
+	return c.Principles == other.Principles ||
+		(c.Principles[0] == other.Principles[1] && c.Principles[1] == other.Principles[0])
+}
+
+// Names returns the principles in conflict without their layer prefix, such as
+// "speed_correctness vs security_posture", or "" when they are not identified.
+func (c Conflict) Names() string {
+	if !c.Identified() {
+		return ""
+	}
+	return shortKey(c.Principles[0]) + " vs " + shortKey(c.Principles[1])
+}
+
+// String formats the conflict for a council prompt.
+func (c Conflict) String() string {
+	var b strings.Builder
+	if c.Identified() {
+		fmt.Fprintf(&b, "Principles: %s vs %s (%s)\n", c.Principles[0], c.Principles[1], c.Type)
+	} else {
+		b.WriteString("Principles: not identified\n")
+	}
+	if len(c.Options) > 0 {
+		b.WriteString("Options considered:\n")
+		for i, o := range c.Options {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, o)
+		}
+	}
+	if c.Context != "" {
+		fmt.Fprintf(&b, "Context: %s\n", c.Context)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// setPrinciples sets the first two principles text mentions and their conflict
+// type, or clears them when fewer than two are mentioned.
+func (c *Conflict) setPrinciples(text string) {
+	c.Principles, c.Type = [2]string{}, ""
+	if found := MentionedPrinciples(text); len(found) >= 2 {
+		c.Principles = [2]string{found[0], found[1]}
+		c.Type = Classify(found[0], found[1])
+	}
+}
+
+// Resolution is a conflict resolved by a rule.
+type Resolution struct {
+	Rule      config.ResolutionRule
+	Winner    string // Principle that takes priority; empty when both are satisfied
+	Decision  string
+	Rationale string
+}
+
+// RuleEngine resolves principle conflicts with Layer 2 rules.
+type RuleEngine struct {
+	principles *config.Principles
+	rules      []config.ResolutionRule
+}
+
+// NewRuleEngine creates a RuleEngine with the custom rules from principles followed
+// by BuiltinRules.
+func NewRuleEngine(principles *config.Principles) *RuleEngine {
+	var rules []config.ResolutionRule
+	if principles != nil {
+		rules = append(rules, principles.Rules...)
+	}
+	return &RuleEngine{
+		principles: principles,
+		rules:      append(rules, BuiltinRules...),
+	}
+}
+
+// Rules returns the rules in evaluation order.
+func (e *RuleEngine) Rules() []config.ResolutionRule {
+	return e.rules
+}
+
+// Classify returns the conflict type between two principles (R10 steps 1 and 2).
+func Classify(a, b string) config.ConflictType {
+	ta, tb := traits[a], traits[b]
+	switch {
+	case ta.dimension != "" && tb.dimension != "" && ta.dimension != tb.dimension:
+		return config.ConflictCompatible
+	case ta.constraint != tb.constraint:
+		return config.ConflictConstraintObjective
+	case ta.constraint:
+		return config.ConflictConstraints
+	default:
+		return config.ConflictObjectives
+	}
+}
+
+// Resolve applies the first rule that matches and decides the conflict.
+// Returns nil when no rule decides it, leaving it to the LLM council.
+func (e *RuleEngine) Resolve(c Conflict) *Resolution {
+	if e.principles == nil {
+		return nil
+	}
+	for _, rule := range e.rules {
+		if !e.matches(rule.When, c) {
+			continue
+		}
+		if res := e.apply(rule, c); res != nil {
+			return res
+		}
+	}
+	return nil
+}
+
+// matches reports whether a rule condition holds for a conflict.
+func (e *RuleEngine) matches(when config.RuleCondition, c Conflict) bool {
+	for _, key := range when.Conflict {
+		name, _ := config.NormalizePrincipleKey(key)
+		if name != c.Principles[0] && name != c.Principles[1] {
+			return false
+		}
+	}
+	if len(when.Types) > 0 {
+		found := false
+		for _, t := range when.Types {
+			found = found || t == c.Type
+		}
+		if !found {
+			return false
+		}
+	}
+	for key, min := range when.Min {
+		if value, _ := e.principles.Principle(key); value < min {
+			return false
+		}
+	}
+	for key, max := range when.Max {
+		if value, _ := e.principles.Principle(key); value > max {
+			return false
+		}
+	}
+	a, b := e.value(c.Principles[0]), e.value(c.Principles[1])
+	return when.MinGap == 0 || abs(a-b) >= when.MinGap
+}
+
+// apply decides a matched conflict, or returns nil when the rule cannot pick a winner.
+func (e *RuleEngine) apply(rule config.ResolutionRule, c Conflict) *Resolution {
+	a, b := c.Principles[0], c.Principles[1]
+	res := &Resolution{Rule: rule}
+
+	switch rule.Prefer {
+	case config.PreferBoth:
+		res.Decision = fmt.Sprintf("Satisfy both %s and %s", e.describe(a), e.describe(b))
+		res.Rationale = fmt.Sprintf("%s - %s: %s governs %s and %s governs %s",
+			rule.ID, rule.Description, shortKey(a), traits[a].dimension, shortKey(b), traits[b].dimension)
+		return res
+	case config.PreferHigher:
+		res.Winner = e.higher(a, b)
+	case config.PreferConstraint:
+		res.Winner = a
+		if !traits[a].constraint {
+			res.Winner = b
+		}
+	case config.PreferPriority:
+		res.Winner = e.byPriority(a, b)
+	default:
+		res.Winner, _ = config.NormalizePrincipleKey(rule.Prefer)
+	}
+	if res.Winner == "" {
+		return nil
+	}
+
+	loser := a
+	if res.Winner == a {
+		loser = b
+	}
+	res.Decision = fmt.Sprintf("Prioritize %s over %s", e.describe(res.Winner), e.describe(loser))
+	res.Rationale = fmt.Sprintf("%s - %s%s", rule.ID, rule.Description, e.because(rule, res.Winner, loser))
+	return res
+}
+
+// byPriority picks the principle in the higher hierarchy class; within a class, the
+// one whose value is at least 2 higher. Returns "" when neither applies.
+func (e *RuleEngine) byPriority(a, b string) string {
+	ra, rb := traits[a].rank, traits[b].rank
+	switch {
+	case ra < rb:
+		return a
+	case rb < ra:
+		return b
+	case abs(e.value(a)-e.value(b)) >= 2:
+		return e.higher(a, b)
+	default:
+		return ""
+	}
+}
+
+// because explains why the winner was chosen, for rationales.
+func (e *RuleEngine) because(rule config.ResolutionRule, winner, loser string) string {
+	var reasons []string
+	keys := make([]string, 0, len(rule.When.Min)+len(rule.When.Max))
+	for key := range rule.When.Min {
+		keys = append(keys, key)
+	}
+	for key := range rule.When.Max {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, _ := e.principles.Principle(key)
+		if min, ok := rule.When.Min[key]; ok {
+			reasons = append(reasons, fmt.Sprintf("%s=%d >= %d", shortKey(key), value, min))
+		}
+		if max, ok := rule.When.Max[key]; ok {
+			reasons = append(reasons, fmt.Sprintf("%s=%d <= %d", shortKey(key), value, max))
+		}
+	}
+	switch rule.Prefer {
+	case config.PreferConstraint:
+		reasons = append(reasons, shortKey(winner)+" is a constraint")
+	case config.PreferPriority:
+		if rw, rl := traits[winner].rank, traits[loser].rank; rw != rl {
+			reasons = append(reasons, fmt.Sprintf("%s outranks %s", rankNames[rw], rankNames[rl]))
+		} else {
+			reasons = append(reasons, "weight difference of 2 or more")
+		}
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(reasons, ", ")
+}
+
+func (e *RuleEngine) value(key string) int {
+	value, _ := e.principles.Principle(key)
+	return value
+}
+
+// higher returns the principle with the larger value, or "" on a tie.
+func (e *RuleEngine) higher(a, b string) string {
+	switch va, vb := e.value(a), e.value(b); {
+	case va > vb:
+		return a
+	case vb > va:
+		return b
+	default:
+		return ""
+	}
+}
+
+// describe formats a principle with its value, such as "security_posture (8)".
+func (e *RuleEngine) describe(key string) string {
+	return fmt.Sprintf("%s (%d)", shortKey(key), e.value(key))
+}
+
+// shortKey drops the layer prefix from a principle key.
+func shortKey(key string) string {
+	if i := strings.IndexByte(key, '.'); i >= 0 {
+		return key[i+1:]
+	}
+	return key
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// principleMention matches principle keys in free text, with or without the layer
+// prefix, allowing spaces or hyphens in place of underscores.
+var principleMention = regexp.MustCompile(`(?i)\b(?:layer[01]\.)?([a-z]+(?:[ _-][a-z]+)+)\b`)
+
+// MentionedPrinciples returns the full keys of the principles text mentions, in
+// order of first mention.
+func MentionedPrinciples(text string) []string {
+	var found []string
+	for _, m := range principleMention.FindAllStringSubmatch(text, -1) {
+		words := strings.FieldsFunc(strings.ToLower(m[1]), func(r rune) bool { return r == ' ' || r == '_' || r == '-' })
+		for start := 0; start < len(words); start++ {
+			// Take the longest run of words from start that names a principle
+			for end := len(words); end > start+1; end-- {
+				name, known := config.NormalizePrincipleKey(strings.Join(words[start:end], "_"))
+				if !known {
+					continue
+				}
+				if !containsKey(found, name) {
+					found = append(found, name)
+				}
+				start = end - 1
+				break
+			}
+		}
+	}
+	return found
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package council
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// principlesWith returns startup principles with every value set to 5, then values applied.
+func principlesWith(t *testing.T, values map[string]int) *config.Principles {
+	t.Helper()
+	p := config.DefaultPrinciples(config.PresetStartup)
+	for _, key := range config.PrincipleKeys() {
+		require.NoError(t, p.SetPrinciple(key, 5))
+	}
+	for key, value := range values {
+		require.NoError(t, p.SetPrinciple(key, value))
+	}
+	return p
+}
+
+func conflictOf(a, b string) Conflict {
+	a, _ = config.NormalizePrincipleKey(a)
+	b, _ = config.NormalizePrincipleKey(b)
+	return Conflict{Principles: [2]string{a, b}, Type: Classify(a, b)}
+}
+
+func TestBuiltinRules_AreValid(t *testing.T) {
+	require.Len(t, BuiltinRules, 10)
+	for i, rule := range BuiltinRules {
+		assert.Equal(t, fmt.Sprintf("R%d", i+1), rule.ID)
+		assert.True(t, config.IsBuiltinRuleID(rule.ID))
+	}
+	// Validate them as custom rules, bypassing the reserved-ID check
+	p := principlesWith(t, nil)
+	p.CreatedAt = "2026-01-11"
+	for _, rule := range BuiltinRules {
+		rule.ID = "check-" + rule.ID
+		p.Rules = append(p.Rules, rule)
+	}
+	assert.NoError(t, p.Validate())
+	assert.Len(t, traits, len(config.PrincipleKeys()))
+}
+
+func TestClassify(t *testing.T) {
+	assert.Equal(t, config.ConflictCompatible, Classify("layer0.scope_philosophy", "layer0.trust_architecture"))
+	assert.Equal(t, config.ConflictConstraintObjective, Classify("layer1.cost_efficiency", "layer0.ux_philosophy"))
+	assert.Equal(t, config.ConflictConstraints, Classify("layer1.security_posture", "layer1.urgency_tiers"))
+	assert.Equal(t, config.ConflictObjectives, Classify("layer1.innovation_stability", "layer0.curation_model"))
+	assert.Equal(t, config.ConflictConstraints, Classify("layer0.scope_philosophy", "layer1.cost_efficiency"))
+}
+
+func TestRuleEngine_Resolve(t *testing.T) {
+	tests := []struct {
+		name          string
+		values        map[string]int
+		a, b          string
+		wantRule      string
+		wantWinner    string
+		wantDecision  string
+		wantRationale string
+	}{
+		{
+			name:   "R1 privacy",
+			values: map[string]int{"privacy_posture": 8},
+			a:      "interoperability", b: "privacy_posture",
+			wantRule: "R1", wantWinner: "layer0.privacy_posture",
+			wantDecision:  "Prioritize privacy_posture (8) over interoperability (5)",
+			wantRationale: "R1 - Privacy obligations come first: privacy_posture=8 >= 7",
+		},
+		{
+			name:   "R3 cost over non-critical scope",
+			values: map[string]int{"scope_philosophy": 4, "cost_efficiency": 6},
+			a:      "scope_philosophy", b: "cost_efficiency",
+			wantRule: "R3", wantWinner: "layer1.cost_efficiency",
+			wantDecision:  "Prioritize cost_efficiency (6) over scope_philosophy (4)",
+			wantRationale: "R3 - Prioritize cost efficiency for non-critical features: scope_philosophy=4 <= 5",
+		},
+		{
+			name:   "R4 security",
+			values: map[string]int{"security_posture": 9, "urgency_tiers": 2},
+			a:      "urgency_tiers", b: "security_posture",
+			wantRule: "R4", wantWinner: "layer1.security_posture",
+		},
+		{
+			name:   "R7 correctness before urgency",
+			values: map[string]int{"speed_correctness": 8},
+			a:      "urgency_tiers", b: "speed_correctness",
+			wantRule: "R7", wantWinner: "layer1.speed_correctness",
+		},
+		{
+			name: "R8 compatible",
+			a:    "scope_philosophy", b: "trust_architecture",
+			wantRule:      "R8",
+			wantDecision:  "Satisfy both scope_philosophy (5) and trust_architecture (5)",
+			wantRationale: "R8 - Principles about different dimensions are compatible: scope_philosophy governs breadth and trust_architecture governs depth",
+		},
+		{
+			name: "R9 constraint first",
+			a:    "ux_philosophy", b: "cost_efficiency",
+			wantRule: "R9", wantWinner: "layer1.cost_efficiency",
+			wantRationale: "R9 - Satisfy constraints before optimizing objectives: cost_efficiency is a constraint",
+		},
+		{
+			name: "R10 hierarchy",
+			a:    "innovation_stability", b: "clarity_of_intent",
+			wantRule: "R10", wantWinner: "layer1.clarity_of_intent",
+			wantRationale: "R10 - Priority hierarchy, or the clearly weightier principle: user intent outranks quality",
+		},
+		{
+			name:   "R10 weight within a class",
+			values: map[string]int{"curation_model": 3, "monetization_model": 6},
+			a:      "curation_model", b: "monetization_model",
+			wantRule: "R10", wantWinner: "layer0.monetization_model",
+			wantRationale: "R10 - Priority hierarchy, or the clearly weightier principle: weight difference of 2 or more",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewRuleEngine(principlesWith(t, tt.values))
+			res := engine.Resolve(conflictOf(tt.a, tt.b))
+			require.NotNil(t, res)
+			assert.Equal(t, tt.wantRule, res.Rule.ID)
+			assert.Equal(t, tt.wantWinner, res.Winner)
+			if tt.wantDecision != "" {
+				assert.Equal(t, tt.wantDecision, res.Decision)
+			}
+			if tt.wantRationale != "" {
+				assert.Equal(t, tt.wantRationale, res.Rationale)
+			}
+		})
+	}
+
+	t.Run("close weights in the same class are left to the council", func(t *testing.T) {
+		engine := NewRuleEngine(principlesWith(t, map[string]int{"curation_model": 5, "monetization_model": 6}))
+		assert.Nil(t, engine.Resolve(conflictOf("curation_model", "monetization_model")))
+	})
+
+	t.Run("custom rules come first", func(t *testing.T) {
+		p := principlesWith(t, map[string]int{"privacy_posture": 9, "interoperability": 8})
+		p.Rules = []config.ResolutionRule{{
+			ID:          "partners",
+			Description: "Partner integrations are contractual",
+			When:        config.RuleCondition{Conflict: []string{"interoperability", "privacy_posture"}, Min: map[string]int{"interoperability": 8}},
+			Prefer:      "interoperability",
+		}}
+		engine := NewRuleEngine(p)
+		require.Len(t, engine.Rules(), 11)
+		assert.Equal(t, "partners", engine.Rules()[0].ID)
+
+		res := engine.Resolve(conflictOf("privacy_posture", "interoperability"))
+		require.NotNil(t, res)
+		assert.Equal(t, "partners", res.Rule.ID)
+		assert.Equal(t, "layer0.interoperability", res.Winner)
+	})
+
+	t.Run("higher with a tie does not decide", func(t *testing.T) {
+		p := principlesWith(t, nil)
+		p.Rules = []config.ResolutionRule{{ID: "tie", When: config.RuleCondition{Types: []config.ConflictType{config.ConflictObjectives}}, Prefer: config.PreferHigher}}
+		res := NewRuleEngine(p).Resolve(conflictOf("innovation_stability", "clarity_of_intent"))
+		require.NotNil(t, res)
+		assert.Equal(t, "R10", res.Rule.ID, "falls through to the next matching rule")
+	})
+
+	t.Run("min gap", func(t *testing.T) {
+		p := principlesWith(t, map[string]int{"curation_model": 2, "monetization_model": 6})
+		p.Rules = []config.ResolutionRule{{ID: "gap", When: config.RuleCondition{MinGap: 4}, Prefer: config.PreferHigher}}
+		res := NewRuleEngine(p).Resolve(conflictOf("curation_model", "monetization_model"))
+		require.NotNil(t, res)
+		assert.Equal(t, "gap", res.Rule.ID)
+
+		p.Rules[0].When.MinGap = 5
+		res = NewRuleEngine(p).Resolve(conflictOf("curation_model", "monetization_model"))
+		assert.Equal(t, "R10", res.Rule.ID)
+	})
+
+	t.Run("without principles", func(t *testing.T) {
+		assert.Nil(t, NewRuleEngine(nil).Resolve(conflictOf("ux_philosophy", "cost_efficiency")))
+	})
+}
+
+func TestConflict_String(t *testing.T) {
+	c := conflictOf("speed_correctness", "security_posture")
+	c.Options = []string{"Ship now", "Audit first"}
+	c.Context = "The login fix has no audit trail."
+
+	assert.Equal(t, "speed_correctness vs security_posture", c.Names())
+	assert.Equal(t, "Principles: layer1.speed_correctness vs layer1.security_posture ("+string(c.Type)+")\n"+
+		"Options considered:\n1. Ship now\n2. Audit first\n"+
+		"Context: The login fix has no audit trail.", c.String())
+
+	unknown := Conflict{Context: "Ship or audit first"}
+	assert.Empty(t, unknown.Names())
+	assert.Equal(t, "Principles: not identified\nContext: Ship or audit first", unknown.String())
+}
+
+func TestConflict_Overlaps(t *testing.T) {
+	c := conflictOf("speed_correctness", "security_posture")
+
+	assert.True(t, c.Overlaps(conflictOf("speed_correctness", "security_posture")))
+	assert.True(t, c.Overlaps(conflictOf("security_posture", "speed_correctness")), "order does not matter")
+	assert.False(t, c.Overlaps(conflictOf("speed_correctness", "blast_radius")))
+	assert.True(t, c.Overlaps(Conflict{Context: "Ship or audit first"}), "unidentified conflicts may be the same")
+}
@@ -24,11 +24,12 @@ type IterationResult struct {
 
 // Council handles principle conflict detection and resolution.
 type Council interface {
-	// DetectConflict checks if output contains unresolved principle conflicts.
-	DetectConflict(output string) bool
+	// DetectConflict returns the principle conflict output asks to have resolved.
+	DetectConflict(output string) (Conflict, bool)
 
-	// Resolve invokes the LLM council for conflict resolution.
-	Resolve(ctx context.Context, conflictContext string) (*Result, error)
+	// Resolve resolves a conflict with Layer 2 rules, or invokes the LLM council
+	// when no rule decides it.
+	Resolve(ctx context.Context, conflict Conflict) (*Result, error)
 
 	// LogDecision logs a decision to the decision log file.
 	LogDecision(decision *Decision) error
@@ -40,12 +41,23 @@ type Config struct {
 	Preset       config.Preset      // Current principle preset
 	LogDecisions bool               // Whether to log decisions
 	LogFile      string             // Path to decision log file
+
+	// Members answer in parallel and a chair synthesises their answers. With
+	// fewer than two members a single resolution call is made instead.
+	Members []Member
+	Chair   Chair
+	MaxCost float64                 // Cost cap per invocation in USD (0 = unlimited)
+	Clients map[string]ClaudeClient // Clients by member name or ChairName; others use the council client
+
+	// PriorDecisions are decisions from earlier runs, oldest first, offered as
+	// precedents alongside the decisions made in this run.
+	PriorDecisions []*DecisionRecord
 }
 
 // DefaultConfig returns a Config with default values.
 func DefaultConfig() *Config {
 	return &Config{
-		LogFile: ".claude/principles-decisions.log",
+		LogFile: DefaultLogFile,
 	}
 }
 
@@ -61,14 +73,46 @@ type Result struct {
 	Duration   time.Duration // How long the council took
 	Resolution string        // Extracted resolution recommendation
 	Rationale  string        // Extracted rationale
+	Rule       string        // Layer 2 rule that resolved the conflict without an LLM call
+
+	// Set when members voted
+	Vote       string   // Option the council adopted
+	Votes      []Vote   // Each member's answer, in member order
+	Dissent    []string // Members whose vote differs from the decision
+	Confidence float64  // 0-1: the chair's confidence, or the winning share of the votes
+	CapReached bool     // The cost cap stopped the council before the chair answered, or kept the chair from being asked
+}
+
+// Vote is one member's answer to a conflict.
+type Vote struct {
+	Member     string  `json:"member"`
+	Vote       string  `json:"vote,omitempty"`       // Option the member supports, such as a principle key or "both"
+	Confidence int     `json:"confidence,omitempty"` // 1-10; 0 when not given
+	Decision   string  `json:"decision,omitempty"`
+	Rationale  string  `json:"rationale,omitempty"`
+	Cost       float64 `json:"cost,omitempty"`
+	Error      string  `json:"error,omitempty"` // Why the member did not vote
+}
+
+// Voted reports whether the member answered with a vote.
+func (v Vote) Voted() bool {
+	return v.Error == "" && v.Vote != ""
 }
 
 // Decision represents a logged decision entry.
 type Decision struct {
 	Timestamp      time.Time     // When the decision was made
+	RunID          string        // Run the decision was made in
 	Iteration      int           // Which iteration this decision occurred
 	Decision       string        // The decision made
 	Rationale      string        // The rationale for the decision
 	Preset         config.Preset // The active preset
 	CouncilInvoked bool          // Whether council was invoked for this decision
+	Rule           string        // Layer 2 rule that decided it, if any
+	Votes          []Vote        // Council members' votes, if the council voted
+	Dissent        []string      // Members who voted against the decision
+	Confidence     float64       // The council's confidence, 0-1
+	Principles     []string      // Principles the decision cites (nil = those mentioned in it)
+	Cost           float64       // Cost of reaching the decision in USD
+	Escalated      bool          // Whether a human made the decision after the council could not
 }
@@ -0,0 +1,285 @@
+package council
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Patterns for the vote and confidence lines of member and chair answers.
+var (
+	VotePattern       = regexp.MustCompile(`\*\*Vote\*\*:\s*([^*\n]+)`)
+	ConfidencePattern = regexp.MustCompile(`\*\*Confidence\*\*:\s*(\d+)`)
+)
+
+// optionBoth is the vote for satisfying both principles in conflict.
+const optionBoth = "both"
+
+// voteTally is the members who voted for one option.
+type voteTally struct {
+	Vote       string
+	Members    []string
+	Confidence int // Sum of the members' confidence
+}
+
+// convene asks every member in parallel, then has the chair synthesise their
+// answers. When the members' cost reaches the cap, members still answering are
+// cancelled and the majority vote decides without the chair. The chair is also
+// skipped when its answer would likely take the cost past the cap.
+func (c *DefaultCouncil) convene(ctx context.Context, bctx BuildContext) (*Result, error) {
+	startTime := time.Now()
+	options := VoteOptions(bctx.Conflict)
+
+	memberCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	votes := make([]Vote, len(c.config.Members))
+	var mu sync.Mutex
+	var spent float64
+	capReached := false
+	var wg sync.WaitGroup
+	for i, m := range c.config.Members {
+		wg.Add(1)
+		go func(i int, m Member) {
+			defer wg.Done()
+			vote := c.ask(memberCtx, bctx, m, options)
+			if vote.Error != "" && memberCtx.Err() != nil && ctx.Err() == nil {
+				vote.Error = fmt.Sprintf("stopped at the $%.2f cost cap", c.config.MaxCost)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			votes[i] = vote
+			spent += vote.Cost
+			if c.config.MaxCost > 0 && spent >= c.config.MaxCost && !capReached {
+				capReached = true
+				cancel()
+			}
+		}(i, m)
+	}
+	wg.Wait()
+
+	tally := tallyVotes(votes)
+	if len(tally) == 0 {
+		return nil, &CouncilError{Phase: "resolve", Message: "no council member voted", Err: firstVoteError(votes)}
+	}
+
+	result := &Result{Votes: votes, Cost: spent, CapReached: capReached}
+	reason := "the chair did not answer"
+	switch {
+	case capReached:
+		reason = "the cost cap was reached before the chair was asked"
+	case c.chairExceedsCap(votes, spent):
+		result.CapReached = true
+		reason = fmt.Sprintf("the chair would likely take the cost past the $%.2f cap", c.config.MaxCost)
+	default:
+		c.chair(ctx, bctx, result, options)
+	}
+	if result.Resolution == "" {
+		majority(result, tally, reason)
+	}
+	if result.Vote == "" {
+		result.Vote = tally[0].Vote
+	}
+	voted, agreed := 0, 0
+	for _, v := range votes {
+		switch {
+		case !v.Voted():
+		case v.Vote == result.Vote:
+			voted++
+			agreed++
+		default:
+			voted++
+			result.Dissent = append(result.Dissent, v.Member)
+		}
+	}
+	if result.Confidence == 0 {
+		result.Confidence = float64(agreed) / float64(voted)
+	}
+	result.Duration = time.Since(startTime)
+	return result, nil
+}
+
+// ask puts the conflict to one member and parses its answer.
+func (c *DefaultCouncil) ask(ctx context.Context, bctx BuildContext, m Member, options []string) Vote {
+	vote := Vote{Member: m.Name}
+	built, err := c.promptBuilder.BuildMember(bctx, m, options)
+	if err != nil {
+		vote.Error = err.Error()
+		return vote
+	}
+	res, err := c.clientFor(m.Name).Execute(ctx, built.Prompt)
+	if res != nil {
+		// A cancelled call can still report what it spent
+		vote.Cost = res.Cost
+	}
+	if err != nil {
+		vote.Error = err.Error()
+		return vote
+	}
+	vote.Vote, vote.Confidence = parseVote(res.Output, options)
+	vote.Decision, vote.Rationale = c.detector.ExtractDecision(res.Output)
+	if vote.Vote == "" {
+		vote.Error = "answer has no vote"
+	}
+	return vote
+}
+
+// chairExceedsCap reports whether asking the chair would likely take the cost
+// past the cap. The chair reads every member's answer, so its cost is estimated
+// as the most any member spent.
+func (c *DefaultCouncil) chairExceedsCap(votes []Vote, spent float64) bool {
+	if c.config.MaxCost <= 0 {
+		return false
+	}
+	var estimate float64
+	for _, v := range votes {
+		if v.Cost > estimate {
+			estimate = v.Cost
+		}
+	}
+	return spent+estimate > c.config.MaxCost
+}
+
+// chair asks the chair to synthesise the votes into result. When the chair
+// fails or gives no decision, result is left without a resolution so the
+// majority decides.
+func (c *DefaultCouncil) chair(ctx context.Context, bctx BuildContext, result *Result, options []string) {
+	built := c.promptBuilder.BuildChair(bctx, c.config.Chair, result.Votes)
+	res, err := c.clientFor(ChairName).Execute(ctx, built.Prompt)
+	if res != nil {
+		// A failed call can still report what it spent
+		result.Cost += res.Cost
+	}
+	if err != nil {
+		return
+	}
+	decision, rationale := c.detector.ExtractDecision(res.Output)
+	if decision == "" {
+		return
+	}
+	result.Output = res.Output
+	result.Resolution, result.Rationale = decision, rationale
+	var confidence int
+	result.Vote, confidence = parseVote(res.Output, options)
+	result.Confidence = float64(confidence) / 10
+}
+
+// clientFor returns the client for a member or the chair.
+func (c *DefaultCouncil) clientFor(name string) ClaudeClient {
+	if client, ok := c.config.Clients[name]; ok && client != nil {
+		return client
+	}
+	return c.client
+}
+
+// majority decides result by the winning vote, using the decision of the most
+// confident member who voted for it. reason says why the chair did not decide.
+func majority(result *Result, tally []voteTally, reason string) {
+	win := tally[0]
+	var best Vote
+	for _, v := range result.Votes {
+		if v.Voted() && v.Vote == win.Vote && (best.Member == "" || v.Confidence > best.Confidence) {
+			best = v
+		}
+	}
+	voted := 0
+	for _, t := range tally {
+		voted += len(t.Members)
+	}
+	result.Resolution = best.Decision
+	if result.Resolution == "" {
+		result.Resolution = "Favour " + win.Vote
+	}
+	result.Rationale = fmt.Sprintf("Majority vote for %s (%d of %d); %s. %s", win.Vote, len(win.Members), voted, reason, best.Rationale)
+	result.Rationale = strings.TrimSpace(result.Rationale)
+	result.Output = result.Resolution + "\n" + result.Rationale
+	result.Vote = win.Vote
+}
+
+// tallyVotes groups the votes by option, most votes first; ties go to the
+// higher total confidence, then to the option voted for first.
+func tallyVotes(votes []Vote) []voteTally {
+	var tally []voteTally
+	index := make(map[string]int)
+	for _, v := range votes {
+		if !v.Voted() {
+			continue
+		}
+		i, ok := index[v.Vote]
+		if !ok {
+			i = len(tally)
+			index[v.Vote] = i
+			tally = append(tally, voteTally{Vote: v.Vote})
+		}
+		tally[i].Members = append(tally[i].Members, v.Member)
+		tally[i].Confidence += v.Confidence
+	}
+	sort.SliceStable(tally, func(i, j int) bool {
+		if len(tally[i].Members) != len(tally[j].Members) {
+			return len(tally[i].Members) > len(tally[j].Members)
+		}
+		return tally[i].Confidence > tally[j].Confidence
+	})
+	return tally
+}
+
+// VoteOptions returns what members may vote for: the two principles in
+// conflict and "both". Returns nil when the principles are not identified.
+func VoteOptions(conflict Conflict) []string {
+	if !conflict.Identified() {
+		return nil
+	}
+	return []string{shortKey(conflict.Principles[0]), shortKey(conflict.Principles[1]), optionBoth}
+}
+
+// parseVote extracts the vote, matched against options when there are any, and
+// the confidence from an answer.
+func parseVote(output string, options []string) (vote string, confidence int) {
+	if m := ConfidencePattern.FindStringSubmatch(output); m != nil {
+		if n, err := strconv.Atoi(m[1]); err == nil && n >= 1 && n <= 10 {
+			confidence = n
+		}
+	}
+	m := VotePattern.FindStringSubmatch(output)
+	if m == nil {
+		return "", confidence
+	}
+	return normalizeVote(m[1], options), confidence
+}
+
+// normalizeVote lowercases a vote and maps it onto one of options: an exact
+// match, a principle key with its layer prefix, or the only option it mentions.
+func normalizeVote(raw string, options []string) string {
+	v := strings.ToLower(strings.Trim(strings.TrimSpace(raw), "`'\"."))
+	if len(options) == 0 {
+		return v
+	}
+	var mentioned []string
+	for _, o := range options {
+		if v == o || strings.HasSuffix(v, "."+o) {
+			return o
+		}
+		if strings.Contains(v, o) {
+			mentioned = append(mentioned, o)
+		}
+	}
+	if len(mentioned) == 1 {
+		return mentioned[0]
+	}
+	return v
+}
+
+// firstVoteError returns the first member error, for reporting a council with no votes.
+func firstVoteError(votes []Vote) error {
+	for _, v := range votes {
+		if v.Error != "" {
+			return fmt.Errorf("%s: %s", v.Member, v.Error)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package council
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// personaClient answers each council member and the chair from a canned reply
+// keyed by the member's name, and is safe for concurrent use.
+type personaClient struct {
+	mu      sync.Mutex
+	replies map[string]*IterationResult // Keyed by member name, or ChairName
+	errs    map[string]error            // Returned with the reply, if any
+	block   map[string]bool             // Members that wait for cancellation, then return their reply with the error
+	prompts map[string]string
+}
+
+func (p *personaClient) Execute(ctx context.Context, prompt string) (*IterationResult, error) {
+	name := ChairName
+	if strings.HasPrefix(prompt, "You are the ") {
+		name = strings.Fields(prompt)[3]
+	}
+	p.mu.Lock()
+	if p.prompts == nil {
+		p.prompts = make(map[string]string)
+	}
+	p.prompts[name] = prompt
+	p.mu.Unlock()
+
+	if p.block[name] {
+		<-ctx.Done()
+		return p.replies[name], ctx.Err()
+	}
+	if err := p.errs[name]; err != nil {
+		// A failed call returns whatever it reported spending
+		return p.replies[name], err
+	}
+	return p.replies[name], nil
+}
+
+func (p *personaClient) prompt(name string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.prompts[name]
+}
+
+// memberReply formats a member or chair answer.
+func memberReply(vote string, confidence int, decision string, cost float64) *IterationResult {
+	return &IterationResult{
+		Output: "**Vote**: " + vote + "\n**Confidence**: " + strconv.Itoa(confidence) +
+			"\n**Decision**: " + decision + "\n**Rationale**: because " + vote,
+		Cost: cost,
+	}
+}
+
+// councilConflict is a conflict no built-in rule decides for councilPrinciples.
+var councilConflict = conflictOf("curation_model", "monetization_model")
+
+func councilPrinciples(t *testing.T) *config.Principles {
+	t.Helper()
+	p := config.DefaultPrinciples(config.PresetEnterprise)
+	require.NoError(t, p.SetPrinciple("curation_model", 6))
+	require.NoError(t, p.SetPrinciple("monetization_model", 6))
+	return p
+}
+
+func TestDefaultCouncil_Convene(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("members vote and the chair decides", func(t *testing.T) {
+		client := &personaClient{replies: map[string]*IterationResult{
+			"security": memberReply("curation_model", 8, "Curate by hand", 0.01),
+			"product":  memberReply("`layer0.curation_model`", 6, "Editors pick", 0.01),
+			"delivery": memberReply("both", 5, "Do both", 0.01),
+			"cost":     memberReply("Favour monetization_model", 7, "Charge first", 0.01),
+			ChairName:  memberReply("curation_model", 7, "Curate by hand, revisit pricing", 0.02),
+		}}
+		cfg := &Config{Principles: councilPrinciples(t), Members: DefaultMembers()}
+
+		result, err := NewCouncil(cfg, client).Resolve(ctx, councilConflict)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Curate by hand, revisit pricing", result.Resolution)
+		assert.Equal(t, "because curation_model", result.Rationale)
+		assert.Equal(t, "curation_model", result.Vote)
+		assert.InDelta(t, 0.7, result.Confidence, 1e-9)
+		assert.InDelta(t, 0.06, result.Cost, 1e-9)
+		assert.Equal(t, []string{"delivery", "cost"}, result.Dissent)
+		assert.False(t, result.CapReached)
+
+		require.Len(t, result.Votes, 4)
+		assert.Equal(t, Vote{Member: "security", Vote: "curation_model", Confidence: 8,
+			Decision: "Curate by hand", Rationale: "because curation_model", Cost: 0.01}, result.Votes[0])
+		assert.Equal(t, "curation_model", result.Votes[1].Vote)
+		assert.Equal(t, "monetization_model", result.Votes[3].Vote)
+
+		security := client.prompt("security")
+		assert.Contains(t, security, "You are the security member")
+		assert.Contains(t, security, "- layer1.security_posture: 9 (1 = Basic, 10 = Maximum security)")
+		assert.Contains(t, security, "one of `curation_model`, `monetization_model`, `both`")
+		chair := client.prompt(ChairName)
+		assert.Contains(t, chair, DefaultChairPersona)
+		assert.Contains(t, chair, "### security (vote: curation_model, confidence 8)")
+		assert.Contains(t, chair, "- curation_model: 2 (security, product)")
+	})
+
+	t.Run("the cost cap skips the chair", func(t *testing.T) {
+		client := &personaClient{
+			replies: map[string]*IterationResult{
+				"security": memberReply("curation_model", 8, "Curate by hand", 0.30),
+				"product":  memberReply("curation_model", 6, "Editors pick", 0.30),
+				"delivery": {Cost: 0.05}, // Spent before it was cancelled
+			},
+			block: map[string]bool{"delivery": true},
+		}
+		members := DefaultMembers()[:3]
+		cfg := &Config{Principles: councilPrinciples(t), Members: members, MaxCost: 0.50}
+
+		result, err := NewCouncil(cfg, client).Resolve(ctx, councilConflict)
+		require.NoError(t, err)
+
+		assert.True(t, result.CapReached)
+		assert.Empty(t, client.prompt(ChairName))
+		assert.Equal(t, "Curate by hand", result.Resolution)
+		assert.Contains(t, result.Rationale, "Majority vote for curation_model (2 of 2); the cost cap was reached")
+		assert.Equal(t, "stopped at the $0.50 cost cap", result.Votes[2].Error)
+		assert.Equal(t, 0.05, result.Votes[2].Cost)
+		assert.Equal(t, 1.0, result.Confidence)
+		assert.InDelta(t, 0.65, result.Cost, 1e-9, "the cancelled member's spend is counted")
+	})
+
+	t.Run("the chair is skipped when it would exceed the cap", func(t *testing.T) {
+		client := &personaClient{replies: map[string]*IterationResult{
+			"security": memberReply("curation_model", 8, "Curate by hand", 0.20),
+			"product":  memberReply("monetization_model", 6, "Charge first", 0.15),
+			ChairName:  memberReply("monetization_model", 9, "Charge first", 0.20),
+		}}
+		cfg := &Config{Principles: councilPrinciples(t), Members: DefaultMembers()[:2], MaxCost: 0.50}
+
+		result, err := NewCouncil(cfg, client).Resolve(ctx, councilConflict)
+		require.NoError(t, err)
+
+		assert.Empty(t, client.prompt(ChairName))
+		assert.True(t, result.CapReached)
+		assert.Equal(t, "curation_model", result.Vote)
+		assert.Contains(t, result.Rationale, "the chair would likely take the cost past the $0.50 cap")
+		assert.InDelta(t, 0.35, result.Cost, 1e-9)
+	})
+
+	t.Run("the majority decides when the chair fails", func(t *testing.T) {
+		client := &personaClient{
+			replies: map[string]*IterationResult{
+				"security": memberReply("curation_model", 8, "Curate by hand", 0),
+				"product":  memberReply("monetization_model", 9, "Charge first", 0),
+				"delivery": memberReply("monetization_model", 4, "Charge now", 0),
+			},
+			errs: map[string]error{ChairName: errors.New("exit 1")},
+		}
+		cfg := &Config{Principles: councilPrinciples(t), Members: DefaultMembers()[:3]}
+
+		result, err := NewCouncil(cfg, client).Resolve(ctx, councilConflict)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Charge first", result.Resolution)
+		assert.Equal(t, "monetization_model", result.Vote)
+		assert.InDelta(t, 2.0/3, result.Confidence, 1e-9)
+		assert.Equal(t, []string{"security"}, result.Dissent)
+		assert.Contains(t, result.Rationale, "the chair did not answer")
+	})
+
+	t.Run("a failed chair still counts its spend", func(t *testing.T) {
+		client := &personaClient{
+			replies: map[string]*IterationResult{
+				"security": memberReply("curation_model", 8, "Curate by hand", 0.01),
+				"product":  memberReply("curation_model", 6, "Editors pick", 0.01),
+				ChairName:  {Cost: 0.04},
+			},
+			errs: map[string]error{ChairName: errors.New("exit 1")},
+		}
+		cfg := &Config{Principles: councilPrinciples(t), Members: DefaultMembers()[:2]}
+
+		result, err := NewCouncil(cfg, client).Resolve(ctx, councilConflict)
+		require.NoError(t, err)
+		assert.Equal(t, "Curate by hand", result.Resolution)
+		assert.InDelta(t, 0.06, result.Cost, 1e-9)
+	})
+
+	t.Run("members use their own clients", func(t *testing.T) {
+		shared := &personaClient{replies: map[string]*IterationResult{
+			"security": memberReply("both", 5, "Do both", 0),
+			ChairName:  memberReply("both", 5, "Do both", 0),
+		}}
+		product := &personaClient{replies: map[string]*IterationResult{"product": memberReply("both", 5, "Do both", 0)}}
+		cfg := &Config{Principles: councilPrinciples(t), Members: DefaultMembers()[:2],
+			Clients: map[string]ClaudeClient{"product": product}}
+
+		result, err := NewCouncil(cfg, shared).Resolve(ctx, councilConflict)
+		require.NoError(t, err)
+		assert.Empty(t, result.Dissent)
+		assert.NotEmpty(t, product.prompt("product"))
+		assert.Empty(t, shared.prompt("product"))
+	})
+
+	t.Run("no votes is an error", func(t *testing.T) {
+		client := &personaClient{errs: map[string]error{"security": errors.New("exit 1"), "product": errors.New("exit 2")}}
+		cfg := &Config{Principles: councilPrinciples(t), Members: DefaultMembers()[:2]}
+
+		_, err := NewCouncil(cfg, client).Resolve(ctx, councilConflict)
+		require.Error(t, err)
+		assert.True(t, IsCouncilError(err))
+		assert.Contains(t, err.Error(), "no council member voted: security: exit 1")
+	})
+
+	t.Run("a single member makes one resolution call", func(t *testing.T) {
+		client := &mockClaudeClient{response: &IterationResult{Output: "**Decision**: Curate by hand"}}
+		cfg := &Config{Principles: councilPrinciples(t), Members: DefaultMembers()[:1]}
+
+		result, err := NewCouncil(cfg, client).Resolve(ctx, councilConflict)
+		require.NoError(t, err)
+		assert.Len(t, client.calls, 1)
+		assert.Empty(t, result.Votes)
+	})
+}
+
+func TestNormalizeVote(t *testing.T) {
+	options := []string{"curation_model", "monetization_model", "both"}
+	tests := []struct {
+		raw, want string
+	}{
+		{"curation_model", "curation_model"},
+		{" `Monetization_Model`.", "monetization_model"},
+		{"layer0.curation_model", "curation_model"},
+		{"Both", "both"},
+		{"favour monetization_model", "monetization_model"},
+		{"curation_model and monetization_model", "curation_model and monetization_model"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, normalizeVote(tt.raw, options), tt.raw)
+	}
+	assert.Equal(t, "ship it", normalizeVote("Ship it", nil))
+}
+
+func TestTallyVotes(t *testing.T) {
+	votes := []Vote{
+		{Member: "a", Vote: "x", Confidence: 3},
+		{Member: "b", Vote: "y", Confidence: 9},
+		{Member: "c", Vote: "x", Confidence: 4},
+		{Member: "d", Vote: "z", Confidence: 2},
+		{Member: "e", Error: "exit 1"},
+	}
+	tally := tallyVotes(votes)
+	require.Len(t, tally, 3)
+	assert.Equal(t, voteTally{Vote: "x", Members: []string{"a", "c"}, Confidence: 7}, tally[0])
+	assert.Equal(t, "y", tally[1].Vote, "ties go to the higher confidence")
+	assert.Equal(t, "z", tally[2].Vote)
+}
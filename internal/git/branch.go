@@ -162,6 +162,51 @@ func (b *BranchManager) DeleteBranch(ctx context.Context, name string, force boo
 	return nil
 }
 
+// CurrentBranch returns the checked-out branch name.
+func (b *BranchManager) CurrentBranch(ctx context.Context) (string, error) {
+	return b.repo.GetCurrentBranch(ctx)
+}
+
+// BranchOff checks out a new branch with an auto-generated name at HEAD, keeping
+// the working tree. When base is given, the branch it left is reset to base, so
+// commits made since base move to the new branch. Returns the new branch name.
+func (b *BranchManager) BranchOff(ctx context.Context, prefix, base string) (string, error) {
+	current, err := b.repo.GetCurrentBranch(ctx)
+	if err != nil {
+		return "", err
+	}
+	name, err := b.GenerateBranchName(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	if err := b.run(ctx, name, "failed to create branch", "checkout", "-q", "-b", name); err != nil {
+		return "", err
+	}
+	if base != "" {
+		if err := b.run(ctx, current, "failed to reset branch", "branch", "-f", current, base); err != nil {
+			return name, err
+		}
+	}
+	return name, nil
+}
+
+// run executes a git command that operates on branch.
+func (b *BranchManager) run(ctx context.Context, branch, message string, args ...string) error {
+	cmd := b.executor.CommandContext(ctx, "git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return &BranchError{
+			Branch:  branch,
+			Message: message,
+			Err:     fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String())),
+		}
+	}
+	return nil
+}
+
 // Checkout switches to the specified branch.
 func (b *BranchManager) Checkout(ctx context.Context, name string) error {
 	cmd := b.executor.CommandContext(ctx, "git", "checkout", name)
@@ -220,6 +220,53 @@ func TestBranchManager_Checkout(t *testing.T) {
 	})
 }
 
+func TestBranchManager_BranchOff(t *testing.T) {
+	t.Run("moves commits since base to a new branch", func(t *testing.T) {
+		mock := &MockExecutor{
+			Commands: []MockCommand{
+				{Stdout: "main"}, // GetCurrentBranch
+				{Stdout: ""},     // git checkout -b
+				{Stdout: ""},     // git branch -f main base
+			},
+		}
+		bm := NewBranchManager(mock)
+
+		name, err := bm.BranchOff(context.Background(), "work/", "abc1234")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(name, "work/"))
+		assert.Equal(t, 3, mock.index)
+	})
+
+	t.Run("without base the old branch is kept", func(t *testing.T) {
+		mock := &MockExecutor{
+			Commands: []MockCommand{
+				{Stdout: "main"},
+				{Stdout: ""},
+			},
+		}
+		bm := NewBranchManager(mock)
+
+		_, err := bm.BranchOff(context.Background(), "", "")
+		require.NoError(t, err)
+		assert.Equal(t, 2, mock.index)
+	})
+
+	t.Run("checkout fails", func(t *testing.T) {
+		mock := &MockExecutor{
+			Commands: []MockCommand{
+				{Stdout: "main"},
+				{ExitCode: 1, Stderr: "fatal: a branch named 'x' already exists"},
+			},
+		}
+		bm := NewBranchManager(mock)
+
+		_, err := bm.BranchOff(context.Background(), "", "abc1234")
+		require.Error(t, err)
+		assert.True(t, IsBranchError(err))
+		assert.Contains(t, err.Error(), "failed to create branch")
+	})
+}
+
 func TestBranchManager_ListBranches(t *testing.T) {
 	t.Run("lists all branches", func(t *testing.T) {
 		mock := &MockExecutor{
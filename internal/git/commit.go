@@ -6,23 +6,48 @@ import (
 	"strings"
 )
 
+// StagedCheck inspects staged changes before CommitAll or CommitAndPush commits them.
+// It may unstage or rewrite files; returning an error aborts the commit.
+type StagedCheck func(ctx context.Context, commits *CommitManager) error
+
 // CommitManager provides Git commit and push operations.
 type CommitManager struct {
 	executor CommandExecutor
+	check    StagedCheck
+	exclude  []string
 }
 
 // NewCommitManager creates a new CommitManager.
 // If executor is nil, DefaultExecutor is used.
 func NewCommitManager(executor CommandExecutor) *CommitManager {
+	return NewCommitManagerWithCheck(executor, nil)
+}
+
+// NewCommitManagerWithCheck creates a CommitManager that runs check after staging
+// and before every commit made by CommitAll and CommitAndPush. A nil check is skipped.
+func NewCommitManagerWithCheck(executor CommandExecutor, check StagedCheck) *CommitManager {
 	if executor == nil {
 		executor = &DefaultExecutor{}
 	}
-	return &CommitManager{executor: executor}
+	return &CommitManager{executor: executor, check: check}
+}
+
+// Exclude keeps paths, such as claude-loop's own artifacts, out of StageAll and
+// therefore out of every commit made by CommitAll and CommitAndPush.
+func (c *CommitManager) Exclude(paths ...string) {
+	c.exclude = append(c.exclude, paths...)
 }
 
-// StageAll stages all changes (git add -A).
+// StageAll stages all changes (git add -A) except the excluded paths.
 func (c *CommitManager) StageAll(ctx context.Context) error {
-	cmd := c.executor.CommandContext(ctx, "git", "add", "-A")
+	args := []string{"add", "-A"}
+	if len(c.exclude) > 0 {
+		args = append(args, "--", ":/")
+		for _, path := range c.exclude {
+			args = append(args, ":(exclude)"+path)
+		}
+	}
+	cmd := c.executor.CommandContext(ctx, "git", args...)
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
@@ -37,6 +62,30 @@ func (c *CommitManager) StageAll(ctx context.Context) error {
 	return nil
 }
 
+// Stage stages the given paths, including deletions.
+func (c *CommitManager) Stage(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	_, err := c.run(ctx, "failed to stage changes", append([]string{"add", "-A", "--"}, literalPathspecs(paths)...)...)
+	return err
+}
+
+// Unstage removes the given paths from the index, keeping the working tree files.
+// Requires a HEAD commit.
+func (c *CommitManager) Unstage(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	_, err := c.run(ctx, "failed to unstage changes", append([]string{"reset", "-q", "--"}, literalPathspecs(paths)...)...)
+	return err
+}
+
+// StagedDiff returns the staged changes as a unified diff without context lines.
+func (c *CommitManager) StagedDiff(ctx context.Context) (string, error) {
+	return c.run(ctx, "failed to diff staged changes", "diff", "--cached", "--no-color", "--no-ext-diff", "--no-renames", "-U0")
+}
+
 // HasStagedChanges checks if there are staged changes to commit.
 // Returns true if there are staged changes, false otherwise.
 func (c *CommitManager) HasStagedChanges(ctx context.Context) (bool, error) {
@@ -101,9 +150,24 @@ func (c *CommitManager) Push(ctx context.Context, remote, branch string) error {
 // CommitAndPush stages, commits, and pushes changes in one operation.
 // Returns ErrNothingToCommit if there are no changes to commit.
 func (c *CommitManager) CommitAndPush(ctx context.Context, message string) error {
+	if err := c.CommitAll(ctx, message); err != nil {
+		return err
+	}
+	return c.Push(ctx, "", "")
+}
+
+// CommitAll stages all changes, runs the staged check and commits without pushing.
+// Nothing is committed when the check fails.
+// Returns ErrNothingToCommit if there are no changes to commit.
+func (c *CommitManager) CommitAll(ctx context.Context, message string) error {
 	if err := c.StageAll(ctx); err != nil {
 		return err
 	}
+	if c.check != nil {
+		if err := c.check(ctx, c); err != nil {
+			return err
+		}
+	}
 
 	hasChanges, err := c.HasStagedChanges(ctx)
 	if err != nil {
@@ -113,9 +177,32 @@ func (c *CommitManager) CommitAndPush(ctx context.Context, message string) error
 		return ErrNothingToCommit
 	}
 
-	if err := c.Commit(ctx, message); err != nil {
-		return err
+	return c.Commit(ctx, message)
+}
+
+// run executes a git command and returns its stdout.
+func (c *CommitManager) run(ctx context.Context, message string, args ...string) (string, error) {
+	cmd := c.executor.CommandContext(ctx, "git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", &GitError{
+			Operation: "commit",
+			Message:   message,
+			Stderr:    strings.TrimSpace(stderr.String()),
+			Err:       err,
+		}
 	}
+	return stdout.String(), nil
+}
 
-	return c.Push(ctx, "", "")
+// literalPathspecs turns repository-relative paths into pathspecs that match them exactly.
+func literalPathspecs(paths []string) []string {
+	specs := make([]string, len(paths))
+	for i, p := range paths {
+		specs[i] = ":(top,literal)" + p
+	}
+	return specs
 }
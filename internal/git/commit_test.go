@@ -2,6 +2,7 @@ package git
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -218,6 +219,80 @@ func TestCommitManager_CommitAndPush(t *testing.T) {
 	})
 }
 
+func TestCommitManager_CommitAndPush_StagedCheck(t *testing.T) {
+	t.Run("check sees staged diff before commit", func(t *testing.T) {
+		mock := &MockExecutor{
+			Commands: []MockCommand{
+				{Stdout: ""}, // git add -A
+				{Stdout: "+++ b/a.go\n@@ -0,0 +1 @@\n+x\n"}, // git diff --cached -U0
+				{ExitCode: 1},                          // git diff --cached --quiet (has changes)
+				{Stdout: "[main abc1234] Test commit"}, // git commit
+				{Stdout: ""},                           // git push
+			},
+		}
+		var diff string
+		cm := NewCommitManagerWithCheck(mock, func(ctx context.Context, commits *CommitManager) error {
+			var err error
+			diff, err = commits.StagedDiff(ctx)
+			return err
+		})
+
+		require.NoError(t, cm.CommitAndPush(context.Background(), "Test commit"))
+		assert.Contains(t, diff, "+++ b/a.go")
+		assert.Equal(t, 5, mock.index)
+	})
+
+	t.Run("check error aborts commit", func(t *testing.T) {
+		mock := &MockExecutor{Commands: []MockCommand{{Stdout: ""}}}
+		blocked := errors.New("blocked")
+		cm := NewCommitManagerWithCheck(mock, func(ctx context.Context, commits *CommitManager) error {
+			return blocked
+		})
+
+		err := cm.CommitAndPush(context.Background(), "Test commit")
+		assert.ErrorIs(t, err, blocked)
+		assert.Equal(t, 1, mock.index)
+	})
+}
+
+func TestCommitManager_CommitAll(t *testing.T) {
+	mock := &MockExecutor{
+		Commands: []MockCommand{
+			{Stdout: ""},                           // git add -A
+			{ExitCode: 1},                          // git diff --cached --quiet (has changes)
+			{Stdout: "[main abc1234] Test commit"}, // git commit
+		},
+	}
+	checked := false
+	cm := NewCommitManagerWithCheck(mock, func(ctx context.Context, commits *CommitManager) error {
+		checked = true
+		return nil
+	})
+
+	require.NoError(t, cm.CommitAll(context.Background(), "Test commit"))
+	assert.True(t, checked)
+	assert.Equal(t, 3, mock.index, "nothing is pushed")
+}
+
+func TestCommitManager_StageAndUnstage(t *testing.T) {
+	t.Run("no paths is a no-op", func(t *testing.T) {
+		mock := &MockExecutor{}
+		cm := NewCommitManager(mock)
+		require.NoError(t, cm.Stage(context.Background(), nil))
+		require.NoError(t, cm.Unstage(context.Background(), nil))
+		assert.Equal(t, 0, mock.index)
+	})
+
+	t.Run("unstage failure", func(t *testing.T) {
+		mock := &MockExecutor{Commands: []MockCommand{{ExitCode: 128, Stderr: "fatal: ambiguous argument 'HEAD'"}}}
+		cm := NewCommitManager(mock)
+
+		err := cm.Unstage(context.Background(), []string{".env"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to unstage changes")
+	})
+}
+
 func TestNewCommitManager(t *testing.T) {
 	t.Run("uses default executor when nil", func(t *testing.T) {
 		cm := NewCommitManager(nil)
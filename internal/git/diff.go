@@ -0,0 +1,153 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FileStat describes the changes to a single file.
+type FileStat struct {
+	Path       string `json:"path"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+	Binary     bool   `json:"binary,omitempty"`
+}
+
+// DiffStat summarizes the changes between two trees.
+type DiffStat struct {
+	Files      []FileStat `json:"files,omitempty"`
+	Insertions int        `json:"insertions"`
+	Deletions  int        `json:"deletions"`
+}
+
+// FilesChanged returns the number of files in the diff.
+func (d *DiffStat) FilesChanged() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.Files)
+}
+
+// DiffManager inspects working tree changes without touching the index,
+// and restores paths to an earlier snapshot when asked to.
+type DiffManager struct {
+	executor CommandExecutor
+}
+
+// NewDiffManager creates a new DiffManager.
+// If executor is nil, DefaultExecutor is used.
+func NewDiffManager(executor CommandExecutor) *DiffManager {
+	if executor == nil {
+		executor = &DefaultExecutor{}
+	}
+	return &DiffManager{executor: executor}
+}
+
+// HeadCommit returns the full hash of HEAD.
+func (d *DiffManager) HeadCommit(ctx context.Context) (string, error) {
+	out, err := d.run(ctx, nil, "failed to resolve HEAD", "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// SnapshotTree writes the current working tree, including untracked files that
+// are not ignored, as a tree object and returns its hash. Paths in exclude are left out.
+// A temporary index is used so the user's staging area is left untouched.
+func (d *DiffManager) SnapshotTree(ctx context.Context, exclude ...string) (string, error) {
+	tmp, err := os.CreateTemp("", "claude-loop-index-*")
+	if err != nil {
+		return "", &GitError{Operation: "diff", Message: "failed to create temporary index", Err: err}
+	}
+	indexPath := tmp.Name()
+	tmp.Close()
+	// git treats a missing index file as empty, but rejects an empty file
+	os.Remove(indexPath)
+	defer os.Remove(indexPath)
+
+	args := []string{"add", "-A", "--", "."}
+	for _, path := range exclude {
+		args = append(args, ":(exclude)"+path)
+	}
+
+	env := append(os.Environ(), "GIT_INDEX_FILE="+indexPath)
+	if _, err := d.run(ctx, env, "failed to snapshot working tree", args...); err != nil {
+		return "", err
+	}
+	out, err := d.run(ctx, env, "failed to write tree", "write-tree")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// DiffTrees returns per-file line counts for the changes between two trees or commits.
+func (d *DiffManager) DiffTrees(ctx context.Context, from, to string) (*DiffStat, error) {
+	out, err := d.run(ctx, nil, "failed to diff trees", "diff-tree", "-r", "--numstat", "--no-renames", from, to)
+	if err != nil {
+		return nil, err
+	}
+	return ParseNumstat(out), nil
+}
+
+// PatchTrees returns the changes between two trees or commits as a unified diff without context lines.
+func (d *DiffManager) PatchTrees(ctx context.Context, from, to string) (string, error) {
+	return d.run(ctx, nil, "failed to diff trees", "diff-tree", "-r", "-p", "-U0", "--no-color", "--no-ext-diff", "--no-renames", from, to)
+}
+
+// CommitsSince returns the hashes of commits reachable from HEAD but not from base, oldest first.
+func (d *DiffManager) CommitsSince(ctx context.Context, base string) ([]string, error) {
+	out, err := d.run(ctx, nil, "failed to list commits", "rev-list", "--reverse", base+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// ParseNumstat parses `git diff --numstat` output.
+// Binary files are reported with zero line counts.
+func ParseNumstat(output string) *DiffStat {
+	stat := &DiffStat{}
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		file := FileStat{Path: parts[2]}
+		if parts[0] == "-" && parts[1] == "-" {
+			file.Binary = true
+		} else {
+			file.Insertions, _ = strconv.Atoi(parts[0])
+			file.Deletions, _ = strconv.Atoi(parts[1])
+		}
+		stat.Files = append(stat.Files, file)
+		stat.Insertions += file.Insertions
+		stat.Deletions += file.Deletions
+	}
+	return stat
+}
+
+// run executes a git command with an optional environment and returns stdout.
+func (d *DiffManager) run(ctx context.Context, env []string, message string, args ...string) (string, error) {
+	cmd := d.executor.CommandContext(ctx, "git", args...)
+	if env != nil {
+		cmd.Env = env
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", &GitError{
+			Operation: "diff",
+			Message:   message,
+			Stderr:    strings.TrimSpace(stderr.String()),
+			Err:       err,
+		}
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,87 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumstat(t *testing.T) {
+	stat := ParseNumstat("3\t1\tmain.go\n-\t-\tlogo.png\n10\t0\tdocs/new.md\n")
+
+	require.Len(t, stat.Files, 3)
+	assert.Equal(t, FileStat{Path: "main.go", Insertions: 3, Deletions: 1}, stat.Files[0])
+	assert.True(t, stat.Files[1].Binary)
+	assert.Equal(t, 13, stat.Insertions)
+	assert.Equal(t, 1, stat.Deletions)
+	assert.Equal(t, 3, stat.FilesChanged())
+}
+
+func TestParseNumstat_Empty(t *testing.T) {
+	stat := ParseNumstat("")
+	assert.Empty(t, stat.Files)
+	assert.Equal(t, 0, stat.FilesChanged())
+
+	var nilStat *DiffStat
+	assert.Equal(t, 0, nilStat.FilesChanged())
+}
+
+func TestDiffManager_HeadCommit(t *testing.T) {
+	mock := &MockExecutor{Commands: []MockCommand{{Stdout: "abc123\n"}}}
+	dm := NewDiffManager(mock)
+
+	head, err := dm.HeadCommit(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", head)
+}
+
+func TestDiffManager_SnapshotTree(t *testing.T) {
+	t.Run("returns tree hash", func(t *testing.T) {
+		mock := &MockExecutor{Commands: []MockCommand{{Stdout: ""}, {Stdout: "tree123\n"}}}
+		dm := NewDiffManager(mock)
+
+		tree, err := dm.SnapshotTree(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "tree123", tree)
+	})
+
+	t.Run("error on add failure", func(t *testing.T) {
+		mock := &MockExecutor{Commands: []MockCommand{{ExitCode: 1, Stderr: "fatal: not a git repository"}}}
+		dm := NewDiffManager(mock)
+
+		_, err := dm.SnapshotTree(context.Background())
+		require.Error(t, err)
+		assert.True(t, IsGitError(err))
+		assert.Contains(t, err.Error(), "failed to snapshot working tree")
+	})
+}
+
+func TestDiffManager_DiffTrees(t *testing.T) {
+	mock := &MockExecutor{Commands: []MockCommand{{Stdout: "2\t0\ta.go\n"}}}
+	dm := NewDiffManager(mock)
+
+	stat, err := dm.DiffTrees(context.Background(), "t1", "t2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, stat.Insertions)
+	assert.Equal(t, "a.go", stat.Files[0].Path)
+}
+
+func TestDiffManager_PatchTrees(t *testing.T) {
+	mock := &MockExecutor{Commands: []MockCommand{{Stdout: "diff --git a/a.go b/a.go\n+++ b/a.go\n"}}}
+	dm := NewDiffManager(mock)
+
+	patch, err := dm.PatchTrees(context.Background(), "t1", "t2")
+	require.NoError(t, err)
+	assert.Contains(t, patch, "+++ b/a.go")
+}
+
+func TestDiffManager_CommitsSince(t *testing.T) {
+	mock := &MockExecutor{Commands: []MockCommand{{Stdout: "c1\nc2\n"}}}
+	dm := NewDiffManager(mock)
+
+	commits, err := dm.CommitsSince(context.Background(), "base")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c1", "c2"}, commits)
+}
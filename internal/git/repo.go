@@ -76,6 +76,22 @@ func (r *Repository) GetCurrentBranch(ctx context.Context) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// GetDefaultBranch returns the branch origin's HEAD points to, or else main or
+// master when it exists locally. Returns empty string if none is found.
+func (r *Repository) GetDefaultBranch(ctx context.Context) string {
+	cmd := r.executor.CommandContext(ctx, "git", "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	if output, err := cmd.Output(); err == nil {
+		return strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/")
+	}
+	for _, name := range []string{"main", "master"} {
+		cmd := r.executor.CommandContext(ctx, "git", "show-ref", "--verify", "--quiet", "refs/heads/"+name)
+		if cmd.Run() == nil {
+			return name
+		}
+	}
+	return ""
+}
+
 // GetRemoteURL returns the URL of the origin remote.
 // Returns empty string if origin remote doesn't exist.
 func (r *Repository) GetRemoteURL(ctx context.Context) (string, error) {
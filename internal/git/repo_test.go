@@ -157,6 +157,39 @@ func TestRepository_GetCurrentBranch(t *testing.T) {
 	})
 }
 
+func TestRepository_GetDefaultBranch(t *testing.T) {
+	t.Run("follows origin HEAD", func(t *testing.T) {
+		mock := &MockExecutor{
+			Commands: []MockCommand{
+				{Stdout: "origin/trunk\n"},
+			},
+		}
+		assert.Equal(t, "trunk", NewRepository(mock).GetDefaultBranch(context.Background()))
+	})
+
+	t.Run("falls back to a local main or master", func(t *testing.T) {
+		mock := &MockExecutor{
+			Commands: []MockCommand{
+				{ExitCode: 1, Stderr: "fatal: ref refs/remotes/origin/HEAD is not a symbolic ref"},
+				{ExitCode: 1}, // no main
+				{Stdout: ""},  // master exists
+			},
+		}
+		assert.Equal(t, "master", NewRepository(mock).GetDefaultBranch(context.Background()))
+	})
+
+	t.Run("returns empty when none is found", func(t *testing.T) {
+		mock := &MockExecutor{
+			Commands: []MockCommand{
+				{ExitCode: 1},
+				{ExitCode: 1},
+				{ExitCode: 1},
+			},
+		}
+		assert.Empty(t, NewRepository(mock).GetDefaultBranch(context.Background()))
+	})
+}
+
 func TestRepository_GetRemoteURL(t *testing.T) {
 	t.Run("returns remote URL", func(t *testing.T) {
 		mock := &MockExecutor{
@@ -0,0 +1,61 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RestorePaths returns paths to a snapshot taken earlier: index entries are reset to
+// commit and working tree files to tree. Files absent from tree are deleted.
+// Paths are relative to the repository root. An empty commit clears the index entries.
+func (d *DiffManager) RestorePaths(ctx context.Context, commit, tree string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	specs := literalPathspecs(paths)
+
+	if commit != "" {
+		args := append([]string{"reset", "-q", commit, "--"}, specs...)
+		if _, err := d.run(ctx, nil, "failed to restore index", args...); err != nil {
+			return err
+		}
+	} else {
+		args := append([]string{"rm", "--cached", "-q", "--ignore-unmatch", "--"}, specs...)
+		if _, err := d.run(ctx, nil, "failed to restore index", args...); err != nil {
+			return err
+		}
+	}
+
+	top, err := d.run(ctx, nil, "failed to locate repository root", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return err
+	}
+	top = strings.TrimSpace(top)
+
+	var inTree []string
+	for i, p := range paths {
+		if _, err := d.run(ctx, nil, "", "cat-file", "-e", tree+":"+p); err == nil {
+			inTree = append(inTree, specs[i])
+			continue
+		}
+		if err := os.Remove(filepath.Join(top, filepath.FromSlash(p))); err != nil && !os.IsNotExist(err) {
+			return &GitError{Operation: "diff", Message: "failed to remove " + p, Err: err}
+		}
+	}
+	if len(inTree) > 0 {
+		args := append([]string{"restore", "--source=" + tree, "--worktree", "--"}, inTree...)
+		if _, err := d.run(ctx, nil, "failed to restore working tree", args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResetSoft moves HEAD back to commit, keeping the index and working tree.
+func (d *DiffManager) ResetSoft(ctx context.Context, commit string) error {
+	_, err := d.run(ctx, nil, "failed to reset HEAD", "reset", "-q", "--soft", commit)
+	return err
+}
@@ -0,0 +1,45 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffManager_RestorePaths(t *testing.T) {
+	t.Run("no paths runs nothing", func(t *testing.T) {
+		// Any command would fail: the mock has none queued
+		dm := NewDiffManager(&MockExecutor{})
+		require.NoError(t, dm.RestorePaths(context.Background(), "c1", "t1", nil))
+	})
+
+	t.Run("restores index and working tree", func(t *testing.T) {
+		mock := &MockExecutor{Commands: []MockCommand{
+			{},                  // reset
+			{Stdout: "/repo\n"}, // rev-parse --show-toplevel
+			{},                  // cat-file -e
+			{},                  // restore
+		}}
+		err := NewDiffManager(mock).RestorePaths(context.Background(), "c1", "t1", []string{"LICENSE"})
+		require.NoError(t, err)
+		assert.Equal(t, 4, mock.index)
+	})
+
+	t.Run("error on index failure", func(t *testing.T) {
+		mock := &MockExecutor{Commands: []MockCommand{{ExitCode: 1, Stderr: "fatal: bad revision"}}}
+		err := NewDiffManager(mock).RestorePaths(context.Background(), "c1", "t1", []string{"a.go"})
+		require.Error(t, err)
+		assert.True(t, IsGitError(err))
+		assert.Contains(t, err.Error(), "failed to restore index")
+	})
+}
+
+func TestDiffManager_ResetSoft(t *testing.T) {
+	require.NoError(t, NewDiffManager(&MockExecutor{Commands: []MockCommand{{}}}).ResetSoft(context.Background(), "c1"))
+
+	err := NewDiffManager(&MockExecutor{Commands: []MockCommand{{ExitCode: 1}}}).ResetSoft(context.Background(), "c1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to reset HEAD")
+}
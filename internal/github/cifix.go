@@ -33,6 +33,13 @@ type CIFixConfig struct {
 
 	// OnAttempt is called at the start of each fix attempt.
 	OnAttempt func(attempt int, max int)
+
+	// Templates holds optional prompt template overrides.
+	Templates *prompt.TemplateSet
+
+	// StagedCheck inspects staged changes before each fix is committed, such as
+	// a secrets.Guard; returning an error aborts the commit (nil = no check).
+	StagedCheck git.StagedCheck
 }
 
 // DefaultCIFixConfig returns CIFixConfig with default values.
@@ -89,9 +96,9 @@ func NewCIFixManager(
 	return &CIFixManager{
 		analyzer:      NewCIAnalyzer(executor, repo),
 		claudeClient:  claudeClient,
-		commitMgr:     git.NewCommitManager(gitExecutor),
+		commitMgr:     git.NewCommitManagerWithCheck(gitExecutor, config.StagedCheck),
 		checkMonitor:  NewCheckMonitor(executor, repo),
-		promptBuilder: prompt.NewCIFixBuilder(),
+		promptBuilder: prompt.NewCIFixBuilderWithTemplates(config.Templates),
 		config:        config,
 		repo:          repo,
 	}
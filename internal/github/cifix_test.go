@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
 	"github.com/DeukWoongWoo/claude-loop/internal/loop"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -305,6 +306,41 @@ func TestCIFixManager_AttemptFix(t *testing.T) {
 		assert.Contains(t, result.LastError.Error(), "Claude made no changes")
 	})
 
+	t.Run("staged check blocks the commit", func(t *testing.T) {
+		ghMock := &MockExecutor{
+			Commands: []MockCommand{
+				{Stdout: `{"headRefOid":"abc123"}`},
+				{Stdout: `[{"databaseId":12345}]`},
+				{Stdout: `{"databaseId":12345,"name":"CI","conclusion":"failure","url":"","createdAt":"2026-01-12T10:00:00Z","jobs":[]}`},
+				{Stdout: "Error"},
+			},
+		}
+
+		gitMock := &MockGitExecutor{
+			MockExecutor: MockExecutor{
+				Commands: []MockCommand{
+					{Stdout: ""}, // git add -A
+				},
+			},
+		}
+
+		config := &CIFixConfig{
+			MaxRetries: 1,
+			PRNumber:   42,
+			StagedCheck: func(ctx context.Context, commits *git.CommitManager) error {
+				return errors.New("staged changes contain secrets")
+			},
+		}
+
+		manager := NewCIFixManager(ghMock, &gitMock.MockExecutor, repo, &MockClaudeClient{}, config)
+		result, err := manager.AttemptFix(context.Background())
+
+		assert.Error(t, err)
+		assert.False(t, result.Success)
+		assert.Contains(t, result.LastError.Error(), "failed to commit/push: staged changes contain secrets")
+		assert.Equal(t, 1, gitMock.index)
+	})
+
 	t.Run("progress callbacks are called", func(t *testing.T) {
 		ghMock := &MockExecutor{
 			Commands: []MockCommand{
@@ -0,0 +1,47 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"strings"
+)
+
+// IssueManager manages issue operations.
+type IssueManager struct {
+	executor CommandExecutor
+	repo     *RepoInfo
+}
+
+// NewIssueManager creates a new IssueManager. With a nil repo, gh uses the
+// repository of the working directory.
+func NewIssueManager(executor CommandExecutor, repo *RepoInfo) *IssueManager {
+	if executor == nil {
+		executor = &DefaultExecutor{}
+	}
+	return &IssueManager{executor: executor, repo: repo}
+}
+
+// Create opens an issue and returns its URL.
+func (m *IssueManager) Create(ctx context.Context, title, body string) (string, error) {
+	args := []string{"issue", "create", "--title", title, "--body", body}
+	if m.repo != nil {
+		args = append(args, "--repo", m.repo.RepoString())
+	}
+
+	cmd := m.executor.CommandContext(ctx, "gh", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", &GitHubError{
+			Operation: "issue",
+			Message:   "failed to create issue",
+			Stderr:    strings.TrimSpace(stderr.String()),
+			Err:       err,
+		}
+	}
+
+	// Output is the issue URL
+	return strings.TrimSpace(stdout.String()), nil
+}
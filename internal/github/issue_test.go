@@ -0,0 +1,38 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueManager_Create(t *testing.T) {
+	t.Run("creates issue successfully", func(t *testing.T) {
+		mock := &MockExecutor{
+			Commands: []MockCommand{
+				{Stdout: "https://github.com/owner/repo/issues/7\n"},
+			},
+		}
+		manager := NewIssueManager(mock, &RepoInfo{Owner: "owner", Repo: "repo"})
+
+		url, err := manager.Create(context.Background(), "Conflict", "Body")
+		require.NoError(t, err)
+		assert.Equal(t, "https://github.com/owner/repo/issues/7", url)
+	})
+
+	t.Run("returns error on failure", func(t *testing.T) {
+		mock := &MockExecutor{
+			Commands: []MockCommand{
+				{Stderr: "not authenticated", ExitCode: 1},
+			},
+		}
+		manager := NewIssueManager(mock, nil)
+
+		_, err := manager.Create(context.Background(), "Conflict", "Body")
+		require.Error(t, err)
+		assert.True(t, IsGitHubError(err))
+		assert.Contains(t, err.Error(), "not authenticated")
+	})
+}
@@ -0,0 +1,34 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+)
+
+// HistoryError represents a failure to read or write run history.
+type HistoryError struct {
+	Path    string // File or directory involved, if any
+	Message string
+	Err     error
+}
+
+func (e *HistoryError) Error() string {
+	prefix := "history"
+	if e.Path != "" {
+		prefix = fmt.Sprintf("history %s", e.Path)
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", prefix, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", prefix, e.Message)
+}
+
+func (e *HistoryError) Unwrap() error {
+	return e.Err
+}
+
+// IsHistoryError checks if an error is a HistoryError.
+func IsHistoryError(err error) bool {
+	var he *HistoryError
+	return errors.As(err, &he)
+}
@@ -0,0 +1,22 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryError(t *testing.T) {
+	inner := errors.New("permission denied")
+
+	assert.Equal(t, "history: failed to encode entry", (&HistoryError{Message: "failed to encode entry"}).Error())
+	assert.Equal(t, "history .claude/history: failed to read history directory: permission denied",
+		(&HistoryError{Path: ".claude/history", Message: "failed to read history directory", Err: inner}).Error())
+
+	wrapped := fmt.Errorf("listing: %w", &HistoryError{Message: "x", Err: inner})
+	assert.True(t, IsHistoryError(wrapped))
+	assert.ErrorIs(t, wrapped, inner)
+	assert.False(t, IsHistoryError(inner))
+}
@@ -0,0 +1,110 @@
+// Package history persists compact summaries of claude-loop runs and aggregates their costs.
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/report"
+)
+
+// DefaultDir is where run summaries are stored, one JSON file per run.
+const DefaultDir = ".claude/history"
+
+// promptPreviewLength bounds the prompt text kept for listing and filtering.
+const promptPreviewLength = 80
+
+// Costs breaks a run's spend down by category.
+type Costs struct {
+	Iterations float64 `json:"iterations"`
+	Reviewer   float64 `json:"reviewer"`
+	Council    float64 `json:"council"`
+	Total      float64 `json:"total"`
+}
+
+// Entry is the compact summary of one run.
+type Entry struct {
+	RunID                string            `json:"run_id"`
+	StartedAt            time.Time         `json:"started_at"`
+	FinishedAt           time.Time         `json:"finished_at"`
+	PromptHash           string            `json:"prompt_hash"`
+	PromptPreview        string            `json:"prompt_preview"`
+	Flags                map[string]string `json:"flags,omitempty"` // Flags set explicitly on the command line, excluding the prompt
+	StopReason           string            `json:"stop_reason"`
+	DryRun               bool              `json:"dry_run,omitempty"`
+	SuccessfulIterations int               `json:"successful_iterations"`
+	TotalIterations      int               `json:"total_iterations"`
+	Costs                Costs             `json:"costs"`
+	PullRequests         []string          `json:"pull_requests,omitempty"`
+}
+
+// NewEntry summarizes a run report. flags are the explicitly set CLI flags.
+func NewEntry(r *report.Report, flags map[string]string) *Entry {
+	return &Entry{
+		RunID:                r.RunID,
+		StartedAt:            r.StartedAt,
+		FinishedAt:           r.FinishedAt,
+		PromptHash:           HashPrompt(r.Prompt),
+		PromptPreview:        previewPrompt(r.Prompt),
+		Flags:                flags,
+		StopReason:           r.StopReason,
+		DryRun:               r.DryRun,
+		SuccessfulIterations: r.SuccessfulIterations,
+		TotalIterations:      r.TotalIterations,
+		Costs: Costs{
+			Iterations: r.TotalCost - r.ReviewerCost - r.CouncilCost,
+			Reviewer:   r.ReviewerCost,
+			Council:    r.CouncilCost,
+			Total:      r.TotalCost,
+		},
+		PullRequests: r.PullRequests(),
+	}
+}
+
+// Duration returns the wall-clock length of the run.
+func (e *Entry) Duration() time.Duration {
+	return e.FinishedAt.Sub(e.StartedAt)
+}
+
+// HashPrompt returns a short stable identifier for a prompt, so repeated runs of the same goal can be grouped.
+func HashPrompt(prompt string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(prompt)))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// previewPrompt collapses whitespace and truncates prompt for display.
+func previewPrompt(prompt string) string {
+	preview := strings.Join(strings.Fields(prompt), " ")
+	if len(preview) > promptPreviewLength {
+		preview = preview[:promptPreviewLength-3] + "..."
+	}
+	return preview
+}
+
+// Filter selects entries by start time and prompt.
+type Filter struct {
+	Since  time.Time // Zero means no lower bound
+	Until  time.Time // Exclusive; zero means no upper bound
+	Prompt string    // Prompt hash prefix or case-insensitive substring of the prompt preview
+}
+
+// Match reports whether e passes the filter.
+func (f *Filter) Match(e *Entry) bool {
+	if f == nil {
+		return true
+	}
+	if !f.Since.IsZero() && e.StartedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !e.StartedAt.Before(f.Until) {
+		return false
+	}
+	if f.Prompt != "" &&
+		!strings.HasPrefix(e.PromptHash, f.Prompt) &&
+		!strings.Contains(strings.ToLower(e.PromptPreview), strings.ToLower(f.Prompt)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package history
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/DeukWoongWoo/claude-loop/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEntry(t *testing.T) {
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	r := &report.Report{
+		RunID:                "run-1",
+		Prompt:               "  Add   tests\nfor the parser  ",
+		StartedAt:            start,
+		FinishedAt:           start.Add(90 * time.Second),
+		StopReason:           "max_runs_reached",
+		SuccessfulIterations: 2,
+		TotalIterations:      3,
+		TotalCost:            1.0,
+		ReviewerCost:         0.2,
+		CouncilCost:          0.1,
+		Iterations: []loop.IterationRecord{
+			{Number: 1, PullRequests: []string{"https://github.com/acme/app/pull/1"}},
+		},
+	}
+
+	e := NewEntry(r, map[string]string{"max-runs": "3"})
+
+	assert.Equal(t, "run-1", e.RunID)
+	assert.Equal(t, "Add tests for the parser", e.PromptPreview)
+	assert.Equal(t, HashPrompt("Add   tests\nfor the parser"), e.PromptHash)
+	assert.Len(t, e.PromptHash, 12)
+	assert.InDelta(t, 0.7, e.Costs.Iterations, 0.0001)
+	assert.Equal(t, 1.0, e.Costs.Total)
+	assert.Equal(t, []string{"https://github.com/acme/app/pull/1"}, e.PullRequests)
+	assert.Equal(t, 90*time.Second, e.Duration())
+	assert.Equal(t, "3", e.Flags["max-runs"])
+}
+
+func TestPreviewPrompt_Truncates(t *testing.T) {
+	preview := previewPrompt(strings.Repeat("a", 200))
+	assert.Len(t, preview, promptPreviewLength)
+	assert.True(t, strings.HasSuffix(preview, "..."))
+}
+
+func TestFilter_Match(t *testing.T) {
+	e := &Entry{
+		StartedAt:     time.Date(2026, 10, 5, 12, 0, 0, 0, time.UTC),
+		PromptHash:    "abcdef123456",
+		PromptPreview: "Add tests for the parser",
+	}
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		filter *Filter
+		want   bool
+	}{
+		{"nil filter", nil, true},
+		{"empty filter", &Filter{}, true},
+		{"since before", &Filter{Since: day(5)}, true},
+		{"since after", &Filter{Since: day(6)}, false},
+		{"until after", &Filter{Until: day(6)}, true},
+		{"until same day start", &Filter{Until: day(5)}, false},
+		{"prompt substring", &Filter{Prompt: "PARSER"}, true},
+		{"prompt hash prefix", &Filter{Prompt: "abcd"}, true},
+		{"prompt mismatch", &Filter{Prompt: "docs"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(e))
+		})
+	}
+}
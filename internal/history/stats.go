@@ -0,0 +1,172 @@
+package history
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Period is the bucket size for cost aggregation.
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+// ParsePeriod validates a period name.
+func ParsePeriod(s string) (Period, error) {
+	switch p := Period(s); p {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return p, nil
+	}
+	return "", &HistoryError{Message: fmt.Sprintf("unknown period %q (valid: day, week, month)", s)}
+}
+
+// Key returns the bucket label for t: 2026-10-18, 2026-W42 or 2026-10.
+func (p Period) Key(t time.Time) string {
+	switch p {
+	case PeriodWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case PeriodMonth:
+		return t.Format("2006-01")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+// PeriodCost aggregates runs that started in one period.
+type PeriodCost struct {
+	Period               string
+	Runs                 int
+	SuccessfulIterations int
+	Costs                Costs
+}
+
+// StopReasonStats aggregates runs that ended for the same reason.
+type StopReasonStats struct {
+	StopReason           string
+	Runs                 int
+	SuccessfulIterations int
+	TotalIterations      int
+}
+
+// SuccessRate is the fraction of iterations that succeeded.
+func (s *StopReasonStats) SuccessRate() float64 {
+	if s.TotalIterations == 0 {
+		return 0
+	}
+	return float64(s.SuccessfulIterations) / float64(s.TotalIterations)
+}
+
+// Stats summarizes a set of runs.
+type Stats struct {
+	Runs                 int
+	SuccessfulIterations int
+	TotalIterations      int
+	Costs                Costs
+	ByPeriod             []PeriodCost      // Oldest period first
+	ByStopReason         []StopReasonStats // Most frequent first
+}
+
+// AvgCostPerSuccessfulIteration divides total spend by successful iterations.
+func (s *Stats) AvgCostPerSuccessfulIteration() float64 {
+	if s.SuccessfulIterations == 0 {
+		return 0
+	}
+	return s.Costs.Total / float64(s.SuccessfulIterations)
+}
+
+// Compute aggregates entries by period and stop reason.
+// Dry runs spend nothing and are left out.
+func Compute(entries []*Entry, period Period) *Stats {
+	stats := &Stats{}
+	periods := make(map[string]*PeriodCost)
+	reasons := make(map[string]*StopReasonStats)
+
+	for _, e := range entries {
+		if e.DryRun {
+			continue
+		}
+		stats.Runs++
+		stats.SuccessfulIterations += e.SuccessfulIterations
+		stats.TotalIterations += e.TotalIterations
+		stats.Costs.add(e.Costs)
+
+		key := period.Key(e.StartedAt)
+		pc, ok := periods[key]
+		if !ok {
+			pc = &PeriodCost{Period: key}
+			periods[key] = pc
+		}
+		pc.Runs++
+		pc.SuccessfulIterations += e.SuccessfulIterations
+		pc.Costs.add(e.Costs)
+
+		rs, ok := reasons[e.StopReason]
+		if !ok {
+			rs = &StopReasonStats{StopReason: e.StopReason}
+			reasons[e.StopReason] = rs
+		}
+		rs.Runs++
+		rs.SuccessfulIterations += e.SuccessfulIterations
+		rs.TotalIterations += e.TotalIterations
+	}
+
+	for _, pc := range periods {
+		stats.ByPeriod = append(stats.ByPeriod, *pc)
+	}
+	sort.Slice(stats.ByPeriod, func(i, j int) bool { return stats.ByPeriod[i].Period < stats.ByPeriod[j].Period })
+
+	for _, rs := range reasons {
+		stats.ByStopReason = append(stats.ByStopReason, *rs)
+	}
+	sort.Slice(stats.ByStopReason, func(i, j int) bool {
+		a, b := stats.ByStopReason[i], stats.ByStopReason[j]
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		return a.StopReason < b.StopReason
+	})
+
+	return stats
+}
+
+// add accumulates other into c.
+func (c *Costs) add(other Costs) {
+	c.Iterations += other.Iterations
+	c.Reviewer += other.Reviewer
+	c.Council += other.Council
+	c.Total += other.Total
+}
+
+// WriteCSV writes the per-period cost breakdown as CSV.
+func WriteCSV(w io.Writer, stats *Stats) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{"period", "runs", "successful_iterations", "iteration_cost", "reviewer_cost", "council_cost", "total_cost"}}
+	for _, pc := range stats.ByPeriod {
+		rows = append(rows, []string{
+			pc.Period,
+			strconv.Itoa(pc.Runs),
+			strconv.Itoa(pc.SuccessfulIterations),
+			formatAmount(pc.Costs.Iterations),
+			formatAmount(pc.Costs.Reviewer),
+			formatAmount(pc.Costs.Council),
+			formatAmount(pc.Costs.Total),
+		})
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return &HistoryError{Message: "failed to write CSV", Err: err}
+	}
+	return nil
+}
+
+// formatAmount formats a USD amount for CSV without a currency symbol.
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
@@ -0,0 +1,84 @@
+package history
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleEntries() []*Entry {
+	at := func(day int) time.Time { return time.Date(2026, 10, day, 10, 0, 0, 0, time.UTC) }
+	return []*Entry{
+		{StartedAt: at(5), StopReason: "max_runs_reached", SuccessfulIterations: 3, TotalIterations: 4,
+			Costs: Costs{Iterations: 0.8, Reviewer: 0.2, Total: 1.0}},
+		{StartedAt: at(6), StopReason: "completion_signal", SuccessfulIterations: 2, TotalIterations: 2,
+			Costs: Costs{Iterations: 0.5, Total: 0.5}},
+		{StartedAt: at(13), StopReason: "max_runs_reached", SuccessfulIterations: 0, TotalIterations: 3,
+			Costs: Costs{Iterations: 0.3, Council: 0.2, Total: 0.5}},
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	p, err := ParsePeriod("week")
+	require.NoError(t, err)
+	assert.Equal(t, PeriodWeek, p)
+
+	_, err = ParsePeriod("year")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown period "year"`)
+}
+
+func TestPeriod_Key(t *testing.T) {
+	ts := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2026-10-18", PeriodDay.Key(ts))
+	assert.Equal(t, "2026-W42", PeriodWeek.Key(ts))
+	assert.Equal(t, "2026-10", PeriodMonth.Key(ts))
+}
+
+func TestCompute(t *testing.T) {
+	stats := Compute(sampleEntries(), PeriodWeek)
+
+	assert.Equal(t, 3, stats.Runs)
+	assert.Equal(t, 5, stats.SuccessfulIterations)
+	assert.InDelta(t, 2.0, stats.Costs.Total, 0.0001)
+	assert.InDelta(t, 0.4, stats.AvgCostPerSuccessfulIteration(), 0.0001)
+
+	require.Len(t, stats.ByPeriod, 2)
+	assert.Equal(t, "2026-W41", stats.ByPeriod[0].Period)
+	assert.Equal(t, 2, stats.ByPeriod[0].Runs)
+	assert.InDelta(t, 1.5, stats.ByPeriod[0].Costs.Total, 0.0001)
+
+	require.Len(t, stats.ByStopReason, 2)
+	assert.Equal(t, "max_runs_reached", stats.ByStopReason[0].StopReason)
+	assert.Equal(t, 2, stats.ByStopReason[0].Runs)
+	assert.InDelta(t, 3.0/7.0, stats.ByStopReason[0].SuccessRate(), 0.0001)
+}
+
+func TestCompute_SkipsDryRuns(t *testing.T) {
+	entries := append(sampleEntries(), &Entry{RunID: "dry", DryRun: true, StopReason: "max_runs_reached", SuccessfulIterations: 3, TotalIterations: 3})
+
+	stats := Compute(entries, PeriodWeek)
+
+	assert.Equal(t, 3, stats.Runs)
+	assert.Equal(t, 5, stats.SuccessfulIterations)
+}
+
+func TestCompute_Empty(t *testing.T) {
+	stats := Compute(nil, PeriodDay)
+	assert.Equal(t, 0, stats.Runs)
+	assert.Equal(t, 0.0, stats.AvgCostPerSuccessfulIteration())
+	assert.Empty(t, stats.ByPeriod)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, Compute(sampleEntries(), PeriodMonth)))
+
+	assert.Equal(t,
+		"period,runs,successful_iterations,iteration_cost,reviewer_cost,council_cost,total_cost\n"+
+			"2026-10,3,5,1.6000,0.2000,0.2000,2.0000\n",
+		buf.String())
+}
@@ -0,0 +1,98 @@
+package history
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Store reads and writes run summaries in a directory.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a Store rooted at dir. If dir is empty, DefaultDir is used.
+func NewStore(dir string) *Store {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	return &Store{dir: dir}
+}
+
+// Dir returns the directory summaries are stored in.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Save writes entry to <dir>/<run-id>.json and returns its path.
+func (s *Store) Save(entry *Entry) (string, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return "", &HistoryError{Path: s.dir, Message: "failed to create history directory", Err: err}
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return "", &HistoryError{Message: "failed to encode entry", Err: err}
+	}
+
+	path := s.path(entry.RunID)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", &HistoryError{Path: path, Message: "failed to write entry", Err: err}
+	}
+	return path, nil
+}
+
+// Get loads the entry for runID.
+func (s *Store) Get(runID string) (*Entry, error) {
+	return s.load(s.path(runID))
+}
+
+// List returns the entries matching filter, oldest first.
+// A missing history directory yields an empty list.
+func (s *Store) List(filter *Filter) ([]*Entry, error) {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, &HistoryError{Path: s.dir, Message: "failed to read history directory", Err: err}
+	}
+
+	var entries []*Entry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		entry, err := s.load(filepath.Join(s.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if filter.Match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].StartedAt.Before(entries[j].StartedAt) })
+	return entries, nil
+}
+
+// path returns the file for runID.
+func (s *Store) path(runID string) string {
+	return filepath.Join(s.dir, runID+".json")
+}
+
+// load reads a single entry file.
+func (s *Store) load(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &HistoryError{Path: path, Message: "failed to read entry", Err: err}
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, &HistoryError{Path: path, Message: "invalid entry", Err: err}
+	}
+	return &entry, nil
+}
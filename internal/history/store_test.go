@@ -0,0 +1,64 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_SaveGetList(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "history"))
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	for i, id := range []string{"run-b", "run-a", "run-c"} {
+		_, err := store.Save(&Entry{
+			RunID:         id,
+			StartedAt:     base.Add(time.Duration(2-i) * 24 * time.Hour),
+			PromptPreview: id,
+			Costs:         Costs{Total: float64(i)},
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := store.Get("run-a")
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, got.Costs.Total)
+
+	all, err := store.List(nil)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "run-c", all[0].RunID) // Oldest first
+	assert.Equal(t, "run-b", all[2].RunID)
+
+	filtered, err := store.List(&Filter{Prompt: "run-a"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+}
+
+func TestStore_List_MissingDir(t *testing.T) {
+	entries, err := NewStore(filepath.Join(t.TempDir(), "missing")).List(nil)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestStore_Errors(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	_, err := store.Get("run-missing")
+	require.Error(t, err)
+	assert.True(t, IsHistoryError(err))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "run-bad.json"), []byte("{"), 0644))
+	_, err = store.List(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid entry")
+}
+
+func TestNewStore_DefaultDir(t *testing.T) {
+	assert.Equal(t, DefaultDir, NewStore("").Dir())
+}
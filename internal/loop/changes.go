@@ -0,0 +1,145 @@
+package loop
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+)
+
+// Snapshot identifies the repository state at a point in time.
+type Snapshot struct {
+	Commit string `json:"commit,omitempty"` // HEAD commit (empty in a repository without commits)
+	Tree   string `json:"tree"`             // Tree object of the working tree, including untracked files
+}
+
+// ChangeSet describes what changed in the repository between two snapshots.
+type ChangeSet struct {
+	Diff    *git.DiffStat `json:"diff,omitempty"`
+	Commits []string      `json:"commits,omitempty"` // Commits created since the first snapshot, oldest first
+}
+
+// ChangeTracker measures repository changes made by an iteration.
+// Implementations must not modify the working tree or index.
+type ChangeTracker interface {
+	Snapshot(ctx context.Context) (*Snapshot, error)
+	Changes(ctx context.Context, since *Snapshot) (*ChangeSet, error)
+}
+
+// ChangeReverter is implemented by ChangeTrackers that can undo changes made since a snapshot.
+// Paths are returned to their state in since; with dropCommits, commits made after
+// since are also undone, leaving their changes in the working tree.
+type ChangeReverter interface {
+	Revert(ctx context.Context, since *Snapshot, paths []string, dropCommits bool) error
+}
+
+// ChangePatcher is implemented by ChangeTrackers that can show changes made since a
+// snapshot as a unified diff.
+type ChangePatcher interface {
+	Patch(ctx context.Context, since *Snapshot) (string, error)
+}
+
+// Committer commits the working tree's changes after checking what is staged,
+// such as a git.CommitManager with a secrets guard.
+type Committer interface {
+	// CommitAll stages every change, checks it and commits it. Nothing is
+	// committed when the check fails; git.ErrNothingToCommit is returned when
+	// nothing is left to commit.
+	CommitAll(ctx context.Context, message string) error
+}
+
+// Brancher moves work off a branch that must not receive commits, such as a
+// git.BranchManager.
+type Brancher interface {
+	// CurrentBranch returns the checked-out branch.
+	CurrentBranch(ctx context.Context) (string, error)
+	// BranchOff checks out a new branch named with prefix at HEAD, keeping the
+	// working tree, and resets the branch it left to base, so commits made since
+	// base move with it. Returns the new branch name.
+	BranchOff(ctx context.Context, prefix, base string) (string, error)
+}
+
+// GitChangeTracker is the ChangeTracker backed by git.
+type GitChangeTracker struct {
+	diff    *git.DiffManager
+	exclude []string
+}
+
+// NewGitChangeTracker creates a ChangeTracker for the repository in the working directory.
+// If diff is nil, a DiffManager with the default executor is used.
+// Paths in exclude, such as claude-loop's own artifact directories, are not counted as changes.
+func NewGitChangeTracker(diff *git.DiffManager, exclude ...string) *GitChangeTracker {
+	if diff == nil {
+		diff = git.NewDiffManager(nil)
+	}
+	return &GitChangeTracker{diff: diff, exclude: exclude}
+}
+
+// Snapshot records HEAD and the current working tree.
+func (t *GitChangeTracker) Snapshot(ctx context.Context) (*Snapshot, error) {
+	tree, err := t.diff.SnapshotTree(ctx, t.exclude...)
+	if err != nil {
+		return nil, err
+	}
+	// HEAD does not exist before the first commit; the tree alone is still comparable
+	head, _ := t.diff.HeadCommit(ctx)
+	return &Snapshot{Commit: head, Tree: tree}, nil
+}
+
+// Changes diffs the working tree against since and lists commits made after it.
+func (t *GitChangeTracker) Changes(ctx context.Context, since *Snapshot) (*ChangeSet, error) {
+	now, err := t.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := t.diff.DiffTrees(ctx, since.Tree, now.Tree)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &ChangeSet{Diff: diff}
+	if since.Commit != "" && now.Commit != since.Commit {
+		commits, err := t.diff.CommitsSince(ctx, since.Commit)
+		if err != nil {
+			return nil, err
+		}
+		changes.Commits = commits
+	}
+	return changes, nil
+}
+
+// Patch returns the changes since the snapshot as a unified diff without context lines.
+func (t *GitChangeTracker) Patch(ctx context.Context, since *Snapshot) (string, error) {
+	now, err := t.Snapshot(ctx)
+	if err != nil {
+		return "", err
+	}
+	return t.diff.PatchTrees(ctx, since.Tree, now.Tree)
+}
+
+// Revert restores paths to since and, with dropCommits, soft-resets HEAD to since.Commit.
+func (t *GitChangeTracker) Revert(ctx context.Context, since *Snapshot, paths []string, dropCommits bool) error {
+	if dropCommits && since.Commit != "" {
+		if err := t.diff.ResetSoft(ctx, since.Commit); err != nil {
+			return err
+		}
+	}
+	return t.diff.RestorePaths(ctx, since.Commit, since.Tree, paths)
+}
+
+// pullRequestURLPattern matches GitHub pull request links in Claude output.
+var pullRequestURLPattern = regexp.MustCompile(`https://github\.com/[\w.-]+/[\w.-]+/pull/\d+`)
+
+// extractPullRequestURLs returns the distinct pull request links in output, in order of appearance.
+func extractPullRequestURLs(output string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, url := range pullRequestURLPattern.FindAllString(output, -1) {
+		if !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,808 @@
+package loop
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+	"github.com/DeukWoongWoo/claude-loop/internal/protected"
+	"github.com/DeukWoongWoo/claude-loop/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dirExecutor runs git commands in a fixed directory.
+type dirExecutor struct {
+	dir string
+}
+
+func (e *dirExecutor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = e.dir
+	return cmd
+}
+
+// newTestRepo creates a git repository with one commit and returns its path.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("one\n"), 0644))
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestGitChangeTracker(t *testing.T) {
+	dir := newTestRepo(t)
+	tracker := NewGitChangeTracker(git.NewDiffManager(&dirExecutor{dir: dir}), ".claude/runs")
+	ctx := context.Background()
+
+	before, err := tracker.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, before.Commit)
+	assert.NotEmpty(t, before.Tree)
+
+	// Modify a tracked file, add an untracked one, and commit something else
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("one\ntwo\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), []byte("x\ny\nz\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.txt"), []byte("c\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude", "runs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude", "runs", "report.md"), []byte("r\n"), 0644))
+	runGit(t, dir, "add", "c.txt")
+	runGit(t, dir, "commit", "-q", "-m", "add c")
+
+	changes, err := tracker.Changes(ctx, before)
+	require.NoError(t, err)
+	assert.Equal(t, 3, changes.Diff.FilesChanged())
+	assert.Equal(t, 5, changes.Diff.Insertions)
+	assert.Len(t, changes.Commits, 1)
+
+	// The user's index is untouched: new.txt is still untracked
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "?? new.txt")
+}
+
+func TestGitChangeTracker_Revert(t *testing.T) {
+	ctx := context.Background()
+	gitOutput := func(t *testing.T, dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	t.Run("restores only the given paths", func(t *testing.T) {
+		dir := newTestRepo(t)
+		tracker := NewGitChangeTracker(git.NewDiffManager(&dirExecutor{dir: dir}))
+		before, err := tracker.Snapshot(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.env"), []byte("KEY=1\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+		runGit(t, dir, "add", "secret.env")
+		runGit(t, dir, "commit", "-q", "-m", "add secret")
+
+		require.NoError(t, tracker.Revert(ctx, before, []string{"a.txt", "secret.env"}, false))
+
+		content, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "one\n", string(content))
+		assert.NoFileExists(t, filepath.Join(dir, "secret.env"))
+		assert.FileExists(t, filepath.Join(dir, "b.txt"))
+
+		// The commit stays; its protected file is staged for removal
+		assert.NotEqual(t, before.Commit, strings.TrimSpace(gitOutput(t, dir, "rev-parse", "HEAD")))
+		assert.Contains(t, gitOutput(t, dir, "status", "--porcelain"), "D  secret.env")
+	})
+
+	t.Run("drops the iteration's commits", func(t *testing.T) {
+		dir := newTestRepo(t)
+		tracker := NewGitChangeTracker(git.NewDiffManager(&dirExecutor{dir: dir}))
+		before, err := tracker.Snapshot(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+		runGit(t, dir, "add", "-A")
+		runGit(t, dir, "commit", "-q", "-m", "iteration work")
+
+		changes, err := tracker.Changes(ctx, before)
+		require.NoError(t, err)
+		var paths []string
+		for _, file := range changes.Diff.Files {
+			paths = append(paths, file.Path)
+		}
+
+		require.NoError(t, tracker.Revert(ctx, before, paths, true))
+		assert.Equal(t, before.Commit, strings.TrimSpace(gitOutput(t, dir, "rev-parse", "HEAD")))
+		assert.Empty(t, gitOutput(t, dir, "status", "--porcelain"))
+	})
+}
+
+func TestGitChangeTracker_Patch(t *testing.T) {
+	dir := newTestRepo(t)
+	tracker := NewGitChangeTracker(git.NewDiffManager(&dirExecutor{dir: dir}), ".claude/runs")
+	ctx := context.Background()
+
+	before, err := tracker.Snapshot(ctx)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("one\ntwo\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+
+	patch, err := tracker.Patch(ctx, before)
+	require.NoError(t, err)
+	assert.Contains(t, patch, "+++ b/a.txt\n@@ -1,0 +2 @@ one\n+two\n")
+	assert.Contains(t, patch, "+++ b/b.txt\n")
+}
+
+func TestExtractPullRequestURLs(t *testing.T) {
+	output := "Opened https://github.com/acme/app/pull/12 and see https://github.com/acme/app/pull/12, " +
+		"plus https://github.com/acme/app/pull/13."
+
+	assert.Equal(t, []string{
+		"https://github.com/acme/app/pull/12",
+		"https://github.com/acme/app/pull/13",
+	}, extractPullRequestURLs(output))
+	assert.Nil(t, extractPullRequestURLs("no links"))
+}
+
+// fakeChangeTracker returns a fixed ChangeSet.
+type fakeChangeTracker struct {
+	snapshotErr error
+	snapshots   int
+}
+
+func (f *fakeChangeTracker) Snapshot(ctx context.Context) (*Snapshot, error) {
+	f.snapshots++
+	if f.snapshotErr != nil {
+		return nil, f.snapshotErr
+	}
+	return &Snapshot{Commit: "c0", Tree: "t0"}, nil
+}
+
+func (f *fakeChangeTracker) Changes(ctx context.Context, since *Snapshot) (*ChangeSet, error) {
+	return &ChangeSet{
+		Diff:    &git.DiffStat{Files: []git.FileStat{{Path: "a.go", Insertions: 2}}, Insertions: 2},
+		Commits: []string{"c1"},
+	}, nil
+}
+
+// revertingTracker is a fakeChangeTracker that records Revert calls.
+type revertingTracker struct {
+	fakeChangeTracker
+	paths       []string
+	dropCommits bool
+	err         error
+	reverts     int
+}
+
+func (r *revertingTracker) Revert(ctx context.Context, since *Snapshot, paths []string, dropCommits bool) error {
+	r.paths, r.dropCommits = paths, dropCommits
+	r.reverts++
+	return r.err
+}
+
+func TestExecutor_Run_EnforcesProtectedPaths(t *testing.T) {
+	matcher, err := protected.NewMatcher([]string{"*.go"})
+	require.NoError(t, err)
+
+	t.Run("reverts protected files and tells the next iteration", func(t *testing.T) {
+		tracker := &revertingTracker{}
+		cfg := &Config{
+			Prompt:               "test",
+			MaxRuns:              2,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        tracker,
+			ProtectedPaths:       matcher,
+			Principles:           config.DefaultPrinciples(config.PresetStartup),
+			RunID:                "run-1",
+		}
+		mock := NewMockClient()
+
+		executor := NewExecutor(cfg, mock)
+		result, err := executor.Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"a.go"}, tracker.paths)
+		assert.True(t, tracker.dropCommits, "files mode also takes the protected change out of the commits")
+		record := result.State.Iterations[0].Protected
+		require.NotNil(t, record)
+		assert.Equal(t, cfg.ProtectedPaths.Check([]string{"a.go"}), record.Violations)
+		assert.Equal(t, []string{"a.go"}, record.Reverted)
+		assert.Equal(t, 1, record.Commits)
+		assert.Empty(t, record.Error)
+		assert.Contains(t, mock.LastPrompt, "REJECTED CHANGES")
+		assert.Contains(t, mock.LastPrompt, `a.go (protected by "*.go")`)
+		assert.Contains(t, mock.LastPrompt, "commit them again")
+		assert.Contains(t, strings.Join(executor.council.Precedents("reverted protected paths"), "\n"),
+			"of run-1: Reverted files changes to protected paths")
+	})
+
+	t.Run("iteration mode drops commits", func(t *testing.T) {
+		tracker := &revertingTracker{}
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              1,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        tracker,
+			ProtectedPaths:       matcher,
+			ProtectedRevert:      config.RevertIteration,
+		}
+
+		result, err := NewExecutor(config, NewMockClient()).Run(context.Background())
+		require.NoError(t, err)
+
+		assert.True(t, tracker.dropCommits)
+		record := result.State.Iterations[0].Protected
+		require.NotNil(t, record)
+		assert.Equal(t, 1, record.Commits)
+		assert.Len(t, result.State.RejectedChanges, 2)
+	})
+
+	t.Run("failed revert is recorded", func(t *testing.T) {
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              1,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        &fakeChangeTracker{},
+			ProtectedPaths:       matcher,
+		}
+
+		result, err := NewExecutor(config, NewMockClient()).Run(context.Background())
+		require.NoError(t, err)
+
+		record := result.State.Iterations[0].Protected
+		require.NotNil(t, record)
+		assert.Equal(t, "change tracker cannot revert changes", record.Error)
+		assert.Empty(t, record.Reverted)
+	})
+
+	t.Run("unprotected changes are kept", func(t *testing.T) {
+		other, err := protected.NewMatcher([]string{"LICENSE"})
+		require.NoError(t, err)
+		tracker := &revertingTracker{}
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              1,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        tracker,
+			ProtectedPaths:       other,
+		}
+
+		result, err := NewExecutor(config, NewMockClient()).Run(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, result.State.Iterations[0].Protected)
+		assert.Nil(t, tracker.paths)
+	})
+}
+
+// workClient runs work before answering each prompt, like a session that edits
+// and commits files.
+type workClient struct {
+	MockClaudeClient
+	work func()
+}
+
+func (w *workClient) Execute(ctx context.Context, prompt string) (*IterationResult, error) {
+	w.work()
+	return w.MockClaudeClient.Execute(ctx, prompt)
+}
+
+func TestExecutor_Run_RevertsCommittedProtectedChange(t *testing.T) {
+	dir := newTestRepo(t)
+	matcher, err := protected.NewMatcher([]string{"LICENSE"})
+	require.NoError(t, err)
+	client := &workClient{work: func() {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "LICENSE"), []byte("MIT\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+		runGit(t, dir, "add", "-A")
+		runGit(t, dir, "commit", "-q", "-m", "add license and b")
+	}}
+	cfg := &Config{
+		Prompt:               "test",
+		MaxRuns:              1,
+		MaxConsecutiveErrors: 3,
+		ChangeTracker:        NewGitChangeTracker(git.NewDiffManager(&dirExecutor{dir: dir})),
+		ProtectedPaths:       matcher,
+	}
+
+	result, err := NewExecutor(cfg, client).Run(context.Background())
+	require.NoError(t, err)
+
+	record := result.State.Iterations[0].Protected
+	require.NotNil(t, record)
+	assert.Equal(t, 1, record.Commits)
+	assert.Empty(t, record.Error)
+
+	log := exec.Command("git", "log", "--format=%s")
+	log.Dir = dir
+	out, err := log.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "initial\n", string(out), "the commit with the protected change is gone")
+	assert.NoFileExists(t, filepath.Join(dir, "LICENSE"))
+	assert.FileExists(t, filepath.Join(dir, "b.txt"), "unprotected changes stay in the working tree")
+}
+
+func TestExecutor_Run_CommitterKeepsSecretsOut(t *testing.T) {
+	awsKey := "AKIA" + "IOSFODNN7EXAMPLE"
+	run := func(t *testing.T, action secrets.Action, maxRuns int, reviewed bool) (string, *LoopResult, *workClient) {
+		t.Helper()
+		dir := newTestRepo(t)
+		runGit(t, dir, "config", "user.name", "test")
+		runGit(t, dir, "config", "user.email", "test@example.com")
+		client := &workClient{}
+		client.work = func() {
+			if client.CallCount > 0 {
+				return
+			}
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "creds.env"), []byte("AWS_KEY="+awsKey+"\n"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+		}
+		guard := secrets.NewGuard(nil, action, dir)
+		cfg := &Config{
+			Prompt:               "test",
+			MaxRuns:              maxRuns,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        NewGitChangeTracker(git.NewDiffManager(&dirExecutor{dir: dir})),
+			SecretScanner:        secrets.NewScanner(nil),
+			Committer:            git.NewCommitManagerWithCheck(&dirExecutor{dir: dir}, guard.Check),
+		}
+		roles := &RoleClients{}
+		if reviewed {
+			cfg.ReviewPrompt = "check it"
+			roles.Reviewer = &MockClaudeClient{Results: []*IterationResult{{Output: "VERDICT: APPROVE"}}}
+		}
+
+		result, err := NewExecutorWithClients(cfg, client, roles).Run(context.Background())
+		require.NoError(t, err)
+		return dir, result, client
+	}
+	committed := func(t *testing.T, dir string) string {
+		t.Helper()
+		cmd := exec.Command("git", "log", "--name-only", "--format=%s")
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	t.Run("block leaves the approved changes uncommitted", func(t *testing.T) {
+		dir, result, client := run(t, secrets.ActionBlock, 1, true)
+
+		assert.Equal(t, "initial\n\na.txt\n", committed(t, dir), "nothing is committed")
+		assert.Equal(t, 1, client.CallCount, "no push pass runs")
+		record := result.State.Iterations[0]
+		assert.False(t, record.Review.Committed)
+		assert.Contains(t, record.CommitError, "commit blocked, staged changes contain secrets: creds.env:1 (aws-access-key)")
+		require.NotEmpty(t, result.State.BlockedCommit)
+		assert.FileExists(t, filepath.Join(dir, "creds.env"))
+	})
+
+	t.Run("unstage commits everything else", func(t *testing.T) {
+		dir, result, client := run(t, secrets.ActionUnstage, 1, true)
+
+		log := committed(t, dir)
+		assert.Contains(t, log, "b.txt")
+		assert.NotContains(t, log, "creds.env")
+		assert.Equal(t, 2, client.CallCount)
+		assert.Contains(t, client.LastPrompt, "## CHANGES COMMITTED", "the pass only pushes")
+		assert.True(t, result.State.Iterations[0].Review.Committed)
+	})
+
+	t.Run("unreviewed iterations are committed through the check", func(t *testing.T) {
+		dir, result, client := run(t, secrets.ActionBlock, 2, false)
+
+		assert.Equal(t, "initial\n\na.txt\n", committed(t, dir), "nothing is committed")
+		assert.Equal(t, 2, client.CallCount, "no push pass runs")
+		assert.Contains(t, client.LastPrompt, "## COMMIT GATE")
+		assert.Contains(t, client.LastPrompt, "- scans the changes for secrets")
+		assert.NotContains(t, client.LastPrompt, "never commits to", "no base branch is guarded")
+		assert.Contains(t, client.LastPrompt, "## COMMIT BLOCKED\n\nThe previous iteration's changes were not committed:\n\n- secrets: commit blocked")
+		record := result.State.Iterations[0]
+		assert.False(t, record.Committed)
+		assert.Contains(t, record.CommitError, "creds.env:1 (aws-access-key)")
+	})
+
+	t.Run("unreviewed iterations without secrets are committed", func(t *testing.T) {
+		dir, result, client := run(t, secrets.ActionUnstage, 1, false)
+
+		assert.Contains(t, committed(t, dir), "b.txt")
+		assert.Equal(t, 2, client.CallCount)
+		assert.Contains(t, client.LastPrompt, "## CHANGES COMMITTED")
+		assert.True(t, result.State.Iterations[0].Committed)
+		assert.Empty(t, result.State.Iterations[0].CommitError)
+	})
+}
+
+func TestExecutor_Run_EnforcesCommitPolicy(t *testing.T) {
+	setup := func(t *testing.T) (string, *Config) {
+		t.Helper()
+		dir := newTestRepo(t)
+		runGit(t, dir, "config", "user.name", "test")
+		runGit(t, dir, "config", "user.email", "test@example.com")
+		runGit(t, dir, "branch", "-M", "main")
+		return dir, &Config{
+			Prompt:               "test",
+			MaxRuns:              1,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        NewGitChangeTracker(git.NewDiffManager(&dirExecutor{dir: dir})),
+			Committer:            git.NewCommitManager(&dirExecutor{dir: dir}),
+		}
+	}
+	gitOutput := func(t *testing.T, dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		require.NoError(t, err)
+		return strings.TrimSpace(string(out))
+	}
+
+	t.Run("commits on the base branch move to a new branch", func(t *testing.T) {
+		dir, cfg := setup(t)
+		cfg.BaseBranch = "main"
+		cfg.BranchPrefix = "work/"
+		cfg.Brancher = git.NewBranchManager(&dirExecutor{dir: dir})
+		client := &workClient{}
+		client.work = func() {
+			if client.CallCount > 0 {
+				return
+			}
+			// Claude commits to main despite the commit gate, then leaves more work
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+			runGit(t, dir, "add", "b.txt")
+			runGit(t, dir, "commit", "-q", "-m", "direct commit")
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "c.txt"), []byte("c\n"), 0644))
+		}
+
+		result, err := NewExecutor(cfg, client).Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "initial", gitOutput(t, dir, "log", "--format=%s", "main"), "main keeps only its own history")
+		branch := gitOutput(t, dir, "rev-parse", "--abbrev-ref", "HEAD")
+		assert.True(t, strings.HasPrefix(branch, "work/"), branch)
+		assert.Equal(t, "default output\ndirect commit\ninitial", gitOutput(t, dir, "log", "--format=%s"))
+		assert.True(t, result.State.Iterations[0].Committed)
+		assert.Contains(t, client.LastPrompt, "## CHANGES COMMITTED", "the push pass runs on the new branch")
+	})
+
+	t.Run("other branches are committed to directly", func(t *testing.T) {
+		dir, cfg := setup(t)
+		runGit(t, dir, "checkout", "-q", "-b", "feature")
+		cfg.BaseBranch = "main"
+		cfg.Brancher = git.NewBranchManager(&dirExecutor{dir: dir})
+		client := &workClient{work: func() {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+		}}
+
+		_, err := NewExecutor(cfg, client).Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "feature", gitOutput(t, dir, "rev-parse", "--abbrev-ref", "HEAD"))
+		assert.Equal(t, "default output\ninitial", gitOutput(t, dir, "log", "--format=%s"))
+	})
+
+	t.Run("a publisher pushes what the main client may not", func(t *testing.T) {
+		dir, cfg := setup(t)
+		client := &workClient{work: func() {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+		}}
+		publisher := &promptRecorder{MockClaudeClient: MockClaudeClient{Results: []*IterationResult{
+			{Output: "Opened https://github.com/o/r/pull/7"},
+		}}}
+
+		result, err := NewExecutorWithClients(cfg, client, &RoleClients{Publisher: publisher}).Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, client.CallCount, "the main client only runs the iteration")
+		require.Len(t, publisher.prompts, 1)
+		assert.Contains(t, publisher.prompts[0], "## CHANGES COMMITTED")
+		assert.Equal(t, []string{"https://github.com/o/r/pull/7"}, result.State.Iterations[0].PullRequests)
+		assert.Equal(t, "default output\ninitial", gitOutput(t, dir, "log", "--format=%s"))
+	})
+
+	t.Run("required verification keeps failing changes uncommitted", func(t *testing.T) {
+		dir, cfg := setup(t)
+		cfg.MaxRuns = 2
+		cfg.Verifier = &fakeVerifier{failing: map[string]bool{"go test": true}}
+		cfg.VerifyCriteria = []string{"go test"}
+		cfg.RequireVerification = true
+		client := &workClient{work: func() {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+		}}
+
+		result, err := NewExecutor(cfg, client).Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "initial", gitOutput(t, dir, "log", "--format=%s"), "nothing is committed")
+		assert.Equal(t, 2, client.CallCount, "no push pass runs")
+		assert.False(t, result.State.Iterations[0].Committed)
+		assert.Contains(t, client.LastPrompt, "- go test: tests failed with exit code 1")
+		assert.Contains(t, client.LastPrompt, "- Verification is required: these changes were not committed")
+
+		// Without RequireVerification the failure is reported but the changes are committed
+		dir, cfg = setup(t)
+		cfg.Verifier = &fakeVerifier{failing: map[string]bool{"go test": true}}
+		cfg.VerifyCriteria = []string{"go test"}
+		client = &workClient{work: func() {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+		}}
+		result, err = NewExecutor(cfg, client).Run(context.Background())
+		require.NoError(t, err)
+		assert.True(t, result.State.Iterations[0].Committed)
+	})
+
+	t.Run("required verification stops an approved commit", func(t *testing.T) {
+		dir, cfg := setup(t)
+		cfg.Verifier = &fakeVerifier{failing: map[string]bool{"go test": true}}
+		cfg.VerifyCriteria = []string{"go test"}
+		cfg.RequireVerification = true
+		cfg.ReviewPrompt = "check it"
+		client := &workClient{work: func() {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+		}}
+		roles := &RoleClients{Reviewer: &MockClaudeClient{Results: []*IterationResult{{Output: "VERDICT: APPROVE"}}}}
+
+		result, err := NewExecutorWithClients(cfg, client, roles).Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "initial", gitOutput(t, dir, "log", "--format=%s"), "nothing is committed")
+		require.NotEmpty(t, result.State.ReviewFindings)
+		assert.Contains(t, result.State.ReviewFindings[0], "it failed verification (see VERIFICATION FAILURES)")
+	})
+
+	t.Run("a fix pass repairs failed verification before approval", func(t *testing.T) {
+		dir, cfg := setup(t)
+		verify := &fakeVerifier{failing: map[string]bool{"go test": true}}
+		cfg.Verifier = verify
+		cfg.VerifyCriteria = []string{"go test"}
+		cfg.RequireVerification = true
+		cfg.ReviewPrompt = "check it"
+		cfg.MaxReviewFixes = 1
+		calls := 0
+		client := &workClient{work: func() {
+			calls++
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+			// The second call is the fix pass, which repairs the tests
+			if calls == 2 {
+				verify.failing = nil
+			}
+		}}
+		roles := &RoleClients{Reviewer: &MockClaudeClient{Results: []*IterationResult{
+			{Output: "VERDICT: REQUEST_CHANGES\n- fix the tests"},
+			{Output: "VERDICT: APPROVE"},
+		}}}
+
+		result, err := NewExecutorWithClients(cfg, client, roles).Run(context.Background())
+		require.NoError(t, err)
+
+		record := result.State.Iterations[0]
+		require.NotNil(t, record.Verification)
+		assert.True(t, record.Verification.Passed, "the last pass's checks decide")
+		assert.Empty(t, record.CommitError)
+		assert.Empty(t, result.State.ReviewFindings)
+		assert.Equal(t, "default output\ninitial", gitOutput(t, dir, "log", "--format=%s"))
+	})
+}
+
+func TestExecutor_RejectionReasons(t *testing.T) {
+	e := NewExecutor(&Config{RequireVerification: true}, NewMockClient())
+
+	assert.Equal(t, []string{"it failed verification (see VERIFICATION FAILURES)", "it changed protected paths (see REJECTED CHANGES)"},
+		e.rejectionReasons(&IterationRecord{Verification: &VerificationRecord{}, Protected: &ProtectedRecord{}}))
+	assert.Equal(t, []string{"a check rejected its changes"}, e.rejectionReasons(&IterationRecord{}), "the reason is never empty")
+}
+
+func TestCommitMessage(t *testing.T) {
+	assert.Equal(t, "Added config parsing", commitMessage("\n## Added config parsing\n\nDetails", 3))
+	assert.Equal(t, "Iteration 3", commitMessage(" \n", 3))
+	long := commitMessage(strings.Repeat("word ", 30), 1)
+	assert.Len(t, long, maxCommitSubject)
+	assert.True(t, strings.HasSuffix(long, "..."))
+}
+
+func TestExecutor_Run_EnforcesChangeLimits(t *testing.T) {
+	t.Run("asks the next iteration to split the change", func(t *testing.T) {
+		tracker := &revertingTracker{}
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              2,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        tracker,
+			ChangeLimits:         config.ChangeLimits{MaxFiles: 5, MaxLines: 1},
+		}
+		mock := NewMockClient()
+
+		result, err := NewExecutor(config, mock).Run(context.Background())
+		require.NoError(t, err)
+
+		record := result.State.Iterations[0].ChangeSize
+		require.NotNil(t, record)
+		assert.Equal(t, ChangeSizeRecord{Files: 1, Lines: 2, MaxFiles: 5, MaxLines: 1, Action: "split"}, *record)
+		assert.Nil(t, tracker.paths, "split keeps the changes")
+		assert.Contains(t, mock.LastPrompt, "CHANGE TOO LARGE")
+		assert.Contains(t, mock.LastPrompt, "It changed 1 files and 2 lines; the limit is 5 files and 1 lines")
+		assert.Contains(t, mock.LastPrompt, "Split the work")
+	})
+
+	t.Run("revert action reverts the iteration", func(t *testing.T) {
+		tracker := &revertingTracker{}
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              1,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        tracker,
+			ChangeLimits:         config.ChangeLimits{MaxLines: 1, OnExceed: config.ExceedRevert},
+		}
+
+		result, err := NewExecutor(config, NewMockClient()).Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"a.go"}, tracker.paths)
+		assert.True(t, tracker.dropCommits)
+		record := result.State.Iterations[0].ChangeSize
+		require.NotNil(t, record)
+		assert.Equal(t, []string{"a.go"}, record.Reverted)
+		assert.Equal(t, 1, record.Commits)
+		require.Len(t, result.State.OversizedChange, 2)
+		assert.Contains(t, result.State.OversizedChange[1], "were reverted")
+	})
+
+	t.Run("changes within the limits are kept", func(t *testing.T) {
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              1,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        &fakeChangeTracker{},
+			ChangeLimits:         config.ChangeLimits{MaxFiles: 1, MaxLines: 2},
+		}
+
+		result, err := NewExecutor(config, NewMockClient()).Run(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, result.State.Iterations[0].ChangeSize)
+	})
+
+	t.Run("reverted protected files are not counted", func(t *testing.T) {
+		matcher, err := protected.NewMatcher([]string{"*.go"})
+		require.NoError(t, err)
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              1,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        &revertingTracker{},
+			ProtectedPaths:       matcher,
+			ChangeLimits:         config.ChangeLimits{MaxLines: 1},
+		}
+
+		result, err := NewExecutor(config, NewMockClient()).Run(context.Background())
+		require.NoError(t, err)
+		assert.NotNil(t, result.State.Iterations[0].Protected)
+		assert.Nil(t, result.State.Iterations[0].ChangeSize)
+	})
+}
+
+// patchingTracker is a fakeChangeTracker that returns a fixed patch.
+type patchingTracker struct {
+	fakeChangeTracker
+	patch string
+}
+
+func (p *patchingTracker) Patch(ctx context.Context, since *Snapshot) (string, error) {
+	return p.patch, nil
+}
+
+func TestExecutor_Run_ScansSecrets(t *testing.T) {
+	key := "AKIA" + "IOSFODNN7EXAMPLE"
+	tracker := &patchingTracker{patch: "--- a/a.go\n+++ b/a.go\n@@ -1,0 +4 @@\n+const key = \"" + key + "\"\n"}
+	config := &Config{
+		Prompt:               "test",
+		MaxRuns:              1,
+		MaxConsecutiveErrors: 3,
+		ChangeTracker:        tracker,
+		SecretScanner:        secrets.NewScanner(nil),
+	}
+
+	result, err := NewExecutor(config, NewMockClient()).Run(context.Background())
+	require.NoError(t, err)
+
+	findings := result.State.Iterations[0].Secrets
+	require.Len(t, findings, 1)
+	assert.Equal(t, "a.go", findings[0].File)
+	assert.Equal(t, 4, findings[0].Line)
+	assert.Equal(t, "aws-access-key", findings[0].Rule)
+
+	// Without a scanner nothing is recorded
+	config.SecretScanner = nil
+	result, err = NewExecutor(config, NewMockClient()).Run(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, result.State.Iterations[0].Secrets)
+}
+
+func TestExecutor_Run_RecordsIterations(t *testing.T) {
+	t.Run("records results and changes", func(t *testing.T) {
+		tracker := &fakeChangeTracker{}
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              2,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        tracker,
+		}
+		mock := &MockClaudeClient{
+			Results: []*IterationResult{
+				{Output: "opened https://github.com/acme/app/pull/7", Cost: 0.5, InputTokens: 100, OutputTokens: 20},
+			},
+			Errors: []error{nil, errors.New("boom")},
+		}
+
+		result, err := NewExecutor(config, mock).Run(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, result.State.Iterations, 3)
+		first := result.State.Iterations[0]
+		assert.Equal(t, 1, first.Number)
+		assert.Equal(t, 0.5, first.Cost)
+		assert.Equal(t, 100, first.InputTokens)
+		assert.Equal(t, []string{"https://github.com/acme/app/pull/7"}, first.PullRequests)
+		require.NotNil(t, first.Changes)
+		assert.Equal(t, []string{"c1"}, first.Changes.Commits)
+
+		assert.Contains(t, result.State.Iterations[1].Error, "boom")
+		assert.Equal(t, 3, tracker.snapshots)
+	})
+
+	t.Run("snapshot failure leaves changes empty", func(t *testing.T) {
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              1,
+			MaxConsecutiveErrors: 3,
+			ChangeTracker:        &fakeChangeTracker{snapshotErr: errors.New("not a repo")},
+		}
+
+		result, err := NewExecutor(config, NewMockClient()).Run(context.Background())
+		require.NoError(t, err)
+		require.Len(t, result.State.Iterations, 1)
+		assert.Nil(t, result.State.Iterations[0].Changes)
+	})
+
+	t.Run("records reviewer outcome", func(t *testing.T) {
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              1,
+			MaxConsecutiveErrors: 3,
+			ReviewPrompt:         "review",
+		}
+		mock := &MockClaudeClient{
+			Results: []*IterationResult{{Output: "work", Cost: 0.1}, {Output: "reviewed", Cost: 0.2}},
+		}
+
+		result, err := NewExecutor(config, mock).Run(context.Background())
+		require.NoError(t, err)
+		record := result.State.Iterations[0]
+		require.NotNil(t, record.Review)
+		assert.Equal(t, 0.2, record.Review.Cost)
+		assert.InDelta(t, 0.3, record.TotalCost(), 0.0001)
+	})
+}
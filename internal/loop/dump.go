@@ -0,0 +1,74 @@
+package loop
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Role identifies which part of claude-loop issued a Claude call.
+type Role string
+
+const (
+	RoleIteration Role = "iteration"
+	RoleReviewer  Role = "reviewer"
+	RoleCouncil   Role = "council"
+	RoleCIFix     Role = "ci_fix"
+	RolePlanner   Role = "planner"
+)
+
+// PromptDump saves every prompt sent through its wrapped clients to a directory.
+// Files are numbered in send order across all roles: 0001-iteration.md, 0002-reviewer.md, ...
+type PromptDump struct {
+	dir string
+	mu  sync.Mutex
+	seq int
+}
+
+// NewPromptDump creates a PromptDump writing into dir.
+// The directory is created on the first write.
+func NewPromptDump(dir string) *PromptDump {
+	return &PromptDump{dir: dir}
+}
+
+// Dir returns the directory prompts are written to.
+func (d *PromptDump) Dir() string {
+	return d.dir
+}
+
+// Wrap returns a ClaudeClient that saves each prompt before delegating to client.
+func (d *PromptDump) Wrap(client ClaudeClient, role Role) ClaudeClient {
+	return &promptDumpClient{dump: d, client: client, role: role}
+}
+
+// Save writes prompt to the next numbered file for role and returns its path.
+func (d *PromptDump) Save(role Role, prompt string) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := os.MkdirAll(d.dir, 0755); err != nil {
+		return "", &LoopError{Field: "dump_prompts", Message: "failed to create prompt directory", Err: err}
+	}
+
+	d.seq++
+	path := filepath.Join(d.dir, fmt.Sprintf("%04d-%s.md", d.seq, role))
+	if err := os.WriteFile(path, []byte(prompt), 0644); err != nil {
+		return "", &LoopError{Field: "dump_prompts", Message: "failed to write prompt", Err: err}
+	}
+	return path, nil
+}
+
+// promptDumpClient is the ClaudeClient returned by PromptDump.Wrap.
+type promptDumpClient struct {
+	dump   *PromptDump
+	client ClaudeClient
+	role   Role
+}
+
+// Execute saves the prompt, then executes it. Dump failures do not block execution.
+func (c *promptDumpClient) Execute(ctx context.Context, prompt string) (*IterationResult, error) {
+	_, _ = c.dump.Save(c.role, prompt)
+	return c.client.Execute(ctx, prompt)
+}
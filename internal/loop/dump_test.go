@@ -0,0 +1,53 @@
+package loop
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptDump_Wrap(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "prompts")
+	dump := NewPromptDump(dir)
+
+	main := dump.Wrap(NewMockClient(), RoleIteration)
+	review := dump.Wrap(NewMockClient(), RoleReviewer)
+
+	_, err := main.Execute(context.Background(), "main prompt")
+	require.NoError(t, err)
+	_, err = review.Execute(context.Background(), "review prompt")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "0001-iteration.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "main prompt", string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "0002-reviewer.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "review prompt", string(data))
+	assert.Equal(t, dir, dump.Dir())
+}
+
+func TestNewExecutorWithClients_UsesRoleClients(t *testing.T) {
+	config := &Config{
+		Prompt:               "test",
+		MaxRuns:              1,
+		MaxConsecutiveErrors: 3,
+		ReviewPrompt:         "review it",
+	}
+
+	mainClient := NewMockClient()
+	reviewClient := NewMockClient()
+
+	executor := NewExecutorWithClients(config, mainClient, &RoleClients{Reviewer: reviewClient})
+	_, err := executor.Run(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, mainClient.CallCount)
+	assert.Equal(t, 1, reviewClient.CallCount)
+	assert.Contains(t, reviewClient.LastPrompt, "review it")
+}
@@ -0,0 +1,138 @@
+package loop
+
+import (
+	"context"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+)
+
+// Escalator hands principle conflicts the council could not settle to a human.
+type Escalator interface {
+	// Escalate asks for a decision on e. It sets e.Decision when the human
+	// answers at once, and otherwise e.Path (and e.URL) where the question waits.
+	Escalate(ctx context.Context, e *council.Escalation) error
+
+	// Answer returns the human's decision on a pending escalation, or "" while
+	// there is none.
+	Answer(ctx context.Context, e *council.Escalation) (string, error)
+}
+
+// escalate hands conflict to a human. A decision given at once is logged and
+// passed to the next iteration; otherwise the escalation stays pending and
+// iterations are told to leave the work that depends on it alone. result is
+// nil when the council failed. Returns nil without an Escalator.
+func (e *Executor) escalate(ctx context.Context, state *State, conflict council.Conflict, reason string, result *council.Result) *EscalationRecord {
+	if e.config.Escalator == nil {
+		return nil
+	}
+	esc := council.NewEscalation(e.config.RunID, state.TotalIterations, conflict, reason, result)
+	record := &EscalationRecord{Reason: reason}
+	if err := e.config.Escalator.Escalate(ctx, esc); err != nil {
+		record.Error = err.Error()
+		return record
+	}
+	record.Path, record.URL, record.Decision = esc.Path, esc.URL, esc.Decision
+
+	// The council's proposal is not followed either way
+	state.CouncilResolution = nil
+	if esc.Decision == "" {
+		// Safe default: take no option until a human decides
+		state.PendingEscalations = append(state.PendingEscalations, esc)
+		return record
+	}
+	e.adoptEscalation(state, esc)
+	return record
+}
+
+// checkEscalations adopts the decisions humans have given on pending escalations.
+func (e *Executor) checkEscalations(ctx context.Context, state *State) {
+	if e.config.Escalator == nil {
+		return
+	}
+	pending := state.PendingEscalations[:0]
+	for _, esc := range state.PendingEscalations {
+		decision, err := e.config.Escalator.Answer(ctx, esc)
+		if err != nil || decision == "" {
+			pending = append(pending, esc)
+			continue
+		}
+		esc.Decision = decision
+		e.adoptEscalation(state, esc)
+	}
+	state.PendingEscalations = pending
+}
+
+// adoptEscalation logs a human's decision and passes it to the next iteration.
+func (e *Executor) adoptEscalation(state *State, esc *council.Escalation) {
+	var principles []string
+	if esc.Conflict.Identified() {
+		principles = esc.Conflict.Principles[:]
+	}
+	_ = e.council.LogDecision(&council.Decision{
+		Timestamp:      time.Now(),
+		RunID:          e.config.RunID,
+		Iteration:      esc.Iteration,
+		Decision:       esc.Decision,
+		Rationale:      "Escalated to a human: " + esc.Reason,
+		Preset:         e.config.Principles.Preset,
+		CouncilInvoked: true,
+		Escalated:      true,
+		Principles:     principles,
+		Cost:           esc.Cost,
+	})
+
+	if names := esc.Conflict.Names(); names != "" {
+		state.CouncilResolution = append(state.CouncilResolution, "Conflict: "+names)
+	}
+	state.CouncilResolution = append(state.CouncilResolution, "Decision (by a human): "+esc.Decision)
+}
+
+// awaitingEscalation returns the pending escalation conflict may be the same
+// conflict as, or nil when there is none.
+func awaitingEscalation(state *State, conflict council.Conflict) *council.Escalation {
+	for _, esc := range state.PendingEscalations {
+		if esc.Conflict.Overlaps(conflict) {
+			return esc
+		}
+	}
+	return nil
+}
+
+// awaitsHuman reports whether the iteration's work depends on a conflict still
+// awaiting a human decision: it escalated one that was not answered at once, or
+// reported one that was already pending.
+func (r *CouncilRecord) awaitsHuman() bool {
+	if r == nil {
+		return false
+	}
+	if r.AwaitingEscalation != "" {
+		return true
+	}
+	return r.Escalation != nil && r.Escalation.Error == "" && r.Escalation.Decision == ""
+}
+
+// holdForEscalation reverts the work of an iteration that depends on a conflict
+// awaiting a human decision, commits included, so nothing built on an undecided
+// option is reviewed or committed. The next iteration is told to work on
+// something else.
+func (e *Executor) holdForEscalation(ctx context.Context, state *State, record *IterationRecord, before *Snapshot) {
+	record.CommitError = "it depends on a conflict awaiting a human decision"
+	reason := "It depended on a conflict awaiting a human decision (see PENDING ESCALATIONS), so all of its changes were reverted; work on tasks that do not depend on the decision"
+	if _, _, err := e.revertIteration(ctx, before); err != nil {
+		reason = "It depended on a conflict awaiting a human decision (see PENDING ESCALATIONS), but reverting its changes failed (" + err.Error() + "); undo them yourself and work on tasks that do not depend on the decision"
+	}
+	state.BlockedCommit = []string{reason}
+}
+
+// pendingEscalations describes the pending escalations for the iteration prompt.
+func pendingEscalations(escalations []*council.Escalation) []string {
+	if len(escalations) == 0 {
+		return nil
+	}
+	lines := make([]string, len(escalations))
+	for i, esc := range escalations {
+		lines[i] = esc.Pending()
+	}
+	return lines
+}
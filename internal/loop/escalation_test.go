@@ -0,0 +1,320 @@
+package loop
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEscalator answers escalations with decision: at once when immediate is
+// set, otherwise from the answerAfter-th Answer call on.
+type fakeEscalator struct {
+	decision    string
+	immediate   bool
+	answerAfter int
+	err         error
+
+	escalated []*council.Escalation
+	answers   int
+}
+
+func (f *fakeEscalator) Escalate(ctx context.Context, e *council.Escalation) error {
+	f.escalated = append(f.escalated, e)
+	if f.err != nil {
+		return f.err
+	}
+	if f.immediate {
+		e.Decision = f.decision
+		return nil
+	}
+	e.Path = ".claude/escalations/" + e.ID + ".md"
+	return nil
+}
+
+func (f *fakeEscalator) Answer(ctx context.Context, e *council.Escalation) (string, error) {
+	f.answers++
+	if f.answers < f.answerAfter {
+		return "", nil
+	}
+	return f.decision, nil
+}
+
+// fileEscalator writes each pending escalation under dir, as the file escalator does.
+type fileEscalator struct {
+	fakeEscalator
+	dir string
+}
+
+func (f *fileEscalator) Escalate(ctx context.Context, e *council.Escalation) error {
+	if err := f.fakeEscalator.Escalate(ctx, e); err != nil || e.Path == "" {
+		return err
+	}
+	path := filepath.Join(f.dir, e.Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte("## Decision\n"), 0644)
+}
+
+// promptRecorder remembers every prompt it executes.
+type promptRecorder struct {
+	MockClaudeClient
+	prompts []string
+}
+
+func (p *promptRecorder) Execute(ctx context.Context, prompt string) (*IterationResult, error) {
+	p.prompts = append(p.prompts, prompt)
+	return p.MockClaudeClient.Execute(ctx, prompt)
+}
+
+// splitCouncil returns a config and clients whose two-member council splits
+// on curation_model vs monetization_model.
+func splitCouncil(escalator Escalator, maxRuns int) (*Config, *promptRecorder, *RoleClients) {
+	cfg := &Config{
+		Prompt:               "test",
+		MaxRuns:              maxRuns,
+		MaxConsecutiveErrors: 3,
+		Principles:           config.DefaultPrinciples(config.PresetStartup),
+		Council: &council.Settings{Members: []council.Member{
+			{Name: "product", Principles: []string{"layer0.curation_model"}},
+			{Name: "cost", Principles: []string{"layer0.monetization_model"}},
+		}},
+		RunID:                "run-1",
+		Escalator:            escalator,
+		EscalationConfidence: council.DefaultEscalationConfidence,
+	}
+	main := &promptRecorder{MockClaudeClient: MockClaudeClient{Results: []*IterationResult{
+		{Output: "PRINCIPLE_CONFLICT_UNRESOLVED: curation_model vs monetization_model", Cost: 0.1},
+	}}}
+	roles := &RoleClients{CouncilMembers: map[string]ClaudeClient{
+		"product": &MockClaudeClient{Results: []*IterationResult{
+			{Output: "**Vote**: curation_model\n**Confidence**: 8\n**Decision**: Curate by hand", Cost: 0.02},
+		}},
+		"cost": &MockClaudeClient{Results: []*IterationResult{
+			{Output: "**Vote**: monetization_model\n**Confidence**: 6\n**Decision**: Rank automatically", Cost: 0.02},
+		}},
+		council.ChairName: &MockClaudeClient{Results: []*IterationResult{
+			{Output: "**Vote**: curation_model\n**Confidence**: 7\n**Decision**: Curate by hand\n**Rationale**: Quality first", Cost: 0.03},
+		}},
+	}}
+	return cfg, main, roles
+}
+
+func TestExecutor_Run_EscalationAnsweredAtOnce(t *testing.T) {
+	escalator := &fakeEscalator{decision: "Curate the top 100, rank the rest", immediate: true}
+	cfg, main, roles := splitCouncil(escalator, 2)
+
+	result, err := NewExecutorWithClients(cfg, main, roles).Run(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, escalator.escalated, 1)
+	esc := escalator.escalated[0]
+	assert.Equal(t, "run-1-1", esc.ID)
+	assert.Equal(t, "no majority: 1 of 2 members dissent", esc.Reason)
+	assert.Equal(t, "Curate by hand", esc.Proposal)
+
+	record := result.State.Iterations[0].Council
+	require.NotNil(t, record.Escalation)
+	assert.Equal(t, "Curate by hand", record.Decision, "the council's proposal is still recorded")
+	assert.Equal(t, "Curate the top 100, rank the rest", record.Escalation.Decision)
+	assert.Empty(t, result.State.PendingEscalations)
+
+	require.Len(t, main.prompts, 2)
+	assert.Contains(t, main.prompts[1], "- Conflict: curation_model vs monetization_model\n"+
+		"- Decision (by a human): Curate the top 100, rank the rest\n")
+	assert.NotContains(t, main.prompts[1], "by the council")
+	assert.Contains(t, main.prompts[1], "- Human, iteration 1 of run-1: Curate the top 100, rank the rest")
+}
+
+func TestExecutor_Run_PendingEscalation(t *testing.T) {
+	escalator := &fakeEscalator{decision: "Curate by hand", answerAfter: 2}
+	cfg, main, roles := splitCouncil(escalator, 3)
+
+	result, err := NewExecutorWithClients(cfg, main, roles).Run(context.Background())
+	require.NoError(t, err)
+
+	record := result.State.Iterations[0].Council
+	require.NotNil(t, record.Escalation)
+	assert.Equal(t, ".claude/escalations/run-1-1.md", record.Escalation.Path)
+	assert.Empty(t, record.Escalation.Decision)
+
+	require.Len(t, main.prompts, 3)
+	assert.Contains(t, main.prompts[1], "## PENDING ESCALATIONS")
+	assert.Contains(t, main.prompts[1], "- Conflict between curation_model vs monetization_model from iteration 1\n")
+	assert.NotContains(t, main.prompts[1], "## CONFLICT RESOLUTION")
+
+	assert.NotContains(t, main.prompts[2], "## PENDING ESCALATIONS")
+	assert.Contains(t, main.prompts[2], "- Decision (by a human): Curate by hand\n")
+	assert.Empty(t, result.State.PendingEscalations)
+}
+
+func TestExecutor_Run_EscalationFailure(t *testing.T) {
+	escalator := &fakeEscalator{err: errors.New("disk full")}
+	cfg, main, roles := splitCouncil(escalator, 2)
+
+	result, err := NewExecutorWithClients(cfg, main, roles).Run(context.Background())
+	require.NoError(t, err)
+
+	record := result.State.Iterations[0].Council
+	require.NotNil(t, record.Escalation)
+	assert.Equal(t, "disk full", record.Escalation.Error)
+	assert.Contains(t, main.prompts[1], "- Decision (by the council): Curate by hand\n", "the council's decision stands")
+}
+
+func TestExecutor_Run_EscalatesFailedCouncil(t *testing.T) {
+	escalator := &fakeEscalator{decision: "Rank automatically", immediate: true}
+	cfg := &Config{
+		Prompt:               "test",
+		MaxRuns:              1,
+		MaxConsecutiveErrors: 3,
+		Principles:           config.DefaultPrinciples(config.PresetStartup),
+		Escalator:            escalator,
+	}
+	main := &MockClaudeClient{Results: []*IterationResult{
+		{Output: "PRINCIPLE_CONFLICT_UNRESOLVED: curation_model vs monetization_model", Cost: 0.1},
+	}}
+	councilClient := &MockClaudeClient{Errors: []error{errors.New("timeout")}}
+
+	result, err := NewExecutorWithClients(cfg, main, &RoleClients{Council: councilClient}).Run(context.Background())
+	require.NoError(t, err)
+
+	record := result.State.Iterations[0].Council
+	require.NotNil(t, record.Escalation)
+	assert.NotEmpty(t, record.Error)
+	assert.Contains(t, record.Escalation.Reason, "the council failed: ")
+	assert.Equal(t, "Rank automatically", record.Escalation.Decision)
+	assert.Equal(t, []string{"Conflict: curation_model vs monetization_model", "Decision (by a human): Rank automatically"},
+		result.State.CouncilResolution)
+}
+
+// recordingCommitter records the messages it commits with.
+type recordingCommitter struct {
+	messages []string
+}
+
+func (c *recordingCommitter) CommitAll(ctx context.Context, message string) error {
+	c.messages = append(c.messages, message)
+	return nil
+}
+
+func TestExecutor_Run_PendingEscalationHoldsDependentWork(t *testing.T) {
+	conflict := &IterationResult{Output: "PRINCIPLE_CONFLICT_UNRESOLVED: curation_model vs monetization_model", Cost: 0.1}
+	other := &IterationResult{Output: "Added the search index", Cost: 0.1}
+
+	t.Run("dependent iterations are reverted until a human decides", func(t *testing.T) {
+		escalator := &fakeEscalator{decision: "Curate by hand", answerAfter: 100}
+		cfg, main, roles := splitCouncil(escalator, 3)
+		// The second iteration reports the pending conflict again; the third does other work
+		main.Results = []*IterationResult{conflict, conflict, other}
+		tracker := &revertingTracker{}
+		committer := &recordingCommitter{}
+		cfg.ChangeTracker = tracker
+		cfg.Committer = committer
+
+		result, err := NewExecutorWithClients(cfg, main, roles).Run(context.Background())
+		require.NoError(t, err)
+		require.Len(t, result.State.Iterations, 3)
+
+		first := result.State.Iterations[0]
+		require.NotNil(t, first.Council.Escalation)
+		assert.Equal(t, "it depends on a conflict awaiting a human decision", first.CommitError)
+
+		second := result.State.Iterations[1]
+		assert.Equal(t, "run-1-1", second.Council.AwaitingEscalation)
+		assert.False(t, second.Council.Invoked, "the council is not asked again")
+		assert.Len(t, escalator.escalated, 1, "the conflict is not escalated again")
+		assert.Equal(t, "it depends on a conflict awaiting a human decision", second.CommitError)
+
+		assert.Equal(t, 2, tracker.reverts)
+		assert.True(t, tracker.dropCommits)
+		assert.Equal(t, []string{"Added the search index"}, committer.messages, "only independent work is committed")
+		assert.True(t, result.State.Iterations[2].Committed)
+
+		require.GreaterOrEqual(t, len(main.prompts), 3)
+		assert.Contains(t, main.prompts[1], "## COMMIT BLOCKED")
+		assert.Contains(t, main.prompts[1], "- It depended on a conflict awaiting a human decision (see PENDING ESCALATIONS), so all of its changes were reverted")
+		assert.Contains(t, main.prompts[2], "## PENDING ESCALATIONS")
+		assert.Len(t, result.State.PendingEscalations, 1)
+	})
+
+	t.Run("a failed revert asks for the changes to be undone", func(t *testing.T) {
+		escalator := &fakeEscalator{decision: "Curate by hand", answerAfter: 100}
+		cfg, main, roles := splitCouncil(escalator, 2)
+		cfg.ChangeTracker = &revertingTracker{err: errors.New("locked index")}
+
+		_, err := NewExecutorWithClients(cfg, main, roles).Run(context.Background())
+		require.NoError(t, err)
+		assert.Contains(t, main.prompts[1], "but reverting its changes failed (locked index); undo them yourself")
+	})
+
+	t.Run("without escalation the council's decision stands", func(t *testing.T) {
+		cfg, main, roles := splitCouncil(nil, 1)
+		tracker := &revertingTracker{}
+		committer := &recordingCommitter{}
+		cfg.ChangeTracker = tracker
+		cfg.Committer = committer
+
+		result, err := NewExecutorWithClients(cfg, main, roles).Run(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, tracker.reverts)
+		assert.Len(t, committer.messages, 1)
+		assert.Empty(t, result.State.Iterations[0].CommitError)
+	})
+}
+
+func TestExecutor_Run_HoldKeepsEscalationFile(t *testing.T) {
+	dir := newTestRepo(t)
+	runGit(t, dir, "config", "user.name", "test")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	artifacts := []string{council.DefaultEscalationDir, council.DefaultLogFile}
+
+	escalator := &fileEscalator{fakeEscalator: fakeEscalator{decision: "Curate by hand", answerAfter: 100}, dir: dir}
+	cfg, main, roles := splitCouncil(escalator, 2)
+	main.Results = []*IterationResult{
+		{Output: "PRINCIPLE_CONFLICT_UNRESOLVED: curation_model vs monetization_model", Cost: 0.1},
+		{Output: "Added the search index", Cost: 0.1},
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude"), 0755))
+	iteration := 0
+	client := &workClient{MockClaudeClient: main.MockClaudeClient, work: func() {
+		// The third call is the commit pass of the second iteration
+		iteration++
+		if iteration > 2 {
+			return
+		}
+		name := filepath.Join(dir, "work"+string(rune('0'+iteration))+".txt")
+		require.NoError(t, os.WriteFile(name, []byte("work\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, council.DefaultLogFile), []byte("decision\n"), 0644))
+	}}
+	cfg.ChangeTracker = NewGitChangeTracker(git.NewDiffManager(&dirExecutor{dir: dir}), artifacts...)
+	committer := git.NewCommitManager(&dirExecutor{dir: dir})
+	committer.Exclude(artifacts...)
+	cfg.Committer = committer
+
+	result, err := NewExecutorWithClients(cfg, client, roles).Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result.State.Iterations, 2)
+	assert.Equal(t, "it depends on a conflict awaiting a human decision", result.State.Iterations[0].CommitError)
+	require.NotNil(t, result.State.Iterations[1].Changes)
+	assert.Len(t, result.State.Iterations[1].Changes.Diff.Files, 1, "the decision log is not counted")
+
+	// The held iteration's work is gone, but the file the human answers in is not
+	assert.NoFileExists(t, filepath.Join(dir, "work1.txt"))
+	assert.FileExists(t, filepath.Join(dir, ".claude", "escalations", "run-1-1.md"))
+	assert.FileExists(t, filepath.Join(dir, council.DefaultLogFile))
+
+	cmd := exec.Command("git", "show", "--name-only", "--format=", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "work2.txt\n", string(out), "only the iteration's own work is committed")
+}
@@ -2,10 +2,16 @@ package loop
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+
 	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/prompt"
 	"github.com/DeukWoongWoo/claude-loop/internal/reviewer"
+	"github.com/DeukWoongWoo/claude-loop/internal/verifier"
 )
 
 // reviewerClientAdapter adapts loop.ClaudeClient to reviewer.ClaudeClient
@@ -16,15 +22,16 @@ type reviewerClientAdapter struct {
 
 func (a *reviewerClientAdapter) Execute(ctx context.Context, prompt string) (*reviewer.IterationResult, error) {
 	result, err := a.client.Execute(ctx, prompt)
-	if err != nil {
+	if result == nil {
 		return nil, err
 	}
+	// A failed reviewer's partial result carries its spend
 	return &reviewer.IterationResult{
 		Output:                result.Output,
 		Cost:                  result.Cost,
 		Duration:              result.Duration,
 		CompletionSignalFound: result.CompletionSignalFound,
-	}, nil
+	}, err
 }
 
 // councilClientAdapter adapts loop.ClaudeClient to council.ClaudeClient
@@ -35,15 +42,16 @@ type councilClientAdapter struct {
 
 func (a *councilClientAdapter) Execute(ctx context.Context, prompt string) (*council.IterationResult, error) {
 	result, err := a.client.Execute(ctx, prompt)
-	if err != nil {
+	if result == nil {
 		return nil, err
 	}
+	// A cancelled member's partial result carries its spend
 	return &council.IterationResult{
 		Output:                result.Output,
 		Cost:                  result.Cost,
 		Duration:              result.Duration,
 		CompletionSignalFound: result.CompletionSignalFound,
-	}, nil
+	}, err
 }
 
 // Executor is the main loop executor that orchestrates iteration execution.
@@ -52,35 +60,99 @@ type Executor struct {
 	limitChecker       *LimitChecker
 	completionDetector *CompletionDetector
 	iterationHandler   *IterationHandler
+	publisher          ClaudeClient
 	reviewer           *reviewer.DefaultReviewer
 	council            *council.DefaultCouncil
 }
 
+// RoleClients overrides the client used by auxiliary roles.
+// Nil fields fall back to the main iteration client.
+type RoleClients struct {
+	Reviewer ClaudeClient
+	Council  ClaudeClient
+
+	// CouncilMembers holds clients for council members and the chair that use
+	// their own model, keyed by member name or council.ChairName. Others use Council.
+	CouncilMembers map[string]ClaudeClient
+
+	// Reviewers holds clients for specialised reviewers, keyed by reviewer name.
+	// Others use Reviewer.
+	Reviewers map[string]ClaudeClient
+
+	// Publisher runs the pass that pushes committed changes and opens the pull
+	// request, when the main client may not.
+	Publisher ClaudeClient
+}
+
 // NewExecutor creates a new Executor with the given configuration and client.
 func NewExecutor(config *Config, client ClaudeClient) *Executor {
+	return NewExecutorWithClients(config, client, nil)
+}
+
+// NewExecutorWithClients creates a new Executor whose reviewer and council
+// use the clients in roles instead of the main iteration client.
+func NewExecutorWithClients(config *Config, client ClaudeClient, roles *RoleClients) *Executor {
+	reviewerClient, councilClient := client, client
+	if roles != nil && roles.Reviewer != nil {
+		reviewerClient = roles.Reviewer
+	}
+	if roles != nil && roles.Council != nil {
+		councilClient = roles.Council
+	}
+
 	e := &Executor{
 		config:             config,
 		limitChecker:       NewLimitChecker(config),
 		completionDetector: NewCompletionDetector(config),
 		iterationHandler:   NewIterationHandler(config, client),
+		publisher:          client,
+	}
+	if roles != nil && roles.Publisher != nil {
+		e.publisher = roles.Publisher
 	}
 
-	// Initialize reviewer if review prompt is provided
-	if config.ReviewPrompt != "" {
-		e.reviewer = reviewer.NewReviewer(&reviewer.Config{
+	// Initialize reviewer if a review prompt or specialised reviewers are provided
+	if config.reviewEnabled() {
+		reviewerConfig := &reviewer.Config{
 			ReviewPrompt:         config.ReviewPrompt,
 			MaxConsecutiveErrors: config.MaxConsecutiveErrors,
-		}, &reviewerClientAdapter{client: client})
+			Templates:            config.Templates,
+		}
+		if config.Reviewers != nil {
+			reviewerConfig.Reviewers = config.Reviewers.Reviewers
+			reviewerConfig.MaxDiffBytes = config.Reviewers.MaxDiffBytes
+		}
+		if roles != nil && len(roles.Reviewers) > 0 {
+			reviewerConfig.Clients = make(map[string]reviewer.ClaudeClient, len(roles.Reviewers))
+			for name, client := range roles.Reviewers {
+				reviewerConfig.Clients[name] = &reviewerClientAdapter{client: client}
+			}
+		}
+		e.reviewer = reviewer.NewReviewer(reviewerConfig, &reviewerClientAdapter{client: reviewerClient})
 	}
 
 	// Initialize council if principles are loaded
 	if config.Principles != nil {
-		e.council = council.NewCouncil(&council.Config{
+		councilConfig := &council.Config{
 			Principles:   config.Principles,
 			Preset:       config.Principles.Preset,
 			LogDecisions: config.LogDecisions,
-			LogFile:      ".claude/principles-decisions.log",
-		}, &councilClientAdapter{client: client})
+			LogFile:      council.DefaultLogFile,
+
+			PriorDecisions: config.PriorDecisions,
+		}
+		if config.Council != nil {
+			councilConfig.Members = config.Council.Members
+			councilConfig.Chair = config.Council.Chair
+			councilConfig.MaxCost = config.Council.MaxCost
+		}
+		if roles != nil && len(roles.CouncilMembers) > 0 {
+			councilConfig.Clients = make(map[string]council.ClaudeClient, len(roles.CouncilMembers))
+			for name, client := range roles.CouncilMembers {
+				councilConfig.Clients[name] = &councilClientAdapter{client: client}
+			}
+		}
+		e.council = council.NewCouncil(councilConfig, &councilClientAdapter{client: councilClient})
 	}
 
 	return e
@@ -120,10 +192,23 @@ func (e *Executor) Run(ctx context.Context) (*LoopResult, error) {
 			}, nil
 		}
 
+		// Remind the iteration of earlier decisions so it does not re-litigate them
+		if e.council != nil {
+			e.checkEscalations(ctx, state)
+			state.PriorDecisions = e.council.Precedents(e.precedentQuery())
+		}
+
+		// Snapshot the repository so the iteration's changes can be measured
+		startedAt := time.Now()
+		before := e.snapshot(ctx)
+
 		// Execute single iteration
 		iterResult, err := e.iterationHandler.Execute(ctx, state)
+		record := &IterationRecord{Number: state.TotalIterations, StartedAt: startedAt}
 
 		if err != nil {
+			record.Error = err.Error()
+			e.recordIteration(ctx, state, record, before)
 			shouldContinue := e.iterationHandler.HandleError(state, err)
 
 			// Call progress callback after error handling (so ErrorCount is updated)
@@ -142,22 +227,36 @@ func (e *Executor) Run(ctx context.Context) (*LoopResult, error) {
 			continue
 		}
 
+		record.Cost = iterResult.Cost
+		record.InputTokens = iterResult.InputTokens
+		record.OutputTokens = iterResult.OutputTokens
+		record.CompletionSignalFound = iterResult.CompletionSignalFound
+		record.PullRequests = extractPullRequestURLs(iterResult.Output)
+
 		// Handle principle conflict detection and council invocation (skip in dry-run)
 		if e.council != nil && !e.config.DryRun {
-			e.handleCouncil(ctx, state, iterResult.Output)
+			record.Council = e.handleCouncil(ctx, state, iterResult.Output)
 		}
 
-		// Run reviewer pass if configured (skip in dry-run)
-		if e.reviewer != nil && !e.config.DryRun {
-			if reviewErr := e.runReviewerPass(ctx, state); reviewErr != nil {
+		// Work that depends on a conflict awaiting a human is not kept; otherwise review
+		// the changes and act on the verdict if a reviewer is configured (skip in dry-run)
+		if record.Council.awaitsHuman() {
+			e.holdForEscalation(ctx, state, record, before)
+		} else if e.reviewer != nil && !e.config.DryRun {
+			if reviewErr := e.reviewIteration(ctx, state, record, before, iterResult.Output); reviewErr != nil {
+				e.recordIteration(ctx, state, record, before)
 				return &LoopResult{
 					State:      state,
 					StopReason: StopReasonConsecutiveErrors,
 					LastError:  reviewErr,
 				}, nil
 			}
+		} else if e.config.commitGated() && !e.config.DryRun {
+			e.commitIteration(ctx, state, record, before, iterResult.Output)
 		}
 
+		e.recordIteration(ctx, state, record, before)
+
 		// Call progress callback after successful iteration (and review)
 		if e.config.OnProgress != nil {
 			e.config.OnProgress(state)
@@ -186,46 +285,47 @@ func (e *Executor) RunOnce(ctx context.Context, state *State) (*IterationResult,
 	return e.iterationHandler.Execute(ctx, state)
 }
 
-// runReviewerPass executes a reviewer pass and updates state.
-// Returns nil to continue the loop, or an error if the loop should stop due to consecutive errors.
-func (e *Executor) runReviewerPass(ctx context.Context, state *State) error {
-	reviewResult, err := e.reviewer.Run(ctx)
-	if err != nil {
-		state.ReviewerErrorCount++
-
-		// Stop if too many consecutive reviewer errors
-		if state.ReviewerErrorCount >= e.config.MaxConsecutiveErrors {
-			return err
-		}
-		return nil
-	}
-
-	// Success: accumulate cost, reset error count
-	state.ReviewerCost += reviewResult.Cost
-	state.TotalCost += reviewResult.Cost
-	state.ReviewerErrorCount = 0
-
-	// Check for completion signal in reviewer output
-	// Only increment if found; do NOT reset on absence (main iteration already updated state)
-	if e.completionDetector.Detect(reviewResult.Output) {
-		state.CompletionSignalCount++
-	}
-
-	return nil
-}
-
 // handleCouncil checks for principle conflicts and invokes council if needed.
 // This is advisory and does not block the loop on failure (graceful degradation).
-func (e *Executor) handleCouncil(ctx context.Context, state *State, output string) {
+// Returns the decision made this iteration, or nil if there was none.
+func (e *Executor) handleCouncil(ctx context.Context, state *State, output string) *CouncilRecord {
 	// Check for unresolved conflicts first
-	hasConflict := e.council.DetectConflict(output)
+	conflict, hasConflict := e.council.DetectConflict(output)
 
 	if hasConflict {
-		// Invoke council for resolution
-		result, err := e.council.Resolve(ctx, output)
+		// A conflict that may already await a human is not put to the council again
+		if esc := awaitingEscalation(state, conflict); esc != nil {
+			return &CouncilRecord{AwaitingEscalation: esc.ID}
+		}
+
+		var principles []string
+		if conflict.Identified() {
+			principles = conflict.Principles[:]
+		}
+
+		// Resolve with Layer 2 rules, or invoke council
+		result, err := e.council.Resolve(ctx, conflict)
 		if err != nil {
-			// Log failure but don't block - council is advisory
-			return
+			// Don't block on failure; a human decides instead when escalation is enabled
+			record := &CouncilRecord{Invoked: true, Error: err.Error()}
+			record.Escalation = e.escalate(ctx, state, conflict, "the council failed: "+err.Error(), nil)
+			return record
+		}
+		// The next iteration carries out the resolution
+		state.CouncilResolution = councilResolution(conflict, result)
+
+		if result.Rule != "" {
+			_ = e.council.LogDecision(&council.Decision{
+				Timestamp:  time.Now(),
+				RunID:      e.config.RunID,
+				Iteration:  state.TotalIterations,
+				Decision:   result.Resolution,
+				Rationale:  result.Rationale,
+				Preset:     e.config.Principles.Preset,
+				Rule:       result.Rule,
+				Principles: principles,
+			})
+			return &CouncilRecord{Decision: result.Resolution, Rationale: result.Rationale, Rule: result.Rule}
 		}
 
 		// Update state with council cost
@@ -233,16 +333,41 @@ func (e *Executor) handleCouncil(ctx context.Context, state *State, output strin
 		state.TotalCost += result.Cost
 		state.CouncilInvocations++
 
+		record := &CouncilRecord{
+			Invoked:    true,
+			Decision:   result.Resolution,
+			Rationale:  result.Rationale,
+			Cost:       result.Cost,
+			Vote:       result.Vote,
+			Votes:      councilVotes(result.Votes),
+			Dissent:    result.Dissent,
+			Confidence: result.Confidence,
+		}
+
+		// An uncertain outcome goes to a human instead of being logged as decided
+		if reason := council.EscalationReason(result, e.config.EscalationConfidence); reason != "" {
+			record.Escalation = e.escalate(ctx, state, conflict, reason, result)
+			if record.Escalation != nil && record.Escalation.Error == "" {
+				return record
+			}
+		}
+
 		// Log the council decision (not the original conflicting decision)
 		_ = e.council.LogDecision(&council.Decision{
 			Timestamp:      time.Now(),
+			RunID:          e.config.RunID,
 			Iteration:      state.TotalIterations,
 			Decision:       result.Resolution,
 			Rationale:      result.Rationale,
 			Preset:         e.config.Principles.Preset,
 			CouncilInvoked: true,
+			Votes:          result.Votes,
+			Dissent:        result.Dissent,
+			Confidence:     result.Confidence,
+			Principles:     principles,
+			Cost:           result.Cost,
 		})
-		return
+		return record
 	}
 
 	// No conflict - extract and log any decisions from normal output
@@ -250,11 +375,385 @@ func (e *Executor) handleCouncil(ctx context.Context, state *State, output strin
 	if decision != "" || rationale != "" {
 		_ = e.council.LogDecision(&council.Decision{
 			Timestamp:      time.Now(),
+			RunID:          e.config.RunID,
 			Iteration:      state.TotalIterations,
 			Decision:       decision,
 			Rationale:      rationale,
 			Preset:         e.config.Principles.Preset,
 			CouncilInvoked: false,
 		})
+		return &CouncilRecord{Decision: decision, Rationale: rationale}
+	}
+	return nil
+}
+
+// councilResolution describes a resolved conflict for the next iteration's prompt.
+func councilResolution(conflict council.Conflict, result *council.Result) []string {
+	if result.Resolution == "" {
+		return nil
+	}
+	by := "the council"
+	if result.Rule != "" {
+		by = "rule " + result.Rule
+	}
+	var lines []string
+	if names := conflict.Names(); names != "" {
+		lines = append(lines, "Conflict: "+names)
+	}
+	lines = append(lines, fmt.Sprintf("Decision (by %s): %s", by, result.Resolution))
+	if result.Rationale != "" {
+		lines = append(lines, "Rationale: "+result.Rationale)
+	}
+	return lines
+}
+
+// precedentQuery returns the text prior decisions are matched against: the
+// prompt and the shared notes.
+func (e *Executor) precedentQuery() string {
+	notes, _, _ := prompt.NewFileNotesLoader().Load(e.config.NotesFile)
+	return e.config.Prompt + "\n" + notes
+}
+
+// councilVotes converts the council's votes for the iteration record.
+func councilVotes(votes []council.Vote) []CouncilVote {
+	if len(votes) == 0 {
+		return nil
+	}
+	records := make([]CouncilVote, len(votes))
+	for i, v := range votes {
+		records[i] = CouncilVote{Member: v.Member, Vote: v.Vote, Confidence: v.Confidence, Cost: v.Cost, Error: v.Error}
+	}
+	return records
+}
+
+// snapshot records the repository state before an iteration.
+// Returns nil when changes are not tracked or the snapshot fails; tracking is best-effort.
+func (e *Executor) snapshot(ctx context.Context) *Snapshot {
+	if e.config.ChangeTracker == nil || e.config.DryRun {
+		return nil
+	}
+	snap, err := e.config.ChangeTracker.Snapshot(ctx)
+	if err != nil {
+		return nil
+	}
+	return snap
+}
+
+// recordIteration completes record with its duration and repository changes and appends it to state.
+// Changes not yet checked before a review are checked here; checked ones are measured
+// again so commits made by the commit pass are counted.
+func (e *Executor) recordIteration(ctx context.Context, state *State, record *IterationRecord, before *Snapshot) {
+	if record.Changes == nil {
+		e.checkChanges(ctx, state, record, before)
+	} else if changes, err := e.config.ChangeTracker.Changes(ctx, before); err == nil {
+		record.Changes = changes
+	}
+	record.Duration = time.Since(record.StartedAt)
+	state.Iterations = append(state.Iterations, *record)
+}
+
+// checkChanges verifies the iteration's work, measures its changes and enforces
+// protected paths, change size limits and secret scanning on them. Returns whether
+// a check rejected the changes, in which case they must not be committed. Failed
+// verification rejects them only with RequireVerification, and possible secrets
+// only without a Committer, whose check handles them at commit time. Each call
+// judges the current changes afresh, replacing the results of earlier calls.
+func (e *Executor) checkChanges(ctx context.Context, state *State, record *IterationRecord, before *Snapshot) bool {
+	record.Verification, record.Protected, record.ChangeSize, record.Secrets = nil, nil, nil, nil
+	e.verify(ctx, state, record)
+	failedVerification := e.config.RequireVerification && record.Verification != nil && !record.Verification.Passed
+	if before == nil {
+		return failedVerification
+	}
+	changes, err := e.config.ChangeTracker.Changes(ctx, before)
+	if err != nil {
+		return false
+	}
+	record.Changes = changes
+	e.enforceProtectedPaths(ctx, state, record, before)
+	e.enforceChangeLimits(ctx, state, record, before)
+	e.scanSecrets(ctx, record, before)
+	secretsFound := len(record.Secrets) > 0 && e.config.Committer == nil
+	return failedVerification || record.Protected != nil || record.ChangeSize != nil || secretsFound
+}
+
+// verify checks the working tree against VerifyCriteria, records the result and
+// queues failed checks, with the end of their output, for the next prompt.
+// Failed iterations and dry runs are not verified, and a verifier that could not
+// run leaves the iteration without a result.
+func (e *Executor) verify(ctx context.Context, state *State, record *IterationRecord) {
+	if e.config.Verifier == nil || len(e.config.VerifyCriteria) == 0 || e.config.DryRun || record.Error != "" {
+		return
+	}
+	result, err := e.config.Verifier.Verify(ctx, &verifier.VerificationTask{
+		TaskID:          fmt.Sprintf("iteration-%d", record.Number),
+		SuccessCriteria: e.config.VerifyCriteria,
+	})
+	if err != nil {
+		return
+	}
+	verification := &VerificationRecord{Passed: result.Passed}
+	state.VerificationFailures = nil
+	for _, check := range result.FailedChecks() {
+		failure := check.Criterion + ": " + check.Error
+		verification.Failures = append(verification.Failures, failure)
+		if check.Evidence != nil {
+			failure += outputTail(check.Evidence.Content)
+		}
+		state.VerificationFailures = append(state.VerificationFailures, failure)
+	}
+	if !result.Passed && e.config.RequireVerification && (e.config.commitGated() || e.config.reviewEnabled()) {
+		state.VerificationFailures = append(state.VerificationFailures,
+			"Verification is required: these changes were not committed and remain in the working tree until every check passes")
+	}
+	record.Verification = verification
+}
+
+// maxFailureOutputLines is how much of a failed check's output the next prompt shows.
+const maxFailureOutputLines = 20
+
+// outputTail returns the last lines of a check's output, indented to continue a
+// list item, or "" when there is no output.
+func outputTail(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return ""
+	}
+	if len(lines) > maxFailureOutputLines {
+		lines = lines[len(lines)-maxFailureOutputLines:]
+	}
+	return "\n  " + strings.Join(lines, "\n  ")
+}
+
+// rejectionReasons lists why record's changes must not be committed.
+func (e *Executor) rejectionReasons(record *IterationRecord) []string {
+	var reasons []string
+	if e.config.RequireVerification && record.Verification != nil && !record.Verification.Passed {
+		reasons = append(reasons, "it failed verification (see VERIFICATION FAILURES)")
+	}
+	if record.Protected != nil {
+		reasons = append(reasons, "it changed protected paths (see REJECTED CHANGES)")
+	}
+	if record.ChangeSize != nil {
+		reasons = append(reasons, "it exceeded the change size limits (see CHANGE TOO LARGE)")
+	}
+	if e.config.Committer == nil {
+		for _, f := range record.Secrets {
+			reasons = append(reasons, "it adds a possible secret: "+f.String())
+		}
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, "a check rejected its changes")
+	}
+	return reasons
+}
+
+// enforceProtectedPaths reverts record's changes to protected paths, or the whole iteration
+// with ProtectedRevert set to iteration, and queues the rejection for the next prompt.
+// A failed revert is recorded but does not stop the loop.
+func (e *Executor) enforceProtectedPaths(ctx context.Context, state *State, record *IterationRecord, before *Snapshot) {
+	if e.config.ProtectedPaths.Empty() || record.Changes.Diff == nil {
+		return
+	}
+	changed := make([]string, len(record.Changes.Diff.Files))
+	for i, file := range record.Changes.Diff.Files {
+		changed[i] = file.Path
+	}
+	violations := e.config.ProtectedPaths.Check(changed)
+	if len(violations) == 0 {
+		return
+	}
+
+	protectedRecord := &ProtectedRecord{Violations: violations, Mode: e.config.ProtectedRevert}
+	if protectedRecord.Mode == "" {
+		protectedRecord.Mode = config.RevertFiles
+	}
+	paths := make([]string, len(violations))
+	for i, v := range violations {
+		paths[i] = v.Path
+	}
+	if protectedRecord.Mode == config.RevertIteration {
+		paths = changed
+	}
+	// Commits are undone in either mode, or the protected changes stay in history
+	dropCommits := len(record.Changes.Commits) > 0
+
+	if reverter, ok := e.config.ChangeTracker.(ChangeReverter); !ok {
+		protectedRecord.Error = "change tracker cannot revert changes"
+	} else if err := reverter.Revert(ctx, before, paths, dropCommits); err != nil {
+		protectedRecord.Error = err.Error()
+	} else {
+		protectedRecord.Reverted = paths
+		if dropCommits {
+			protectedRecord.Commits = len(record.Changes.Commits)
+		}
+	}
+	record.Protected = protectedRecord
+	state.RejectedChanges = rejectedChanges(protectedRecord)
+
+	if e.council != nil {
+		patterns := make([]string, 0, len(violations))
+		seen := make(map[string]bool)
+		for _, v := range violations {
+			if !seen[v.Pattern] {
+				seen[v.Pattern] = true
+				patterns = append(patterns, fmt.Sprintf("%q", v.Pattern))
+			}
+		}
+		decision := fmt.Sprintf("Reverted %s changes to protected paths: %s",
+			protectedRecord.Mode, strings.Join(paths, ", "))
+		if protectedRecord.Error != "" {
+			decision = fmt.Sprintf("Failed to revert changes to protected paths: %s", protectedRecord.Error)
+		}
+		_ = e.council.LogDecision(&council.Decision{
+			Timestamp: time.Now(),
+			RunID:     e.config.RunID,
+			Iteration: record.Number,
+			Decision:  decision,
+			Rationale: "Protected by " + strings.Join(patterns, ", "),
+			Preset:    e.config.Principles.Preset,
+		})
+	}
+}
+
+// enforceChangeLimits checks record's changes against the change size limits. When they
+// are exceeded, the next prompt asks for a smaller change; with the revert action the
+// whole iteration is also reverted. Paths already reverted as protected are not counted.
+func (e *Executor) enforceChangeLimits(ctx context.Context, state *State, record *IterationRecord, before *Snapshot) {
+	limits := e.config.ChangeLimits
+	if limits.MaxFiles <= 0 && limits.MaxLines <= 0 || record.Changes.Diff == nil {
+		return
+	}
+	if p := record.Protected; p != nil && p.Mode == config.RevertIteration && p.Error == "" {
+		return
+	}
+	reverted := make(map[string]bool)
+	if record.Protected != nil {
+		for _, path := range record.Protected.Reverted {
+			reverted[path] = true
+		}
+	}
+	var changed []string
+	lines := 0
+	for _, file := range record.Changes.Diff.Files {
+		if !reverted[file.Path] {
+			changed = append(changed, file.Path)
+			lines += file.Insertions + file.Deletions
+		}
+	}
+	filesExceeded := limits.MaxFiles > 0 && len(changed) > limits.MaxFiles
+	linesExceeded := limits.MaxLines > 0 && lines > limits.MaxLines
+	if !filesExceeded && !linesExceeded {
+		return
+	}
+
+	sizeRecord := &ChangeSizeRecord{
+		Files:    len(changed),
+		Lines:    lines,
+		MaxFiles: limits.MaxFiles,
+		MaxLines: limits.MaxLines,
+		Action:   limits.OnExceed,
+	}
+	if sizeRecord.Action == "" {
+		sizeRecord.Action = config.ExceedSplit
+	}
+	if sizeRecord.Action == config.ExceedRevert {
+		dropCommits := len(record.Changes.Commits) > 0
+		if reverter, ok := e.config.ChangeTracker.(ChangeReverter); !ok {
+			sizeRecord.Error = "change tracker cannot revert changes"
+		} else if err := reverter.Revert(ctx, before, changed, dropCommits); err != nil {
+			sizeRecord.Error = err.Error()
+		} else {
+			sizeRecord.Reverted = changed
+			if dropCommits {
+				sizeRecord.Commits = len(record.Changes.Commits)
+			}
+		}
+	}
+	record.ChangeSize = sizeRecord
+	state.OversizedChange = oversizedChange(sizeRecord)
+
+	if e.council != nil {
+		decision := fmt.Sprintf("Asked to split an oversized change (%s)", changeSizeSummary(sizeRecord))
+		switch {
+		case sizeRecord.Error != "":
+			decision = fmt.Sprintf("Failed to revert an oversized change: %s", sizeRecord.Error)
+		case sizeRecord.Action == config.ExceedRevert:
+			decision = fmt.Sprintf("Reverted an oversized change (%s)", changeSizeSummary(sizeRecord))
+		}
+		_ = e.council.LogDecision(&council.Decision{
+			Timestamp: time.Now(),
+			RunID:     e.config.RunID,
+			Iteration: record.Number,
+			Decision:  decision,
+			Rationale: fmt.Sprintf("blast_radius %d limits each iteration to %s", e.config.Principles.Layer1.BlastRadius, changeLimitsSummary(sizeRecord)),
+			Preset:    e.config.Principles.Preset,
+		})
+	}
+}
+
+// scanSecrets records possible secrets in the lines the iteration added.
+// Runs after protected paths are reverted, so only changes that remain are scanned.
+// Scanning is best-effort: a tracker that cannot produce a diff is skipped.
+func (e *Executor) scanSecrets(ctx context.Context, record *IterationRecord, before *Snapshot) {
+	if e.config.SecretScanner == nil {
+		return
+	}
+	patcher, ok := e.config.ChangeTracker.(ChangePatcher)
+	if !ok {
+		return
+	}
+	patch, err := patcher.Patch(ctx, before)
+	if err != nil {
+		return
+	}
+	record.Secrets = e.config.SecretScanner.ScanDiff(patch)
+}
+
+// oversizedChange describes a change size violation for the next iteration's prompt.
+func oversizedChange(r *ChangeSizeRecord) []string {
+	out := []string{fmt.Sprintf("It changed %s; the limit is %s", changeSizeSummary(r), changeLimitsSummary(r))}
+	switch {
+	case r.Error != "":
+		out = append(out, "Reverting it failed ("+r.Error+"); undo it yourself, then redo the work in smaller steps")
+	case r.Action == config.ExceedRevert:
+		out = append(out, "All of its changes were reverted; redo the work in smaller steps that each stay within the limit")
+	default:
+		out = append(out, "Split the work: keep the current change within the limit and move the rest into follow-up changes in later iterations")
+	}
+	return out
+}
+
+// changeSizeSummary describes the size of an oversized change, e.g. "42 files and 3100 lines".
+func changeSizeSummary(r *ChangeSizeRecord) string {
+	return fmt.Sprintf("%d files and %d lines", r.Files, r.Lines)
+}
+
+// changeLimitsSummary describes the limits an oversized change was measured against.
+func changeLimitsSummary(r *ChangeSizeRecord) string {
+	var limits []string
+	if r.MaxFiles > 0 {
+		limits = append(limits, fmt.Sprintf("%d files", r.MaxFiles))
+	}
+	if r.MaxLines > 0 {
+		limits = append(limits, fmt.Sprintf("%d lines", r.MaxLines))
+	}
+	return strings.Join(limits, " and ")
+}
+
+// rejectedChanges describes a protected-path revert for the next iteration's prompt.
+func rejectedChanges(r *ProtectedRecord) []string {
+	var out []string
+	for _, v := range r.Violations {
+		out = append(out, v.String())
+	}
+	switch {
+	case r.Error != "":
+		out = append(out, "The revert failed ("+r.Error+"); restore these paths yourself before continuing")
+	case r.Mode == config.RevertIteration:
+		out = append(out, "All other changes from that iteration were reverted as well; redo the work without touching these paths")
+	case r.Commits > 0:
+		out = append(out, "Its commits were undone to take these changes out of history; its other changes are uncommitted in the working tree, so commit them again and force-push if the undone commits were already pushed")
 	}
+	return out
 }
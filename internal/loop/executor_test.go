@@ -3,9 +3,14 @@ package loop
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/verifier"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -357,11 +362,11 @@ func TestExecutor_WithReviewer_Success(t *testing.T) {
 			// Iteration 1: main
 			{Output: "main output 1", Cost: 0.10},
 			// Iteration 1: reviewer
-			{Output: "review output 1", Cost: 0.02},
+			{Output: "review output 1\nVERDICT: APPROVE", Cost: 0.02},
 			// Iteration 2: main
 			{Output: "main output 2", Cost: 0.10},
 			// Iteration 2: reviewer
-			{Output: "review output 2", Cost: 0.02},
+			{Output: "review output 2\nVERDICT: APPROVE", Cost: 0.02},
 		},
 	}
 
@@ -401,9 +406,9 @@ func TestExecutor_WithReviewer_ReviewerError_Continues(t *testing.T) {
 			{Output: "main 1", Cost: 0.10},
 			nil, // Reviewer 1 will error
 			{Output: "main 2", Cost: 0.10},
-			{Output: "review 2", Cost: 0.02},
+			{Output: "review 2\nVERDICT: APPROVE", Cost: 0.02},
 			{Output: "main 3", Cost: 0.10},
-			{Output: "review 3", Cost: 0.02},
+			{Output: "review 3\nVERDICT: APPROVE", Cost: 0.02},
 		},
 		Errors: []error{
 			nil,                           // main 1 success
@@ -479,10 +484,10 @@ func TestExecutor_WithReviewer_CompletionSignal(t *testing.T) {
 		Results: []*IterationResult{
 			// Iteration 1: main has DONE (count=1), reviewer has DONE (count=2)
 			{Output: "main DONE", Cost: 0.10},
-			{Output: "review DONE", Cost: 0.02},
+			{Output: "review DONE\nVERDICT: APPROVE", Cost: 0.02},
 			// Iteration 2: main has DONE (count=3), reviewer has DONE (count=4)
 			{Output: "main DONE", Cost: 0.10},
-			{Output: "review DONE", Cost: 0.02},
+			{Output: "review DONE\nVERDICT: APPROVE", Cost: 0.02},
 		},
 	}
 
@@ -565,13 +570,13 @@ func TestExecutor_WithReviewer_CompletionSignal_OnlyMainHasDone(t *testing.T) {
 		Results: []*IterationResult{
 			// Iteration 1: main DONE (count=1), reviewer no DONE (count stays 1)
 			{Output: "Task DONE", Cost: 0.10},
-			{Output: "All tests passed", Cost: 0.02},
+			{Output: "All tests passed\nVERDICT: APPROVE", Cost: 0.02},
 			// Iteration 2: main DONE (count=2), reviewer no DONE (count stays 2)
 			{Output: "More work DONE", Cost: 0.10},
-			{Output: "Tests still passing", Cost: 0.02},
+			{Output: "Tests still passing\nVERDICT: APPROVE", Cost: 0.02},
 			// Iteration 3: main DONE (count=3), threshold reached
 			{Output: "Final DONE", Cost: 0.10},
-			{Output: "Validated successfully", Cost: 0.02},
+			{Output: "Validated successfully\nVERDICT: APPROVE", Cost: 0.02},
 		},
 	}
 
@@ -599,10 +604,10 @@ func TestExecutor_WithReviewer_CompletionSignal_ReviewerAlsoHasDone(t *testing.T
 		Results: []*IterationResult{
 			// Iteration 1: main DONE (count=1), reviewer DONE (count=2)
 			{Output: "Task DONE", Cost: 0.10},
-			{Output: "Review DONE", Cost: 0.02},
+			{Output: "Review DONE\nVERDICT: APPROVE", Cost: 0.02},
 			// Iteration 2: main DONE (count=3), threshold reached after main
 			{Output: "More DONE", Cost: 0.10},
-			{Output: "Also DONE", Cost: 0.02}, // count=4
+			{Output: "Also DONE\nVERDICT: APPROVE", Cost: 0.02}, // count=4
 		},
 	}
 
@@ -630,19 +635,19 @@ func TestExecutor_WithReviewer_CompletionSignal_ResetOnNoDone(t *testing.T) {
 		Results: []*IterationResult{
 			// Iteration 1: main DONE (count=1), reviewer no DONE (count=1)
 			{Output: "DONE", Cost: 0.10},
-			{Output: "ok", Cost: 0.02},
+			{Output: "ok\nVERDICT: APPROVE", Cost: 0.02},
 			// Iteration 2: main DONE (count=2), reviewer no DONE (count=2)
 			{Output: "DONE", Cost: 0.10},
-			{Output: "ok", Cost: 0.02},
+			{Output: "ok\nVERDICT: APPROVE", Cost: 0.02},
 			// Iteration 3: main no DONE (count resets to 0), reviewer no DONE
 			{Output: "working", Cost: 0.10},
-			{Output: "ok", Cost: 0.02},
+			{Output: "ok\nVERDICT: APPROVE", Cost: 0.02},
 			// Iteration 4: main DONE (count=1), reviewer DONE (count=2)
 			{Output: "DONE", Cost: 0.10},
-			{Output: "DONE", Cost: 0.02},
+			{Output: "DONE\nVERDICT: APPROVE", Cost: 0.02},
 			// Iteration 5: main DONE (count=3), threshold reached
 			{Output: "DONE", Cost: 0.10},
-			{Output: "ok", Cost: 0.02},
+			{Output: "ok\nVERDICT: APPROVE", Cost: 0.02},
 		},
 	}
 
@@ -653,3 +658,234 @@ func TestExecutor_WithReviewer_CompletionSignal_ResetOnNoDone(t *testing.T) {
 	assert.Equal(t, StopReasonCompletionSignal, result.StopReason)
 	assert.Equal(t, 5, result.State.SuccessfulIterations)
 }
+
+func TestExecutor_Run_ResolvesConflictsWithRules(t *testing.T) {
+	cfg := &Config{
+		Prompt:               "test",
+		MaxRuns:              1,
+		MaxConsecutiveErrors: 3,
+		Principles:           config.DefaultPrinciples(config.PresetEnterprise),
+	}
+	mock := &MockClaudeClient{
+		Results: []*IterationResult{
+			{Output: "PRINCIPLE_CONFLICT_UNRESOLVED: urgency_tiers vs security_posture", Cost: 0.1},
+		},
+	}
+
+	result, err := NewExecutor(cfg, mock).Run(context.Background())
+	require.NoError(t, err)
+
+	record := result.State.Iterations[0].Council
+	require.NotNil(t, record)
+	assert.Equal(t, "R4", record.Rule)
+	assert.False(t, record.Invoked)
+	assert.Equal(t, "Prioritize security_posture (9) over urgency_tiers (5)", record.Decision)
+	assert.Equal(t, 1, mock.CallCount, "no council call")
+	assert.Zero(t, result.State.CouncilInvocations)
+	assert.Zero(t, result.State.CouncilCost)
+}
+
+func TestExecutor_Run_ConflictResolutionInNextPrompt(t *testing.T) {
+	cfg := &Config{
+		Prompt:               "Fix the login bug",
+		MaxRuns:              2,
+		MaxConsecutiveErrors: 3,
+		Principles:           config.DefaultPrinciples(config.PresetEnterprise),
+	}
+	mock := &MockClaudeClient{
+		Results: []*IterationResult{
+			{Output: "<principle_conflict>\nprinciples: urgency_tiers, security_posture\n" +
+				"options:\n- Ship the fix now\n- Audit the session code first\n</principle_conflict>", Cost: 0.1},
+			{Output: "done", Cost: 0.1},
+		},
+	}
+
+	result, err := NewExecutor(cfg, mock).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "R4", result.State.Iterations[0].Council.Rule)
+	assert.Contains(t, mock.LastPrompt, "## CONFLICT RESOLUTION")
+	assert.Contains(t, mock.LastPrompt, "- Conflict: urgency_tiers vs security_posture\n"+
+		"- Decision (by rule R4): Prioritize security_posture (9) over urgency_tiers (5)\n"+
+		"- Rationale: R4 - Security posture outranks delivery pressure: security_posture=9 >= 7\n")
+	assert.Nil(t, result.State.CouncilResolution, "reported once")
+}
+
+func TestExecutor_Run_CouncilVotes(t *testing.T) {
+	cfg := &Config{
+		Prompt:               "test",
+		MaxRuns:              1,
+		MaxConsecutiveErrors: 3,
+		Principles:           config.DefaultPrinciples(config.PresetStartup),
+		Council: &council.Settings{Members: []council.Member{
+			{Name: "product", Principles: []string{"layer0.curation_model"}},
+			{Name: "cost", Principles: []string{"layer0.monetization_model"}},
+		}},
+	}
+	mainClient := &MockClaudeClient{Results: []*IterationResult{
+		{Output: "PRINCIPLE_CONFLICT_UNRESOLVED: curation_model vs monetization_model", Cost: 0.1},
+	}}
+	product := &MockClaudeClient{Results: []*IterationResult{
+		{Output: "**Vote**: curation_model\n**Confidence**: 8\n**Decision**: Curate by hand", Cost: 0.02},
+	}}
+	cost := &MockClaudeClient{Results: []*IterationResult{
+		{Output: "**Vote**: monetization_model\n**Confidence**: 6\n**Decision**: Rank automatically", Cost: 0.02},
+	}}
+	chair := &MockClaudeClient{Results: []*IterationResult{
+		{Output: "**Vote**: curation_model\n**Confidence**: 7\n**Decision**: Curate by hand\n**Rationale**: Quality first", Cost: 0.03},
+	}}
+
+	executor := NewExecutorWithClients(cfg, mainClient, &RoleClients{CouncilMembers: map[string]ClaudeClient{
+		"product": product, "cost": cost, council.ChairName: chair,
+	}})
+	result, err := executor.Run(context.Background())
+	require.NoError(t, err)
+
+	record := result.State.Iterations[0].Council
+	require.NotNil(t, record)
+	assert.True(t, record.Invoked)
+	assert.Equal(t, "Curate by hand", record.Decision)
+	assert.Equal(t, "curation_model", record.Vote)
+	assert.Equal(t, []string{"cost"}, record.Dissent)
+	assert.InDelta(t, 0.7, record.Confidence, 0.001)
+	require.Len(t, record.Votes, 2)
+	assert.Equal(t, CouncilVote{Member: "product", Vote: "curation_model", Confidence: 8, Cost: 0.02}, record.Votes[0])
+	assert.InDelta(t, 0.07, result.State.CouncilCost, 0.001)
+	assert.Equal(t, 1, chair.CallCount)
+}
+
+func TestExecutor_Run_PriorDecisionsInPrompt(t *testing.T) {
+	cfg := &Config{
+		Prompt:               "Speed up the API",
+		MaxRuns:              2,
+		MaxConsecutiveErrors: 3,
+		Principles:           config.DefaultPrinciples(config.PresetStartup),
+		PriorDecisions:       []*council.DecisionRecord{{RunID: "run-1", Iteration: 4, Decision: "Keep the API stable"}},
+	}
+	mock := &MockClaudeClient{
+		Results: []*IterationResult{
+			{Output: "**Decision**: Cache results in memory\n**Rationale**: Cheapest option", Cost: 0.1},
+			{Output: "done", Cost: 0.1},
+		},
+	}
+
+	_, err := NewExecutor(cfg, mock).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Contains(t, mock.LastPrompt, "## PRIOR DECISIONS")
+	assert.Contains(t, mock.LastPrompt, "- Decided, iteration 1: Cache results in memory (because: Cheapest option)\n")
+	assert.Contains(t, mock.LastPrompt, "- Decided, iteration 4 of run-1: Keep the API stable\n")
+}
+
+// fakeVerifier fails the criteria in failing, passes the rest and counts its runs.
+type fakeVerifier struct {
+	failing map[string]bool
+	runs    int
+}
+
+func (v *fakeVerifier) Verify(ctx context.Context, task *verifier.VerificationTask) (*verifier.VerificationResult, error) {
+	v.runs++
+	result := &verifier.VerificationResult{TaskID: task.TaskID}
+	for _, criterion := range task.SuccessCriteria {
+		check := verifier.CheckResult{Criterion: criterion, Passed: !v.failing[criterion]}
+		if !check.Passed {
+			check.Error = "tests failed with exit code 1"
+			check.Evidence = &verifier.Evidence{Content: "--- FAIL: TestParse\nFAIL\n", ExitCode: 1}
+		}
+		result.Checks = append(result.Checks, check)
+	}
+	result.Passed = result.AllPassed()
+	return result, nil
+}
+
+func TestExecutor_Run_VerifiesIterations(t *testing.T) {
+	t.Run("records passed and failed checks", func(t *testing.T) {
+		verify := &fakeVerifier{failing: map[string]bool{"go test": true}}
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              2,
+			MaxConsecutiveErrors: 3,
+			Verifier:             verify,
+			VerifyCriteria:       []string{"go build", "go test"},
+		}
+		mock := &MockClaudeClient{Errors: []error{errors.New("boom")}}
+
+		result, err := NewExecutor(config, mock).Run(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, result.State.Iterations, 3)
+		assert.Nil(t, result.State.Iterations[0].Verification, "failed iterations are not verified")
+		assert.Equal(t, &VerificationRecord{
+			Passed:   false,
+			Failures: []string{"go test: tests failed with exit code 1"},
+		}, result.State.Iterations[1].Verification)
+		assert.Equal(t, 2, verify.runs)
+
+		verify.failing = nil
+		result, err = NewExecutor(config, NewMockClient()).Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &VerificationRecord{Passed: true}, result.State.Iterations[0].Verification)
+	})
+
+	t.Run("failures reach the next prompt once", func(t *testing.T) {
+		verify := &fakeVerifier{failing: map[string]bool{"go test": true}}
+		config := &Config{
+			Prompt:               "test",
+			MaxRuns:              2,
+			MaxConsecutiveErrors: 3,
+			Verifier:             verify,
+			VerifyCriteria:       []string{"go test"},
+		}
+		mock := NewMockClient()
+		executor := NewExecutor(config, mock)
+		state := NewState()
+
+		_, err := executor.Run(context.Background())
+		require.NoError(t, err)
+		assert.Contains(t, mock.LastPrompt, "## VERIFICATION FAILURES")
+		assert.Contains(t, mock.LastPrompt, "- go test: tests failed with exit code 1\n  --- FAIL: TestParse\n  FAIL\n")
+
+		// A passing check clears the failures; a prompt shows them only once
+		state.VerificationFailures = []string{"go test: tests failed"}
+		_, err = executor.RunOnce(context.Background(), state)
+		require.NoError(t, err)
+		assert.Contains(t, mock.LastPrompt, "- go test: tests failed\n")
+		assert.Nil(t, state.VerificationFailures)
+
+		verify.failing = nil
+		record := &IterationRecord{Number: 3}
+		state.VerificationFailures = []string{"stale"}
+		executor.verify(context.Background(), state, record)
+		assert.Nil(t, state.VerificationFailures)
+		assert.True(t, record.Verification.Passed)
+	})
+
+	t.Run("no criteria or dry run skips verification", func(t *testing.T) {
+		verify := &fakeVerifier{}
+		config := &Config{Prompt: "test", MaxRuns: 1, MaxConsecutiveErrors: 3, Verifier: verify}
+
+		result, err := NewExecutor(config, NewMockClient()).Run(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, result.State.Iterations[0].Verification)
+
+		config.VerifyCriteria = []string{"go test"}
+		config.DryRun = true
+		result, err = NewExecutor(config, NewMockClient()).Run(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, result.State.Iterations[0].Verification)
+		assert.Zero(t, verify.runs)
+	})
+}
+
+func TestOutputTail(t *testing.T) {
+	assert.Equal(t, "", outputTail(""))
+	assert.Equal(t, "\n  ok\n  FAIL", outputTail("ok\nFAIL\n"))
+
+	var long []string
+	for i := 1; i <= 25; i++ {
+		long = append(long, fmt.Sprintf("line %d", i))
+	}
+	tail := outputTail(strings.Join(long, "\n"))
+	assert.True(t, strings.HasPrefix(tail, "\n  line 6\n"), "only the last 20 lines are kept")
+	assert.True(t, strings.HasSuffix(tail, "\n  line 25"))
+}
@@ -21,7 +21,7 @@ func NewIterationHandler(config *Config, client ClaudeClient) *IterationHandler
 		config:             config,
 		client:             client,
 		completionDetector: NewCompletionDetector(config),
-		promptBuilder:      prompt.NewBuilder(),
+		promptBuilder:      prompt.NewBuilderWithTemplates(config.Templates),
 	}
 }
 
@@ -48,12 +48,32 @@ func (ih *IterationHandler) Execute(ctx context.Context, state *State) (*Iterati
 
 	// Build enhanced prompt
 	buildCtx := prompt.BuildContext{
-		UserPrompt:       ih.config.Prompt,
-		Principles:       ih.config.Principles,
-		CompletionSignal: ih.config.CompletionSignal,
-		NotesFile:        ih.config.NotesFile,
-		Iteration:        state.TotalIterations,
+		UserPrompt:           ih.config.Prompt,
+		Principles:           ih.config.Principles,
+		CompletionSignal:     ih.config.CompletionSignal,
+		NotesFile:            ih.config.NotesFile,
+		Iteration:            state.TotalIterations,
+		Branch:               ih.config.Branch,
+		RejectedChanges:      state.RejectedChanges,
+		OversizedChange:      state.OversizedChange,
+		PriorDecisions:       state.PriorDecisions,
+		Resolution:           state.CouncilResolution,
+		Escalations:          pendingEscalations(state.PendingEscalations),
+		ReviewGate:           ih.config.reviewEnabled(),
+		CommitGate:           ih.config.commitGated(),
+		CommitChecks:         ih.config.commitChecks(),
+		BlockedCommit:        state.BlockedCommit,
+		ReviewFindings:       state.ReviewFindings,
+		Policy:               ih.config.Policy,
+		VerificationFailures: state.VerificationFailures,
 	}
+	// Rejections are reported once, to the iteration right after the revert
+	state.RejectedChanges = nil
+	state.OversizedChange = nil
+	state.CouncilResolution = nil
+	state.ReviewFindings = nil
+	state.BlockedCommit = nil
+	state.VerificationFailures = nil
 
 	buildResult, err := ih.promptBuilder.Build(buildCtx)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
 	"github.com/DeukWoongWoo/claude-loop/internal/prompt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -112,6 +113,20 @@ func TestIterationHandler_Execute_Success(t *testing.T) {
 	assert.Contains(t, mock.LastPrompt, "DONE")
 }
 
+func TestIterationHandler_Execute_WithPolicy(t *testing.T) {
+	config := &Config{
+		Prompt:           "test prompt",
+		CompletionSignal: "DONE",
+		Policy:           config.DerivePolicy(config.DefaultPrinciples(config.PresetEnterprise)),
+	}
+	mock := &MockClaudeClient{Results: []*IterationResult{{Output: "ok"}}}
+
+	_, err := NewIterationHandler(config, mock).Execute(context.Background(), NewState())
+	require.NoError(t, err)
+	assert.Contains(t, mock.LastPrompt, "RUNTIME POLICY")
+	assert.Contains(t, mock.LastPrompt, "Open pull requests as drafts")
+}
+
 func TestIterationHandler_Execute_WithCompletionSignal(t *testing.T) {
 	config := &Config{
 		Prompt:           "test prompt",
@@ -0,0 +1,349 @@
+package loop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+	"github.com/DeukWoongWoo/claude-loop/internal/prompt"
+	"github.com/DeukWoongWoo/claude-loop/internal/reviewer"
+)
+
+// reviewEnabled reports whether iterations are reviewed.
+func (c *Config) reviewEnabled() bool {
+	return c.ReviewPrompt != "" || (c.Reviewers != nil && len(c.Reviewers.Reviewers) > 0)
+}
+
+// commitGated reports whether claude-loop commits unreviewed iterations itself.
+// Reviewed iterations are committed after approval instead.
+func (c *Config) commitGated() bool {
+	return c.Committer != nil && c.ChangeTracker != nil && !c.reviewEnabled()
+}
+
+// commitChecks describes the checks applied before claude-loop commits an
+// unreviewed iteration's changes, for the iteration prompt.
+func (c *Config) commitChecks() prompt.CommitChecks {
+	return prompt.CommitChecks{
+		Secrets:      c.SecretScanner != nil,
+		Verification: c.RequireVerification && c.Verifier != nil && len(c.VerifyCriteria) > 0,
+		BaseBranch:   c.BaseBranch,
+	}
+}
+
+// reviewIteration has the reviewers judge the iteration's changes and acts on the
+// verdict: REQUEST_CHANGES runs up to MaxReviewFixes fix passes, each reviewed
+// again; APPROVE runs the commit pass; BLOCK reverts the iteration. Changes that
+// are not approved are not committed, and the findings go to the next iteration.
+// Protected paths, change size limits and secrets are checked before every
+// reviewer pass; changes the last pass's checks rejected are not committed even
+// when approved, while a fix pass may repair what an earlier check rejected.
+// summary is the iteration's output, shown to the reviewers with its diff.
+// Returns an error only when the loop should stop due to consecutive reviewer errors.
+func (e *Executor) reviewIteration(ctx context.Context, state *State, record *IterationRecord, before *Snapshot, summary string) error {
+	review := &ReviewRecord{}
+	record.Review = review
+
+	var rejected bool
+	for {
+		rejected = e.checkChanges(ctx, state, record, before)
+		verdict, err := e.runReviewerPass(ctx, state, review, e.reviewChanges(ctx, before, summary))
+		if err != nil {
+			return err
+		}
+		if verdict != reviewer.VerdictRequestChanges || review.Fixes >= e.config.MaxReviewFixes {
+			break
+		}
+		if err := e.runFixPass(ctx, state, record); err != nil {
+			review.Error = "fix pass failed: " + err.Error()
+			break
+		}
+	}
+
+	// Only the last pass decides whether the reviewer saw the project as complete
+	if review.CompletionSignalFound {
+		state.CompletionSignalCount++
+	}
+
+	switch {
+	case review.Verdict == reviewer.VerdictApprove && rejected:
+		summary := "The reviewer approved it, but it was not committed because " +
+			strings.Join(e.rejectionReasons(record), "; ") + "; fix that, then commit the remaining changes"
+		state.ReviewFindings = append([]string{summary}, review.Findings...)
+	case review.Verdict == reviewer.VerdictApprove:
+		e.commitApproved(ctx, state, record, before, summary)
+	case review.Verdict == reviewer.VerdictRequestChanges:
+		summary := "The reviewer requested changes; they are not committed and remain in the working tree"
+		if review.Fixes > 0 {
+			summary = fmt.Sprintf("The reviewer still requests changes after %d fix pass(es); they are not committed and remain in the working tree", review.Fixes)
+		}
+		state.ReviewFindings = append([]string{summary}, review.Findings...)
+	case review.Verdict == reviewer.VerdictBlock:
+		e.revertBlocked(ctx, state, record, before)
+	}
+	return nil
+}
+
+// runReviewerPass executes one reviewer pass and adds its cost and verdict to review.
+// Returns the verdict, empty when the pass failed or gave none, and an error only
+// when the loop should stop due to consecutive reviewer errors.
+func (e *Executor) runReviewerPass(ctx context.Context, state *State, review *ReviewRecord, changes *reviewer.Changes) (reviewer.Verdict, error) {
+	review.Passes++
+	review.Verdict, review.Findings, review.Reviewers = "", nil, nil
+
+	reviewResult, err := e.reviewer.Run(ctx, changes)
+	if reviewResult != nil {
+		// A failed pass still counts what its reviewers spent
+		state.ReviewerCost += reviewResult.Cost
+		state.TotalCost += reviewResult.Cost
+		review.Cost += reviewResult.Cost
+		review.Duration += reviewResult.Duration
+	}
+	if err != nil {
+		if reviewResult != nil {
+			review.Reviewers = reviewResult.Reviews
+		}
+		return "", e.reviewerFailed(state, review, err)
+	}
+
+	// Check for completion signal in reviewer output
+	// Only counted once per iteration; the main iteration already updated state
+	review.CompletionSignalFound = e.completionDetector.Detect(reviewResult.Output)
+	review.Reviewers = reviewResult.Reviews
+
+	if reviewResult.Verdict == "" {
+		return "", e.reviewerFailed(state, review, reviewer.ErrNoVerdict)
+	}
+	state.ReviewerErrorCount = 0
+	review.Error = ""
+	review.Verdict, review.Findings = reviewResult.Verdict, reviewResult.Findings
+	verdict := VerdictRecord{
+		Iteration: state.TotalIterations,
+		Pass:      review.Passes,
+		Verdict:   reviewResult.Verdict,
+		Findings:  reviewResult.Findings,
+	}
+	if len(reviewResult.Reviews) > 1 {
+		verdict.Reviewers = reviewResult.Reviews
+	}
+	state.ReviewVerdicts = append(state.ReviewVerdicts, verdict)
+	return reviewResult.Verdict, nil
+}
+
+// reviewChanges collects what the iteration changed for the reviewers: summary,
+// the changed files and the diff. Returns nil when changes are not tracked, so
+// reviewers find them themselves. The diff is best-effort: a tracker that cannot
+// produce one leaves it empty.
+func (e *Executor) reviewChanges(ctx context.Context, before *Snapshot, summary string) *reviewer.Changes {
+	if before == nil {
+		return nil
+	}
+	changes, err := e.config.ChangeTracker.Changes(ctx, before)
+	if err != nil {
+		return nil
+	}
+	out := &reviewer.Changes{Summary: summary}
+	if changes.Diff != nil {
+		for _, file := range changes.Diff.Files {
+			if file.Binary {
+				out.Files = append(out.Files, file.Path+" (binary)")
+			} else {
+				out.Files = append(out.Files, fmt.Sprintf("%s (+%d -%d)", file.Path, file.Insertions, file.Deletions))
+			}
+		}
+	}
+	if patcher, ok := e.config.ChangeTracker.(ChangePatcher); ok && len(out.Files) > 0 {
+		out.Diff, _ = patcher.Patch(ctx, before)
+	}
+	return out
+}
+
+// reviewerFailed records a failed reviewer pass. Returns err when the loop should
+// stop due to consecutive reviewer errors, nil otherwise.
+func (e *Executor) reviewerFailed(state *State, review *ReviewRecord, err error) error {
+	state.ReviewerErrorCount++
+	review.Error = err.Error()
+	if state.ReviewerErrorCount >= e.config.MaxConsecutiveErrors {
+		return err
+	}
+	return nil
+}
+
+// runFixPass asks Claude to address the findings of the last reviewer pass.
+func (e *Executor) runFixPass(ctx context.Context, state *State, record *IterationRecord) error {
+	review := record.Review
+	review.Fixes++
+	fix := prompt.BuildReviewFix(prompt.ReviewFixContext{
+		UserPrompt:  e.config.Prompt,
+		Findings:    review.Findings,
+		Attempt:     review.Fixes,
+		MaxAttempts: e.config.MaxReviewFixes,
+	})
+	result, err := e.iterationHandler.client.Execute(ctx, fix.Prompt)
+	if err != nil {
+		return err
+	}
+	e.addPassCost(state, record, result)
+	return nil
+}
+
+// commitApproved commits changes the reviewer approved and has Claude push them.
+func (e *Executor) commitApproved(ctx context.Context, state *State, record *IterationRecord, before *Snapshot, summary string) {
+	committed, err := e.commitChanges(ctx, state, record, before, summary)
+	if err != nil {
+		record.Review.Error = "commit pass failed: " + err.Error()
+		return
+	}
+	record.Review.Committed = committed
+}
+
+// commitIteration commits an unreviewed iteration's changes and has Claude push
+// them, unless protected paths or change size limits rejected them.
+func (e *Executor) commitIteration(ctx context.Context, state *State, record *IterationRecord, before *Snapshot, summary string) {
+	if e.checkChanges(ctx, state, record, before) {
+		return
+	}
+	committed, err := e.commitChanges(ctx, state, record, before, summary)
+	if err != nil {
+		record.CommitError = "commit pass failed: " + err.Error()
+		return
+	}
+	record.Committed = committed
+}
+
+// commitChanges runs the commit pass for the iteration's changes. It is skipped
+// when changes are not tracked or the iteration changed nothing. On BaseBranch
+// the work first moves to a new branch. With a Committer, claude-loop commits the
+// changes through its check and the pass only pushes them; a failed check leaves
+// them uncommitted and tells the next iteration why. summary is the iteration's
+// output, used for the commit message. Returns whether the pass ran, and its error.
+func (e *Executor) commitChanges(ctx context.Context, state *State, record *IterationRecord, before *Snapshot, summary string) (bool, error) {
+	if before == nil {
+		return false, nil
+	}
+	changes, err := e.config.ChangeTracker.Changes(ctx, before)
+	if err == nil && len(changes.Commits) == 0 && (changes.Diff == nil || len(changes.Diff.Files) == 0) {
+		return false, nil
+	}
+
+	if err := e.leaveBaseBranch(ctx, before); err != nil {
+		record.CommitError = err.Error()
+		state.BlockedCommit = []string{
+			err.Error(),
+			fmt.Sprintf("The changes remain uncommitted in the working tree; switch to a new branch, since nothing may be committed to %s", e.config.BaseBranch),
+		}
+		return false, nil
+	}
+
+	pass := prompt.BuildReviewApproved(e.config.Policy)
+	if e.config.Committer != nil {
+		err := e.config.Committer.CommitAll(ctx, commitMessage(summary, state.TotalIterations))
+		if err != nil && !errors.Is(err, git.ErrNothingToCommit) {
+			record.CommitError = err.Error()
+			state.BlockedCommit = []string{
+				err.Error(),
+				"The changes remain uncommitted in the working tree; remove what was rejected, and the rest is committed with this iteration's changes",
+			}
+			return false, nil
+		}
+		pass = prompt.BuildChangesCommitted(e.config.Policy)
+	}
+
+	result, err := e.publisher.Execute(ctx, pass.Prompt)
+	if err != nil {
+		return false, err
+	}
+	e.addPassCost(state, record, result)
+	record.PullRequests = append(record.PullRequests, extractPullRequestURLs(result.Output)...)
+	return true, nil
+}
+
+// leaveBaseBranch moves the iteration's work to a new branch when it is on
+// BaseBranch, taking along the commits the iteration made there, so the commit
+// and the push pass land on the new branch. The new branch stays checked out
+// for later iterations.
+func (e *Executor) leaveBaseBranch(ctx context.Context, before *Snapshot) error {
+	if e.config.BaseBranch == "" || e.config.Brancher == nil {
+		return nil
+	}
+	branch, err := e.config.Brancher.CurrentBranch(ctx)
+	if err != nil {
+		return fmt.Errorf("not committed: %w", err)
+	}
+	if branch != e.config.BaseBranch {
+		return nil
+	}
+	if _, err := e.config.Brancher.BranchOff(ctx, e.config.BranchPrefix, before.Commit); err != nil {
+		return fmt.Errorf("not committed to %s, and moving the changes to a new branch failed: %w", branch, err)
+	}
+	return nil
+}
+
+// commitMessage derives a commit message from the first line of an iteration's
+// output, falling back to the iteration number.
+func commitMessage(summary string, iteration int) string {
+	for _, line := range strings.Split(summary, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(line, "#*->` \t"))
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > maxCommitSubject {
+			line = strings.TrimSpace(string(runes[:maxCommitSubject-3])) + "..."
+		}
+		return line
+	}
+	return fmt.Sprintf("Iteration %d", iteration)
+}
+
+// maxCommitSubject caps the length of commit messages derived from iteration output.
+const maxCommitSubject = 72
+
+// revertBlocked reverts every change the iteration made, including its commits,
+// and tells the next iteration why.
+func (e *Executor) revertBlocked(ctx context.Context, state *State, record *IterationRecord, before *Snapshot) {
+	review := record.Review
+	if paths, commits, err := e.revertIteration(ctx, before); err != nil {
+		review.RevertError = err.Error()
+	} else {
+		review.Reverted, review.Commits = paths, commits
+	}
+
+	summary := "The reviewer blocked it and all of its changes were reverted; do not redo them the same way"
+	if review.RevertError != "" {
+		summary = "The reviewer blocked it, but reverting failed (" + review.RevertError + "); undo its changes yourself before continuing"
+	}
+	state.ReviewFindings = append([]string{summary}, review.Findings...)
+}
+
+// revertIteration reverts every change made since before, dropping the commits
+// made since then. Returns the restored paths and the number of dropped commits.
+func (e *Executor) revertIteration(ctx context.Context, before *Snapshot) ([]string, int, error) {
+	reverter, ok := e.config.ChangeTracker.(ChangeReverter)
+	if before == nil || !ok {
+		return nil, 0, errors.New("changes are not tracked")
+	}
+	changes, err := e.config.ChangeTracker.Changes(ctx, before)
+	if err != nil {
+		return nil, 0, err
+	}
+	var paths []string
+	if changes.Diff != nil {
+		for _, file := range changes.Diff.Files {
+			paths = append(paths, file.Path)
+		}
+	}
+	dropCommits := len(changes.Commits) > 0
+	if err := reverter.Revert(ctx, before, paths, dropCommits); err != nil {
+		return nil, 0, err
+	}
+	return paths, len(changes.Commits), nil
+}
+
+// addPassCost adds the cost and tokens of a fix or commit pass to the iteration.
+func (e *Executor) addPassCost(state *State, record *IterationRecord, result *IterationResult) {
+	state.TotalCost += result.Cost
+	record.Cost += result.Cost
+	record.InputTokens += result.InputTokens
+	record.OutputTokens += result.OutputTokens
+}
@@ -0,0 +1,285 @@
+package loop
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/protected"
+	"github.com/DeukWoongWoo/claude-loop/internal/reviewer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reviewedRun runs the loop with separate main and reviewer clients and change tracking.
+func reviewedRun(t *testing.T, maxRuns int, tracker ChangeTracker, main *promptRecorder, reviews ...string) (*LoopResult, *MockClaudeClient) {
+	t.Helper()
+	results := make([]*IterationResult, len(reviews))
+	for i, output := range reviews {
+		results[i] = &IterationResult{Output: output, Cost: 0.02}
+	}
+	reviewerClient := &MockClaudeClient{Results: results}
+	cfg := &Config{
+		Prompt:               "Add config parsing",
+		MaxRuns:              maxRuns,
+		MaxConsecutiveErrors: 3,
+		ReviewPrompt:         "check the tests",
+		MaxReviewFixes:       DefaultMaxReviewFixes,
+		ChangeTracker:        tracker,
+	}
+	result, err := NewExecutorWithClients(cfg, main, &RoleClients{Reviewer: reviewerClient}).Run(context.Background())
+	require.NoError(t, err)
+	return result, reviewerClient
+}
+
+func TestExecutor_Run_ReviewApproved(t *testing.T) {
+	main := &promptRecorder{MockClaudeClient: MockClaudeClient{Results: []*IterationResult{
+		{Output: "Parsed the config", Cost: 0.1},
+		{Output: "Opened https://github.com/acme/app/pull/7", Cost: 0.01},
+	}}}
+
+	result, _ := reviewedRun(t, 1, &fakeChangeTracker{}, main, "<review_verdict>\nverdict: APPROVE\n</review_verdict>")
+
+	require.Len(t, main.prompts, 2)
+	assert.Contains(t, main.prompts[0], "## REVIEW GATE")
+	assert.Contains(t, main.prompts[1], "## REVIEW APPROVED")
+
+	record := result.State.Iterations[0]
+	require.NotNil(t, record.Review)
+	assert.Equal(t, reviewer.VerdictApprove, record.Review.Verdict)
+	assert.True(t, record.Review.Committed)
+	assert.Equal(t, 1, record.Review.Passes)
+	assert.Equal(t, []string{"https://github.com/acme/app/pull/7"}, record.PullRequests)
+	assert.InDelta(t, 0.11, record.Cost, 0.001, "the commit pass counts as main cost")
+	assert.InDelta(t, 0.13, result.State.TotalCost, 0.001)
+	assert.Equal(t, []VerdictRecord{{Iteration: 1, Pass: 1, Verdict: reviewer.VerdictApprove}}, result.State.ReviewVerdicts)
+}
+
+func TestExecutor_Run_ReviewApprovedProtectedChange(t *testing.T) {
+	matcher, err := protected.NewMatcher([]string{"*.go"})
+	require.NoError(t, err)
+	tracker := &revertingTracker{}
+	cfg := &Config{
+		Prompt:               "Add config parsing",
+		MaxRuns:              1,
+		MaxConsecutiveErrors: 3,
+		ReviewPrompt:         "check the tests",
+		MaxReviewFixes:       DefaultMaxReviewFixes,
+		ChangeTracker:        tracker,
+		ProtectedPaths:       matcher,
+	}
+	main := &promptRecorder{}
+	reviewerClient := &MockClaudeClient{Results: []*IterationResult{
+		{Output: "<review_verdict>\nverdict: APPROVE\n</review_verdict>"},
+	}}
+
+	result, err := NewExecutorWithClients(cfg, main, &RoleClients{Reviewer: reviewerClient}).Run(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, main.prompts, 1, "no commit pass runs")
+	assert.Equal(t, []string{"a.go"}, tracker.paths, "the protected change is reverted before the review")
+
+	record := result.State.Iterations[0]
+	require.NotNil(t, record.Protected)
+	assert.Equal(t, reviewer.VerdictApprove, record.Review.Verdict)
+	assert.False(t, record.Review.Committed)
+	require.NotEmpty(t, result.State.ReviewFindings)
+	assert.Contains(t, result.State.ReviewFindings[0], "The reviewer approved it, but it was not committed because it changed protected paths")
+}
+
+func TestExecutor_Run_ReviewApprovedWithoutTracking(t *testing.T) {
+	main := &promptRecorder{}
+
+	result, _ := reviewedRun(t, 1, nil, main, "VERDICT: APPROVE")
+
+	assert.Len(t, main.prompts, 1, "no commit pass without change tracking")
+	assert.False(t, result.State.Iterations[0].Review.Committed)
+}
+
+func TestExecutor_Run_ReviewRequestsChanges(t *testing.T) {
+	requestChanges := "<review_verdict>\nverdict: REQUEST_CHANGES\nfindings:\n- Handle a missing file\n</review_verdict>"
+
+	t.Run("fixed and approved", func(t *testing.T) {
+		main := &promptRecorder{}
+		result, _ := reviewedRun(t, 1, &fakeChangeTracker{}, main, requestChanges, "VERDICT: APPROVE")
+
+		require.Len(t, main.prompts, 3)
+		assert.Contains(t, main.prompts[1], "## REVIEW FIX")
+		assert.Contains(t, main.prompts[1], "- Handle a missing file\n")
+		assert.Contains(t, main.prompts[1], "## ORIGINAL GOAL\n\nAdd config parsing\n")
+		assert.Contains(t, main.prompts[2], "## REVIEW APPROVED")
+
+		review := result.State.Iterations[0].Review
+		assert.Equal(t, 1, review.Fixes)
+		assert.Equal(t, 2, review.Passes)
+		assert.True(t, review.Committed)
+		assert.Equal(t, []VerdictRecord{
+			{Iteration: 1, Pass: 1, Verdict: reviewer.VerdictRequestChanges, Findings: []string{"Handle a missing file"}},
+			{Iteration: 1, Pass: 2, Verdict: reviewer.VerdictApprove},
+		}, result.State.ReviewVerdicts)
+		assert.InDelta(t, 0.04, result.State.ReviewerCost, 0.001)
+	})
+
+	t.Run("still not approved", func(t *testing.T) {
+		main := &promptRecorder{}
+		result, _ := reviewedRun(t, 2, &fakeChangeTracker{}, main, requestChanges, requestChanges, requestChanges, "VERDICT: APPROVE")
+
+		// Iteration 1, two fix passes, iteration 2, commit pass
+		require.Len(t, main.prompts, 5)
+		assert.Contains(t, main.prompts[2], "This is fix pass 2 of 2")
+		assert.Contains(t, main.prompts[3], "## REVIEW FINDINGS")
+		assert.Contains(t, main.prompts[3], "- The reviewer still requests changes after 2 fix pass(es); they are not committed and remain in the working tree\n"+
+			"- Handle a missing file\n")
+		assert.NotContains(t, main.prompts[4], "## REVIEW FINDINGS")
+
+		review := result.State.Iterations[0].Review
+		assert.Equal(t, reviewer.VerdictRequestChanges, review.Verdict)
+		assert.Equal(t, 2, review.Fixes)
+		assert.False(t, review.Committed)
+		assert.Len(t, result.State.ReviewVerdicts, 4)
+	})
+}
+
+func TestExecutor_Run_ReviewBlocks(t *testing.T) {
+	block := "<review_verdict>\nverdict: BLOCK\nfindings:\n- Deletes the user table\n</review_verdict>"
+
+	t.Run("reverts the iteration", func(t *testing.T) {
+		tracker := &revertingTracker{}
+		main := &promptRecorder{}
+		result, _ := reviewedRun(t, 2, tracker, main, block, "VERDICT: APPROVE")
+
+		assert.Equal(t, []string{"a.go"}, tracker.paths)
+		assert.True(t, tracker.dropCommits)
+		review := result.State.Iterations[0].Review
+		assert.Equal(t, reviewer.VerdictBlock, review.Verdict)
+		assert.Equal(t, []string{"a.go"}, review.Reverted)
+		assert.Equal(t, 1, review.Commits)
+		assert.False(t, review.Committed)
+
+		require.Len(t, main.prompts, 3)
+		assert.Contains(t, main.prompts[1], "- The reviewer blocked it and all of its changes were reverted; do not redo them the same way\n"+
+			"- Deletes the user table\n")
+	})
+
+	t.Run("revert fails", func(t *testing.T) {
+		tracker := &revertingTracker{err: errors.New("index locked")}
+		main := &promptRecorder{}
+		result, _ := reviewedRun(t, 2, tracker, main, block, "VERDICT: APPROVE")
+
+		assert.Equal(t, "index locked", result.State.Iterations[0].Review.RevertError)
+		assert.Contains(t, main.prompts[1], "reverting failed (index locked); undo its changes yourself")
+	})
+}
+
+func TestExecutor_Run_ReviewWithoutVerdict(t *testing.T) {
+	main := &promptRecorder{}
+	result, _ := reviewedRun(t, 2, &fakeChangeTracker{}, main, "Looks fine to me", "VERDICT: APPROVE")
+
+	first := result.State.Iterations[0].Review
+	assert.Equal(t, reviewer.ErrNoVerdict.Error(), first.Error)
+	assert.Empty(t, first.Verdict)
+	assert.False(t, first.Committed)
+	assert.InDelta(t, 0.02, first.Cost, 0.001, "the pass still costs")
+
+	assert.True(t, result.State.Iterations[1].Review.Committed)
+	assert.Zero(t, result.State.ReviewerErrorCount)
+}
+
+// failedSpend fails every call after spending cost.
+type failedSpend struct {
+	cost float64
+}
+
+func (f *failedSpend) Execute(ctx context.Context, prompt string) (*IterationResult, error) {
+	return &IterationResult{Cost: f.cost}, errors.New("exit 1")
+}
+
+func TestExecutor_Run_FailedReviewerSpendCounts(t *testing.T) {
+	cfg := &Config{
+		Prompt:               "Add config parsing",
+		MaxRuns:              1,
+		MaxConsecutiveErrors: 3,
+		ReviewPrompt:         "check the tests",
+		ChangeTracker:        &fakeChangeTracker{},
+	}
+	roles := &RoleClients{Reviewer: &failedSpend{cost: 0.04}}
+
+	result, err := NewExecutorWithClients(cfg, &promptRecorder{}, roles).Run(context.Background())
+	require.NoError(t, err)
+
+	review := result.State.Iterations[0].Review
+	assert.Contains(t, review.Error, "exit 1")
+	assert.False(t, review.Committed)
+	assert.InDelta(t, 0.04, review.Cost, 0.001)
+	assert.InDelta(t, 0.04, result.State.ReviewerCost, 0.001)
+	assert.Equal(t, 1, result.State.ReviewerErrorCount)
+}
+
+func TestExecutor_Run_ReviewerSeesChanges(t *testing.T) {
+	main := &promptRecorder{MockClaudeClient: MockClaudeClient{Results: []*IterationResult{{Output: "Parsed the config"}}}}
+	reviewerClient := &promptRecorder{MockClaudeClient: MockClaudeClient{Results: []*IterationResult{{Output: "VERDICT: APPROVE"}}}}
+	cfg := &Config{
+		Prompt:               "Add config parsing",
+		MaxRuns:              1,
+		MaxConsecutiveErrors: 3,
+		ReviewPrompt:         "check the tests",
+		ChangeTracker:        &patchingTracker{patch: "+func Parse() {}\n"},
+	}
+
+	_, err := NewExecutorWithClients(cfg, main, &RoleClients{Reviewer: reviewerClient}).Run(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, reviewerClient.prompts, 1)
+	assert.Contains(t, reviewerClient.prompts[0], "## CHANGES UNDER REVIEW")
+	assert.Contains(t, reviewerClient.prompts[0], "**Iteration summary**:\n\nParsed the config\n")
+	assert.Contains(t, reviewerClient.prompts[0], "- a.go (+2 -0)\n")
+	assert.Contains(t, reviewerClient.prompts[0], "```diff\n+func Parse() {}\n```")
+}
+
+// fixedReviewer answers every review with the same output; safe for parallel reviewers.
+type fixedReviewer struct {
+	output string
+	mu     sync.Mutex
+	calls  int
+}
+
+func (f *fixedReviewer) Execute(ctx context.Context, prompt string) (*IterationResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return &IterationResult{Output: f.output, Cost: 0.01}, nil
+}
+
+func TestExecutor_Run_SpecialisedReviewers(t *testing.T) {
+	tests := &fixedReviewer{output: "<review_verdict>\nverdict: REQUEST_CHANGES\nfindings:\n- Add a test\n</review_verdict>"}
+	security := &fixedReviewer{output: "VERDICT: APPROVE"}
+	cfg := &Config{
+		Prompt:               "Add config parsing",
+		MaxRuns:              1,
+		MaxConsecutiveErrors: 3,
+		Reviewers: &reviewer.Settings{Reviewers: []reviewer.Spec{
+			{Name: "tests", Prompt: "Check the tests"},
+			{Name: "security", Prompt: "Check for vulnerabilities"},
+		}},
+		ChangeTracker: &fakeChangeTracker{},
+	}
+	main := &promptRecorder{}
+	roles := &RoleClients{Reviewers: map[string]ClaudeClient{"tests": tests, "security": security}}
+
+	result, err := NewExecutorWithClients(cfg, main, roles).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, tests.calls)
+	assert.Equal(t, 1, security.calls)
+	assert.Contains(t, main.prompts[0], "## REVIEW GATE", "specialised reviewers alone gate commits")
+
+	review := result.State.Iterations[0].Review
+	assert.Equal(t, reviewer.VerdictRequestChanges, review.Verdict)
+	assert.Equal(t, []string{"tests: Add a test"}, review.Findings)
+	require.Len(t, review.Reviewers, 2)
+	assert.Equal(t, reviewer.VerdictApprove, review.Reviewers[1].Verdict)
+	require.Len(t, result.State.ReviewVerdicts, 1)
+	assert.Len(t, result.State.ReviewVerdicts[0].Reviewers, 2)
+	assert.InDelta(t, 0.02, result.State.ReviewerCost, 0.001)
+}
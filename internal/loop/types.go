@@ -6,6 +6,12 @@ import (
 	"time"
 
 	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/council"
+	"github.com/DeukWoongWoo/claude-loop/internal/prompt"
+	"github.com/DeukWoongWoo/claude-loop/internal/protected"
+	"github.com/DeukWoongWoo/claude-loop/internal/reviewer"
+	"github.com/DeukWoongWoo/claude-loop/internal/secrets"
+	"github.com/DeukWoongWoo/claude-loop/internal/verifier"
 )
 
 // ClaudeClient executes Claude Code iterations.
@@ -20,6 +26,8 @@ type IterationResult struct {
 	Cost                  float64       // Cost in USD for this iteration
 	Duration              time.Duration // How long this iteration took
 	CompletionSignalFound bool          // Whether completion signal was detected in output
+	InputTokens           int           // Input tokens reported by Claude (0 if unknown)
+	OutputTokens          int           // Output tokens reported by Claude (0 if unknown)
 }
 
 // StopReason indicates why the loop stopped.
@@ -37,17 +45,153 @@ const (
 
 // State tracks the internal state of the loop during execution.
 type State struct {
-	SuccessfulIterations  int           // Count of completed iterations
-	TotalIterations       int           // All iterations including errors
-	ErrorCount            int           // Consecutive error counter (reset on success)
-	CompletionSignalCount int           // Consecutive completion signals
-	TotalCost             float64       // Accumulated USD cost
-	StartTime             time.Time     // Loop start time
-	LastIterationTime     time.Time     // When last iteration completed
-	ReviewerCost          float64       // Accumulated reviewer pass cost (separate tracking)
-	ReviewerErrorCount    int           // Consecutive reviewer error counter (reset on success)
-	CouncilCost           float64       // Accumulated council invocation cost
-	CouncilInvocations    int           // Number of council invocations
+	SuccessfulIterations  int                   // Count of completed iterations
+	TotalIterations       int                   // All iterations including errors
+	ErrorCount            int                   // Consecutive error counter (reset on success)
+	CompletionSignalCount int                   // Consecutive completion signals
+	TotalCost             float64               // Accumulated USD cost
+	StartTime             time.Time             // Loop start time
+	LastIterationTime     time.Time             // When last iteration completed
+	ReviewerCost          float64               // Accumulated reviewer pass cost (separate tracking)
+	ReviewerErrorCount    int                   // Consecutive reviewer error counter (reset on success)
+	CouncilCost           float64               // Accumulated council invocation cost
+	CouncilInvocations    int                   // Number of council invocations
+	Iterations            []IterationRecord     // Per-iteration history for reporting
+	RejectedChanges       []string              // Protected-path reverts to report to the next iteration
+	OversizedChange       []string              // Change size limit violation to report to the next iteration
+	PriorDecisions        []string              // Earlier decisions relevant to the next iteration
+	CouncilResolution     []string              // Resolved principle conflict for the next iteration to follow
+	PendingEscalations    []*council.Escalation // Conflicts awaiting a human decision
+	ReviewVerdicts        []VerdictRecord       // Every reviewer verdict, in order
+	ReviewFindings        []string              // Unapproved review outcome for the next iteration to address
+	BlockedCommit         []string              // Why claude-loop did not commit, for the next iteration
+	VerificationFailures  []string              // Failed verification checks for the next iteration to fix
+}
+
+// IterationRecord captures everything that happened in one iteration.
+type IterationRecord struct {
+	Number                int                 `json:"number"`
+	StartedAt             time.Time           `json:"started_at"`
+	Duration              time.Duration       `json:"duration"` // Wall-clock time including reviewer and council
+	Cost                  float64             `json:"cost"`     // Main iteration cost only
+	InputTokens           int                 `json:"input_tokens"`
+	OutputTokens          int                 `json:"output_tokens"`
+	CompletionSignalFound bool                `json:"completion_signal_found"`
+	Error                 string              `json:"error,omitempty"`
+	Changes               *ChangeSet          `json:"changes,omitempty"`       // nil when no ChangeTracker is configured
+	PullRequests          []string            `json:"pull_requests,omitempty"` // PR links found in Claude output
+	Review                *ReviewRecord       `json:"review,omitempty"`        // nil when no reviewer pass ran
+	Council               *CouncilRecord      `json:"council,omitempty"`       // nil when no decision was made
+	Verification          *VerificationRecord `json:"verification,omitempty"`  // nil when verification did not run
+	Protected             *ProtectedRecord    `json:"protected,omitempty"`     // nil when no protected path was touched
+	Secrets               []secrets.Finding   `json:"secrets,omitempty"`       // Possible secrets the iteration added
+	ChangeSize            *ChangeSizeRecord   `json:"change_size,omitempty"`   // nil when the change size limits were kept
+	Committed             bool                `json:"committed,omitempty"`     // True when claude-loop committed the changes through its Committer
+	CommitError           string              `json:"commit_error,omitempty"`  // Why claude-loop did not commit the changes
+}
+
+// ReviewRecord is the outcome of reviewing an iteration's changes.
+type ReviewRecord struct {
+	Cost                  float64       `json:"cost"`     // All reviewer passes
+	Duration              time.Duration `json:"duration"` // All reviewer passes
+	CompletionSignalFound bool          `json:"completion_signal_found"`
+	Error                 string        `json:"error,omitempty"`
+
+	Verdict     reviewer.Verdict `json:"verdict,omitempty"`         // Verdict of the last pass; empty when it gave none
+	Findings    []string         `json:"findings,omitempty"`        // Findings of the last pass
+	Passes      int              `json:"passes"`                    // Reviewer passes, including re-reviews after fixes
+	Fixes       int              `json:"fixes,omitempty"`           // Fix passes run on requested changes
+	Committed   bool             `json:"committed,omitempty"`       // True when the commit pass ran for approved changes
+	Reverted    []string         `json:"reverted,omitempty"`        // Paths restored after a block
+	Commits     int              `json:"commits_dropped,omitempty"` // Commits undone after a block
+	RevertError string           `json:"revert_error,omitempty"`    // Set when reverting a blocked iteration failed
+
+	Reviewers []reviewer.Review `json:"reviewers,omitempty"` // Each reviewer's part of the last pass
+}
+
+// VerdictRecord is one reviewer verdict.
+type VerdictRecord struct {
+	Iteration int               `json:"iteration"`
+	Pass      int               `json:"pass"` // 1 for the first review, higher after fix passes
+	Verdict   reviewer.Verdict  `json:"verdict"`
+	Findings  []string          `json:"findings,omitempty"`
+	Reviewers []reviewer.Review `json:"reviewers,omitempty"` // Each reviewer's verdict when several reviewed
+}
+
+// CouncilRecord is a decision extracted from output or resolved by the council.
+type CouncilRecord struct {
+	Invoked   bool    `json:"invoked"`        // True when the LLM council resolved a conflict
+	Rule      string  `json:"rule,omitempty"` // Layer 2 rule that resolved the conflict without the council
+	Decision  string  `json:"decision,omitempty"`
+	Rationale string  `json:"rationale,omitempty"`
+	Cost      float64 `json:"cost"`
+	Error     string  `json:"error,omitempty"`
+
+	// Set when council members voted
+	Vote       string        `json:"vote,omitempty"`       // Option the council adopted
+	Votes      []CouncilVote `json:"votes,omitempty"`      // Each member's vote
+	Dissent    []string      `json:"dissent,omitempty"`    // Members who voted against the decision
+	Confidence float64       `json:"confidence,omitempty"` // 0-1
+
+	Escalation         *EscalationRecord `json:"escalation,omitempty"`          // nil when the outcome was not escalated
+	AwaitingEscalation string            `json:"awaiting_escalation,omitempty"` // ID of the pending escalation the reported conflict waits on
+}
+
+// EscalationRecord describes a conflict handed to a human.
+type EscalationRecord struct {
+	Reason   string `json:"reason"`
+	Path     string `json:"path,omitempty"`     // Escalation file awaiting the decision
+	URL      string `json:"url,omitempty"`      // GitHub issue opened for it
+	Decision string `json:"decision,omitempty"` // Set when the human answered at once
+	Error    string `json:"error,omitempty"`    // Set when the conflict could not be escalated
+}
+
+// CouncilVote is one council member's vote.
+type CouncilVote struct {
+	Member     string  `json:"member"`
+	Vote       string  `json:"vote,omitempty"`
+	Confidence int     `json:"confidence,omitempty"` // 1-10
+	Cost       float64 `json:"cost"`
+	Error      string  `json:"error,omitempty"` // Why the member did not vote
+}
+
+// VerificationRecord is the result of verifying an iteration's work.
+type VerificationRecord struct {
+	Passed   bool     `json:"passed"`
+	Failures []string `json:"failures,omitempty"`
+}
+
+// ProtectedRecord describes changes to protected paths and how they were reverted.
+type ProtectedRecord struct {
+	Violations []protected.Violation `json:"violations"`
+	Mode       config.RevertMode     `json:"mode"`
+	Reverted   []string              `json:"reverted,omitempty"`        // Paths restored to their pre-iteration state
+	Commits    int                   `json:"commits_dropped,omitempty"` // Commits undone so the protected changes leave history
+	Error      string                `json:"error,omitempty"`           // Set when the revert failed
+}
+
+// ChangeSizeRecord describes an iteration that exceeded the change size limits.
+type ChangeSizeRecord struct {
+	Files    int                 `json:"files"`
+	Lines    int                 `json:"lines"`               // Lines inserted plus deleted
+	MaxFiles int                 `json:"max_files,omitempty"` // 0 = unlimited
+	MaxLines int                 `json:"max_lines,omitempty"` // 0 = unlimited
+	Action   config.ExceedAction `json:"action"`
+	Reverted []string            `json:"reverted,omitempty"`        // Paths restored with the revert action
+	Commits  int                 `json:"commits_dropped,omitempty"` // Commits undone with the revert action
+	Error    string              `json:"error,omitempty"`           // Set when the revert failed
+}
+
+// TotalCost returns the iteration cost including reviewer and council.
+func (r *IterationRecord) TotalCost() float64 {
+	total := r.Cost
+	if r.Review != nil {
+		total += r.Review.Cost
+	}
+	if r.Council != nil {
+		total += r.Council.Cost
+	}
+	return total
 }
 
 // NewState creates a new State with initialized start time.
@@ -73,24 +217,75 @@ type Config struct {
 	MaxConsecutiveErrors int // Default: 3
 	DryRun               bool
 	OnProgress           func(state *State) // Optional progress callback (nil allowed)
+	ChangeTracker        ChangeTracker      // Measures per-iteration repository changes (nil = not tracked)
 
 	// Prompt builder fields
-	NotesFile  string             // Path to shared notes file
-	Principles *config.Principles // Loaded principles (may be nil)
+	NotesFile  string              // Path to shared notes file
+	Principles *config.Principles  // Loaded principles (may be nil)
+	Templates  *prompt.TemplateSet // Prompt template overrides (nil = built-in)
+	Branch     string              // Current git branch exposed to templates (may be empty)
+	Policy     *config.Policy      // Runtime policy whose rules are added to the prompt (nil = none)
 
 	// Reviewer fields
-	ReviewPrompt string // Reviewer pass prompt (empty = disabled)
+	ReviewPrompt   string             // Reviewer pass prompt (empty = only the reviewers in Reviewers)
+	Reviewers      *reviewer.Settings // Specialised reviewers and the diff size cap (nil = only ReviewPrompt)
+	MaxReviewFixes int                // Fix passes per iteration when the reviewer requests changes (0 = none)
 
 	// Council fields
-	LogDecisions bool // Enable decision logging (--log-decisions)
+	LogDecisions bool              // Enable decision logging (--log-decisions)
+	Council      *council.Settings // Council members, chair and cost cap (nil = a single resolution call)
+	RunID        string            // Run ID recorded with each logged decision (may be empty)
+
+	// Escalator hands conflicts the council failed, split on or was unsure about
+	// to a human (nil = the council's decision stands). Decisions with a
+	// confidence below EscalationConfidence are escalated.
+	Escalator            Escalator
+	EscalationConfidence float64
+
+	// PriorDecisions are decisions logged by earlier runs, oldest first, offered
+	// to iterations and the council as precedents (may be nil).
+	PriorDecisions []*council.DecisionRecord
+
+	// Protected path fields
+	ProtectedPaths  *protected.Matcher // Paths iterations must not change (nil = no policy)
+	ProtectedRevert config.RevertMode  // What to undo on a violation (empty = files)
+
+	// Secret scanning fields
+	SecretScanner *secrets.Scanner // Scans each iteration's changes for secrets (nil = disabled)
+
+	// Committer commits iteration changes, checking them for secrets first
+	// (nil = Claude commits them). Without a reviewer, iterations then leave
+	// their changes for claude-loop to commit. With a Committer, possible secrets
+	// found by SecretScanner are left for its check to handle.
+	Committer Committer
+
+	// Change size fields
+	ChangeLimits config.ChangeLimits // Per-iteration change size limits (zero limits = unlimited)
+
+	// Verification fields
+	Verifier            verifier.Verifier // Checks each iteration's work against VerifyCriteria (nil = not verified)
+	VerifyCriteria      []string          // Success criteria such as "go test" (empty = not verified)
+	RequireVerification bool              // Changes that fail verification are not committed
+
+	// BaseBranch is the branch claude-loop never commits to: before committing on
+	// it, Brancher moves the iteration's work to a new branch named with
+	// BranchPrefix, which the push pass then pushes (empty = any branch).
+	BaseBranch   string
+	BranchPrefix string
+	Brancher     Brancher
 }
 
+// DefaultMaxReviewFixes is the number of fix passes an iteration gets when the
+// reviewer requests changes.
+const DefaultMaxReviewFixes = 2
+
 // DefaultConfig returns a Config with default values.
 func DefaultConfig() *Config {
 	return &Config{
 		CompletionSignal:     "CONTINUOUS_CLAUDE_PROJECT_COMPLETE",
 		CompletionThreshold:  3,
 		MaxConsecutiveErrors: 3,
+		MaxReviewFixes:       DefaultMaxReviewFixes,
 	}
 }
 
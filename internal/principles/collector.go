@@ -18,21 +18,25 @@ import (
 type Collector struct {
 	principlesPath string
 	reader         io.Reader
+	presets        *config.PresetRegistry
 }
 
-// NewCollector creates a new Collector.
-func NewCollector(principlesPath string) *Collector {
+// NewCollector creates a new Collector offering the built-in presets and those in
+// presets, which may be nil.
+func NewCollector(principlesPath string, presets *config.PresetRegistry) *Collector {
 	return &Collector{
 		principlesPath: principlesPath,
 		reader:         os.Stdin,
+		presets:        presets,
 	}
 }
 
 // NewCollectorWithReader creates a new Collector with a custom reader (for testing).
-func NewCollectorWithReader(principlesPath string, reader io.Reader) *Collector {
+func NewCollectorWithReader(principlesPath string, reader io.Reader, presets *config.PresetRegistry) *Collector {
 	return &Collector{
 		principlesPath: principlesPath,
 		reader:         reader,
+		presets:        presets,
 	}
 }
 
@@ -56,9 +60,19 @@ func (c *Collector) Collect(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	return c.collect(ctx, reader, preset)
+}
+
+// CollectPreset runs the interactive session for a known preset, asking only the
+// preset's follow-up questions.
+func (c *Collector) CollectPreset(ctx context.Context, preset config.Preset) error {
+	return c.collect(ctx, bufio.NewReader(c.reader), preset)
+}
 
+// collect asks the follow-up questions for preset and saves the principles.
+func (c *Collector) collect(ctx context.Context, reader *bufio.Reader, preset config.Preset) error {
 	// Step 2: Load defaults and ask follow-up questions
-	principles := config.DefaultPrinciples(preset)
+	principles := c.presets.Defaults(preset)
 	if err := c.askFollowUpQuestions(ctx, reader, preset, principles); err != nil {
 		return err
 	}
@@ -87,50 +101,68 @@ func (c *Collector) askProjectType(ctx context.Context, reader *bufio.Reader) (c
 	fmt.Println("  1) Startup/MVP - Fast validation, focus on core features")
 	fmt.Println("  2) Enterprise - Stability first, thorough testing")
 	fmt.Println("  3) Open Source - Community contributions, API stability")
+	custom := c.presets.Definitions()
+	for i, d := range custom {
+		fmt.Printf("  %d) %s\n", i+4, describeCustomPreset(d))
+	}
 	fmt.Print("> ")
 
 	input, _ := reader.ReadString('\n')
-	switch strings.TrimSpace(input) {
+	input = strings.TrimSpace(input)
+	switch input {
 	case "1":
 		return config.PresetStartup, nil
 	case "2":
 		return config.PresetEnterprise, nil
 	case "3":
 		return config.PresetOpenSource, nil
-	default:
-		return config.PresetStartup, nil
 	}
+	for i, d := range custom {
+		if input == strconv.Itoa(i+4) || input == string(d.Name) {
+			return d.Name, nil
+		}
+	}
+	return config.PresetStartup, nil
 }
 
-// askFollowUpQuestions asks preset-specific follow-up questions.
+// describeCustomPreset returns a custom preset's menu entry.
+func describeCustomPreset(d *config.PresetDefinition) string {
+	if d.Description == "" {
+		return fmt.Sprintf("%s (extends %s)", d.Name, d.Extends)
+	}
+	return fmt.Sprintf("%s - %s (extends %s)", d.Name, d.Description, d.Extends)
+}
+
+// askFollowUpQuestions asks preset-specific follow-up questions, defaulting to the
+// preset's values. A custom preset gets the questions of the preset it extends.
 func (c *Collector) askFollowUpQuestions(ctx context.Context, reader *bufio.Reader, preset config.Preset, p *config.Principles) error {
 	fmt.Println()
 	var err error
-	switch preset {
+	switch c.presets.Base(preset) {
 	case config.PresetStartup:
-		p.Layer0.ScopePhilosophy, err = c.askNumber(ctx, reader, "MVP scope (1=minimal, 10=expansive)", 3)
+		p.Layer0.ScopePhilosophy, err = c.askNumber(ctx, reader, "MVP scope (1=minimal, 10=expansive)", p.Layer0.ScopePhilosophy)
 		if err != nil {
 			return err
 		}
-		p.Layer1.SpeedCorrectness, err = c.askNumber(ctx, reader, "Speed vs Quality (1=speed, 10=quality)", 4)
+		p.Layer1.SpeedCorrectness, err = c.askNumber(ctx, reader, "Speed vs Quality (1=speed, 10=quality)", p.Layer1.SpeedCorrectness)
 		if err != nil {
 			return err
 		}
 	case config.PresetEnterprise:
-		p.Layer1.BlastRadius, err = c.askNumber(ctx, reader, "Change size - how large changes can be (1=large sweeping changes, 10=small incremental changes)", 9)
+		p.Layer1.BlastRadius, err = c.askNumber(ctx, reader, "Change size - how large changes can be (1=large sweeping changes, 10=small incremental changes)", p.Layer1.BlastRadius)
 		if err != nil {
 			return err
 		}
-		p.Layer1.InnovationStability, err = c.askNumber(ctx, reader, "Tech choice - technology preference (1=new/experimental tech, 10=proven/stable tech)", 8)
+		p.Layer1.InnovationStability, err = c.askNumber(ctx, reader, "Tech choice - technology preference (1=new/experimental tech, 10=proven/stable tech)", p.Layer1.InnovationStability)
 		if err != nil {
 			return err
 		}
 	case config.PresetOpenSource:
-		p.Layer0.CurationModel, err = c.askNumber(ctx, reader, "Contributions (1=open, 10=verified)", 5)
+		p.Layer0.CurationModel, err = c.askNumber(ctx, reader, "Contributions (1=open, 10=verified)", p.Layer0.CurationModel)
 		if err != nil {
 			return err
 		}
-		p.Layer0.UXPhilosophy, err = c.askNumber(ctx, reader, "UX (1=easy, 10=powerful)", 5)
+		p.Layer0.UXPhilosophy, err = c.askNumber(ctx, reader, "UX (1=easy, 10=powerful)", p.Layer0.UXPhilosophy)
 		if err != nil {
 			return err
 		}
@@ -158,6 +190,13 @@ func (c *Collector) askNumber(ctx context.Context, reader *bufio.Reader, prompt
 	return defaultVal, nil
 }
 
+// IsTerminal reports whether f is an interactive terminal. Collect blocks on
+// input, so callers should not start it when stdin is a pipe or /dev/null.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 // CollectorError represents an error during principle collection.
 type CollectorError struct {
 	Message string
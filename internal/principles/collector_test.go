@@ -18,7 +18,7 @@ func TestNewCollector(t *testing.T) {
 
 	path := "/some/path/principles.yaml"
 
-	collector := NewCollector(path)
+	collector := NewCollector(path, nil)
 
 	assert.NotNil(t, collector)
 	assert.Equal(t, path, collector.principlesPath)
@@ -31,7 +31,7 @@ func TestNewCollectorWithReader(t *testing.T) {
 	path := "/some/path/principles.yaml"
 	reader := strings.NewReader("test")
 
-	collector := NewCollectorWithReader(path, reader)
+	collector := NewCollectorWithReader(path, reader, nil)
 
 	assert.NotNil(t, collector)
 	assert.Equal(t, path, collector.principlesPath)
@@ -86,7 +86,7 @@ func TestCollector_NeedsCollection(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			collector := NewCollector(principlesPath)
+			collector := NewCollector(principlesPath, nil)
 
 			result := collector.NeedsCollection(tc.forceReset)
 			assert.Equal(t, tc.expected, result)
@@ -102,7 +102,7 @@ func TestCollector_Collect_Startup(t *testing.T) {
 
 	// Simulate user input: "1" for startup, "5" for scope, "6" for speed
 	input := "1\n5\n6\n"
-	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input))
+	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input), nil)
 
 	err := collector.Collect(context.Background())
 
@@ -128,7 +128,7 @@ func TestCollector_Collect_Enterprise(t *testing.T) {
 
 	// Simulate user input: "2" for enterprise, "8" for blast radius, "7" for innovation
 	input := "2\n8\n7\n"
-	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input))
+	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input), nil)
 
 	err := collector.Collect(context.Background())
 
@@ -149,7 +149,7 @@ func TestCollector_Collect_OpenSource(t *testing.T) {
 
 	// Simulate user input: "3" for opensource, "4" for curation, "6" for UX
 	input := "3\n4\n6\n"
-	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input))
+	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input), nil)
 
 	err := collector.Collect(context.Background())
 
@@ -170,7 +170,7 @@ func TestCollector_Collect_DefaultsOnEmpty(t *testing.T) {
 
 	// Simulate user input: empty lines (use defaults)
 	input := "\n\n\n"
-	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input))
+	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input), nil)
 
 	err := collector.Collect(context.Background())
 
@@ -193,7 +193,7 @@ func TestCollector_Collect_InvalidInput(t *testing.T) {
 
 	// Simulate user input: invalid preset, out of range values
 	input := "invalid\nabc\n15\n"
-	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input))
+	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input), nil)
 
 	err := collector.Collect(context.Background())
 
@@ -214,7 +214,7 @@ func TestCollector_Collect_CreatesParentDirectory(t *testing.T) {
 	principlesPath := filepath.Join(tmpDir, "nested", "dir", "principles.yaml")
 
 	input := "1\n\n\n"
-	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input))
+	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input), nil)
 
 	err := collector.Collect(context.Background())
 
@@ -249,7 +249,7 @@ func TestCollector_Collect_ValidNumberRange(t *testing.T) {
 			tmpDir := t.TempDir()
 			principlesPath := filepath.Join(tmpDir, "principles.yaml")
 
-			collector := NewCollectorWithReader(principlesPath, strings.NewReader(tc.input))
+			collector := NewCollectorWithReader(principlesPath, strings.NewReader(tc.input), nil)
 			err := collector.Collect(context.Background())
 
 			require.NoError(t, err)
@@ -269,7 +269,7 @@ func TestCollector_Collect_PreservesOtherDefaults(t *testing.T) {
 
 	// Only specify the asked questions, others should use defaults
 	input := "1\n7\n8\n"
-	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input))
+	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input), nil)
 
 	err := collector.Collect(context.Background())
 
@@ -355,7 +355,7 @@ func TestCollector_Collect_ContextCancellation(t *testing.T) {
 	cancel()
 
 	input := "1\n5\n6\n"
-	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input))
+	collector := NewCollectorWithReader(principlesPath, strings.NewReader(input), nil)
 
 	err := collector.Collect(ctx)
 
@@ -365,3 +365,58 @@ func TestCollector_Collect_ContextCancellation(t *testing.T) {
 	assert.Equal(t, "cancelled", collectorErr.Message)
 	assert.ErrorIs(t, collectorErr.Err, context.Canceled)
 }
+
+func TestCollector_CollectPreset(t *testing.T) {
+	t.Parallel()
+
+	principlesPath := filepath.Join(t.TempDir(), "principles.yaml")
+
+	// Only the enterprise follow-ups are asked: change size, then tech choice
+	collector := NewCollectorWithReader(principlesPath, strings.NewReader("7\n\n"), nil)
+	require.NoError(t, collector.CollectPreset(context.Background(), config.PresetEnterprise))
+
+	loaded, err := config.LoadFromFile(principlesPath)
+	require.NoError(t, err)
+	assert.Equal(t, config.PresetEnterprise, loaded.Preset)
+	assert.Equal(t, 7, loaded.Layer1.BlastRadius)
+	assert.Equal(t, 8, loaded.Layer1.InnovationStability)
+}
+
+func TestIsTerminal(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.CreateTemp(t.TempDir(), "stdin")
+	require.NoError(t, err)
+	defer f.Close()
+
+	assert.False(t, IsTerminal(f))
+}
+
+func TestCollector_Collect_CustomPreset(t *testing.T) {
+	principlesPath := filepath.Join(t.TempDir(), "principles.yaml")
+
+	// "4" selects the custom preset, which gets the enterprise follow-ups with its own defaults
+	collector := NewCollectorWithReader(principlesPath, strings.NewReader("4\n\n6\n"), fintechPresets(t))
+	require.NoError(t, collector.Collect(context.Background()))
+
+	loaded, err := config.LoadFromFile(principlesPath)
+	require.NoError(t, err)
+	assert.Equal(t, config.Preset("regulated-fintech"), loaded.Preset)
+	assert.Equal(t, 10, loaded.Layer1.BlastRadius)
+	assert.Equal(t, 6, loaded.Layer1.InnovationStability)
+	assert.Equal(t, 10, loaded.Layer1.SecurityPosture)
+}
+
+// fintechPresets returns a registry with a custom preset extending enterprise.
+func fintechPresets(t *testing.T) *config.PresetRegistry {
+	t.Helper()
+	d := &config.PresetDefinition{
+		Name:        "regulated-fintech",
+		Description: "Payments under PCI DSS",
+		Extends:     config.PresetEnterprise,
+		Layer1:      config.Layer1{BlastRadius: 10, SecurityPosture: 10},
+	}
+	presets := config.NewPresetRegistry()
+	require.NoError(t, presets.Register(d))
+	return presets
+}
@@ -0,0 +1,341 @@
+package principles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxReadmeBytes is how much of the README is kept as a signal.
+const maxReadmeBytes = 4000
+
+// CommandExecutor abstracts exec.Command for testing.
+type CommandExecutor interface {
+	CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd
+}
+
+// DefaultExecutor uses the real exec.CommandContext.
+type DefaultExecutor struct{}
+
+// CommandContext creates a new exec.Cmd with the given context.
+func (e *DefaultExecutor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, name, args...)
+}
+
+// Signals are facts about a repository that hint at its principles.
+// They are gathered locally; only this summary is sent to Claude.
+type Signals struct {
+	Readme         string   // Start of the README, "" when there is none
+	License        string   // License name, such as "MIT", "proprietary or unrecognized", or "" when there is none
+	Contributing   bool     // CONTRIBUTING guide present
+	CodeOfConduct  bool     // CODE_OF_CONDUCT present
+	SecurityPolicy bool     // SECURITY policy present
+	CodeOwners     bool     // CODEOWNERS present
+	Workflows      []string // CI configuration files, such as ".github/workflows/ci.yml"
+	SourceFiles    int      // Source files, including tests
+	TestFiles      int      // Test files
+	// BranchProtection summarizes the default branch's protection on GitHub,
+	// "none" when it is unprotected, or "" when it could not be checked.
+	BranchProtection string
+	Tags             int       // Release tags
+	TagsLastYear     int       // Release tags created in the year before gathering
+	LatestTag        string    // Most recent tag
+	LatestTagDate    time.Time // When the most recent tag was created
+}
+
+// GatherSignals collects repository signals from dir. Missing files and failing
+// git or gh commands leave the corresponding signal empty; only an unreadable
+// directory is an error. A nil executor runs real commands.
+func GatherSignals(ctx context.Context, dir string, executor CommandExecutor) (*Signals, error) {
+	if executor == nil {
+		executor = &DefaultExecutor{}
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, &CollectorError{Message: "failed to read repository", Err: err}
+	}
+
+	s := &Signals{}
+	if path := findFile(dir, "README.md", "README", "README.rst", "README.txt"); path != "" {
+		s.Readme = readPrefix(path, maxReadmeBytes)
+	}
+	if path := findFile(dir, "LICENSE", "LICENSE.md", "LICENSE.txt", "COPYING"); path != "" {
+		s.License = detectLicense(readPrefix(path, maxReadmeBytes))
+	}
+	s.Contributing = findFile(dir, "CONTRIBUTING.md", ".github/CONTRIBUTING.md", "docs/CONTRIBUTING.md") != ""
+	s.CodeOfConduct = findFile(dir, "CODE_OF_CONDUCT.md", ".github/CODE_OF_CONDUCT.md") != ""
+	s.SecurityPolicy = findFile(dir, "SECURITY.md", ".github/SECURITY.md") != ""
+	s.CodeOwners = findFile(dir, "CODEOWNERS", ".github/CODEOWNERS", "docs/CODEOWNERS") != ""
+	s.Workflows = findWorkflows(dir)
+	s.SourceFiles, s.TestFiles = countSourceFiles(dir)
+	s.gatherTags(ctx, dir, executor, time.Now())
+	s.BranchProtection = branchProtection(ctx, dir, executor)
+	return s, nil
+}
+
+// TestRatio returns test files per source file, or 0 without source files.
+func (s *Signals) TestRatio() float64 {
+	if s.SourceFiles == 0 {
+		return 0
+	}
+	return float64(s.TestFiles) / float64(s.SourceFiles)
+}
+
+// Summary renders the signals as a Markdown list, as shown to Claude and the user.
+func (s *Signals) Summary() string {
+	var b strings.Builder
+	yesNo := func(ok bool) string {
+		if ok {
+			return "yes"
+		}
+		return "no"
+	}
+	orNone := func(v string) string {
+		if v == "" {
+			return "none"
+		}
+		return v
+	}
+
+	fmt.Fprintf(&b, "- License: %s\n", orNone(s.License))
+	fmt.Fprintf(&b, "- CONTRIBUTING guide: %s\n", yesNo(s.Contributing))
+	fmt.Fprintf(&b, "- Code of conduct: %s\n", yesNo(s.CodeOfConduct))
+	fmt.Fprintf(&b, "- Security policy: %s\n", yesNo(s.SecurityPolicy))
+	fmt.Fprintf(&b, "- CODEOWNERS: %s\n", yesNo(s.CodeOwners))
+	fmt.Fprintf(&b, "- CI workflows: %s\n", orNone(strings.Join(s.Workflows, ", ")))
+	fmt.Fprintf(&b, "- Source files: %d, of which tests: %d (ratio %.2f)\n", s.SourceFiles, s.TestFiles, s.TestRatio())
+	protection := s.BranchProtection
+	if protection == "" {
+		protection = "unknown"
+	}
+	fmt.Fprintf(&b, "- Default branch protection: %s\n", protection)
+	if s.Tags == 0 {
+		fmt.Fprintf(&b, "- Release tags: none\n")
+	} else {
+		fmt.Fprintf(&b, "- Release tags: %d, %d in the last year, latest %s (%s)\n",
+			s.Tags, s.TagsLastYear, s.LatestTag, s.LatestTagDate.Format("2006-01-02"))
+	}
+	return b.String()
+}
+
+// findFile returns the first of names that exists under dir, or "".
+func findFile(dir string, names ...string) string {
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// readPrefix returns up to n bytes of a file.
+func readPrefix(path string, n int) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	if len(data) > n {
+		data = data[:n]
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// licenseMarkers maps license text to a license name, most specific first.
+var licenseMarkers = []struct{ marker, name string }{
+	{"GNU AFFERO GENERAL PUBLIC LICENSE", "AGPL"},
+	{"GNU LESSER GENERAL PUBLIC LICENSE", "LGPL"},
+	{"GNU GENERAL PUBLIC LICENSE", "GPL"},
+	{"Mozilla Public License", "MPL"},
+	{"Apache License", "Apache-2.0"},
+	{"MIT License", "MIT"},
+	{"Permission is hereby granted, free of charge", "MIT"},
+	{"ISC License", "ISC"},
+	{"Redistribution and use in source and binary forms", "BSD"},
+	{"This is free and unencumbered software", "Unlicense"},
+}
+
+// detectLicense names the license in text.
+func detectLicense(text string) string {
+	for _, m := range licenseMarkers {
+		if strings.Contains(text, m.marker) {
+			return m.name
+		}
+	}
+	return "proprietary or unrecognized"
+}
+
+// findWorkflows lists CI configuration files in dir.
+func findWorkflows(dir string) []string {
+	var workflows []string
+	for _, pattern := range []string{".github/workflows/*.yml", ".github/workflows/*.yaml"} {
+		matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+		for _, m := range matches {
+			rel, _ := filepath.Rel(dir, m)
+			workflows = append(workflows, filepath.ToSlash(rel))
+		}
+	}
+	for _, name := range []string{".gitlab-ci.yml", ".circleci/config.yml", "Jenkinsfile", ".travis.yml", "azure-pipelines.yml"} {
+		if findFile(dir, name) != "" {
+			workflows = append(workflows, name)
+		}
+	}
+	return workflows
+}
+
+// sourceExtensions are the file extensions counted as source code.
+var sourceExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true,
+	".rb": true, ".rs": true, ".java": true, ".kt": true, ".swift": true, ".scala": true,
+	".c": true, ".cc": true, ".cpp": true, ".h": true, ".hpp": true, ".cs": true, ".php": true,
+	".ex": true, ".exs": true, ".dart": true,
+}
+
+// skippedDirs are directories that hold dependencies or build output.
+var skippedDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "dist": true, "build": true, "target": true, "third_party": true,
+}
+
+// countSourceFiles counts source files in dir, and how many of them are tests.
+func countSourceFiles(dir string) (source, tests int) {
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != dir && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !sourceExtensions[filepath.Ext(name)] {
+			return nil
+		}
+		source++
+		if isTestFile(path, name) {
+			tests++
+		}
+		return nil
+	})
+	return source, tests
+}
+
+// isTestFile reports whether a source file is a test by common naming conventions.
+func isTestFile(path, name string) bool {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	switch {
+	case strings.HasSuffix(base, "_test"), strings.HasSuffix(base, ".test"), strings.HasSuffix(base, ".spec"),
+		strings.HasPrefix(base, "test_"), strings.HasSuffix(base, "Test"), strings.HasSuffix(base, "Tests"):
+		return true
+	}
+	for _, part := range strings.Split(filepath.ToSlash(filepath.Dir(path)), "/") {
+		if part == "test" || part == "tests" || part == "__tests__" || part == "spec" {
+			return true
+		}
+	}
+	return false
+}
+
+// gatherTags reads release tags and their creation dates from git.
+func (s *Signals) gatherTags(ctx context.Context, dir string, executor CommandExecutor, now time.Time) {
+	out, err := run(ctx, dir, executor, "git", "tag", "--sort=-creatordate", "--format=%(refname:short) %(creatordate:short)")
+	if err != nil {
+		return
+	}
+	yearAgo := now.AddDate(-1, 0, 0)
+	for _, line := range strings.Split(out, "\n") {
+		name, date, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		created, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			continue
+		}
+		if s.Tags == 0 {
+			s.LatestTag, s.LatestTagDate = name, created
+		}
+		s.Tags++
+		if created.After(yearAgo) {
+			s.TagsLastYear++
+		}
+	}
+}
+
+// protectionResponse is the part of GitHub's branch protection response used as a signal.
+type protectionResponse struct {
+	RequiredPullRequestReviews *struct {
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+	} `json:"required_pull_request_reviews"`
+	RequiredStatusChecks *struct {
+		Contexts []string `json:"contexts"`
+	} `json:"required_status_checks"`
+	EnforceAdmins *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"enforce_admins"`
+	RequiredSignatures *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"required_signatures"`
+}
+
+// branchProtection summarizes the default branch's protection using the gh CLI.
+func branchProtection(ctx context.Context, dir string, executor CommandExecutor) string {
+	branch, err := run(ctx, dir, executor, "gh", "repo", "view", "--json", "defaultBranchRef", "--jq", ".defaultBranchRef.name")
+	if err != nil || branch == "" {
+		return ""
+	}
+	out, err := run(ctx, dir, executor, "gh", "api", "repos/{owner}/{repo}/branches/"+branch+"/protection")
+	if err != nil {
+		if strings.Contains(out+err.Error(), "Branch not protected") {
+			return "none"
+		}
+		return ""
+	}
+	var resp protectionResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		return ""
+	}
+
+	var rules []string
+	if r := resp.RequiredPullRequestReviews; r != nil {
+		rules = append(rules, fmt.Sprintf("%d required reviews", r.RequiredApprovingReviewCount))
+		if r.RequireCodeOwnerReviews {
+			rules = append(rules, "code owner review")
+		}
+	}
+	if c := resp.RequiredStatusChecks; c != nil {
+		rules = append(rules, fmt.Sprintf("%d required status checks", len(c.Contexts)))
+	}
+	if resp.EnforceAdmins != nil && resp.EnforceAdmins.Enabled {
+		rules = append(rules, "enforced for admins")
+	}
+	if resp.RequiredSignatures != nil && resp.RequiredSignatures.Enabled {
+		rules = append(rules, "signed commits")
+	}
+	if len(rules) == 0 {
+		return branch + " protected"
+	}
+	return branch + ": " + strings.Join(rules, ", ")
+}
+
+// run runs a command in dir and returns its trimmed stdout. On failure the
+// returned output holds stderr.
+func run(ctx context.Context, dir string, executor CommandExecutor, name string, args ...string) (string, error) {
+	cmd := executor.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return strings.TrimSpace(stderr.String()), err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
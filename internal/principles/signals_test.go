@@ -0,0 +1,136 @@
+package principles
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockExecutor answers commands by their first argument, failing the rest.
+type mockExecutor struct {
+	outputs map[string]string // "git tag" -> stdout
+	errors  map[string]string // "gh api" -> stderr, exit 1
+}
+
+func (m *mockExecutor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	key := name + " " + args[0]
+	if out, ok := m.outputs[key]; ok {
+		return exec.CommandContext(ctx, "printf", "%s", out)
+	}
+	if msg, ok := m.errors[key]; ok {
+		return exec.CommandContext(ctx, "sh", "-c", "printf '%s' \"$0\" >&2; exit 1", msg)
+	}
+	return exec.CommandContext(ctx, "false")
+}
+
+func writeRepoFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestGatherSignals(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeRepoFile(t, dir, "README.md", "# Widget\n\nA library for widgets.")
+	writeRepoFile(t, dir, "LICENSE", "Apache License\nVersion 2.0, January 2004")
+	writeRepoFile(t, dir, "CONTRIBUTING.md", "PRs welcome")
+	writeRepoFile(t, dir, ".github/CODEOWNERS", "* @acme/core")
+	writeRepoFile(t, dir, ".github/workflows/ci.yml", "on: push")
+	writeRepoFile(t, dir, "widget.go", "package widget")
+	writeRepoFile(t, dir, "widget_test.go", "package widget")
+	writeRepoFile(t, dir, "web/app.ts", "")
+	writeRepoFile(t, dir, "web/__tests__/app.ts", "")
+	writeRepoFile(t, dir, "node_modules/dep/index.js", "")
+
+	lastMonth := time.Now().AddDate(0, -1, 0).Format("2006-01-02")
+	executor := &mockExecutor{
+		outputs: map[string]string{
+			"git tag": "v1.2.0 " + lastMonth + "\nv1.0.0 2019-05-01\n",
+			"gh repo": "main\n",
+			"gh api":  `{"required_pull_request_reviews":{"required_approving_review_count":2},"required_status_checks":{"contexts":["ci"]},"enforce_admins":{"enabled":true}}`,
+		},
+	}
+
+	s, err := GatherSignals(context.Background(), dir, executor)
+	require.NoError(t, err)
+
+	assert.Contains(t, s.Readme, "A library for widgets.")
+	assert.Equal(t, "Apache-2.0", s.License)
+	assert.True(t, s.Contributing)
+	assert.True(t, s.CodeOwners)
+	assert.False(t, s.SecurityPolicy)
+	assert.Equal(t, []string{".github/workflows/ci.yml"}, s.Workflows)
+	assert.Equal(t, 4, s.SourceFiles)
+	assert.Equal(t, 2, s.TestFiles)
+	assert.Equal(t, 0.5, s.TestRatio())
+	assert.Equal(t, 2, s.Tags)
+	assert.Equal(t, 1, s.TagsLastYear)
+	assert.Equal(t, "v1.2.0", s.LatestTag)
+	assert.Equal(t, "main: 2 required reviews, 1 required status checks, enforced for admins", s.BranchProtection)
+}
+
+func TestGatherSignals_EmptyRepository(t *testing.T) {
+	t.Parallel()
+
+	s, err := GatherSignals(context.Background(), t.TempDir(), &mockExecutor{})
+	require.NoError(t, err)
+
+	assert.Empty(t, s.Readme)
+	assert.Empty(t, s.License)
+	assert.Empty(t, s.Workflows)
+	assert.Zero(t, s.Tags)
+	assert.Empty(t, s.BranchProtection)
+	assert.Zero(t, s.TestRatio())
+
+	summary := s.Summary()
+	assert.Contains(t, summary, "- License: none\n")
+	assert.Contains(t, summary, "- Default branch protection: unknown\n")
+	assert.Contains(t, summary, "- Release tags: none\n")
+
+	_, err = GatherSignals(context.Background(), filepath.Join(t.TempDir(), "missing"), &mockExecutor{})
+	assert.Error(t, err)
+}
+
+func TestGatherSignals_UnprotectedBranch(t *testing.T) {
+	t.Parallel()
+
+	executor := &mockExecutor{
+		outputs: map[string]string{"gh repo": "main"},
+		errors:  map[string]string{"gh api": "Branch not protected (HTTP 404)"},
+	}
+	s, err := GatherSignals(context.Background(), t.TempDir(), executor)
+	require.NoError(t, err)
+	assert.Equal(t, "none", s.BranchProtection)
+}
+
+func TestDetectLicense(t *testing.T) {
+	tests := map[string]string{
+		"MIT License\n\nCopyright (c) 2024":                 "MIT",
+		"GNU LESSER GENERAL PUBLIC LICENSE\nVersion 3":      "LGPL",
+		"GNU GENERAL PUBLIC LICENSE\nVersion 3":             "GPL",
+		"Redistribution and use in source and binary forms": "BSD",
+		"Copyright Acme Corp. All rights reserved.":         "proprietary or unrecognized",
+	}
+	for text, want := range tests {
+		assert.Equal(t, want, detectLicense(text), strings.SplitN(text, "\n", 2)[0])
+	}
+}
+
+func TestIsTestFile(t *testing.T) {
+	assert.True(t, isTestFile("pkg/a_test.go", "a_test.go"))
+	assert.True(t, isTestFile("src/a.spec.ts", "a.spec.ts"))
+	assert.True(t, isTestFile("test_a.py", "test_a.py"))
+	assert.True(t, isTestFile("src/WidgetTest.java", "WidgetTest.java"))
+	assert.True(t, isTestFile("tests/helpers.py", "helpers.py"))
+	assert.False(t, isTestFile("src/contest.go", "contest.go"))
+}
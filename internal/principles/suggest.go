@@ -0,0 +1,159 @@
+package principles
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+)
+
+// ClaudeClient defines the interface for Claude execution.
+type ClaudeClient interface {
+	Execute(ctx context.Context, prompt string) (*IterationResult, error)
+}
+
+// IterationResult mirrors loop.IterationResult to avoid import cycles.
+type IterationResult struct {
+	Output string
+	Cost   float64
+}
+
+// TemplateSuggest is the prompt asking Claude to propose principles from repository signals.
+const TemplateSuggest = `Propose project principles for claude-loop, an autonomous coding loop, based on
+the signals below gathered from the repository.
+
+## Repository Signals
+%s
+## README (start)
+%s
+
+## Principles
+Each principle is scored 1-10:
+%s
+## Instructions
+1. Choose the closest preset: startup (fast validation, small scope), enterprise
+   (stability, compliance, large teams) or opensource (community contributions,
+   API stability).%s
+2. Propose a value for every principle, starting from the preset and adjusting
+   only where the signals justify it.
+3. Give a one-sentence rationale per principle that cites the signal it rests on.
+
+## Response Format
+Reply with only this YAML block:
+` + "```yaml" + `
+preset: <%s>
+principles:
+  <key>:
+    value: <1-10>
+    rationale: <one sentence>
+` + "```"
+
+// Suggestion is a proposed principles file with the reasoning behind each value.
+type Suggestion struct {
+	Principles *config.Principles
+	Rationale  map[string]string // Keyed by full principle key; preset defaults have none
+	Cost       float64
+}
+
+// Suggester asks Claude to propose principles from repository signals.
+type Suggester struct {
+	client  ClaudeClient
+	presets *config.PresetRegistry
+}
+
+// NewSuggester creates a new Suggester choosing among the built-in presets and
+// those in presets, which may be nil.
+func NewSuggester(client ClaudeClient, presets *config.PresetRegistry) *Suggester {
+	return &Suggester{client: client, presets: presets}
+}
+
+// BuildSuggestPrompt renders the suggestion prompt for signals.
+func BuildSuggestPrompt(signals *Signals, presets *config.PresetRegistry) string {
+	var scale strings.Builder
+	for _, key := range config.PrincipleKeys() {
+		info, _ := config.DescribePrinciple(key)
+		fmt.Fprintf(&scale, "- %s: %s (1-3 %s, 7-10 %s)\n", key, info.Description, info.Low, info.High)
+	}
+	readme := signals.Readme
+	if readme == "" {
+		readme = "(no README)"
+	}
+	names := presets.Names()
+	choices := make([]string, len(names))
+	for i, name := range names {
+		choices[i] = string(name)
+	}
+	var custom strings.Builder
+	if defs := presets.Definitions(); len(defs) > 0 {
+		custom.WriteString("\n   The team also defines these presets; prefer one when it fits:")
+		for _, d := range defs {
+			fmt.Fprintf(&custom, "\n   - %s", describeCustomPreset(d))
+		}
+	}
+	return fmt.Sprintf(TemplateSuggest, signals.Summary(), readme, scale.String(), custom.String(), strings.Join(choices, "|"))
+}
+
+// Suggest gathers Claude's proposal for signals. Principles Claude leaves out keep
+// the preset's default.
+func (s *Suggester) Suggest(ctx context.Context, signals *Signals) (*Suggestion, error) {
+	result, err := s.client.Execute(ctx, BuildSuggestPrompt(signals, s.presets))
+	if err != nil {
+		return nil, &CollectorError{Message: "suggestion failed", Err: err}
+	}
+	suggestion, err := ParseSuggestion(result.Output, s.presets)
+	if err != nil {
+		return nil, err
+	}
+	suggestion.Cost = result.Cost
+	return suggestion, nil
+}
+
+// yamlBlockRegex matches a fenced YAML block.
+var yamlBlockRegex = regexp.MustCompile("(?s)```(?:yaml|yml)?\\s*\\n(.*?)```")
+
+// suggestionResponse is the YAML Claude is asked to reply with.
+type suggestionResponse struct {
+	Preset     string `yaml:"preset"`
+	Principles map[string]struct {
+		Value     int    `yaml:"value"`
+		Rationale string `yaml:"rationale"`
+	} `yaml:"principles"`
+}
+
+// ParseSuggestion parses Claude's reply into a Suggestion. The YAML may be fenced
+// or bare; presets outside presets, unknown keys and out-of-range values are errors.
+func ParseSuggestion(output string, presets *config.PresetRegistry) (*Suggestion, error) {
+	text := output
+	if m := yamlBlockRegex.FindStringSubmatch(output); m != nil {
+		text = m[1]
+	}
+	var resp suggestionResponse
+	if err := yaml.Unmarshal([]byte(text), &resp); err != nil {
+		return nil, &CollectorError{Message: "suggestion is not valid YAML", Err: err}
+	}
+
+	preset := config.Preset(strings.ToLower(strings.TrimSpace(resp.Preset)))
+	if !presets.HasDefaults(preset) {
+		return nil, &CollectorError{Message: fmt.Sprintf("suggestion has unknown preset %q", resp.Preset)}
+	}
+
+	p := presets.Defaults(preset)
+	p.CreatedAt = time.Now().Format("2006-01-02")
+	suggestion := &Suggestion{Principles: p, Rationale: make(map[string]string)}
+	for key, entry := range resp.Principles {
+		full, ok := config.NormalizePrincipleKey(key)
+		if !ok {
+			return nil, &CollectorError{Message: fmt.Sprintf("suggestion has unknown principle %q", key)}
+		}
+		if err := p.SetPrinciple(full, entry.Value); err != nil {
+			return nil, &CollectorError{Message: "suggestion has an invalid value", Err: err}
+		}
+		suggestion.Rationale[full] = strings.TrimSpace(entry.Rationale)
+	}
+	return suggestion, nil
+}
@@ -0,0 +1,109 @@
+package principles
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient returns a canned reply and records the prompt.
+type fakeClient struct {
+	output string
+	cost   float64
+	err    error
+	prompt string
+}
+
+func (f *fakeClient) Execute(ctx context.Context, prompt string) (*IterationResult, error) {
+	f.prompt = prompt
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &IterationResult{Output: f.output, Cost: f.cost}, nil
+}
+
+const suggestionReply = "Based on the signals:\n\n```yaml\n" + `preset: opensource
+principles:
+  interoperability:
+    value: 9
+    rationale: Apache-2.0 library with public API
+  layer1.blast_radius:
+    value: 9
+    rationale: Branch protection requires 2 reviews
+` + "```\n"
+
+func TestParseSuggestion(t *testing.T) {
+	s, err := ParseSuggestion(suggestionReply, nil)
+	require.NoError(t, err)
+
+	p := s.Principles
+	assert.Equal(t, config.PresetOpenSource, p.Preset)
+	assert.Equal(t, 9, p.Layer0.Interoperability)
+	assert.Equal(t, 9, p.Layer1.BlastRadius)
+	assert.Equal(t, config.DefaultPrinciples(config.PresetOpenSource).Layer1.SecurityPosture, p.Layer1.SecurityPosture)
+	assert.NoError(t, p.Validate())
+	assert.Equal(t, "Apache-2.0 library with public API", s.Rationale["layer0.interoperability"])
+	assert.Len(t, s.Rationale, 2)
+}
+
+func TestParseSuggestion_Errors(t *testing.T) {
+	tests := []struct {
+		name, output, wantErr string
+	}{
+		{"not yaml", "preset: [", "not valid YAML"},
+		{"unknown preset", "preset: agency", `unknown preset "agency"`},
+		{"unknown principle", "preset: startup\nprinciples:\n  velocity: {value: 5}", `unknown principle "velocity"`},
+		{"out of range", "preset: startup\nprinciples:\n  blast_radius: {value: 12}", "must be between 1 and 10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSuggestion(tt.output, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSuggester_Suggest(t *testing.T) {
+	client := &fakeClient{output: suggestionReply, cost: 0.02}
+	signals := &Signals{Readme: "# Widget", License: "Apache-2.0", Workflows: []string{".github/workflows/ci.yml"}}
+
+	s, err := NewSuggester(client, nil).Suggest(context.Background(), signals)
+	require.NoError(t, err)
+	assert.Equal(t, 0.02, s.Cost)
+	assert.Equal(t, config.PresetOpenSource, s.Principles.Preset)
+
+	assert.Contains(t, client.prompt, "- License: Apache-2.0\n")
+	assert.Contains(t, client.prompt, "# Widget")
+	assert.Contains(t, client.prompt, "- layer1.security_posture: ")
+	assert.Contains(t, client.prompt, "(1-3 Basic, 7-10 Maximum security)")
+
+	client.err = errors.New("exit 1")
+	_, err = NewSuggester(client, nil).Suggest(context.Background(), signals)
+	assert.ErrorContains(t, err, "suggestion failed: exit 1")
+}
+
+func TestBuildSuggestPrompt_NoReadme(t *testing.T) {
+	assert.Contains(t, BuildSuggestPrompt(&Signals{}, nil), "(no README)")
+}
+
+func TestSuggest_CustomPreset(t *testing.T) {
+	presets := fintechPresets(t)
+
+	prompt := BuildSuggestPrompt(&Signals{}, presets)
+	assert.Contains(t, prompt, "- regulated-fintech - Payments under PCI DSS (extends enterprise)")
+	assert.Contains(t, prompt, "preset: <startup|enterprise|opensource|regulated-fintech>")
+
+	s, err := ParseSuggestion("preset: regulated-fintech\nprinciples:\n  urgency_tiers: {value: 3}", presets)
+	require.NoError(t, err)
+	assert.Equal(t, config.Preset("regulated-fintech"), s.Principles.Preset)
+	assert.Equal(t, 10, s.Principles.Layer1.SecurityPosture)
+	assert.Equal(t, 3, s.Principles.Layer1.UrgencyTiers)
+
+	_, err = ParseSuggestion("preset: regulated-fintech", nil)
+	assert.ErrorContains(t, err, `unknown preset "regulated-fintech"`)
+}
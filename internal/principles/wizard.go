@@ -0,0 +1,286 @@
+package principles
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+)
+
+// layerTitles names the principle layers, keyed by key prefix.
+var layerTitles = map[string]string{
+	"layer0": "Layer 0 - Product Principles",
+	"layer1": "Layer 1 - Development Principles",
+}
+
+// Wizard walks through every principle, grouped by layer, and saves the result.
+// Unlike Collect, it re-asks on invalid input, can go back or skip a layer, and
+// edits an existing file with its current values as defaults.
+type Wizard struct {
+	principlesPath string
+	reader         *bufio.Reader
+	out            io.Writer
+	presets        *config.PresetRegistry
+}
+
+// NewWizard creates a new Wizard reading answers from in and writing prompts to out.
+// Custom presets come from presets, which may be nil.
+func NewWizard(principlesPath string, in io.Reader, out io.Writer, presets *config.PresetRegistry) *Wizard {
+	return &Wizard{
+		principlesPath: principlesPath,
+		reader:         bufio.NewReader(in),
+		out:            out,
+		presets:        presets,
+	}
+}
+
+// Run asks every principle, shows a summary and saves the file once confirmed.
+// An existing file is edited in place; otherwise the values start from preset,
+// which is asked for when empty. Returns nil principles when the user cancels.
+func (w *Wizard) Run(ctx context.Context, preset config.Preset) (*config.Principles, error) {
+	p, editing, err := w.start(ctx, preset)
+	if err != nil {
+		return nil, err
+	}
+	defaults := w.presets.Defaults(p.Preset)
+
+	keys := config.PrincipleKeys()
+	for i := 0; i < len(keys); {
+		if i == 0 || layerOf(keys[i]) != layerOf(keys[i-1]) {
+			fmt.Fprintf(w.out, "\n== %s ==\n", layerTitles[layerOf(keys[i])])
+		}
+		fmt.Fprintf(w.out, "\n[%d/%d] ", i+1, len(keys))
+		answer, err := w.askPrinciple(ctx, p, defaults, keys[i], true)
+		if err != nil {
+			return nil, err
+		}
+		switch answer {
+		case answerBack:
+			if i == 0 {
+				fmt.Fprintln(w.out, "  Already at the first principle.")
+				continue
+			}
+			i--
+		case answerSkip:
+			i = nextLayer(keys, i)
+		default:
+			i++
+		}
+	}
+
+	for {
+		w.writeSummary(p, defaults, editing)
+		fmt.Fprintf(w.out, "Save to %s? [Y/n, or a principle key to change it]: ", w.principlesPath)
+		input, err := w.readLine(ctx)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(input) {
+		case "", "y", "yes":
+			if err := w.save(p); err != nil {
+				return nil, err
+			}
+			return p, nil
+		case "n", "no":
+			fmt.Fprintln(w.out, "Cancelled; nothing written.")
+			return nil, nil
+		}
+		key, ok := config.NormalizePrincipleKey(input)
+		if !ok {
+			fmt.Fprintf(w.out, "  Unknown principle %q.\n", input)
+			continue
+		}
+		fmt.Fprintln(w.out)
+		if _, err := w.askPrinciple(ctx, p, defaults, key, false); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// start loads the file being edited, or asks for the preset of a new one.
+func (w *Wizard) start(ctx context.Context, preset config.Preset) (*config.Principles, bool, error) {
+	if _, err := os.Stat(w.principlesPath); err == nil {
+		p, _, err := config.LoadAndMigrate(w.principlesPath)
+		if err != nil {
+			return nil, false, err
+		}
+		fmt.Fprintf(w.out, "Editing %s (preset %s). Press Enter to keep a current value.\n", w.principlesPath, p.Preset)
+		return p, true, nil
+	}
+
+	if preset == "" {
+		var err error
+		if preset, err = w.askPreset(ctx); err != nil {
+			return nil, false, err
+		}
+	}
+	p := w.presets.Defaults(preset)
+	p.CreatedAt = time.Now().Format("2006-01-02")
+	fmt.Fprintf(w.out, "Starting from the %s preset. Press Enter to keep its value.\n", preset)
+	return p, false, nil
+}
+
+// askPreset asks for the project type until it gets a valid answer.
+func (w *Wizard) askPreset(ctx context.Context) (config.Preset, error) {
+	presets := w.presets.Names()
+	fmt.Fprintln(w.out, "Select project type:")
+	fmt.Fprintln(w.out, "  1) Startup/MVP - Fast validation, focus on core features")
+	fmt.Fprintln(w.out, "  2) Enterprise - Stability first, thorough testing")
+	fmt.Fprintln(w.out, "  3) Open Source - Community contributions, API stability")
+	for i, d := range w.presets.Definitions() {
+		fmt.Fprintf(w.out, "  %d) %s\n", i+4, describeCustomPreset(d))
+	}
+	for {
+		fmt.Fprint(w.out, "> [1]: ")
+		input, err := w.readLine(ctx)
+		if err != nil {
+			return "", err
+		}
+		if input == "" {
+			return config.PresetStartup, nil
+		}
+		for i, preset := range presets {
+			if input == strconv.Itoa(i+1) || strings.EqualFold(input, string(preset)) {
+				return preset, nil
+			}
+		}
+		fmt.Fprintf(w.out, "  Enter a number from 1 to %d or a preset name.\n", len(presets))
+	}
+}
+
+// wizardAnswer is what the user did at a principle question.
+type wizardAnswer int
+
+const (
+	answerValue wizardAnswer = iota // Kept or entered a value
+	answerBack                      // Went back to the previous principle
+	answerSkip                      // Skipped the rest of the layer
+)
+
+// askPrinciple describes a principle and asks for its value until the answer is
+// valid. With navigation, "b" and "s" go back or skip the rest of the layer.
+func (w *Wizard) askPrinciple(ctx context.Context, p, defaults *config.Principles, key string, navigation bool) (wizardAnswer, error) {
+	info, err := config.DescribePrinciple(key)
+	if err != nil {
+		return 0, err
+	}
+	current, _ := p.Principle(key)
+	preset, _ := defaults.Principle(key)
+
+	fmt.Fprintf(w.out, "%s (%s)\n", info.Title, info.Key)
+	fmt.Fprintf(w.out, "  %s\n", info.Description)
+	fmt.Fprintf(w.out, "  1  = %s: %s\n", info.Low, info.LowExample)
+	fmt.Fprintf(w.out, "  10 = %s: %s\n", info.High, info.HighExample)
+	fmt.Fprintf(w.out, "  Preset default (%s): %d\n", defaults.Preset, preset)
+
+	hint := ""
+	if navigation {
+		hint = ", b = back, s = skip layer"
+	}
+	for {
+		fmt.Fprintf(w.out, "Value 1-10 [%d]%s: ", current, hint)
+		input, err := w.readLine(ctx)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case input == "":
+			return answerValue, nil
+		case navigation && strings.EqualFold(input, "b"):
+			return answerBack, nil
+		case navigation && strings.EqualFold(input, "s"):
+			return answerSkip, nil
+		}
+		value, err := strconv.Atoi(input)
+		if err == nil {
+			if err := p.SetPrinciple(key, value); err == nil {
+				return answerValue, nil
+			}
+		}
+		fmt.Fprintf(w.out, "  Enter a number from 1 to 10%s.\n", hint)
+	}
+}
+
+// writeSummary prints every value, marking those that differ from the preset.
+func (w *Wizard) writeSummary(p, defaults *config.Principles, editing bool) {
+	verb := "create"
+	if editing {
+		verb = "update"
+	}
+	fmt.Fprintf(w.out, "\nSummary: %s %s (preset %s)\n", verb, w.principlesPath, p.Preset)
+	layer := ""
+	for _, d := range allValues(p, defaults) {
+		if l := layerOf(d.Key); l != layer {
+			layer = l
+			fmt.Fprintf(w.out, "  %s\n", layerTitles[l])
+		}
+		if d.Value == d.Baseline {
+			fmt.Fprintf(w.out, "    %-30s %2d\n", d.Key, d.Value)
+		} else {
+			fmt.Fprintf(w.out, "    %-30s %2d  (%s: %d)\n", d.Key, d.Value, defaults.Preset, d.Baseline)
+		}
+	}
+}
+
+// save validates and writes the principles.
+func (w *Wizard) save(p *config.Principles) error {
+	if p.CreatedAt == "" {
+		p.CreatedAt = time.Now().Format("2006-01-02")
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if err := config.SaveToFile(w.principlesPath, p); err != nil {
+		return &CollectorError{Message: "failed to save principles file", Err: err}
+	}
+	fmt.Fprintf(w.out, "Wrote %s\n", w.principlesPath)
+	return nil
+}
+
+// readLine reads one trimmed line. Input that ends before an answer is an error,
+// so a closed stdin never saves a half-answered file.
+func (w *Wizard) readLine(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", &CollectorError{Message: "cancelled", Err: ctx.Err()}
+	default:
+	}
+	line, err := w.reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", &CollectorError{Message: "input ended before the wizard finished", Err: err}
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// allValues returns every principle with its preset default as Baseline.
+func allValues(p, defaults *config.Principles) []config.PrincipleDiff {
+	keys := config.PrincipleKeys()
+	values := make([]config.PrincipleDiff, len(keys))
+	for i, key := range keys {
+		value, _ := p.Principle(key)
+		preset, _ := defaults.Principle(key)
+		values[i] = config.PrincipleDiff{Key: key, Value: value, Baseline: preset}
+	}
+	return values
+}
+
+// layerOf returns a key's layer prefix, such as "layer1".
+func layerOf(key string) string {
+	layer, _, _ := strings.Cut(key, ".")
+	return layer
+}
+
+// nextLayer returns the index of the first key after i in a different layer.
+func nextLayer(keys []string, i int) int {
+	layer := layerOf(keys[i])
+	for i < len(keys) && layerOf(keys[i]) == layer {
+		i++
+	}
+	return i
+}
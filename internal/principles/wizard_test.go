@@ -0,0 +1,143 @@
+package principles
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runWizard runs a wizard on a file in a temp dir with the given answers, one per line.
+func runWizard(t *testing.T, path string, preset config.Preset, answers ...string) (*config.Principles, string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	input := strings.Join(answers, "\n") + "\n"
+	p, err := NewWizard(path, strings.NewReader(input), &out, nil).Run(context.Background(), preset)
+	return p, out.String(), err
+}
+
+func TestWizard_NewFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), ".claude", "principles.yaml")
+
+	// Preset 2, then 18 answers: an invalid value that is re-asked, a value, Enter for the rest
+	answers := []string{"x", "2", "11", "8"}
+	for i := 1; i < 18; i++ {
+		answers = append(answers, "")
+	}
+	answers = append(answers, "")
+
+	p, out, err := runWizard(t, path, "", answers...)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "Enter a number from 1 to 3 or a preset name.")
+	assert.Contains(t, out, "== Layer 0 - Product Principles ==")
+	assert.Contains(t, out, "== Layer 1 - Development Principles ==")
+	assert.Contains(t, out, "[1/18] Trust Architecture (layer0.trust_architecture)")
+	assert.Contains(t, out, "1  = Permissive: Anyone can sign up and post immediately")
+	assert.Contains(t, out, "Preset default (enterprise): 9")
+	assert.Contains(t, out, "Enter a number from 1 to 10, b = back, s = skip layer.")
+	assert.Contains(t, out, "Summary: create "+path+" (preset enterprise)")
+	assert.Contains(t, out, "    layer0.trust_architecture       8  (enterprise: 9)\n")
+	assert.Contains(t, out, "Wrote "+path)
+
+	saved, err := config.LoadFromFile(path)
+	require.NoError(t, err)
+	require.NoError(t, saved.Validate())
+	assert.Equal(t, 8, saved.Layer0.TrustArchitecture)
+	assert.Equal(t, config.DefaultPrinciples(config.PresetEnterprise).Layer1, saved.Layer1)
+	assert.Equal(t, p.Layer0, saved.Layer0)
+}
+
+func TestWizard_BackAndSkip(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "principles.yaml")
+
+	answers := []string{
+		"b",  // already at the first principle
+		"3",  // trust_architecture
+		"b",  // back to trust_architecture
+		"4",  // trust_architecture again
+		"s",  // skip the rest of layer 0
+		"10", // speed_correctness
+		"s",  // skip the rest of layer 1
+		"",   // save
+	}
+	_, out, err := runWizard(t, path, config.PresetStartup, answers...)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Already at the first principle.")
+	assert.Contains(t, out, "[10/18] Speed vs Correctness")
+	assert.NotContains(t, out, "[3/18]")
+
+	saved, err := config.LoadFromFile(path)
+	require.NoError(t, err)
+	defaults := config.DefaultPrinciples(config.PresetStartup)
+	assert.Equal(t, 4, saved.Layer0.TrustArchitecture)
+	assert.Equal(t, defaults.Layer0.CurationModel, saved.Layer0.CurationModel)
+	assert.Equal(t, 10, saved.Layer1.SpeedCorrectness)
+	assert.Equal(t, defaults.Layer1.BlastRadius, saved.Layer1.BlastRadius)
+}
+
+func TestWizard_EditExisting(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "principles.yaml")
+	existing := config.DefaultPrinciples(config.PresetOpenSource)
+	existing.CreatedAt = "2025-06-01"
+	existing.Layer0.TrustArchitecture = 2
+	existing.Layer1.SecurityPosture = 10
+	require.NoError(t, config.SaveToFile(path, existing))
+
+	// Keep everything, then change one key from the summary before saving
+	answers := []string{"s", "s", "urgency", "urgency_tiers", "2", "y"}
+	_, out, err := runWizard(t, path, config.PresetEnterprise, answers...)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "Editing "+path+" (preset opensource)")
+	assert.Contains(t, out, "Value 1-10 [2], b = back, s = skip layer: ")
+	assert.Contains(t, out, `Unknown principle "urgency"`)
+	assert.Contains(t, out, "Summary: update "+path)
+	assert.Contains(t, out, "    layer1.security_posture        10  (opensource: 8)\n")
+
+	saved, err := config.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06-01", saved.CreatedAt)
+	assert.Equal(t, config.PresetOpenSource, saved.Preset)
+	assert.Equal(t, 2, saved.Layer0.TrustArchitecture)
+	assert.Equal(t, 10, saved.Layer1.SecurityPosture)
+	assert.Equal(t, 2, saved.Layer1.UrgencyTiers)
+}
+
+func TestWizard_CancelAndEndOfInput(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "principles.yaml")
+
+	p, out, err := runWizard(t, path, config.PresetStartup, "s", "s", "n")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+	assert.Contains(t, out, "Cancelled; nothing written.")
+	assert.NoFileExists(t, path)
+
+	_, _, err = runWizard(t, path, config.PresetStartup, "5")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "input ended before the wizard finished")
+	assert.NoFileExists(t, path)
+}
+
+func TestWizard_CustomPreset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "principles.yaml")
+
+	var out bytes.Buffer
+	input := strings.NewReader("regulated-fintech\ns\ns\n\n")
+	p, err := NewWizard(path, input, &out, fintechPresets(t)).Run(context.Background(), "")
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "  4) regulated-fintech - Payments under PCI DSS (extends enterprise)")
+	assert.Contains(t, out.String(), "layer1.blast_radius            10\n")
+	assert.Equal(t, config.Preset("regulated-fintech"), p.Preset)
+	assert.Equal(t, 10, p.Layer1.BlastRadius)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,6 +17,7 @@ type Builder interface {
 // DefaultBuilder implements the Builder interface.
 type DefaultBuilder struct {
 	notesLoader NotesLoader
+	templates   *TemplateSet
 }
 
 // NewBuilder creates a new DefaultBuilder with a FileNotesLoader.
@@ -25,6 +27,15 @@ func NewBuilder() *DefaultBuilder {
 	}
 }
 
+// NewBuilderWithTemplates creates a DefaultBuilder that renders the given
+// template overrides, falling back to built-in templates for the rest.
+func NewBuilderWithTemplates(templates *TemplateSet) *DefaultBuilder {
+	return &DefaultBuilder{
+		notesLoader: NewFileNotesLoader(),
+		templates:   templates,
+	}
+}
+
 // NewBuilderWithLoader creates a DefaultBuilder with a custom NotesLoader.
 // This is primarily useful for testing.
 func NewBuilderWithLoader(loader NotesLoader) *DefaultBuilder {
@@ -38,36 +49,84 @@ func NewBuilderWithLoader(loader NotesLoader) *DefaultBuilder {
 // 1. [Conditional] Decision principles (if Principles != nil)
 // 2. Workflow context (with CompletionSignal placeholder replaced)
 // 3. User prompt
-// 4. [Conditional] Notes from previous iteration (if file exists)
-// 5. Notes instructions (UPDATE or CREATE)
-// 6. Notes guidelines
+// 4. [Conditional] Runtime policy (if it sets any rules)
+// 5. [Conditional] Review or commit gate (if changes are checked before they are committed)
+// 6. [Conditional] Prior decisions (if any)
+// 7. [Conditional] Notes from previous iteration (if file exists)
+// 8. [Conditional] Verification failures (if any)
+// 9. [Conditional] Rejected changes (if any)
+// 10. [Conditional] Oversized change (if any)
+// 11. [Conditional] Blocked commit (if any)
+// 12. [Conditional] Review findings (if any)
+// 13. [Conditional] Conflict resolution (if any)
+// 14. [Conditional] Pending escalations (if any)
+// 15. [Conditional] Notes instructions, UPDATE or CREATE (if NotesFile is set)
+// 16. [Conditional] Notes guidelines (if NotesFile is set)
+//
+// Each section backed by a template can be overridden via the builder's TemplateSet.
 func (b *DefaultBuilder) Build(ctx BuildContext) (*BuildResult, error) {
 	var sb strings.Builder
 	result := &BuildResult{}
 
+	// Notes are loaded up front so every template sees the same data
+	notesContent, notesExists, err := b.notesLoader.Load(ctx.NotesFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notes: %w", err)
+	}
+
+	data := TemplateData{
+		Prompt:               ctx.UserPrompt,
+		Iteration:            ctx.Iteration,
+		CompletionSignal:     ctx.CompletionSignal,
+		Principles:           ctx.Principles,
+		Notes:                notesContent,
+		NotesFile:            ctx.NotesFile,
+		NotesExist:           notesExists,
+		VerificationFailures: ctx.VerificationFailures,
+		RejectedChanges:      ctx.RejectedChanges,
+		OversizedChange:      ctx.OversizedChange,
+		PriorDecisions:       ctx.PriorDecisions,
+		Resolution:           ctx.Resolution,
+		Escalations:          ctx.Escalations,
+		ReviewGate:           ctx.ReviewGate,
+		CommitGate:           ctx.CommitGate,
+		CommitChecks:         ctx.CommitChecks,
+		BlockedCommit:        ctx.BlockedCommit,
+		ReviewFindings:       ctx.ReviewFindings,
+		Policy:               ctx.Policy,
+		Branch:               ctx.Branch,
+	}
+
 	// 1. Decision Principles (if principles are loaded)
 	if ctx.Principles != nil {
 		principlesYAML, err := yaml.Marshal(ctx.Principles)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal principles: %w", err)
 		}
+		data.PrinciplesYAML = string(principlesYAML)
 
-		principlesPrompt := strings.ReplaceAll(
+		principlesPrompt, err := b.templates.Render(TemplateNameDecisionPrinciples, data, strings.ReplaceAll(
 			TemplateDecisionPrinciples,
 			PlaceholderPrinciplesYAML,
-			string(principlesYAML),
-		)
+			data.PrinciplesYAML,
+		))
+		if err != nil {
+			return nil, err
+		}
 		sb.WriteString(principlesPrompt)
 		sb.WriteString("\n\n")
 		result.PrinciplesInjected = true
 	}
 
 	// 2. Workflow Context (with completion signal replaced)
-	workflowContext := strings.ReplaceAll(
+	workflowContext, err := b.templates.Render(TemplateNameWorkflowContext, data, strings.ReplaceAll(
 		TemplateWorkflowContext,
 		PlaceholderCompletionSignal,
 		ctx.CompletionSignal,
-	)
+	))
+	if err != nil {
+		return nil, err
+	}
 	sb.WriteString(workflowContext)
 	sb.WriteString("\n\n")
 
@@ -75,41 +134,183 @@ func (b *DefaultBuilder) Build(ctx BuildContext) (*BuildResult, error) {
 	sb.WriteString(ctx.UserPrompt)
 	sb.WriteString("\n\n")
 
-	// 4. Notes from Previous Iteration (if file exists)
-	notesContent, notesExists, err := b.notesLoader.Load(ctx.NotesFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load notes: %w", err)
+	// 4. Runtime Policy (if the policy sets any rules)
+	if directives := policyDirectives(ctx.Policy); len(directives) > 0 {
+		sb.WriteString(TemplateRuntimePolicy)
+		for _, directive := range directives {
+			fmt.Fprintf(&sb, "- %s\n", directive)
+		}
+		sb.WriteString("\n")
+	}
+
+	// 5. Review or Commit Gate (if the changes are checked before they are committed)
+	if ctx.ReviewGate {
+		sb.WriteString(TemplateReviewGate)
+	} else if ctx.CommitGate {
+		sb.WriteString(TemplateCommitGate)
+		if checks := commitCheckDirectives(ctx.CommitChecks); len(checks) > 0 {
+			sb.WriteString(TemplateCommitChecks)
+			for _, check := range checks {
+				fmt.Fprintf(&sb, "- %s\n", check)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	// 6. Prior Decisions (if earlier decisions are relevant)
+	if len(ctx.PriorDecisions) > 0 {
+		sb.WriteString(TemplatePriorDecisions)
+		for _, decision := range ctx.PriorDecisions {
+			fmt.Fprintf(&sb, "- %s\n", decision)
+		}
+		sb.WriteString("\n")
 	}
 
+	// 7. Notes from Previous Iteration (if file exists)
 	if notesExists && notesContent != "" {
-		notesHeader := strings.ReplaceAll(
+		notesHeader, err := b.templates.Render(TemplateNameNotesContext, data, strings.ReplaceAll(
 			TemplateNotesContext,
 			PlaceholderNotesFile,
 			ctx.NotesFile,
-		)
+		))
+		if err != nil {
+			return nil, err
+		}
 		sb.WriteString(notesHeader)
 		sb.WriteString(notesContent)
 		sb.WriteString("\n\n")
 		result.NotesIncluded = true
 	}
 
-	// 5. Iteration Notes Instructions (only if NotesFile is specified)
+	// 8. Verification Failures (if the previous iteration failed checks)
+	if len(ctx.VerificationFailures) > 0 {
+		sb.WriteString(TemplateVerificationFailures)
+		for _, failure := range ctx.VerificationFailures {
+			fmt.Fprintf(&sb, "- %s\n", failure)
+		}
+		sb.WriteString("\n")
+	}
+
+	// 9. Rejected Changes (if the previous iteration touched protected paths)
+	if len(ctx.RejectedChanges) > 0 {
+		sb.WriteString(TemplateRejectedChanges)
+		for _, rejected := range ctx.RejectedChanges {
+			fmt.Fprintf(&sb, "- %s\n", rejected)
+		}
+		sb.WriteString("\n")
+	}
+
+	// 10. Oversized Change (if the previous iteration exceeded the change size limits)
+	if len(ctx.OversizedChange) > 0 {
+		sb.WriteString(TemplateOversizedChange)
+		for _, line := range ctx.OversizedChange {
+			fmt.Fprintf(&sb, "- %s\n", line)
+		}
+		sb.WriteString("\n")
+	}
+
+	// 11. Blocked Commit (if claude-loop refused to commit the previous iteration's changes)
+	if len(ctx.BlockedCommit) > 0 {
+		sb.WriteString(TemplateBlockedCommit)
+		for _, line := range ctx.BlockedCommit {
+			fmt.Fprintf(&sb, "- %s\n", line)
+		}
+		sb.WriteString("\n")
+	}
+
+	// 12. Review Findings (if the reviewer did not approve the previous iteration)
+	if len(ctx.ReviewFindings) > 0 {
+		sb.WriteString(TemplateReviewFindings)
+		for _, finding := range ctx.ReviewFindings {
+			fmt.Fprintf(&sb, "- %s\n", finding)
+		}
+		sb.WriteString("\n")
+	}
+
+	// 13. Conflict Resolution (if the previous iteration's conflict was resolved)
+	if len(ctx.Resolution) > 0 {
+		sb.WriteString(TemplateConflictResolution)
+		for _, line := range ctx.Resolution {
+			fmt.Fprintf(&sb, "- %s\n", line)
+		}
+		sb.WriteString("\n")
+	}
+
+	// 14. Pending Escalations (while conflicts await a human decision)
+	if len(ctx.Escalations) > 0 {
+		sb.WriteString(TemplatePendingEscalations)
+		for _, line := range ctx.Escalations {
+			fmt.Fprintf(&sb, "- %s\n", line)
+		}
+		sb.WriteString("\n")
+	}
+
+	// 15. Iteration Notes Instructions (only if NotesFile is specified)
 	if ctx.NotesFile != "" {
-		sb.WriteString(TemplateIterationNotes)
+		iterationNotes, err := b.templates.Render(TemplateNameIterationNotes, data, TemplateIterationNotes)
+		if err != nil {
+			return nil, err
+		}
+		sb.WriteString(iterationNotes)
 
-		notesTemplate := TemplateNotesCreateNew
+		name, notesTemplate := TemplateNameNotesCreateNew, TemplateNotesCreateNew
 		if notesExists {
-			notesTemplate = TemplateNotesUpdateExisting
+			name, notesTemplate = TemplateNameNotesUpdateExisting, TemplateNotesUpdateExisting
+		}
+		notesInstruction, err := b.templates.Render(name, data,
+			strings.ReplaceAll(notesTemplate, PlaceholderNotesFile, ctx.NotesFile))
+		if err != nil {
+			return nil, err
 		}
-		notesInstruction := strings.ReplaceAll(notesTemplate, PlaceholderNotesFile, ctx.NotesFile)
 		sb.WriteString(notesInstruction)
 	}
 
-	// 6. Notes Guidelines (only if NotesFile is specified)
+	// 16. Notes Guidelines (only if NotesFile is specified)
 	if ctx.NotesFile != "" {
-		sb.WriteString(TemplateNotesGuidelines)
+		guidelines, err := b.templates.Render(TemplateNameNotesGuidelines, data, TemplateNotesGuidelines)
+		if err != nil {
+			return nil, err
+		}
+		sb.WriteString(guidelines)
 	}
 
 	result.Prompt = sb.String()
 	return result, nil
 }
+
+// commitCheckDirectives describes the checks claude-loop applies before committing.
+func commitCheckDirectives(c CommitChecks) []string {
+	var checks []string
+	if c.Secrets {
+		checks = append(checks, "scans the changes for secrets and does not commit them while any are found")
+	}
+	if c.Verification {
+		checks = append(checks, "runs the verification checks and does not commit changes that fail them")
+	}
+	if c.BaseBranch != "" {
+		checks = append(checks, fmt.Sprintf("never commits to %s: work on it moves to a new branch first", c.BaseBranch))
+	}
+	return checks
+}
+
+// policyDirectives returns the prompt rules for a runtime policy.
+// Default settings add no rules.
+func policyDirectives(p *config.Policy) []string {
+	if p == nil {
+		return nil
+	}
+	var directives []string
+	switch p.Verification {
+	case config.VerificationStrict:
+		directives = append(directives, "Verification is strict: run the full build, linters and test suite before committing, and never commit while any of them fail")
+	case config.VerificationRelaxed:
+		directives = append(directives, "Verification is relaxed: a passing build and the tests for the code you changed are enough")
+	}
+	if p.DraftPRs {
+		directives = append(directives, "Open pull requests as drafts")
+	}
+	if !p.DirectPush {
+		directives = append(directives, "Never push directly to the main branch; push to a feature branch and open a pull request")
+	}
+	return directives
+}
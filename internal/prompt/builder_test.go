@@ -137,6 +137,220 @@ func TestBuilder_Build_WithoutPrinciples(t *testing.T) {
 	assert.Contains(t, result.Prompt, "CONTINUOUS WORKFLOW CONTEXT")
 }
 
+func TestBuilder_Build_WithRejectedChanges(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithLoader(&MockNotesLoader{Exists: false})
+
+	result, err := builder.Build(BuildContext{
+		UserPrompt:       "Fix the build",
+		CompletionSignal: "COMPLETE",
+		NotesFile:        "notes.md",
+		Iteration:        2,
+		RejectedChanges:  []string{`LICENSE (protected by "LICENSE")`},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "## REJECTED CHANGES")
+	assert.Contains(t, result.Prompt, `- LICENSE (protected by "LICENSE")`)
+	assert.Less(t, strings.Index(result.Prompt, "Fix the build"), strings.Index(result.Prompt, "REJECTED CHANGES"))
+	assert.Less(t, strings.Index(result.Prompt, "REJECTED CHANGES"), strings.Index(result.Prompt, "ITERATION NOTES"))
+
+	result, err = builder.Build(BuildContext{UserPrompt: "Fix the build", NotesFile: "notes.md"})
+	require.NoError(t, err)
+	assert.NotContains(t, result.Prompt, "REJECTED CHANGES")
+}
+
+func TestBuilder_Build_WithOversizedChange(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithLoader(&MockNotesLoader{Exists: false})
+
+	result, err := builder.Build(BuildContext{
+		UserPrompt:       "Fix the build",
+		CompletionSignal: "COMPLETE",
+		NotesFile:        "notes.md",
+		Iteration:        2,
+		RejectedChanges:  []string{`LICENSE (protected by "LICENSE")`},
+		OversizedChange:  []string{"Changed 42 files (limit 8)"},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "## CHANGE TOO LARGE")
+	assert.Contains(t, result.Prompt, "- Changed 42 files (limit 8)\n")
+	assert.Less(t, strings.Index(result.Prompt, "REJECTED CHANGES"), strings.Index(result.Prompt, "CHANGE TOO LARGE"))
+	assert.Less(t, strings.Index(result.Prompt, "CHANGE TOO LARGE"), strings.Index(result.Prompt, "ITERATION NOTES"))
+
+	result, err = builder.Build(BuildContext{UserPrompt: "Fix the build", NotesFile: "notes.md"})
+	require.NoError(t, err)
+	assert.NotContains(t, result.Prompt, "CHANGE TOO LARGE")
+}
+
+func TestBuilder_Build_WithPriorDecisions(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithLoader(&MockNotesLoader{Content: "Working on caching", Exists: true})
+
+	result, err := builder.Build(BuildContext{
+		UserPrompt:       "Fix the build",
+		CompletionSignal: "COMPLETE",
+		NotesFile:        "notes.md",
+		PriorDecisions:   []string{"Council, iteration 3: Cache results in memory"},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "## PRIOR DECISIONS")
+	assert.Contains(t, result.Prompt, "- Council, iteration 3: Cache results in memory\n")
+	assert.Less(t, strings.Index(result.Prompt, "Fix the build"), strings.Index(result.Prompt, "PRIOR DECISIONS"))
+	assert.Less(t, strings.Index(result.Prompt, "PRIOR DECISIONS"), strings.Index(result.Prompt, "Working on caching"))
+
+	result, err = builder.Build(BuildContext{UserPrompt: "Fix the build", NotesFile: "notes.md"})
+	require.NoError(t, err)
+	assert.NotContains(t, result.Prompt, "PRIOR DECISIONS")
+}
+
+func TestBuilder_Build_WithResolution(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithLoader(&MockNotesLoader{Content: "Working on login", Exists: true})
+
+	result, err := builder.Build(BuildContext{
+		UserPrompt:       "Fix the login bug",
+		CompletionSignal: "COMPLETE",
+		NotesFile:        "notes.md",
+		Resolution:       []string{"Conflict: urgency_tiers vs security_posture", "Decision (by the council): Audit first"},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "## CONFLICT RESOLUTION")
+	assert.Contains(t, result.Prompt, "- Conflict: urgency_tiers vs security_posture\n- Decision (by the council): Audit first\n")
+	assert.Less(t, strings.Index(result.Prompt, "Working on login"), strings.Index(result.Prompt, "CONFLICT RESOLUTION"))
+
+	result, err = builder.Build(BuildContext{UserPrompt: "Fix the login bug", NotesFile: "notes.md"})
+	require.NoError(t, err)
+	assert.NotContains(t, result.Prompt, "CONFLICT RESOLUTION")
+}
+
+func TestBuilder_Build_WithEscalations(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithLoader(&MockNotesLoader{Exists: false})
+
+	result, err := builder.Build(BuildContext{
+		UserPrompt:  "Fix the login bug",
+		NotesFile:   "notes.md",
+		Resolution:  []string{"Decision (by a human): Audit first"},
+		Escalations: []string{"Conflict between curation_model vs monetization_model from iteration 2"},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "## PENDING ESCALATIONS")
+	assert.Contains(t, result.Prompt, "- Conflict between curation_model vs monetization_model from iteration 2\n")
+	assert.Less(t, strings.Index(result.Prompt, "CONFLICT RESOLUTION"), strings.Index(result.Prompt, "PENDING ESCALATIONS"))
+
+	result, err = builder.Build(BuildContext{UserPrompt: "Fix the login bug", NotesFile: "notes.md"})
+	require.NoError(t, err)
+	assert.NotContains(t, result.Prompt, "PENDING ESCALATIONS")
+}
+
+func TestBuilder_Build_WithReviewGate(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithLoader(&MockNotesLoader{Exists: false})
+
+	result, err := builder.Build(BuildContext{
+		UserPrompt:     "Fix the login bug",
+		NotesFile:      "notes.md",
+		ReviewGate:     true,
+		ReviewFindings: []string{"The reviewer requested changes; they are not committed and remain in the working tree", "Add a test"},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "## REVIEW GATE")
+	assert.Contains(t, result.Prompt, "## REVIEW FINDINGS")
+	assert.Contains(t, result.Prompt, "- Add a test\n")
+	assert.Less(t, strings.Index(result.Prompt, "Fix the login bug"), strings.Index(result.Prompt, "REVIEW GATE"))
+
+	result, err = builder.Build(BuildContext{UserPrompt: "Fix the login bug", NotesFile: "notes.md"})
+	require.NoError(t, err)
+	assert.NotContains(t, result.Prompt, "REVIEW GATE")
+	assert.NotContains(t, result.Prompt, "REVIEW FINDINGS")
+}
+
+func TestBuilder_Build_WithCommitGate(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithLoader(&MockNotesLoader{Exists: false})
+
+	result, err := builder.Build(BuildContext{
+		UserPrompt:    "Fix the login bug",
+		NotesFile:     "notes.md",
+		CommitGate:    true,
+		BlockedCommit: []string{"secrets: commit blocked, staged changes contain secrets: creds.env:1 (aws-access-key)"},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "## COMMIT GATE")
+	assert.Contains(t, result.Prompt, "## COMMIT BLOCKED")
+	assert.Contains(t, result.Prompt, "- secrets: commit blocked")
+	assert.Less(t, strings.Index(result.Prompt, "COMMIT BLOCKED"), strings.Index(result.Prompt, "ITERATION NOTES"))
+
+	result, err = builder.Build(BuildContext{UserPrompt: "Fix the login bug", ReviewGate: true, CommitGate: true})
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "REVIEW GATE")
+	assert.NotContains(t, result.Prompt, "COMMIT GATE", "the review gate covers commits")
+}
+
+func TestBuilder_Build_CommitChecks(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithLoader(&MockNotesLoader{Exists: false})
+	build := func(checks CommitChecks) string {
+		result, err := builder.Build(BuildContext{UserPrompt: "Fix the login bug", CommitGate: true, CommitChecks: checks})
+		require.NoError(t, err)
+		return result.Prompt
+	}
+
+	all := build(CommitChecks{Secrets: true, Verification: true, BaseBranch: "main"})
+	assert.Contains(t, all, "Before committing, claude-loop:\n- scans the changes for secrets")
+	assert.Contains(t, all, "- runs the verification checks")
+	assert.Contains(t, all, "- never commits to main")
+
+	unscanned := build(CommitChecks{Verification: true})
+	assert.Contains(t, unscanned, "## COMMIT GATE")
+	assert.NotContains(t, unscanned, "secrets", "secret scanning is disabled")
+	assert.Contains(t, unscanned, "- runs the verification checks")
+	assert.NotContains(t, unscanned, "never commits to")
+
+	none := build(CommitChecks{})
+	assert.Contains(t, none, "claude-loop commits this iteration's changes itself")
+	assert.NotContains(t, none, "Before committing")
+}
+
+func TestBuilder_Build_WithPolicy(t *testing.T) {
+	t.Parallel()
+
+	builder := NewBuilderWithLoader(&MockNotesLoader{Exists: false})
+
+	result, err := builder.Build(BuildContext{
+		UserPrompt:       "Fix the build",
+		CompletionSignal: "COMPLETE",
+		NotesFile:        "notes.md",
+		Policy:           config.DerivePolicy(config.DefaultPrinciples(config.PresetEnterprise)),
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Prompt, "## RUNTIME POLICY")
+	assert.Contains(t, result.Prompt, "- Verification is strict")
+	assert.Contains(t, result.Prompt, "- Open pull requests as drafts\n")
+	assert.Contains(t, result.Prompt, "- Never push directly to the main branch")
+	assert.Less(t, strings.Index(result.Prompt, "Fix the build"), strings.Index(result.Prompt, "RUNTIME POLICY"))
+
+	result, err = builder.Build(BuildContext{UserPrompt: "Fix the build", Policy: config.DefaultPolicy()})
+	require.NoError(t, err)
+	assert.NotContains(t, result.Prompt, "RUNTIME POLICY")
+}
+
 func TestBuilder_Build_WithExistingNotes(t *testing.T) {
 	t.Parallel()
 
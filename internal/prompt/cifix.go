@@ -6,13 +6,21 @@ import (
 )
 
 // CIFixBuilder builds prompts for CI failure fixes.
-type CIFixBuilder struct{}
+type CIFixBuilder struct {
+	templates *TemplateSet
+}
 
 // NewCIFixBuilder creates a new CIFixBuilder.
 func NewCIFixBuilder() *CIFixBuilder {
 	return &CIFixBuilder{}
 }
 
+// NewCIFixBuilderWithTemplates creates a CIFixBuilder that honours the
+// ci_fix_context override in templates.
+func NewCIFixBuilderWithTemplates(templates *TemplateSet) *CIFixBuilder {
+	return &CIFixBuilder{templates: templates}
+}
+
 // CIFailureInfo contains information about a CI failure.
 // This mirrors github.CIFailureInfo but is defined here to avoid import cycles.
 type CIFailureInfo struct {
@@ -51,7 +59,18 @@ func (b *CIFixBuilder) Build(ctx CIFixContext) (*BuildResult, error) {
 	var sb strings.Builder
 
 	// Add CI fix context template
-	fmt.Fprintf(&sb, "%s\n\n", TemplateCIFixContext)
+	fixContext, err := b.templates.Render(TemplateNameCIFixContext, TemplateData{
+		Iteration:   ctx.Attempt,
+		Branch:      ctx.BranchName,
+		CIFailure:   ctx.FailureInfo,
+		PRNumber:    ctx.PRNumber,
+		Attempt:     ctx.Attempt,
+		MaxAttempts: ctx.MaxAttempts,
+	}, TemplateCIFixContext)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&sb, "%s\n\n", fixContext)
 
 	// Add failure details
 	b.writeFailureDetails(&sb, ctx.FailureInfo)
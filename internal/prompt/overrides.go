@@ -0,0 +1,330 @@
+package prompt
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+)
+
+// DefaultTemplatesDir is the directory searched for template overrides.
+const DefaultTemplatesDir = ".claude/templates"
+
+// TemplateExtension is the file extension of template override files.
+const TemplateExtension = ".tmpl"
+
+// Template names that can be overridden by placing <name>.tmpl in the templates directory.
+const (
+	TemplateNameWorkflowContext     = "workflow_context"
+	TemplateNameDecisionPrinciples  = "decision_principles"
+	TemplateNameNotesContext        = "notes_context"
+	TemplateNameIterationNotes      = "iteration_notes"
+	TemplateNameNotesUpdateExisting = "notes_update_existing"
+	TemplateNameNotesCreateNew      = "notes_create_new"
+	TemplateNameNotesGuidelines     = "notes_guidelines"
+	TemplateNameReviewerContext     = "reviewer_context"
+	TemplateNameCIFixContext        = "ci_fix_context"
+)
+
+// OverridableTemplates lists every template name that may be overridden.
+var OverridableTemplates = []string{
+	TemplateNameWorkflowContext,
+	TemplateNameDecisionPrinciples,
+	TemplateNameNotesContext,
+	TemplateNameIterationNotes,
+	TemplateNameNotesUpdateExisting,
+	TemplateNameNotesCreateNew,
+	TemplateNameNotesGuidelines,
+	TemplateNameReviewerContext,
+	TemplateNameCIFixContext,
+}
+
+// TemplateData is the data model exposed to override templates.
+// Every template receives the same struct; fields that do not apply to
+// the prompt being rendered are left at their zero value.
+type TemplateData struct {
+	// Prompt is the user's goal from -p (main loop templates).
+	Prompt string
+
+	// Iteration is the current iteration number (1-based).
+	Iteration int
+
+	// CompletionSignal is the project completion phrase.
+	CompletionSignal string
+
+	// Principles is the loaded principles configuration (may be nil).
+	Principles *config.Principles
+
+	// PrinciplesYAML is Principles serialized as YAML (empty if nil).
+	PrinciplesYAML string
+
+	// Notes is the content of the notes file from the previous iteration.
+	Notes string
+
+	// NotesFile is the path of the shared notes file.
+	NotesFile string
+
+	// NotesExist reports whether the notes file existed.
+	NotesExist bool
+
+	// VerificationFailures lists failed verification checks from the previous iteration.
+	VerificationFailures []string
+
+	// RejectedChanges lists changes from the previous iteration reverted by the protected-path policy.
+	RejectedChanges []string
+
+	// OversizedChange describes how the previous iteration exceeded the change size limits.
+	OversizedChange []string
+
+	// PriorDecisions lists earlier principle decisions to stay consistent with.
+	PriorDecisions []string
+
+	// Resolution is how the previous iteration's principle conflict was resolved.
+	Resolution []string
+
+	// Escalations lists principle conflicts awaiting a human decision.
+	Escalations []string
+
+	// ReviewGate reports whether the iteration's changes are reviewed before they are committed.
+	ReviewGate bool
+
+	// CommitGate reports whether claude-loop commits the iteration's changes itself.
+	CommitGate bool
+
+	// CommitChecks are the checks claude-loop applies before committing under CommitGate.
+	CommitChecks CommitChecks
+
+	// BlockedCommit describes why claude-loop did not commit the previous iteration's changes.
+	BlockedCommit []string
+
+	// ReviewFindings describes why the reviewer did not approve the previous iteration's changes.
+	ReviewFindings []string
+
+	// Policy is the runtime policy derived from principles (may be nil).
+	Policy *config.Policy
+
+	// Branch is the git branch the loop is working on (may be empty).
+	Branch string
+
+	// ReviewPrompt is the user's review instructions (reviewer_context only).
+	ReviewPrompt string
+
+	// CIFailure describes the failed CI run (ci_fix_context only).
+	CIFailure *CIFailureInfo
+
+	// PRNumber is the pull request being fixed (ci_fix_context only).
+	PRNumber int
+
+	// Attempt and MaxAttempts describe the CI fix attempt (ci_fix_context only).
+	Attempt     int
+	MaxAttempts int
+}
+
+// TemplateSet holds user-provided template overrides.
+// A nil *TemplateSet is valid and always falls back to built-in templates.
+type TemplateSet struct {
+	dir       string
+	templates map[string]*template.Template
+}
+
+// LoadTemplates parses all *.tmpl files in dir.
+// A missing directory yields an empty set. Unknown template names and
+// parse errors are reported as a TemplateError.
+func LoadTemplates(dir string) (*TemplateSet, error) {
+	set := &TemplateSet{dir: dir, templates: make(map[string]*template.Template)}
+	if dir == "" {
+		return set, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return set, nil
+		}
+		return nil, &TemplateError{Path: dir, Message: "failed to read templates directory", Err: err}
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != TemplateExtension {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), TemplateExtension)
+		path := filepath.Join(dir, entry.Name())
+		if !isOverridableTemplate(name) {
+			return nil, &TemplateError{
+				Path:    path,
+				Message: fmt.Sprintf("unknown template %q (valid: %s)", name, strings.Join(OverridableTemplates, ", ")),
+			}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, &TemplateError{Path: path, Message: "failed to read template", Err: err}
+		}
+
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(data))
+		if err != nil {
+			return nil, &TemplateError{Path: path, Message: "failed to parse template", Err: err}
+		}
+		set.templates[name] = tmpl
+	}
+
+	return set, nil
+}
+
+// Validate renders every override against fully populated and against empty
+// data so that field typos and runtime template errors, such as indexing a list
+// that is empty in some iterations, surface at startup instead of mid-run.
+func (s *TemplateSet) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	sample := sampleTemplateData()
+	for _, name := range s.Names() {
+		if _, err := s.execute(name, sample); err != nil {
+			return err
+		}
+		if _, err := s.execute(name, emptyTemplateData(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Has reports whether an override exists for the named template.
+func (s *TemplateSet) Has(name string) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.templates[name]
+	return ok
+}
+
+// Names returns the sorted names of all loaded overrides.
+func (s *TemplateSet) Names() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.templates))
+	for name := range s.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Dir returns the directory the overrides were loaded from.
+func (s *TemplateSet) Dir() string {
+	if s == nil {
+		return ""
+	}
+	return s.dir
+}
+
+// Render renders the named override with data.
+// If no override exists, fallback is returned unchanged.
+func (s *TemplateSet) Render(name string, data TemplateData, fallback string) (string, error) {
+	if !s.Has(name) {
+		return fallback, nil
+	}
+	return s.execute(name, data)
+}
+
+func (s *TemplateSet) execute(name string, data TemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := s.templates[name].Execute(&buf, data); err != nil {
+		return "", &TemplateError{
+			Path:    filepath.Join(s.dir, name+TemplateExtension),
+			Message: "failed to render template",
+			Err:     err,
+		}
+	}
+	return buf.String(), nil
+}
+
+// isOverridableTemplate checks if name is a known template name.
+func isOverridableTemplate(name string) bool {
+	for _, valid := range OverridableTemplates {
+		if name == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// emptyTemplateData returns the least data the named template is rendered with:
+// only what its prompt always provides is set.
+func emptyTemplateData(name string) TemplateData {
+	var data TemplateData
+	switch name {
+	case TemplateNameDecisionPrinciples:
+		data.Principles = config.DefaultPrinciples(config.PresetStartup)
+	case TemplateNameCIFixContext:
+		data.CIFailure = &CIFailureInfo{}
+	}
+	return data
+}
+
+// sampleTemplateData returns fully populated data used for validation.
+func sampleTemplateData() TemplateData {
+	return TemplateData{
+		Prompt:               "sample prompt",
+		Iteration:            1,
+		CompletionSignal:     "CONTINUOUS_CLAUDE_PROJECT_COMPLETE",
+		Principles:           config.DefaultPrinciples(config.PresetStartup),
+		PrinciplesYAML:       "version: \"2.3\"\n",
+		Notes:                "sample notes",
+		NotesFile:            "SHARED_TASK_NOTES.md",
+		NotesExist:           true,
+		VerificationFailures: []string{"sample failure"},
+		RejectedChanges:      []string{"sample rejection"},
+		OversizedChange:      []string{"sample oversized change"},
+		PriorDecisions:       []string{"sample decision"},
+		Resolution:           []string{"sample resolution"},
+		Escalations:          []string{"sample escalation"},
+		ReviewGate:           true,
+		CommitGate:           true,
+		CommitChecks:         CommitChecks{Secrets: true, Verification: true, BaseBranch: "main"},
+		BlockedCommit:        []string{"sample blocked commit"},
+		ReviewFindings:       []string{"sample finding"},
+		Policy:               config.DerivePolicy(config.DefaultPrinciples(config.PresetEnterprise)),
+		Branch:               "claude-loop/sample",
+		ReviewPrompt:         "sample review",
+		CIFailure: &CIFailureInfo{
+			RunID:        "1",
+			WorkflowName: "CI",
+			JobName:      "test",
+			FailedSteps:  []string{"go test"},
+			ErrorLogs:    "sample logs",
+			URL:          "https://example.com/run/1",
+		},
+		PRNumber:    1,
+		Attempt:     1,
+		MaxAttempts: 1,
+	}
+}
+
+// TemplateError represents an error loading or rendering a template override.
+type TemplateError struct {
+	Path    string
+	Message string
+	Err     error
+}
+
+func (e *TemplateError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("template %s: %s: %v", e.Path, e.Message, e.Err)
+	}
+	return fmt.Sprintf("template %s: %s", e.Path, e.Message)
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,181 @@
+package prompt
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+TemplateExtension), []byte(content), 0644))
+}
+
+func TestLoadTemplates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing directory yields empty set", func(t *testing.T) {
+		t.Parallel()
+		set, err := LoadTemplates(filepath.Join(t.TempDir(), "missing"))
+		require.NoError(t, err)
+		assert.Empty(t, set.Names())
+		assert.NoError(t, set.Validate())
+	})
+
+	t.Run("empty dir string yields empty set", func(t *testing.T) {
+		t.Parallel()
+		set, err := LoadTemplates("")
+		require.NoError(t, err)
+		assert.Empty(t, set.Names())
+	})
+
+	t.Run("loads known templates and ignores other files", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTemplate(t, dir, TemplateNameWorkflowContext, "workflow {{.Iteration}}")
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("docs"), 0644))
+
+		set, err := LoadTemplates(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{TemplateNameWorkflowContext}, set.Names())
+		assert.True(t, set.Has(TemplateNameWorkflowContext))
+		assert.Equal(t, dir, set.Dir())
+	})
+
+	t.Run("unknown template name", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTemplate(t, dir, "workflow", "x")
+
+		_, err := LoadTemplates(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown template "workflow"`)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTemplate(t, dir, TemplateNameNotesGuidelines, "{{.Notes")
+
+		_, err := LoadTemplates(dir)
+		require.Error(t, err)
+		var te *TemplateError
+		assert.True(t, errors.As(err, &te))
+		assert.Contains(t, err.Error(), "failed to parse template")
+	})
+}
+
+func TestTemplateSet_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown field is reported", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTemplate(t, dir, TemplateNameWorkflowContext, "{{.NoSuchField}}")
+
+		set, err := LoadTemplates(dir)
+		require.NoError(t, err)
+		err = set.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to render template")
+	})
+
+	t.Run("empty data is rendered too", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTemplate(t, dir, TemplateNameWorkflowContext, "First failure: {{index .VerificationFailures 0}}")
+
+		set, err := LoadTemplates(dir)
+		require.NoError(t, err)
+		err = set.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "index out of range")
+	})
+
+	t.Run("data a prompt always has may be assumed", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTemplate(t, dir, TemplateNameDecisionPrinciples, "{{.Principles.Preset}}")
+		writeTemplate(t, dir, TemplateNameCIFixContext, "{{.CIFailure.RunID}}")
+
+		set, err := LoadTemplates(dir)
+		require.NoError(t, err)
+		assert.NoError(t, set.Validate())
+	})
+
+	t.Run("nil set is valid", func(t *testing.T) {
+		t.Parallel()
+		var set *TemplateSet
+		assert.NoError(t, set.Validate())
+		assert.False(t, set.Has(TemplateNameWorkflowContext))
+	})
+}
+
+func TestTemplateSet_Render(t *testing.T) {
+	t.Parallel()
+
+	t.Run("falls back when no override", func(t *testing.T) {
+		t.Parallel()
+		var set *TemplateSet
+		out, err := set.Render(TemplateNameWorkflowContext, TemplateData{}, "built-in")
+		require.NoError(t, err)
+		assert.Equal(t, "built-in", out)
+	})
+
+	t.Run("renders override", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTemplate(t, dir, TemplateNameReviewerContext, "Review on {{.Branch}}: {{.ReviewPrompt}}")
+
+		set, err := LoadTemplates(dir)
+		require.NoError(t, err)
+		out, err := set.Render(TemplateNameReviewerContext, TemplateData{Branch: "main", ReviewPrompt: "run tests"}, "built-in")
+		require.NoError(t, err)
+		assert.Equal(t, "Review on main: run tests", out)
+	})
+}
+
+func TestBuilder_Build_WithTemplateOverrides(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTemplate(t, dir, TemplateNameWorkflowContext,
+		"## HOUSE RULES\nIteration {{.Iteration}} on {{.Branch}}. Signal: {{.CompletionSignal}}")
+	writeTemplate(t, dir, TemplateNameDecisionPrinciples,
+		"## PRINCIPLES ({{.Principles.Preset}})\n{{.PrinciplesYAML}}")
+
+	set, err := LoadTemplates(dir)
+	require.NoError(t, err)
+	require.NoError(t, set.Validate())
+
+	builder := NewBuilderWithTemplates(set)
+	builder.notesLoader = &MockNotesLoader{Content: "previous notes", Exists: true}
+
+	result, err := builder.Build(BuildContext{
+		UserPrompt:           "Add tests",
+		Principles:           config.DefaultPrinciples(config.PresetEnterprise),
+		CompletionSignal:     "DONE",
+		NotesFile:            "NOTES.md",
+		Iteration:            4,
+		Branch:               "claude-loop/feature",
+		VerificationFailures: []string{"go test ./... failed"},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, result.Prompt, "Iteration 4 on claude-loop/feature. Signal: DONE")
+	assert.Contains(t, result.Prompt, "## PRINCIPLES (enterprise)")
+	assert.NotContains(t, result.Prompt, "CONTINUOUS WORKFLOW CONTEXT")
+	assert.NotContains(t, result.Prompt, "Decision Protocol")
+
+	// Sections without overrides keep their built-in wording
+	assert.Contains(t, result.Prompt, "CONTEXT FROM PREVIOUS ITERATION")
+	assert.Contains(t, result.Prompt, "VERIFICATION FAILURES")
+	assert.Contains(t, result.Prompt, "- go test ./... failed")
+	assert.True(t, result.PrinciplesInjected)
+	assert.True(t, result.NotesIncluded)
+}
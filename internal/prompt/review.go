@@ -0,0 +1,66 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+)
+
+// ReviewFixContext contains context for building a review fix prompt.
+type ReviewFixContext struct {
+	// UserPrompt is the goal the reviewed iteration worked on.
+	UserPrompt string
+
+	// Findings lists what the reviewer asked to change.
+	Findings []string
+
+	// Attempt is the current fix pass (1-based).
+	Attempt int
+
+	// MaxAttempts is the number of fix passes allowed per iteration.
+	MaxAttempts int
+}
+
+// BuildReviewFix constructs the prompt for a fix pass on changes the reviewer
+// requested.
+func BuildReviewFix(ctx ReviewFixContext) *BuildResult {
+	var sb strings.Builder
+	sb.WriteString(TemplateReviewFix)
+	sb.WriteString("**Findings**:\n")
+	for _, finding := range ctx.Findings {
+		fmt.Fprintf(&sb, "- %s\n", finding)
+	}
+	if ctx.Attempt > 1 {
+		fmt.Fprintf(&sb, "\n**Note**: This is fix pass %d of %d. The previous pass did not satisfy the reviewer.\n",
+			ctx.Attempt, ctx.MaxAttempts)
+	}
+	fmt.Fprintf(&sb, "\n## ORIGINAL GOAL\n\n%s\n", ctx.UserPrompt)
+	return &BuildResult{Prompt: sb.String()}
+}
+
+// BuildReviewApproved constructs the prompt asking for the commit of changes
+// the reviewer approved, with the runtime policy's rules.
+func BuildReviewApproved(policy *config.Policy) *BuildResult {
+	return buildCommitPass(TemplateReviewApproved, policy)
+}
+
+// BuildChangesCommitted constructs the prompt asking for the push of changes
+// claude-loop already committed, with the runtime policy's rules.
+func BuildChangesCommitted(policy *config.Policy) *BuildResult {
+	return buildCommitPass(TemplateChangesCommitted, policy)
+}
+
+// buildCommitPass appends the runtime policy's rules to a commit pass template.
+func buildCommitPass(template string, policy *config.Policy) *BuildResult {
+	var sb strings.Builder
+	sb.WriteString(template)
+	if directives := policyDirectives(policy); len(directives) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(TemplateRuntimePolicy)
+		for _, directive := range directives {
+			fmt.Fprintf(&sb, "- %s\n", directive)
+		}
+	}
+	return &BuildResult{Prompt: sb.String()}
+}
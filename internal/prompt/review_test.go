@@ -0,0 +1,51 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildReviewFix(t *testing.T) {
+	t.Parallel()
+
+	result := BuildReviewFix(ReviewFixContext{
+		UserPrompt:  "Add config parsing",
+		Findings:    []string{"Handle a missing file", "Add a test"},
+		Attempt:     1,
+		MaxAttempts: 2,
+	})
+
+	assert.True(t, strings.HasPrefix(result.Prompt, TemplateReviewFix))
+	assert.Contains(t, result.Prompt, "**Findings**:\n- Handle a missing file\n- Add a test\n")
+	assert.NotContains(t, result.Prompt, "fix pass")
+	assert.True(t, strings.HasSuffix(result.Prompt, "## ORIGINAL GOAL\n\nAdd config parsing\n"))
+
+	result = BuildReviewFix(ReviewFixContext{UserPrompt: "Add config parsing", Attempt: 2, MaxAttempts: 2})
+	assert.Contains(t, result.Prompt, "This is fix pass 2 of 2")
+}
+
+func TestBuildReviewApproved(t *testing.T) {
+	t.Parallel()
+
+	result := BuildReviewApproved(nil)
+	assert.Equal(t, TemplateReviewApproved, result.Prompt)
+
+	result = BuildReviewApproved(config.DerivePolicy(config.DefaultPrinciples(config.PresetEnterprise)))
+	assert.Contains(t, result.Prompt, "## RUNTIME POLICY")
+	assert.Contains(t, result.Prompt, "- Open pull requests as drafts\n")
+	assert.Less(t, strings.Index(result.Prompt, "REVIEW APPROVED"), strings.Index(result.Prompt, "RUNTIME POLICY"))
+}
+
+func TestBuildChangesCommitted(t *testing.T) {
+	t.Parallel()
+
+	result := BuildChangesCommitted(nil)
+	assert.Equal(t, TemplateChangesCommitted, result.Prompt)
+	assert.Contains(t, result.Prompt, "do not change any code or stage files")
+
+	result = BuildChangesCommitted(config.DerivePolicy(config.DefaultPrinciples(config.PresetEnterprise)))
+	assert.Contains(t, result.Prompt, "- Open pull requests as drafts\n")
+}
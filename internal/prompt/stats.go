@@ -0,0 +1,62 @@
+package prompt
+
+import "strings"
+
+// charsPerToken is the rough characters-per-token ratio used for estimates.
+const charsPerToken = 4
+
+// sectionPreamble names text that appears before the first heading.
+const sectionPreamble = "(preamble)"
+
+// Section describes the size of one part of a built prompt.
+type Section struct {
+	Name   string // Heading text without the leading "## "
+	Chars  int    // Length in bytes
+	Tokens int    // Estimated token count
+}
+
+// EstimateTokens approximates the number of tokens in text.
+// It is a heuristic for comparing prompt sizes, not an exact tokenizer.
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// SplitSections splits a prompt at level-2 markdown headings ("## ").
+// Text before the first heading is reported as a preamble section.
+// Headings inside fenced code blocks are ignored.
+func SplitSections(text string) []Section {
+	var sections []Section
+	name := sectionPreamble
+	var current strings.Builder
+	inFence := false
+
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+		content := current.String()
+		sections = append(sections, Section{
+			Name:   name,
+			Chars:  len(content),
+			Tokens: EstimateTokens(content),
+		})
+		current.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(line, "## ") {
+			flush()
+			name = strings.TrimSpace(strings.TrimPrefix(line, "## "))
+		}
+		current.WriteString(line)
+	}
+	flush()
+
+	return sections
+}
@@ -0,0 +1,65 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, EstimateTokens(""))
+	assert.Equal(t, 1, EstimateTokens("abc"))
+	assert.Equal(t, 1, EstimateTokens("abcd"))
+	assert.Equal(t, 2, EstimateTokens("abcde"))
+}
+
+func TestSplitSections(t *testing.T) {
+	t.Parallel()
+
+	t.Run("splits on level-2 headings", func(t *testing.T) {
+		t.Parallel()
+		text := "intro\n## FIRST\nbody one\n## SECOND\nbody two\n"
+
+		sections := SplitSections(text)
+
+		assert.Len(t, sections, 3)
+		assert.Equal(t, "(preamble)", sections[0].Name)
+		assert.Equal(t, "FIRST", sections[1].Name)
+		assert.Equal(t, "SECOND", sections[2].Name)
+
+		total := 0
+		for _, s := range sections {
+			total += s.Chars
+		}
+		assert.Equal(t, len(text), total)
+	})
+
+	t.Run("ignores headings in code fences", func(t *testing.T) {
+		t.Parallel()
+		text := "## ONLY\n```\n## not a heading\n```\n"
+
+		sections := SplitSections(text)
+
+		assert.Len(t, sections, 1)
+		assert.Equal(t, "ONLY", sections[0].Name)
+	})
+
+	t.Run("built prompt sections", func(t *testing.T) {
+		t.Parallel()
+		result, err := NewBuilderWithLoader(&MockNotesLoader{}).Build(BuildContext{
+			UserPrompt:       "Add tests",
+			CompletionSignal: "DONE",
+			NotesFile:        "NOTES.md",
+		})
+		assert.NoError(t, err)
+
+		var names []string
+		for _, s := range SplitSections(result.Prompt) {
+			names = append(names, s.Name)
+		}
+		assert.Equal(t, "CONTINUOUS WORKFLOW CONTEXT,PRIMARY GOAL,ITERATION NOTES", strings.Join(names, ","))
+	})
+}
@@ -63,6 +63,19 @@ Only ask the user when ALL conditions are met:
 - R10 3-step resolution doesn't resolve it
 - Options are mutually exclusive (can't satisfy both)
 
+To ask, output one conflict block naming the two principle keys, the options you weighed and
+the context, then stop; the resolution is given to the next iteration:
+
+` + "```" + `
+<principle_conflict>
+principles: <principle>, <principle>
+options:
+- <first option>
+- <second option>
+context: <what you were doing and why the principles collide>
+</principle_conflict>
+` + "```" + `
+
 **Default behavior**: Decide autonomously and report your reasoning.
 `
 
@@ -96,6 +109,121 @@ The following is from ` + "`" + `NOTES_FILE_PLACEHOLDER` + "`" + `, maintained b
 
 `
 
+// TemplateVerificationFailures introduces failed checks from the previous iteration.
+const TemplateVerificationFailures = `## VERIFICATION FAILURES
+
+The previous iteration did not pass the following checks. Address them before starting new work:
+
+`
+
+// TemplateRejectedChanges introduces changes reverted by the protected-path policy.
+const TemplateRejectedChanges = `## REJECTED CHANGES
+
+The previous iteration modified protected paths. Those changes were reverted and must not be made again:
+
+`
+
+// TemplateOversizedChange introduces a change that exceeded the blast_radius size limits.
+const TemplateOversizedChange = `## CHANGE TOO LARGE
+
+The previous iteration changed more than the blast_radius principle allows per iteration:
+
+`
+
+// TemplateReviewFindings introduces the findings of a reviewer who did not approve the previous iteration.
+const TemplateReviewFindings = `## REVIEW FINDINGS
+
+The reviewer did not approve the previous iteration's changes. Address these findings
+before starting new work:
+
+`
+
+// TemplateConflictResolution introduces the resolution of a conflict the previous iteration reported.
+const TemplateConflictResolution = `## CONFLICT RESOLUTION
+
+The principle conflict you reported in the previous iteration has been resolved. Carry out
+this decision now; do not report the same conflict again unless the situation has changed:
+
+`
+
+// TemplatePendingEscalations introduces principle conflicts awaiting a human decision.
+const TemplatePendingEscalations = `## PENDING ESCALATIONS
+
+These principle conflicts are waiting for a human decision. Until one is answered, make
+no change that depends on it: leave the affected code as it is and work on tasks that do
+not depend on the decision. Do not report these conflicts again:
+
+`
+
+// TemplatePriorDecisions introduces earlier principle decisions.
+const TemplatePriorDecisions = `## PRIOR DECISIONS
+
+These trade-offs were already decided. Stay consistent with them; if one no longer
+fits, say so and why instead of silently deciding otherwise:
+
+`
+
+// TemplateRuntimePolicy introduces the rules derived from the runtime policy.
+const TemplateRuntimePolicy = `## RUNTIME POLICY
+
+These rules follow from the project's principles:
+
+`
+
+// TemplateReviewGate tells the iteration its changes are reviewed before they are committed.
+const TemplateReviewGate = `## REVIEW GATE
+
+A reviewer checks this iteration's changes before they are committed. Leave your changes
+uncommitted: do not commit, push or open a pull request. Once the reviewer approves, you
+will be asked to commit them.
+
+`
+
+// TemplateCommitGate tells the iteration claude-loop commits its changes. The
+// checks applied before committing follow as a list.
+const TemplateCommitGate = `## COMMIT GATE
+
+claude-loop commits this iteration's changes itself. Leave your changes uncommitted: do not
+commit, push or open a pull request. Once they are committed, you will be asked to push them.
+`
+
+// TemplateCommitChecks introduces the checks claude-loop applies before committing.
+const TemplateCommitChecks = `
+Before committing, claude-loop:
+`
+
+// TemplateBlockedCommit introduces changes claude-loop refused to commit.
+const TemplateBlockedCommit = `## COMMIT BLOCKED
+
+The previous iteration's changes were not committed:
+
+`
+
+// TemplateReviewFix introduces a fix pass for changes the reviewer asked for.
+const TemplateReviewFix = `## REVIEW FIX
+
+A reviewer checked the changes you just made and requested changes. Address every finding
+below now, without starting new work. Leave your changes uncommitted: the reviewer checks
+them again before they are committed.
+
+`
+
+// TemplateReviewApproved asks for the commit of changes the reviewer approved.
+const TemplateReviewApproved = `## REVIEW APPROVED
+
+A reviewer approved the uncommitted changes in the working tree. Commit them now with a
+message that describes the work, then push and open a pull request as you normally would.
+Do not change any code. If there is nothing to commit, do nothing.
+`
+
+// TemplateChangesCommitted asks for the push of changes claude-loop committed itself.
+const TemplateChangesCommitted = `## CHANGES COMMITTED
+
+claude-loop checked this iteration's changes and committed them. Push them and open a pull
+request as you normally would. You may reword the last commit's message with
+` + "`git commit --amend --only`" + ` to describe the work, but do not change any code or stage files.
+`
+
 // TemplateIterationNotes header for notes instructions.
 const TemplateIterationNotes = `## ITERATION NOTES
 
@@ -106,6 +234,31 @@ const TemplateReviewerContext = `## CODE REVIEW CONTEXT
 
 You are performing a review pass on changes just made by another developer. This is NOT a new feature implementation - you are reviewing and validating existing changes using the instructions given below by the user. Feel free to use git commands to see what changes were made if it's helpful to you.`
 
+// TemplateReviewChanges introduces the changes under review: the iteration's summary,
+// the files it changed and its diff.
+const TemplateReviewChanges = `## CHANGES UNDER REVIEW
+
+These are the changes the iteration made, so you do not need to rediscover them. Read the
+changed files for more context where the diff is not enough.
+
+`
+
+// TemplateReviewVerdict asks the reviewer for a verdict the loop acts on.
+// It is not overridable: the loop parses the block it describes.
+const TemplateReviewVerdict = `## REVIEW VERDICT
+
+End your review with exactly one verdict block. The loop acts on it: APPROVE lets the
+changes be committed, REQUEST_CHANGES sends your findings back for a fix pass, and BLOCK
+reverts every change of the iteration.
+
+<review_verdict>
+verdict: <APPROVE, REQUEST_CHANGES or BLOCK>
+findings:
+- <one finding per item: what is wrong, where, and what to do about it>
+</review_verdict>
+
+Use BLOCK only for changes that are harmful or beyond repair. Findings are optional with APPROVE.`
+
 // TemplateCIFixContext provides context for CI failure fixes.
 const TemplateCIFixContext = `## CI FAILURE FIX CONTEXT
 
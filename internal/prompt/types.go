@@ -20,6 +20,63 @@ type BuildContext struct {
 
 	// Iteration is the current iteration number (1-based).
 	Iteration int
+
+	// Branch is the git branch the loop is working on (may be empty).
+	Branch string
+
+	// VerificationFailures lists failed checks from the previous iteration (may be empty).
+	VerificationFailures []string
+
+	// RejectedChanges lists changes from the previous iteration that were reverted
+	// because they touched protected paths (may be empty).
+	RejectedChanges []string
+
+	// OversizedChange describes how the previous iteration exceeded the change size
+	// limits and what to do about it (may be empty).
+	OversizedChange []string
+
+	// Policy is the runtime policy derived from principles (may be nil).
+	Policy *config.Policy
+
+	// PriorDecisions lists earlier principle decisions to stay consistent with,
+	// one per line (may be empty).
+	PriorDecisions []string
+
+	// Resolution is how a principle conflict the previous iteration reported was
+	// resolved, one line per part (may be empty).
+	Resolution []string
+
+	// Escalations lists principle conflicts awaiting a human decision, one per
+	// line (may be empty).
+	Escalations []string
+
+	// ReviewGate reports whether a reviewer approves the iteration's changes
+	// before they are committed.
+	ReviewGate bool
+
+	// CommitGate reports whether claude-loop commits the iteration's changes
+	// itself. Ignored with ReviewGate.
+	CommitGate bool
+
+	// CommitChecks are the checks claude-loop applies before it commits the
+	// changes under CommitGate.
+	CommitChecks CommitChecks
+
+	// BlockedCommit describes why claude-loop did not commit the previous
+	// iteration's changes (may be empty).
+	BlockedCommit []string
+
+	// ReviewFindings describes why the reviewer did not approve the previous
+	// iteration's changes, one line per finding (may be empty).
+	ReviewFindings []string
+}
+
+// CommitChecks describes what claude-loop checks before committing an
+// iteration's changes itself.
+type CommitChecks struct {
+	Secrets      bool   // Changes are scanned for secrets
+	Verification bool   // Changes that fail verification are not committed
+	BaseBranch   string // Branch never committed to; work on it moves to a new branch (empty = none)
 }
 
 // BuildResult contains the built prompt and metadata.
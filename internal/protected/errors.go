@@ -0,0 +1,30 @@
+package protected
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PolicyError represents an invalid protected-path pattern.
+type PolicyError struct {
+	Pattern string
+	Message string
+	Err     error
+}
+
+func (e *PolicyError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("protected path %q: %s: %v", e.Pattern, e.Message, e.Err)
+	}
+	return fmt.Sprintf("protected path %q: %s", e.Pattern, e.Message)
+}
+
+func (e *PolicyError) Unwrap() error {
+	return e.Err
+}
+
+// IsPolicyError checks if an error is a PolicyError.
+func IsPolicyError(err error) bool {
+	var pe *PolicyError
+	return errors.As(err, &pe)
+}
@@ -0,0 +1,22 @@
+package protected
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyError(t *testing.T) {
+	inner := errors.New("unterminated character class")
+
+	assert.Equal(t, `protected path "": invalid pattern`, (&PolicyError{Message: "invalid pattern"}).Error())
+	assert.Equal(t, `protected path "a/[b": invalid pattern: unterminated character class`,
+		(&PolicyError{Pattern: "a/[b", Message: "invalid pattern", Err: inner}).Error())
+
+	wrapped := fmt.Errorf("loading: %w", &PolicyError{Message: "x", Err: inner})
+	assert.True(t, IsPolicyError(wrapped))
+	assert.ErrorIs(t, wrapped, inner)
+	assert.False(t, IsPolicyError(inner))
+}
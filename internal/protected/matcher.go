@@ -0,0 +1,128 @@
+// Package protected matches repository paths against the protected-path policy.
+package protected
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Violation is a changed path that matches a protected pattern.
+type Violation struct {
+	Path    string `json:"path"`
+	Pattern string `json:"pattern"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s (protected by %q)", v.Path, v.Pattern)
+}
+
+// Matcher tests slash-separated paths, relative to the repository root, against glob patterns.
+//
+// Patterns follow gitignore conventions: `*` and `?` stay within one path segment,
+// `**` spans any number of segments, a pattern without a slash matches at any depth,
+// and a pattern that names a directory also protects everything below it.
+type Matcher struct {
+	patterns []string
+	regexps  []*regexp.Regexp
+}
+
+// NewMatcher compiles patterns. An empty list yields a matcher that matches nothing.
+func NewMatcher(patterns []string) (*Matcher, error) {
+	m := &Matcher{}
+	for _, pattern := range patterns {
+		re, err := compile(pattern)
+		if err != nil {
+			return nil, &PolicyError{Pattern: pattern, Message: "invalid pattern", Err: err}
+		}
+		m.patterns = append(m.patterns, pattern)
+		m.regexps = append(m.regexps, re)
+	}
+	return m, nil
+}
+
+// Patterns returns the configured patterns.
+func (m *Matcher) Patterns() []string {
+	if m == nil {
+		return nil
+	}
+	return m.patterns
+}
+
+// Empty reports whether the matcher has no patterns.
+func (m *Matcher) Empty() bool {
+	return m == nil || len(m.patterns) == 0
+}
+
+// Match returns the first pattern that matches p.
+func (m *Matcher) Match(p string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	p = strings.TrimPrefix(path.Clean(strings.ReplaceAll(p, "\\", "/")), "./")
+	for i, re := range m.regexps {
+		if re.MatchString(p) {
+			return m.patterns[i], true
+		}
+	}
+	return "", false
+}
+
+// Check returns a violation for every path that matches a pattern, in input order.
+func (m *Matcher) Check(paths []string) []Violation {
+	var violations []Violation
+	for _, p := range paths {
+		if pattern, ok := m.Match(p); ok {
+			violations = append(violations, Violation{Path: p, Pattern: pattern})
+		}
+	}
+	return violations
+}
+
+// compile translates a glob pattern into an anchored regular expression.
+func compile(pattern string) (*regexp.Regexp, error) {
+	p := strings.TrimPrefix(strings.TrimSpace(pattern), "./")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+	p = strings.TrimSuffix(p, "/")
+	if strings.HasPrefix(p, "/") {
+		p = strings.TrimPrefix(p, "/")
+	} else if !strings.Contains(p, "/") {
+		p = "**/" + p
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class")
+			}
+			class := p[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("(?:/.*)?$")
+	return regexp.Compile(b.String())
+}
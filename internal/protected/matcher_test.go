@@ -0,0 +1,86 @@
+package protected
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	m, err := NewMatcher([]string{
+		"migrations/**",
+		".github/workflows/",
+		"LICENSE",
+		"vendor",
+		"config/*.env.template",
+		"/Makefile",
+		"secrets/[!a]*.yaml",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path    string
+		pattern string
+	}{
+		{"migrations/001_init.sql", "migrations/**"},
+		{"migrations/2026/002.sql", "migrations/**"},
+		{".github/workflows/ci.yml", ".github/workflows/"},
+		{"LICENSE", "LICENSE"},
+		{"third_party/lib/LICENSE", "LICENSE"},
+		{"vendor/github.com/x/y.go", "vendor"},
+		{"pkg/vendor/z.go", "vendor"},
+		{"config/prod.env.template", "config/*.env.template"},
+		{"./Makefile", "/Makefile"},
+		{"secrets/db.yaml", "secrets/[!a]*.yaml"},
+	}
+	for _, tt := range tests {
+		pattern, ok := m.Match(tt.path)
+		assert.True(t, ok, tt.path)
+		assert.Equal(t, tt.pattern, pattern, tt.path)
+	}
+
+	for _, path := range []string{
+		"src/migrations.go",
+		".github/dependabot.yml",
+		"LICENSE.md",
+		"config/nested/prod.env.template",
+		"tools/Makefile",
+		"secrets/api.yaml",
+	} {
+		_, ok := m.Match(path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestMatcher_Check(t *testing.T) {
+	m, err := NewMatcher([]string{"LICENSE", "migrations/**"})
+	require.NoError(t, err)
+
+	violations := m.Check([]string{"main.go", "migrations/1.sql", "LICENSE"})
+	assert.Equal(t, []Violation{
+		{Path: "migrations/1.sql", Pattern: "migrations/**"},
+		{Path: "LICENSE", Pattern: "LICENSE"},
+	}, violations)
+	assert.Equal(t, `LICENSE (protected by "LICENSE")`, violations[1].String())
+}
+
+func TestMatcher_Empty(t *testing.T) {
+	var m *Matcher
+	assert.True(t, m.Empty())
+	assert.Nil(t, m.Check([]string{"LICENSE"}))
+
+	m, err := NewMatcher(nil)
+	require.NoError(t, err)
+	assert.True(t, m.Empty())
+}
+
+func TestNewMatcher_Invalid(t *testing.T) {
+	_, err := NewMatcher([]string{"secrets/[abc"})
+	require.Error(t, err)
+	assert.True(t, IsPolicyError(err))
+	assert.Contains(t, err.Error(), "unterminated character class")
+
+	_, err = NewMatcher([]string{"  "})
+	assert.Error(t, err)
+}
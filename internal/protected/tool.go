@@ -0,0 +1,40 @@
+package protected
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+)
+
+// ToolPath returns the file a file-editing tool call writes to, or "" for other tools.
+// input is the tool's JSON input as it appears in Claude's stream.
+func ToolPath(tool, input string) string {
+	var args struct {
+		FilePath     string `json:"file_path"`
+		NotebookPath string `json:"notebook_path"`
+	}
+	switch tool {
+	case "Write", "Edit", "MultiEdit":
+		if json.Unmarshal([]byte(input), &args) == nil {
+			return args.FilePath
+		}
+	case "NotebookEdit":
+		if json.Unmarshal([]byte(input), &args) == nil {
+			return args.NotebookPath
+		}
+	}
+	return ""
+}
+
+// RelativePath converts a tool's file path to a slash path relative to root.
+// Paths outside root are returned unchanged.
+func RelativePath(root, p string) string {
+	if !filepath.IsAbs(p) {
+		return filepath.ToSlash(p)
+	}
+	rel, err := filepath.Rel(root, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(p)
+	}
+	return filepath.ToSlash(rel)
+}
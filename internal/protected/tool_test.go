@@ -0,0 +1,24 @@
+package protected
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolPath(t *testing.T) {
+	assert.Equal(t, "/repo/LICENSE", ToolPath("Write", `{"file_path":"/repo/LICENSE","content":"x"}`))
+	assert.Equal(t, "a.go", ToolPath("Edit", `{"file_path":"a.go","old_string":"a","new_string":"b"}`))
+	assert.Equal(t, "a.go", ToolPath("MultiEdit", `{"file_path":"a.go","edits":[]}`))
+	assert.Equal(t, "nb.ipynb", ToolPath("NotebookEdit", `{"notebook_path":"nb.ipynb"}`))
+	assert.Empty(t, ToolPath("Read", `{"file_path":"a.go"}`))
+	assert.Empty(t, ToolPath("Write", `not json`))
+}
+
+func TestRelativePath(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	assert.Equal(t, "migrations/1.sql", RelativePath(root, filepath.FromSlash("/repo/migrations/1.sql")))
+	assert.Equal(t, "docs/a.md", RelativePath(root, "docs/a.md"))
+	assert.Equal(t, "/etc/passwd", RelativePath(root, filepath.FromSlash("/etc/passwd")))
+}
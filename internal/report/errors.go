@@ -0,0 +1,34 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ReportError represents a failure to render, read or write a report.
+type ReportError struct {
+	Path    string // File involved, if any
+	Message string
+	Err     error
+}
+
+func (e *ReportError) Error() string {
+	prefix := "report"
+	if e.Path != "" {
+		prefix = fmt.Sprintf("report %s", e.Path)
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", prefix, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", prefix, e.Message)
+}
+
+func (e *ReportError) Unwrap() error {
+	return e.Err
+}
+
+// IsReportError checks if an error is a ReportError.
+func IsReportError(err error) bool {
+	var re *ReportError
+	return errors.As(err, &re)
+}
@@ -0,0 +1,22 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportError(t *testing.T) {
+	inner := errors.New("disk full")
+
+	assert.Equal(t, "report: failed to encode report", (&ReportError{Message: "failed to encode report"}).Error())
+	assert.Equal(t, "report out/report.md: failed to write report: disk full",
+		(&ReportError{Path: "out/report.md", Message: "failed to write report", Err: inner}).Error())
+
+	wrapped := fmt.Errorf("saving: %w", &ReportError{Message: "x", Err: inner})
+	assert.True(t, IsReportError(wrapped))
+	assert.ErrorIs(t, wrapped, inner)
+	assert.False(t, IsReportError(inner))
+}
@@ -0,0 +1,207 @@
+package report
+
+import (
+	"bytes"
+	"html/template"
+)
+
+// htmlFuncs exposes the shared formatting helpers to the HTML template.
+var htmlFuncs = template.FuncMap{
+	"cost":         formatCost,
+	"duration":     formatDuration,
+	"tokens":       formatTokens,
+	"shortSHA":     shortSHA,
+	"status":       iterationStatus,
+	"changes":      changeSummary,
+	"review":       reviewSummary,
+	"reviewers":    reviewersSummary,
+	"council":      councilSummary,
+	"verification": verificationSummary,
+	"protected":    protectedSummary,
+	"changeSize":   changeSizeSummary,
+}
+
+// htmlTemplate renders a self-contained page: inline styles, no external assets.
+var htmlTemplate = template.Must(template.New("report").Funcs(htmlFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>claude-loop run report: {{.RunID}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 1100px; margin: 2em auto; padding: 0 1em; color: #1f2328; }
+h1 { font-size: 1.6em; border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+h2 { font-size: 1.25em; margin-top: 1.8em; }
+table { border-collapse: collapse; width: 100%; font-size: .9em; }
+th, td { border: 1px solid #d0d7de; padding: 6px 10px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+table.summary th { width: 12em; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: .9em; }
+pre { background: #f6f8fa; padding: 1em; overflow-x: auto; white-space: pre-wrap; }
+.failed { color: #cf222e; }
+.muted { color: #59636e; font-style: italic; }
+</style>
+</head>
+<body>
+<h1>claude-loop run report: {{.RunID}}</h1>
+
+<table class="summary">
+<tr><th>Prompt</th><td>{{.Prompt}}</td></tr>
+{{- if .Branch}}
+<tr><th>Branch</th><td><code>{{.Branch}}</code></td></tr>
+{{- end}}
+{{- if .DryRun}}
+<tr><th>Mode</th><td>dry run</td></tr>
+{{- end}}
+<tr><th>Started</th><td>{{.StartedAt.Format "2006-01-02 15:04:05"}}</td></tr>
+<tr><th>Duration</th><td>{{duration .Duration}}</td></tr>
+<tr><th>Stop reason</th><td>{{.StopReason}}</td></tr>
+<tr><th>Iterations</th><td>{{.SuccessfulIterations}} successful / {{.TotalIterations}} total</td></tr>
+<tr><th>Cost</th><td>{{cost .TotalCost}} (reviewer {{cost .ReviewerCost}}, council {{cost .CouncilCost}})</td></tr>
+<tr><th>Tokens</th><td>{{tokens .InputTokens}} in / {{tokens .OutputTokens}} out</td></tr>
+{{- if .ChangesTracked}}
+<tr><th>Changes</th><td>{{.FilesChanged}} files, +{{.Insertions}} -{{.Deletions}}</td></tr>
+{{- end}}
+{{- if .LastError}}
+<tr><th>Last error</th><td class="failed">{{.LastError}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Iterations</h2>
+{{- if .Iterations}}
+<table>
+<tr><th>#</th><th>Status</th><th>Duration</th><th>Cost</th><th>Tokens (in/out)</th><th>Changes</th><th>Commits</th><th>Review</th><th>Council</th><th>Verification</th></tr>
+{{- range .Iterations}}
+<tr{{if .Error}} class="failed" title="{{.Error}}"{{end}}>
+<td>{{.Number}}</td><td>{{status .}}</td><td>{{duration .Duration}}</td><td>{{cost .TotalCost}}</td>
+<td>{{tokens .InputTokens}} / {{tokens .OutputTokens}}</td><td>{{changes .}}</td>
+<td>{{if .Changes}}{{len .Changes.Commits}}{{else}}0{{end}}</td>
+<td>{{review .Review}}</td><td>{{council .Council}}</td><td>{{verification .Verification}}</td>
+</tr>
+{{- end}}
+</table>
+{{- else}}
+<p class="muted">No iterations ran.</p>
+{{- end}}
+
+{{- with .PullRequests}}
+<h2>Pull Requests</h2>
+<ul>
+{{- range .}}
+<li><a href="{{.}}">{{.}}</a></li>
+{{- end}}
+</ul>
+{{- end}}
+
+{{- with .Commits}}
+<h2>Commits</h2>
+<ul>
+{{- range .}}
+<li><code title="{{.SHA}}">{{shortSHA .SHA}}</code> (iteration {{.Iteration}})</li>
+{{- end}}
+</ul>
+{{- end}}
+
+{{- with .ChangedFiles}}
+<h2>Changed Files</h2>
+<table>
+<tr><th>File</th><th>Added</th><th>Removed</th></tr>
+{{- range .}}
+<tr><td><code>{{.Path}}</code></td>{{if .Binary}}<td>binary</td><td>binary</td>{{else}}<td>{{.Insertions}}</td><td>{{.Deletions}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- end}}
+
+{{- with .ReviewVerdicts}}
+<h2>Review Verdicts</h2>
+<ul>
+{{- range .}}
+<li>Iteration {{.Iteration}}, pass {{.Pass}}: {{.Verdict}}{{reviewers .Reviewers}}
+{{- if .Findings}}<ul>{{range .Findings}}<li>{{.}}</li>{{end}}</ul>{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+
+{{- if .Decisions}}
+<h2>Council Decisions</h2>
+<ul>
+{{- range .Decisions}}
+<li><strong>Iteration {{.Number}}</strong> ({{council .Council}}): {{.Council.Decision}}
+{{- if .Council.Rationale}}<br><span class="muted">Rationale: {{.Council.Rationale}}</span>{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+
+{{- if .ProtectedReverts}}
+<h2>Protected Paths</h2>
+<ul>
+{{- range .ProtectedReverts}}
+<li>Iteration {{.Number}}: {{protected .Protected}}
+<ul>{{range .Protected.Violations}}<li><code>{{.Path}}</code> (protected by <code>{{.Pattern}}</code>)</li>{{end}}</ul></li>
+{{- end}}
+</ul>
+{{- end}}
+
+{{- if .OversizedChanges}}
+<h2>Oversized Changes</h2>
+<ul>
+{{- range .OversizedChanges}}
+<li>Iteration {{.Number}}: {{changeSize .ChangeSize}}</li>
+{{- end}}
+</ul>
+{{- end}}
+
+{{- if .SecretFindings}}
+<h2>Possible Secrets</h2>
+<ul>
+{{- range .SecretFindings}}
+<li>Iteration {{.Number}}: {{len .Secrets}} findings
+<ul>{{range .Secrets}}<li><code>{{.File}}:{{.Line}}</code> {{.Rule}} <code>{{.Redacted}}</code> (fingerprint <code>{{.Fingerprint}}</code>)</li>{{end}}</ul></li>
+{{- end}}
+</ul>
+{{- end}}
+
+<h2>Verification</h2>
+{{- if .Verified}}
+<ul>
+{{- range .Iterations}}{{if .Verification}}
+<li>Iteration {{.Number}}: {{verification .Verification}}
+{{- if .Verification.Failures}}<ul>{{range .Verification.Failures}}<li>{{.}}</li>{{end}}</ul>{{end}}</li>
+{{- end}}{{end}}
+</ul>
+{{- else}}
+<p class="muted">Verification did not run.</p>
+{{- end}}
+
+<h2>Final Notes</h2>
+{{- if .Notes}}
+<p>From <code>{{.NotesFile}}</code>:</p>
+<pre>{{.Notes}}</pre>
+{{- else}}
+<p class="muted">No notes file was written.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// htmlView adds the derived values the template needs to a Report.
+type htmlView struct {
+	*Report
+	InputTokens  int
+	OutputTokens int
+	FilesChanged int
+	Insertions   int
+	Deletions    int
+}
+
+// HTML renders the report as a self-contained HTML page.
+func HTML(r *Report) (string, error) {
+	view := &htmlView{Report: r}
+	view.InputTokens, view.OutputTokens = r.Tokens()
+	view.FilesChanged, view.Insertions, view.Deletions = r.ChangeTotals()
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, view); err != nil {
+		return "", &ReportError{Message: "failed to render HTML", Err: err}
+	}
+	return buf.String(), nil
+}
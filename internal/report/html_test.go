@@ -0,0 +1,41 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTML(t *testing.T) {
+	r := sampleReport()
+	r.Notes = "<script>alert(1)</script>"
+
+	page, err := HTML(r)
+	require.NoError(t, err)
+
+	assert.Contains(t, page, "<title>claude-loop run report: run-20261001-090000</title>")
+	assert.Contains(t, page, "<style>")
+	assert.NotContains(t, page, "<link")
+	assert.Contains(t, page, "12,000 in / 800 out")
+	assert.Contains(t, page, `<a href="https://github.com/acme/app/pull/7">`)
+	assert.Contains(t, page, "<td>$1.1000</td>")
+	assert.Contains(t, page, "<li>Iteration 1, pass 1: REQUEST_CHANGES<ul><li>Handle a missing file</li></ul></li>")
+	assert.Contains(t, page, "<li>Iteration 1, pass 2: APPROVE (default: APPROVE, tests: APPROVE)</li>")
+	assert.Contains(t, page, "Ship tests first")
+	assert.Contains(t, page, "<li>go test failed</li>")
+	assert.Contains(t, page, "<li><code>go.sum</code> (protected by <code>go.sum</code>)</li>")
+	assert.Contains(t, page, "<li>Iteration 3: 12 files, 900 lines (limit 8 files, 300 lines), split requested</li>")
+	assert.Contains(t, page, "<li><code>.env:2</code> aws-access-key <code>AKIA****</code> (fingerprint <code>0a1b2c3d4e5f6071</code>)</li>")
+	assert.Contains(t, page, "&lt;script&gt;")
+	assert.NotContains(t, page, "<script>")
+}
+
+func TestHTML_Empty(t *testing.T) {
+	page, err := HTML(&Report{RunID: "run-1"})
+	require.NoError(t, err)
+
+	assert.Contains(t, page, "No iterations ran.")
+	assert.Contains(t, page, "Verification did not run.")
+	assert.NotContains(t, page, "Pull Requests")
+}
@@ -0,0 +1,199 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown renders the report as a Markdown document.
+func Markdown(r *Report) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# claude-loop run report: %s\n\n", r.RunID)
+
+	b.WriteString("| | |\n|---|---|\n")
+	writeRow(&b, "Prompt", mdCell(r.Prompt))
+	if r.Branch != "" {
+		writeRow(&b, "Branch", "`"+r.Branch+"`")
+	}
+	if r.DryRun {
+		writeRow(&b, "Mode", "dry run")
+	}
+	writeRow(&b, "Started", r.StartedAt.Format("2006-01-02 15:04:05"))
+	writeRow(&b, "Duration", formatDuration(r.Duration()))
+	writeRow(&b, "Stop reason", r.StopReason)
+	writeRow(&b, "Iterations", fmt.Sprintf("%d successful / %d total", r.SuccessfulIterations, r.TotalIterations))
+	writeRow(&b, "Cost", fmt.Sprintf("%s (reviewer %s, council %s)",
+		formatCost(r.TotalCost), formatCost(r.ReviewerCost), formatCost(r.CouncilCost)))
+	in, out := r.Tokens()
+	writeRow(&b, "Tokens", fmt.Sprintf("%s in / %s out", formatTokens(in), formatTokens(out)))
+	if r.ChangesTracked() {
+		files, ins, del := r.ChangeTotals()
+		writeRow(&b, "Changes", fmt.Sprintf("%d files, +%d -%d", files, ins, del))
+	}
+	if r.LastError != "" {
+		writeRow(&b, "Last error", mdCell(r.LastError))
+	}
+
+	b.WriteString("\n## Iterations\n\n")
+	if len(r.Iterations) == 0 {
+		b.WriteString("_No iterations ran._\n")
+	} else {
+		b.WriteString("| # | Status | Duration | Cost | Tokens (in/out) | Changes | Commits | Review | Council | Verification |\n")
+		b.WriteString("|---|---|---|---|---|---|---|---|---|---|\n")
+		for _, it := range r.Iterations {
+			commits := 0
+			if it.Changes != nil {
+				commits = len(it.Changes.Commits)
+			}
+			fmt.Fprintf(&b, "| %d | %s | %s | %s | %s / %s | %s | %d | %s | %s | %s |\n",
+				it.Number,
+				iterationStatus(it),
+				formatDuration(it.Duration),
+				formatCost(it.TotalCost()),
+				formatTokens(it.InputTokens), formatTokens(it.OutputTokens),
+				changeSummary(it),
+				commits,
+				mdCell(reviewSummary(it.Review)),
+				councilSummary(it.Council),
+				verificationSummary(it.Verification),
+			)
+		}
+
+		var failures []string
+		for _, it := range r.Iterations {
+			if it.Error != "" {
+				failures = append(failures, fmt.Sprintf("- Iteration %d: %s", it.Number, oneLine(it.Error)))
+			}
+			if it.CommitError != "" {
+				failures = append(failures, fmt.Sprintf("- Iteration %d: not committed: %s", it.Number, oneLine(it.CommitError)))
+			}
+		}
+		if len(failures) > 0 {
+			b.WriteString("\n**Errors**\n\n")
+			b.WriteString(strings.Join(failures, "\n") + "\n")
+		}
+	}
+
+	if prs := r.PullRequests(); len(prs) > 0 {
+		b.WriteString("\n## Pull Requests\n\n")
+		for _, url := range prs {
+			fmt.Fprintf(&b, "- %s\n", url)
+		}
+	}
+
+	if commits := r.Commits(); len(commits) > 0 {
+		b.WriteString("\n## Commits\n\n")
+		for _, c := range commits {
+			fmt.Fprintf(&b, "- `%s` (iteration %d)\n", shortSHA(c.SHA), c.Iteration)
+		}
+	}
+
+	if files := r.ChangedFiles(); len(files) > 0 {
+		b.WriteString("\n## Changed Files\n\n| File | Added | Removed |\n|---|---|---|\n")
+		for _, f := range files {
+			if f.Binary {
+				fmt.Fprintf(&b, "| `%s` | binary | binary |\n", f.Path)
+				continue
+			}
+			fmt.Fprintf(&b, "| `%s` | %d | %d |\n", f.Path, f.Insertions, f.Deletions)
+		}
+	}
+
+	if len(r.ReviewVerdicts) > 0 {
+		b.WriteString("\n## Review Verdicts\n\n")
+		for _, v := range r.ReviewVerdicts {
+			fmt.Fprintf(&b, "- Iteration %d, pass %d: %s%s\n", v.Iteration, v.Pass, v.Verdict, reviewersSummary(v.Reviewers))
+			for _, f := range v.Findings {
+				fmt.Fprintf(&b, "  - %s\n", oneLine(f))
+			}
+		}
+	}
+
+	var decisions []string
+	for _, it := range r.Decisions() {
+		entry := fmt.Sprintf("- **Iteration %d** (%s): %s", it.Number, councilSummary(it.Council), oneLine(it.Council.Decision))
+		if it.Council.Rationale != "" {
+			entry += "\n  - Rationale: " + oneLine(it.Council.Rationale)
+		}
+		decisions = append(decisions, entry)
+	}
+	if len(decisions) > 0 {
+		b.WriteString("\n## Council Decisions\n\n")
+		b.WriteString(strings.Join(decisions, "\n") + "\n")
+	}
+
+	if reverts := r.ProtectedReverts(); len(reverts) > 0 {
+		b.WriteString("\n## Protected Paths\n\n")
+		for _, it := range reverts {
+			fmt.Fprintf(&b, "- Iteration %d: %s\n", it.Number, protectedSummary(it.Protected))
+			for _, v := range it.Protected.Violations {
+				fmt.Fprintf(&b, "  - `%s` (protected by `%s`)\n", v.Path, v.Pattern)
+			}
+		}
+	}
+
+	if oversized := r.OversizedChanges(); len(oversized) > 0 {
+		b.WriteString("\n## Oversized Changes\n\n")
+		for _, it := range oversized {
+			fmt.Fprintf(&b, "- Iteration %d: %s\n", it.Number, changeSizeSummary(it.ChangeSize))
+		}
+	}
+
+	if found := r.SecretFindings(); len(found) > 0 {
+		b.WriteString("\n## Possible Secrets\n\n")
+		for _, it := range found {
+			fmt.Fprintf(&b, "- Iteration %d: %d findings\n", it.Number, len(it.Secrets))
+			for _, f := range it.Secrets {
+				fmt.Fprintf(&b, "  - `%s:%d` %s `%s` (fingerprint `%s`)\n", f.File, f.Line, f.Rule, f.Redacted, f.Fingerprint)
+			}
+		}
+	}
+
+	b.WriteString("\n## Verification\n\n")
+	for _, it := range r.Iterations {
+		if it.Verification == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "- Iteration %d: %s\n", it.Number, verificationSummary(it.Verification))
+		for _, f := range it.Verification.Failures {
+			fmt.Fprintf(&b, "  - %s\n", oneLine(f))
+		}
+	}
+	if !r.Verified() {
+		b.WriteString("_Verification did not run._\n")
+	}
+
+	b.WriteString("\n## Final Notes\n\n")
+	if r.Notes == "" {
+		b.WriteString("_No notes file was written._\n")
+	} else {
+		fmt.Fprintf(&b, "From `%s`:\n\n", r.NotesFile)
+		b.WriteString(quote(r.Notes))
+	}
+
+	return b.String()
+}
+
+// writeRow writes a two-column summary table row.
+func writeRow(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "| %s | %s |\n", key, value)
+}
+
+// mdCell makes text safe to place in a Markdown table cell.
+func mdCell(s string) string {
+	return strings.ReplaceAll(oneLine(s), "|", `\|`)
+}
+
+// quote renders text as a Markdown blockquote so embedded headings stay nested under the report.
+func quote(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
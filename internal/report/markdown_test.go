@@ -0,0 +1,45 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+	md := Markdown(sampleReport())
+
+	assert.Contains(t, md, "# claude-loop run report: run-20261001-090000")
+	assert.Contains(t, md, `| Prompt | Add tests \| docs |`)
+	assert.Contains(t, md, "| Duration | 5m0s |")
+	assert.Contains(t, md, "| Tokens | 12,000 in / 800 out |")
+	assert.Contains(t, md, "| Changes | 2 files, +11 -2 |")
+	assert.Contains(t, md, "| 1 | ok | 2m0s | $1.1000 | 12,000 / 800 | 2 files, +10 -2 | 1 | passed ($0.2500) | council resolved | - |")
+	assert.Contains(t, md, "- Iteration 2: iteration 2: claude execution failed: exit 1")
+	assert.Contains(t, md, "| 3 | ok, not committed |")
+	assert.Contains(t, md, "- Iteration 3: not committed: secrets: commit blocked, staged changes contain secrets: .env:2 (aws-access-key)")
+	assert.Contains(t, md, "- https://github.com/acme/app/pull/7")
+	assert.Contains(t, md, "- `0123456` (iteration 1)")
+	assert.Contains(t, md, "| `logo.png` | binary | binary |")
+	assert.Contains(t, md, "## Review Verdicts\n\n- Iteration 1, pass 1: REQUEST_CHANGES\n  - Handle a missing file\n- Iteration 1, pass 2: APPROVE (default: APPROVE, tests: APPROVE)\n")
+	assert.Contains(t, md, "- **Iteration 1** (council resolved): Ship tests first\n  - Rationale: Speed")
+	assert.Contains(t, md, "- Iteration 3: reverted 1 files (files)\n  - `go.sum` (protected by `go.sum`)")
+	assert.Contains(t, md, "## Oversized Changes\n\n- Iteration 3: 12 files, 900 lines (limit 8 files, 300 lines), split requested\n")
+	assert.Contains(t, md, "- Iteration 3: 1 findings\n  - `.env:2` aws-access-key `AKIA****` (fingerprint `0a1b2c3d4e5f6071`)")
+	assert.Contains(t, md, "- Iteration 3: failed (1)\n  - go test failed")
+	assert.Contains(t, md, "> # Notes\n>\n> All good.\n")
+}
+
+func TestMarkdown_Empty(t *testing.T) {
+	md := Markdown(&Report{RunID: "run-1"})
+
+	assert.Contains(t, md, "_No iterations ran._")
+	assert.Contains(t, md, "_Verification did not run._")
+	assert.Contains(t, md, "_No notes file was written._")
+	assert.NotContains(t, md, "## Pull Requests")
+	assert.NotContains(t, md, "## Protected Paths")
+	assert.NotContains(t, md, "## Oversized Changes")
+	assert.NotContains(t, md, "## Review Verdicts")
+	assert.NotContains(t, md, "## Possible Secrets")
+	assert.NotContains(t, md, "| Changes |")
+}
@@ -0,0 +1,426 @@
+// Package report generates Markdown and HTML summaries of claude-loop runs.
+package report
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/DeukWoongWoo/claude-loop/internal/reviewer"
+)
+
+// Report is everything known about a finished run.
+// It is persisted as JSON so reports for past runs can be regenerated.
+type Report struct {
+	RunID                string                 `json:"run_id"`
+	Prompt               string                 `json:"prompt"`
+	Branch               string                 `json:"branch,omitempty"`
+	DryRun               bool                   `json:"dry_run,omitempty"`
+	StartedAt            time.Time              `json:"started_at"`
+	FinishedAt           time.Time              `json:"finished_at"`
+	StopReason           string                 `json:"stop_reason"`
+	LastError            string                 `json:"last_error,omitempty"`
+	SuccessfulIterations int                    `json:"successful_iterations"`
+	TotalIterations      int                    `json:"total_iterations"`
+	TotalCost            float64                `json:"total_cost"`
+	ReviewerCost         float64                `json:"reviewer_cost"`
+	CouncilCost          float64                `json:"council_cost"`
+	CouncilInvocations   int                    `json:"council_invocations"`
+	Iterations           []loop.IterationRecord `json:"iterations"`
+	ReviewVerdicts       []loop.VerdictRecord   `json:"review_verdicts,omitempty"`
+	NotesFile            string                 `json:"notes_file,omitempty"`
+	Notes                string                 `json:"notes,omitempty"` // Notes file contents at the end of the run
+}
+
+// New builds a report from a finished loop.
+// The notes file named in config is read as the run's final notes; a missing file is not an error.
+func New(runID string, config *loop.Config, result *loop.LoopResult, finishedAt time.Time) *Report {
+	state := result.State
+	r := &Report{
+		RunID:                runID,
+		Prompt:               config.Prompt,
+		Branch:               config.Branch,
+		DryRun:               config.DryRun,
+		StartedAt:            state.StartTime,
+		FinishedAt:           finishedAt,
+		StopReason:           string(result.StopReason),
+		SuccessfulIterations: state.SuccessfulIterations,
+		TotalIterations:      state.TotalIterations,
+		TotalCost:            state.TotalCost,
+		ReviewerCost:         state.ReviewerCost,
+		CouncilCost:          state.CouncilCost,
+		CouncilInvocations:   state.CouncilInvocations,
+		Iterations:           state.Iterations,
+		ReviewVerdicts:       state.ReviewVerdicts,
+		NotesFile:            config.NotesFile,
+	}
+	if result.LastError != nil {
+		r.LastError = result.LastError.Error()
+	}
+	if config.NotesFile != "" {
+		if data, err := os.ReadFile(config.NotesFile); err == nil {
+			r.Notes = string(data)
+		}
+	}
+	return r
+}
+
+// Duration returns the wall-clock length of the run.
+func (r *Report) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// Tokens returns total input and output tokens across all iterations.
+func (r *Report) Tokens() (input, output int) {
+	for _, it := range r.Iterations {
+		input += it.InputTokens
+		output += it.OutputTokens
+	}
+	return input, output
+}
+
+// CommitRef is a commit made during a run.
+type CommitRef struct {
+	SHA       string
+	Iteration int
+}
+
+// Commits returns every commit made during the run, oldest first.
+func (r *Report) Commits() []CommitRef {
+	var commits []CommitRef
+	for _, it := range r.Iterations {
+		if it.Changes == nil {
+			continue
+		}
+		for _, sha := range it.Changes.Commits {
+			commits = append(commits, CommitRef{SHA: sha, Iteration: it.Number})
+		}
+	}
+	return commits
+}
+
+// PullRequests returns the distinct pull request links seen during the run.
+func (r *Report) PullRequests() []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, it := range r.Iterations {
+		for _, url := range it.PullRequests {
+			if !seen[url] {
+				seen[url] = true
+				urls = append(urls, url)
+			}
+		}
+	}
+	return urls
+}
+
+// ChangedFiles merges per-iteration diff stats into one entry per file, sorted by path.
+func (r *Report) ChangedFiles() []git.FileStat {
+	byPath := make(map[string]*git.FileStat)
+	for _, it := range r.Iterations {
+		if it.Changes == nil || it.Changes.Diff == nil {
+			continue
+		}
+		for _, f := range it.Changes.Diff.Files {
+			entry, ok := byPath[f.Path]
+			if !ok {
+				entry = &git.FileStat{Path: f.Path}
+				byPath[f.Path] = entry
+			}
+			entry.Insertions += f.Insertions
+			entry.Deletions += f.Deletions
+			entry.Binary = entry.Binary || f.Binary
+		}
+	}
+
+	files := make([]git.FileStat, 0, len(byPath))
+	for _, f := range byPath {
+		files = append(files, *f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// ChangeTotals returns the number of files changed and total lines added and removed.
+func (r *Report) ChangeTotals() (files, insertions, deletions int) {
+	changed := r.ChangedFiles()
+	for _, f := range changed {
+		insertions += f.Insertions
+		deletions += f.Deletions
+	}
+	return len(changed), insertions, deletions
+}
+
+// ChangesTracked reports whether any iteration recorded repository changes.
+func (r *Report) ChangesTracked() bool {
+	for _, it := range r.Iterations {
+		if it.Changes != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Decisions returns the iterations that produced a council decision.
+func (r *Report) Decisions() []loop.IterationRecord {
+	var decisions []loop.IterationRecord
+	for _, it := range r.Iterations {
+		if it.Council != nil && it.Council.Error == "" {
+			decisions = append(decisions, it)
+		}
+	}
+	return decisions
+}
+
+// ProtectedReverts returns the iterations that changed protected paths.
+func (r *Report) ProtectedReverts() []loop.IterationRecord {
+	var reverts []loop.IterationRecord
+	for _, it := range r.Iterations {
+		if it.Protected != nil {
+			reverts = append(reverts, it)
+		}
+	}
+	return reverts
+}
+
+// OversizedChanges returns the iterations that exceeded the change size limits.
+func (r *Report) OversizedChanges() []loop.IterationRecord {
+	var oversized []loop.IterationRecord
+	for _, it := range r.Iterations {
+		if it.ChangeSize != nil {
+			oversized = append(oversized, it)
+		}
+	}
+	return oversized
+}
+
+// SecretFindings returns the iterations whose changes contained possible secrets.
+func (r *Report) SecretFindings() []loop.IterationRecord {
+	var found []loop.IterationRecord
+	for _, it := range r.Iterations {
+		if len(it.Secrets) > 0 {
+			found = append(found, it)
+		}
+	}
+	return found
+}
+
+// Verified reports whether verification ran in any iteration.
+func (r *Report) Verified() bool {
+	for _, it := range r.Iterations {
+		if it.Verification != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// formatCost formats a USD amount the way the CLI prints costs.
+func formatCost(cost float64) string {
+	return fmt.Sprintf("$%.4f", cost)
+}
+
+// formatDuration rounds d for display.
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
+
+// formatTokens formats a token count with thousands separators.
+func formatTokens(n int) string {
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// shortSHA abbreviates a commit hash.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// iterationStatus summarizes whether an iteration succeeded.
+func iterationStatus(it loop.IterationRecord) string {
+	switch {
+	case it.Error != "":
+		return "failed"
+	case it.CommitError != "":
+		return "ok, not committed"
+	case it.CompletionSignalFound:
+		return "ok (complete)"
+	default:
+		return "ok"
+	}
+}
+
+// changeSummary describes an iteration's diff in one line.
+func changeSummary(it loop.IterationRecord) string {
+	if it.Changes == nil || it.Changes.Diff == nil {
+		return "-"
+	}
+	d := it.Changes.Diff
+	return fmt.Sprintf("%d files, +%d -%d", d.FilesChanged(), d.Insertions, d.Deletions)
+}
+
+// reviewSummary describes an iteration's review in one line.
+func reviewSummary(review *loop.ReviewRecord) string {
+	if review == nil {
+		return "-"
+	}
+	switch {
+	case review.Verdict == "" && review.Error != "":
+		return "error: " + review.Error
+	case review.Verdict == "" && review.CompletionSignalFound:
+		// Reports saved before reviewers gave verdicts
+		return fmt.Sprintf("passed, signalled completion (%s)", formatCost(review.Cost))
+	case review.Verdict == "":
+		return fmt.Sprintf("passed (%s)", formatCost(review.Cost))
+	}
+
+	var parts []string
+	switch review.Verdict {
+	case reviewer.VerdictApprove:
+		if review.Committed {
+			parts = append(parts, "approved, committed")
+		} else {
+			parts = append(parts, "approved")
+		}
+	case reviewer.VerdictRequestChanges:
+		parts = append(parts, "changes requested, left uncommitted")
+	case reviewer.VerdictBlock:
+		switch {
+		case review.RevertError != "":
+			parts = append(parts, "blocked, revert failed: "+review.RevertError)
+		default:
+			parts = append(parts, fmt.Sprintf("blocked, reverted %d files", len(review.Reverted)))
+		}
+	}
+	if review.Fixes > 0 {
+		parts = append(parts, fmt.Sprintf("%d fix passes", review.Fixes))
+	}
+	if review.CompletionSignalFound {
+		parts = append(parts, "signalled completion")
+	}
+	if review.Error != "" {
+		parts = append(parts, "error: "+review.Error)
+	}
+	return fmt.Sprintf("%s (%s)", strings.Join(parts, ", "), formatCost(review.Cost))
+}
+
+// reviewersSummary lists each reviewer's verdict, e.g. " (tests: APPROVE, security: BLOCK)".
+// Returns "" when fewer than two reviewers reviewed.
+func reviewersSummary(reviews []reviewer.Review) string {
+	if len(reviews) < 2 {
+		return ""
+	}
+	parts := make([]string, len(reviews))
+	for i, r := range reviews {
+		switch {
+		case r.Verdict != "":
+			parts[i] = fmt.Sprintf("%s: %s", r.Name, r.Verdict)
+		default:
+			parts[i] = fmt.Sprintf("%s: error (%s)", r.Name, r.Error)
+		}
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// councilSummary describes a council decision in one line.
+func councilSummary(c *loop.CouncilRecord) string {
+	switch {
+	case c == nil:
+		return "-"
+	case c.AwaitingEscalation != "":
+		return "held back, awaiting escalation " + c.AwaitingEscalation
+	case c.Escalation != nil && c.Escalation.Error == "":
+		return escalationSummary(c.Escalation)
+	case c.Error != "":
+		return "council error: " + c.Error
+	case c.Rule != "":
+		return "resolved by " + c.Rule
+	case c.Invoked && len(c.Votes) > 0:
+		agreed := 0
+		for _, v := range c.Votes {
+			if v.Error == "" && v.Vote == c.Vote {
+				agreed++
+			}
+		}
+		return fmt.Sprintf("council resolved (vote %s, %d/%d, confidence %.2f)", c.Vote, agreed, len(c.Votes), c.Confidence)
+	case c.Invoked:
+		return "council resolved"
+	default:
+		return "decision logged"
+	}
+}
+
+// escalationSummary describes a conflict handed to a human in one line.
+func escalationSummary(e *loop.EscalationRecord) string {
+	switch {
+	case e.Decision != "":
+		return "escalated (" + e.Reason + "), decided by a human"
+	case e.URL != "":
+		return "escalated (" + e.Reason + "), awaiting a decision in " + e.URL
+	default:
+		return "escalated (" + e.Reason + "), awaiting a decision in " + e.Path
+	}
+}
+
+// verificationSummary describes a verification result in one line.
+func verificationSummary(v *loop.VerificationRecord) string {
+	switch {
+	case v == nil:
+		return "-"
+	case v.Passed:
+		return "passed"
+	default:
+		return fmt.Sprintf("failed (%d)", len(v.Failures))
+	}
+}
+
+// changeSizeSummary describes an oversized change and how it was handled.
+func changeSizeSummary(c *loop.ChangeSizeRecord) string {
+	var limits []string
+	if c.MaxFiles > 0 {
+		limits = append(limits, fmt.Sprintf("%d files", c.MaxFiles))
+	}
+	if c.MaxLines > 0 {
+		limits = append(limits, fmt.Sprintf("%d lines", c.MaxLines))
+	}
+	size := fmt.Sprintf("%d files, %d lines (limit %s)", c.Files, c.Lines, strings.Join(limits, ", "))
+	switch {
+	case c.Error != "":
+		return size + ", revert failed: " + c.Error
+	case c.Action == config.ExceedRevert:
+		return size + ", reverted"
+	default:
+		return size + ", split requested"
+	}
+}
+
+// protectedSummary describes how a protected-path violation was handled.
+func protectedSummary(p *loop.ProtectedRecord) string {
+	switch {
+	case p.Error != "":
+		return "revert failed: " + p.Error
+	case p.Commits > 0:
+		return fmt.Sprintf("reverted iteration (%d files, %d commits)", len(p.Reverted), p.Commits)
+	default:
+		return fmt.Sprintf("reverted %d files (%s)", len(p.Reverted), p.Mode)
+	}
+}
+
+// oneLine collapses whitespace so text fits in a table cell.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
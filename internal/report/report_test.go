@@ -0,0 +1,198 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/config"
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+	"github.com/DeukWoongWoo/claude-loop/internal/loop"
+	"github.com/DeukWoongWoo/claude-loop/internal/protected"
+	"github.com/DeukWoongWoo/claude-loop/internal/reviewer"
+	"github.com/DeukWoongWoo/claude-loop/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleReport returns a report exercising every section.
+func sampleReport() *Report {
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	return &Report{
+		RunID:                "run-20261001-090000",
+		Prompt:               "Add tests | docs",
+		Branch:               "claude-loop/tests",
+		StartedAt:            start,
+		FinishedAt:           start.Add(5 * time.Minute),
+		StopReason:           "max_runs_reached",
+		SuccessfulIterations: 2,
+		TotalIterations:      3,
+		TotalCost:            1.5,
+		ReviewerCost:         0.25,
+		Iterations: []loop.IterationRecord{
+			{
+				Number: 1, Duration: 2 * time.Minute, Cost: 0.75, InputTokens: 12000, OutputTokens: 800,
+				Changes: &loop.ChangeSet{
+					Diff: &git.DiffStat{
+						Files:      []git.FileStat{{Path: "a.go", Insertions: 10, Deletions: 2}, {Path: "logo.png", Binary: true}},
+						Insertions: 10, Deletions: 2,
+					},
+					Commits: []string{"0123456789abcdef"},
+				},
+				PullRequests: []string{"https://github.com/acme/app/pull/7"},
+				Review:       &loop.ReviewRecord{Cost: 0.25},
+				Council:      &loop.CouncilRecord{Invoked: true, Decision: "Ship tests first", Rationale: "Speed", Cost: 0.1},
+			},
+			{Number: 2, Error: "iteration 2: claude execution failed: exit 1"},
+			{
+				Number: 3, Cost: 0.4,
+				Changes:      &loop.ChangeSet{Diff: &git.DiffStat{Files: []git.FileStat{{Path: "a.go", Insertions: 1}}, Insertions: 1}},
+				Verification: &loop.VerificationRecord{Passed: false, Failures: []string{"go test failed"}},
+				Protected: &loop.ProtectedRecord{
+					Violations: []protected.Violation{{Path: "go.sum", Pattern: "go.sum"}},
+					Mode:       config.RevertFiles,
+					Reverted:   []string{"go.sum"},
+				},
+				ChangeSize:  &loop.ChangeSizeRecord{Files: 12, Lines: 900, MaxFiles: 8, MaxLines: 300, Action: config.ExceedSplit},
+				Secrets:     []secrets.Finding{{File: ".env", Line: 2, Rule: "aws-access-key", Redacted: "AKIA****", Fingerprint: "0a1b2c3d4e5f6071"}},
+				CommitError: "secrets: commit blocked, staged changes contain secrets: .env:2 (aws-access-key)",
+			},
+		},
+		ReviewVerdicts: []loop.VerdictRecord{
+			{Iteration: 1, Pass: 1, Verdict: reviewer.VerdictRequestChanges, Findings: []string{"Handle a missing file"}},
+			{Iteration: 1, Pass: 2, Verdict: reviewer.VerdictApprove, Reviewers: []reviewer.Review{
+				{Name: "default", Verdict: reviewer.VerdictApprove}, {Name: "tests", Verdict: reviewer.VerdictApprove},
+			}},
+		},
+		NotesFile: "SHARED_TASK_NOTES.md",
+		Notes:     "# Notes\n\nAll good.\n",
+	}
+}
+
+func TestNew(t *testing.T) {
+	notes := filepath.Join(t.TempDir(), "NOTES.md")
+	require.NoError(t, os.WriteFile(notes, []byte("done"), 0644))
+
+	state := loop.NewState()
+	state.SuccessfulIterations = 1
+	state.TotalIterations = 1
+	state.TotalCost = 0.5
+	state.Iterations = []loop.IterationRecord{{Number: 1, Cost: 0.5}}
+	state.ReviewVerdicts = []loop.VerdictRecord{{Iteration: 1, Pass: 1, Verdict: reviewer.VerdictApprove}}
+	finished := state.StartTime.Add(time.Minute)
+
+	r := New("run-1", &loop.Config{Prompt: "p", NotesFile: notes, Branch: "main"}, &loop.LoopResult{
+		State:      state,
+		StopReason: loop.StopReasonMaxRuns,
+		LastError:  errors.New("boom"),
+	}, finished)
+
+	assert.Equal(t, "run-1", r.RunID)
+	assert.Equal(t, "max_runs_reached", r.StopReason)
+	assert.Equal(t, "boom", r.LastError)
+	assert.Equal(t, "done", r.Notes)
+	assert.Equal(t, "main", r.Branch)
+	assert.Equal(t, time.Minute, r.Duration())
+	assert.Len(t, r.Iterations, 1)
+	assert.Equal(t, state.ReviewVerdicts, r.ReviewVerdicts)
+}
+
+func TestNew_MissingNotes(t *testing.T) {
+	r := New("run-1", &loop.Config{NotesFile: filepath.Join(t.TempDir(), "missing.md")},
+		&loop.LoopResult{State: loop.NewState()}, time.Now())
+	assert.Empty(t, r.Notes)
+}
+
+func TestReport_Aggregates(t *testing.T) {
+	r := sampleReport()
+
+	in, out := r.Tokens()
+	assert.Equal(t, 12000, in)
+	assert.Equal(t, 800, out)
+
+	assert.Equal(t, []CommitRef{{SHA: "0123456789abcdef", Iteration: 1}}, r.Commits())
+	assert.Equal(t, []string{"https://github.com/acme/app/pull/7"}, r.PullRequests())
+
+	files := r.ChangedFiles()
+	require.Len(t, files, 2)
+	assert.Equal(t, git.FileStat{Path: "a.go", Insertions: 11, Deletions: 2}, files[0])
+
+	n, ins, del := r.ChangeTotals()
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 11, ins)
+	assert.Equal(t, 2, del)
+
+	assert.True(t, r.ChangesTracked())
+	assert.True(t, r.Verified())
+	require.Len(t, r.Decisions(), 1)
+	assert.Equal(t, 1, r.Decisions()[0].Number)
+	require.Len(t, r.ProtectedReverts(), 1)
+	assert.Equal(t, 3, r.ProtectedReverts()[0].Number)
+	require.Len(t, r.OversizedChanges(), 1)
+	assert.Equal(t, 3, r.OversizedChanges()[0].Number)
+	require.Len(t, r.SecretFindings(), 1)
+	assert.Equal(t, 3, r.SecretFindings()[0].Number)
+}
+
+func TestFormatTokens(t *testing.T) {
+	assert.Equal(t, "0", formatTokens(0))
+	assert.Equal(t, "999", formatTokens(999))
+	assert.Equal(t, "1,000", formatTokens(1000))
+	assert.Equal(t, "1,234,567", formatTokens(1234567))
+}
+
+func TestReviewSummary(t *testing.T) {
+	assert.Equal(t, "-", reviewSummary(nil))
+	assert.Equal(t, "error: reviewer output has no verdict", reviewSummary(&loop.ReviewRecord{Error: "reviewer output has no verdict"}))
+	assert.Equal(t, "passed ($0.2500)", reviewSummary(&loop.ReviewRecord{Cost: 0.25}))
+	assert.Equal(t, "approved, committed, 1 fix passes ($0.0400)", reviewSummary(&loop.ReviewRecord{
+		Verdict: reviewer.VerdictApprove, Committed: true, Fixes: 1, Cost: 0.04,
+	}))
+	assert.Equal(t, "approved, error: commit pass failed: exit 1 ($0.0200)", reviewSummary(&loop.ReviewRecord{
+		Verdict: reviewer.VerdictApprove, Error: "commit pass failed: exit 1", Cost: 0.02,
+	}))
+	assert.Equal(t, "changes requested, left uncommitted, 2 fix passes ($0.0600)", reviewSummary(&loop.ReviewRecord{
+		Verdict: reviewer.VerdictRequestChanges, Fixes: 2, Cost: 0.06,
+	}))
+	assert.Equal(t, "blocked, reverted 2 files ($0.0200)", reviewSummary(&loop.ReviewRecord{
+		Verdict: reviewer.VerdictBlock, Reverted: []string{"a.go", "b.go"}, Cost: 0.02,
+	}))
+	assert.Equal(t, "blocked, revert failed: index locked ($0.0200)", reviewSummary(&loop.ReviewRecord{
+		Verdict: reviewer.VerdictBlock, RevertError: "index locked", Cost: 0.02,
+	}))
+}
+
+func TestReviewersSummary(t *testing.T) {
+	assert.Empty(t, reviewersSummary(nil))
+	assert.Empty(t, reviewersSummary([]reviewer.Review{{Name: "default", Verdict: reviewer.VerdictApprove}}))
+	assert.Equal(t, " (tests: REQUEST_CHANGES, security: error (timeout))", reviewersSummary([]reviewer.Review{
+		{Name: "tests", Verdict: reviewer.VerdictRequestChanges},
+		{Name: "security", Error: "timeout"},
+	}))
+}
+
+func TestCouncilSummary(t *testing.T) {
+	assert.Equal(t, "-", councilSummary(nil))
+	assert.Equal(t, "council error: timeout", councilSummary(&loop.CouncilRecord{Invoked: true, Error: "timeout"}))
+	assert.Equal(t, "resolved by R3", councilSummary(&loop.CouncilRecord{Decision: "Prioritize cost", Rule: "R3"}))
+	assert.Equal(t, "council resolved", councilSummary(&loop.CouncilRecord{Invoked: true}))
+	assert.Equal(t, "council resolved (vote both, 2/3, confidence 0.80)", councilSummary(&loop.CouncilRecord{
+		Invoked: true, Vote: "both", Confidence: 0.8,
+		Votes: []loop.CouncilVote{{Member: "security", Vote: "both"}, {Member: "cost", Vote: "both"}, {Member: "product", Error: "timeout"}},
+	}))
+	assert.Equal(t, "decision logged", councilSummary(&loop.CouncilRecord{Decision: "Ship"}))
+	assert.Equal(t, "held back, awaiting escalation run-1-1", councilSummary(&loop.CouncilRecord{AwaitingEscalation: "run-1-1"}))
+	assert.Equal(t, "escalated (the council failed: timeout), awaiting a decision in .claude/escalations/r-1.md",
+		councilSummary(&loop.CouncilRecord{Invoked: true, Error: "timeout", Escalation: &loop.EscalationRecord{
+			Reason: "the council failed: timeout", Path: ".claude/escalations/r-1.md",
+		}}))
+	assert.Equal(t, "escalated (confidence 0.40 is below 0.60), decided by a human",
+		councilSummary(&loop.CouncilRecord{Invoked: true, Escalation: &loop.EscalationRecord{
+			Reason: "confidence 0.40 is below 0.60", Decision: "Audit first",
+		}}))
+	assert.Equal(t, "council error: timeout", councilSummary(&loop.CouncilRecord{
+		Invoked: true, Error: "timeout", Escalation: &loop.EscalationRecord{Error: "disk full"},
+	}))
+}
@@ -0,0 +1,64 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// File names written into a run directory.
+const (
+	JSONFile     = "report.json"
+	MarkdownFile = "report.md"
+	HTMLFile     = "report.html"
+)
+
+// Save writes the report as JSON, Markdown and HTML into dir and returns the written paths.
+func Save(dir string, r *Report) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, &ReportError{Path: dir, Message: "failed to create report directory", Err: err}
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, &ReportError{Message: "failed to encode report", Err: err}
+	}
+	page, err := HTML(r)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{JSONFile, data},
+		{MarkdownFile, []byte(Markdown(r))},
+		{HTMLFile, []byte(page)},
+	}
+
+	var paths []string
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if err := os.WriteFile(path, f.content, 0644); err != nil {
+			return nil, &ReportError{Path: path, Message: "failed to write report", Err: err}
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// Load reads the JSON report saved in dir.
+func Load(dir string) (*Report, error) {
+	path := filepath.Join(dir, JSONFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ReportError{Path: path, Message: "failed to read report", Err: err}
+	}
+
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, &ReportError{Path: path, Message: "invalid report", Err: err}
+	}
+	return &r, nil
+}
@@ -0,0 +1,50 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "run-1")
+	r := sampleReport()
+
+	paths, err := Save(dir, r)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, JSONFile),
+		filepath.Join(dir, MarkdownFile),
+		filepath.Join(dir, HTMLFile),
+	}, paths)
+
+	md, err := os.ReadFile(filepath.Join(dir, MarkdownFile))
+	require.NoError(t, err)
+	assert.Equal(t, Markdown(r), string(md))
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, r.RunID, loaded.RunID)
+	assert.Equal(t, Markdown(r), Markdown(loaded))
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Run("missing report", func(t *testing.T) {
+		_, err := Load(t.TempDir())
+		require.Error(t, err)
+		assert.True(t, IsReportError(err))
+		assert.True(t, os.IsNotExist(err.(*ReportError).Err))
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, JSONFile), []byte("{"), 0644))
+
+		_, err := Load(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid report")
+	})
+}
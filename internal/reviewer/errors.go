@@ -7,7 +7,7 @@ import (
 
 // ReviewerError represents an error during reviewer pass execution.
 type ReviewerError struct {
-	Phase   string // "config", "prompt", or "execute"
+	Phase   string // "config", "prompt", "execute", or "verdict"
 	Message string
 	Err     error
 }
@@ -33,4 +33,7 @@ func IsReviewerError(err error) bool {
 var (
 	// ErrNoReviewPrompt indicates no review prompt was provided.
 	ErrNoReviewPrompt = &ReviewerError{Phase: "prompt", Message: "no review prompt provided"}
+
+	// ErrNoVerdict indicates the reviewer output has no verdict block.
+	ErrNoVerdict = &ReviewerError{Phase: "verdict", Message: "reviewer output has no verdict"}
 )
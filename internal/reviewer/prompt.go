@@ -1,20 +1,36 @@
 package reviewer
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/DeukWoongWoo/claude-loop/internal/prompt"
 )
 
+// maxSummary is the most characters of the iteration's summary shown to a reviewer.
+const maxSummary = 2000
+
 // PromptBuilder builds prompts for reviewer passes.
-type PromptBuilder struct{}
+type PromptBuilder struct {
+	templates *prompt.TemplateSet
+}
 
 // NewPromptBuilder creates a new PromptBuilder.
 func NewPromptBuilder() *PromptBuilder {
 	return &PromptBuilder{}
 }
 
+// NewPromptBuilderWithTemplates creates a PromptBuilder that honours the
+// reviewer_context override in templates.
+func NewPromptBuilderWithTemplates(templates *prompt.TemplateSet) *PromptBuilder {
+	return &PromptBuilder{templates: templates}
+}
+
 // BuildContext contains inputs for building a reviewer prompt.
 type BuildContext struct {
-	UserReviewPrompt string // User's review instructions from -r flag
+	UserReviewPrompt string   // User's review instructions from -r flag or the reviewers file
+	Changes          *Changes // The iteration's changes (nil = the reviewer finds them itself)
+	MaxDiffBytes     int      // Diff size cap in bytes (0 = DefaultMaxDiffBytes)
 }
 
 // BuildResult contains the built prompt.
@@ -23,15 +39,79 @@ type BuildResult struct {
 }
 
 // Build constructs a reviewer prompt by combining the reviewer context template
-// with the user's review instructions.
+// with the user's review instructions, the changes under review and the verdict format.
 func (b *PromptBuilder) Build(ctx BuildContext) (*BuildResult, error) {
 	if ctx.UserReviewPrompt == "" {
 		return nil, ErrNoReviewPrompt
 	}
 
-	fullPrompt := prompt.TemplateReviewerContext +
-		"\n\n## USER REVIEW INSTRUCTIONS\n\n" +
-		ctx.UserReviewPrompt
+	reviewerContext, err := b.templates.Render(prompt.TemplateNameReviewerContext, prompt.TemplateData{
+		ReviewPrompt: ctx.UserReviewPrompt,
+	}, prompt.TemplateReviewerContext)
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(reviewerContext)
+	sb.WriteString("\n\n## USER REVIEW INSTRUCTIONS\n\n")
+	sb.WriteString(ctx.UserReviewPrompt)
+	sb.WriteString("\n\n")
+	if ctx.Changes != nil {
+		writeChanges(&sb, ctx.Changes, ctx.MaxDiffBytes)
+	}
+	sb.WriteString(prompt.TemplateReviewVerdict)
+
+	return &BuildResult{Prompt: sb.String()}, nil
+}
+
+// writeChanges writes the changes under review, with the diff cut to maxDiff bytes.
+func writeChanges(sb *strings.Builder, changes *Changes, maxDiff int) {
+	sb.WriteString(prompt.TemplateReviewChanges)
+	if summary := strings.TrimSpace(changes.Summary); summary != "" {
+		if runes := []rune(summary); len(runes) > maxSummary {
+			summary = strings.TrimSpace(string(runes[:maxSummary-3])) + "..."
+		}
+		fmt.Fprintf(sb, "**Iteration summary**:\n\n%s\n\n", summary)
+	}
+	if len(changes.Files) == 0 {
+		sb.WriteString("**Changed files**: none\n\n")
+		return
+	}
+	sb.WriteString("**Changed files**:\n")
+	for _, file := range changes.Files {
+		fmt.Fprintf(sb, "- %s\n", file)
+	}
+	sb.WriteString("\n")
+
+	diff, cut := capDiff(changes.Diff, maxDiff)
+	if diff == "" {
+		return
+	}
+	sb.WriteString("**Diff**:\n\n```diff\n")
+	sb.WriteString(diff)
+	if !strings.HasSuffix(diff, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("```\n\n")
+	if cut {
+		fmt.Fprintf(sb, "The diff was cut at %d of %d bytes; read the changed files for the rest.\n\n",
+			len(diff), len(changes.Diff))
+	}
+}
 
-	return &BuildResult{Prompt: fullPrompt}, nil
+// capDiff cuts diff to at most max bytes (0 = DefaultMaxDiffBytes), at a line
+// boundary when there is one. Reports whether anything was cut.
+func capDiff(diff string, max int) (string, bool) {
+	if max <= 0 {
+		max = DefaultMaxDiffBytes
+	}
+	if len(diff) <= max {
+		return diff, false
+	}
+	cut := diff[:max]
+	if i := strings.LastIndexByte(cut, '\n'); i > 0 {
+		cut = cut[:i+1]
+	}
+	return strings.ToValidUTF8(cut, ""), true
 }
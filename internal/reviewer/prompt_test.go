@@ -103,8 +103,8 @@ func TestPromptBuilder_Build_PromptFormat(t *testing.T) {
 	// First part should be the reviewer context
 	assert.Equal(t, prompt.TemplateReviewerContext, parts[0])
 
-	// Second part should be the user's review instructions
-	assert.Equal(t, "test instructions", parts[1])
+	// Second part should be the user's review instructions, then the verdict format
+	assert.Equal(t, "test instructions\n\n"+prompt.TemplateReviewVerdict, parts[1])
 }
 
 func TestPromptBuilder_Build_ContainsReviewerContext(t *testing.T) {
@@ -122,3 +122,72 @@ func TestPromptBuilder_Build_ContainsReviewerContext(t *testing.T) {
 	assert.Contains(t, result.Prompt, "review pass")
 	assert.Contains(t, result.Prompt, "git commands")
 }
+
+func TestPromptBuilder_Build_Changes(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPromptBuilder()
+
+	t.Run("summary, files and diff", func(t *testing.T) {
+		t.Parallel()
+		result, err := builder.Build(BuildContext{
+			UserReviewPrompt: "check the tests",
+			Changes: &Changes{
+				Summary: "Added config parsing",
+				Files:   []string{"config.go (+10 -2)", "logo.png (binary)"},
+				Diff:    "diff --git a/config.go b/config.go\n+func Parse() {}\n",
+			},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, result.Prompt, prompt.TemplateReviewChanges+"**Iteration summary**:\n\nAdded config parsing\n\n")
+		assert.Contains(t, result.Prompt, "**Changed files**:\n- config.go (+10 -2)\n- logo.png (binary)\n")
+		assert.Contains(t, result.Prompt, "```diff\ndiff --git a/config.go b/config.go\n+func Parse() {}\n```\n")
+		assert.NotContains(t, result.Prompt, "was cut")
+		assert.Less(t, strings.Index(result.Prompt, "USER REVIEW INSTRUCTIONS"), strings.Index(result.Prompt, "CHANGES UNDER REVIEW"))
+		assert.Less(t, strings.Index(result.Prompt, "CHANGES UNDER REVIEW"), strings.Index(result.Prompt, "REVIEW VERDICT"))
+	})
+
+	t.Run("diff over the cap", func(t *testing.T) {
+		t.Parallel()
+		diff := "+line one\n+line two\n+line three\n"
+		result, err := builder.Build(BuildContext{
+			UserReviewPrompt: "check the tests",
+			Changes:          &Changes{Files: []string{"a.go (+3 -0)"}, Diff: diff},
+			MaxDiffBytes:     25,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, result.Prompt, "```diff\n+line one\n+line two\n```\n")
+		assert.Contains(t, result.Prompt, "The diff was cut at 20 of 32 bytes")
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		t.Parallel()
+		result, err := builder.Build(BuildContext{UserReviewPrompt: "check the tests", Changes: &Changes{}})
+		require.NoError(t, err)
+		assert.Contains(t, result.Prompt, "**Changed files**: none\n")
+		assert.NotContains(t, result.Prompt, "```diff")
+	})
+
+	t.Run("no changes given", func(t *testing.T) {
+		t.Parallel()
+		result, err := builder.Build(BuildContext{UserReviewPrompt: "check the tests"})
+		require.NoError(t, err)
+		assert.NotContains(t, result.Prompt, "CHANGES UNDER REVIEW")
+	})
+}
+
+func TestCapDiff(t *testing.T) {
+	t.Parallel()
+
+	diff, cut := capDiff("short", 0)
+	assert.Equal(t, "short", diff)
+	assert.False(t, cut)
+
+	diff, cut = capDiff(strings.Repeat("x", DefaultMaxDiffBytes+1), 0)
+	assert.Len(t, diff, DefaultMaxDiffBytes)
+	assert.True(t, cut)
+
+	diff, cut = capDiff("ééé", 3)
+	assert.Equal(t, "é", diff, "a rune is not split")
+	assert.True(t, cut)
+}
@@ -2,6 +2,9 @@ package reviewer
 
 import (
 	"context"
+	"strings"
+	"sync"
+	"time"
 )
 
 // DefaultReviewer implements the Reviewer interface.
@@ -20,14 +23,99 @@ func NewReviewer(config *Config, client ClaudeClient) *DefaultReviewer {
 	return &DefaultReviewer{
 		config:        config,
 		client:        client,
-		promptBuilder: NewPromptBuilder(),
+		promptBuilder: NewPromptBuilderWithTemplates(config.Templates),
 	}
 }
 
-// Run executes a reviewer pass by building a prompt and executing Claude.
-func (r *DefaultReviewer) Run(ctx context.Context) (*Result, error) {
+// verdictSeverity orders verdicts from least to most severe.
+var verdictSeverity = map[Verdict]int{
+	VerdictApprove:        1,
+	VerdictRequestChanges: 2,
+	VerdictBlock:          3,
+}
+
+// Run executes a reviewer pass: every configured reviewer reviews changes in
+// parallel and their verdicts are merged.
+// The merged verdict is the most severe one given. It is empty when a reviewer
+// failed or gave no verdict and nobody asked for more than APPROVE, so a
+// failed reviewer never approves. With several reviewers, findings are prefixed
+// with the reviewer's name. The cost counts every reviewer that returned a
+// result, including the partial spend of failed ones. An error is returned only
+// when every reviewer failed, with a result carrying their spend if any.
+func (r *DefaultReviewer) Run(ctx context.Context, changes *Changes) (*Result, error) {
+	specs := r.config.specs()
+	if len(specs) == 0 {
+		return nil, ErrNoReviewPrompt
+	}
+	if len(specs) == 1 {
+		return r.review(ctx, specs[0], changes)
+	}
+
+	startTime := time.Now()
+	results := make([]*Result, len(specs))
+	errs := make([]error, len(specs))
+	var wg sync.WaitGroup
+	for i, spec := range specs {
+		wg.Add(1)
+		go func(i int, spec Spec) {
+			defer wg.Done()
+			results[i], errs[i] = r.review(ctx, spec, changes)
+		}(i, spec)
+	}
+	wg.Wait()
+
+	merged := &Result{CompletionSignalFound: true}
+	var outputs []string
+	answered, failed := 0, false
+	for i, spec := range specs {
+		res := results[i]
+		if res != nil {
+			merged.Cost += res.Cost
+		}
+		if errs[i] != nil {
+			review := Review{Name: spec.Name, Error: errs[i].Error()}
+			if res != nil {
+				review.Cost = res.Cost
+			}
+			merged.Reviews = append(merged.Reviews, review)
+			failed = true
+			continue
+		}
+		answered++
+		merged.Reviews = append(merged.Reviews, res.Reviews...)
+		failed = failed || res.Verdict == ""
+
+		merged.CompletionSignalFound = merged.CompletionSignalFound && res.CompletionSignalFound
+		outputs = append(outputs, "## "+spec.Name+"\n\n"+res.Output)
+		if verdictSeverity[res.Verdict] > verdictSeverity[merged.Verdict] {
+			merged.Verdict = res.Verdict
+		}
+		for _, finding := range res.Findings {
+			merged.Findings = append(merged.Findings, spec.Name+": "+finding)
+		}
+	}
+	if answered == 0 {
+		if merged.Cost > 0 {
+			return &Result{Cost: merged.Cost, Reviews: merged.Reviews, Duration: time.Since(startTime)}, firstError(errs)
+		}
+		return nil, firstError(errs)
+	}
+	if failed && merged.Verdict == VerdictApprove {
+		merged.Verdict = ""
+	}
+	merged.Output = strings.Join(outputs, "\n\n")
+	merged.Duration = time.Since(startTime)
+	return merged, nil
+}
+
+// review runs one reviewer and parses its verdict.
+// A verdict other than APPROVE without findings takes the rest of the output as its finding.
+// A failed reviewer that reported its spend returns a result with the cost along with the error.
+func (r *DefaultReviewer) review(ctx context.Context, spec Spec, changes *Changes) (*Result, error) {
 	buildResult, err := r.promptBuilder.Build(BuildContext{
-		UserReviewPrompt: r.config.ReviewPrompt,
+		UserReviewPrompt: spec.Prompt,
+		Changes:          changes,
+		MaxDiffBytes:     r.config.MaxDiffBytes,
 	})
 	if err != nil {
 		// ErrNoReviewPrompt is already a ReviewerError, wrap others
@@ -37,19 +125,58 @@ func (r *DefaultReviewer) Run(ctx context.Context) (*Result, error) {
 		return nil, &ReviewerError{Phase: "prompt", Message: "failed to build prompt", Err: err}
 	}
 
-	iterResult, err := r.client.Execute(ctx, buildResult.Prompt)
+	iterResult, err := r.clientFor(spec.Name).Execute(ctx, buildResult.Prompt)
 	if err != nil {
-		return nil, &ReviewerError{Phase: "execute", Message: "claude execution failed", Err: err}
+		err = &ReviewerError{Phase: "execute", Message: "claude execution failed", Err: err}
+		if iterResult == nil {
+			return nil, err
+		}
+		return &Result{
+			Cost:     iterResult.Cost,
+			Duration: iterResult.Duration,
+			Reviews:  []Review{{Name: spec.Name, Error: err.Error(), Cost: iterResult.Cost}},
+		}, err
 	}
 
+	verdict, findings, _ := ParseVerdict(iterResult.Output)
+	if len(findings) == 0 && verdict != "" && verdict != VerdictApprove {
+		if text := outputFinding(iterResult.Output); text != "" {
+			findings = []string{text}
+		}
+	}
+	review := Review{Name: spec.Name, Verdict: verdict, Findings: findings, Cost: iterResult.Cost}
+	if verdict == "" {
+		review.Error = ErrNoVerdict.Message
+	}
 	return &Result{
 		Output:                iterResult.Output,
 		Cost:                  iterResult.Cost,
 		Duration:              iterResult.Duration,
 		CompletionSignalFound: iterResult.CompletionSignalFound,
+		Verdict:               verdict,
+		Findings:              findings,
+		Reviews:               []Review{review},
 	}, nil
 }
 
+// clientFor returns the client for the named reviewer.
+func (r *DefaultReviewer) clientFor(name string) ClaudeClient {
+	if client, ok := r.config.Clients[name]; ok {
+		return client
+	}
+	return r.client
+}
+
+// firstError returns the first non-nil error in errs.
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Config returns the reviewer's configuration.
 func (r *DefaultReviewer) Config() *Config {
 	return r.config
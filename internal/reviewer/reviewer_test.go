@@ -3,6 +3,7 @@ package reviewer
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -74,7 +75,7 @@ func TestDefaultReviewer_Run_Success(t *testing.T) {
 	config := &Config{ReviewPrompt: "run npm test"}
 	reviewer := NewReviewer(config, client)
 
-	result, err := reviewer.Run(context.Background())
+	result, err := reviewer.Run(context.Background(), nil)
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -87,6 +88,27 @@ func TestDefaultReviewer_Run_Success(t *testing.T) {
 	require.Len(t, client.calls, 1)
 	assert.Contains(t, client.calls[0], "CODE REVIEW CONTEXT")
 	assert.Contains(t, client.calls[0], "run npm test")
+	assert.Contains(t, client.calls[0], "<review_verdict>")
+	assert.Empty(t, result.Verdict, "no verdict in the output")
+}
+
+func TestDefaultReviewer_Run_Verdict(t *testing.T) {
+	t.Parallel()
+
+	client := &MockClaudeClient{
+		ExecuteFunc: func(ctx context.Context, prompt string) (*IterationResult, error) {
+			return &IterationResult{
+				Output: "<review_verdict>\nverdict: REQUEST_CHANGES\nfindings:\n- Add a test for the empty file\n</review_verdict>",
+				Cost:   0.03,
+			}, nil
+		},
+	}
+
+	result, err := NewReviewer(&Config{ReviewPrompt: "review"}, client).Run(context.Background(), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, VerdictRequestChanges, result.Verdict)
+	assert.Equal(t, []string{"Add a test for the empty file"}, result.Findings)
 }
 
 func TestDefaultReviewer_Run_WithCompletionSignal(t *testing.T) {
@@ -106,7 +128,7 @@ func TestDefaultReviewer_Run_WithCompletionSignal(t *testing.T) {
 	config := &Config{ReviewPrompt: "check completion"}
 	reviewer := NewReviewer(config, client)
 
-	result, err := reviewer.Run(context.Background())
+	result, err := reviewer.Run(context.Background(), nil)
 
 	require.NoError(t, err)
 	assert.True(t, result.CompletionSignalFound)
@@ -125,7 +147,7 @@ func TestDefaultReviewer_Run_ClientError(t *testing.T) {
 	config := &Config{ReviewPrompt: "run tests"}
 	reviewer := NewReviewer(config, client)
 
-	result, err := reviewer.Run(context.Background())
+	result, err := reviewer.Run(context.Background(), nil)
 
 	require.Error(t, err)
 	assert.Nil(t, result)
@@ -144,7 +166,7 @@ func TestDefaultReviewer_Run_NoPromptError(t *testing.T) {
 	config := &Config{ReviewPrompt: ""} // Empty prompt
 	reviewer := NewReviewer(config, client)
 
-	result, err := reviewer.Run(context.Background())
+	result, err := reviewer.Run(context.Background(), nil)
 
 	require.Error(t, err)
 	assert.Nil(t, result)
@@ -173,7 +195,7 @@ func TestDefaultReviewer_Run_ContextCancelled(t *testing.T) {
 	config := &Config{ReviewPrompt: "run tests"}
 	reviewer := NewReviewer(config, client)
 
-	result, err := reviewer.Run(ctx)
+	result, err := reviewer.Run(ctx, nil)
 
 	require.Error(t, err)
 	assert.Nil(t, result)
@@ -217,10 +239,130 @@ func TestDefaultReviewer_Run_CostAccumulation(t *testing.T) {
 	// Run multiple times and verify costs
 	var totalCost float64
 	for i := 0; i < 3; i++ {
-		result, err := reviewer.Run(context.Background())
+		result, err := reviewer.Run(context.Background(), nil)
 		require.NoError(t, err)
 		totalCost += result.Cost
 	}
 
 	assert.InDelta(t, 0.06, totalCost, 0.001)
 }
+
+// answerClient answers every prompt with a fixed output; safe for parallel reviewers.
+type answerClient struct {
+	output  string
+	err     error
+	partial bool // With err, also return what was spent before failing
+
+	mu      sync.Mutex
+	prompts []string
+}
+
+func (c *answerClient) Execute(ctx context.Context, prompt string) (*IterationResult, error) {
+	c.mu.Lock()
+	c.prompts = append(c.prompts, prompt)
+	c.mu.Unlock()
+	if c.err != nil && c.partial {
+		return &IterationResult{Cost: 0.01}, c.err
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &IterationResult{Output: c.output, Cost: 0.01, CompletionSignalFound: true}, nil
+}
+
+func TestDefaultReviewer_Run_Reviewers(t *testing.T) {
+	t.Parallel()
+
+	approve := "<review_verdict>verdict: APPROVE</review_verdict>"
+	requestChanges := "<review_verdict>\nverdict: REQUEST_CHANGES\nfindings:\n- Add a test\n</review_verdict>"
+	block := "<review_verdict>\nverdict: BLOCK\nfindings:\n- Leaks the API key\n</review_verdict>"
+	changes := &Changes{Summary: "Added config parsing", Files: []string{"config.go (+10 -2)"}, Diff: "+func Parse() {}\n"}
+
+	run := func(t *testing.T, main, tests, security *answerClient) (*Result, error) {
+		t.Helper()
+		config := &Config{
+			ReviewPrompt: "run go test",
+			Reviewers: []Spec{
+				{Name: "tests", Prompt: "Check the tests"},
+				{Name: "security", Prompt: "Check for vulnerabilities"},
+			},
+			Clients: map[string]ClaudeClient{"tests": tests, "security": security},
+		}
+		return NewReviewer(config, main).Run(context.Background(), changes)
+	}
+
+	t.Run("each reviewer sees its instructions and the changes", func(t *testing.T) {
+		t.Parallel()
+		main, tests, security := &answerClient{output: approve}, &answerClient{output: approve}, &answerClient{output: approve}
+		result, err := run(t, main, tests, security)
+		require.NoError(t, err)
+
+		require.Len(t, tests.prompts, 1)
+		assert.Contains(t, tests.prompts[0], "## USER REVIEW INSTRUCTIONS\n\nCheck the tests\n")
+		assert.Contains(t, tests.prompts[0], "- config.go (+10 -2)\n")
+		assert.Contains(t, security.prompts[0], "Check for vulnerabilities")
+		assert.Contains(t, main.prompts[0], "run go test")
+
+		assert.Equal(t, VerdictApprove, result.Verdict)
+		assert.Empty(t, result.Findings)
+		assert.InDelta(t, 0.03, result.Cost, 0.001)
+		assert.True(t, result.CompletionSignalFound)
+		assert.Equal(t, []string{DefaultName, "tests", "security"},
+			[]string{result.Reviews[0].Name, result.Reviews[1].Name, result.Reviews[2].Name})
+		assert.Contains(t, result.Output, "## security\n\n"+approve)
+	})
+
+	t.Run("the most severe verdict wins", func(t *testing.T) {
+		t.Parallel()
+		result, err := run(t, &answerClient{output: approve}, &answerClient{output: requestChanges}, &answerClient{output: block})
+		require.NoError(t, err)
+		assert.Equal(t, VerdictBlock, result.Verdict)
+		assert.Equal(t, []string{"tests: Add a test", "security: Leaks the API key"}, result.Findings)
+		assert.Equal(t, VerdictRequestChanges, result.Reviews[1].Verdict)
+	})
+
+	t.Run("a failed reviewer does not approve", func(t *testing.T) {
+		t.Parallel()
+		result, err := run(t, &answerClient{output: approve}, &answerClient{output: "looks fine"}, &answerClient{err: errors.New("timeout")})
+		require.NoError(t, err)
+		assert.Empty(t, result.Verdict)
+		assert.Equal(t, ErrNoVerdict.Message, result.Reviews[1].Error)
+		assert.Contains(t, result.Reviews[2].Error, "timeout")
+		assert.InDelta(t, 0.02, result.Cost, 0.001)
+	})
+
+	t.Run("a failed reviewer's spend is counted", func(t *testing.T) {
+		t.Parallel()
+		result, err := run(t, &answerClient{output: approve}, &answerClient{output: "looks fine"},
+			&answerClient{err: errors.New("timeout"), partial: true})
+		require.NoError(t, err)
+		assert.InDelta(t, 0.03, result.Cost, 0.001)
+		assert.InDelta(t, 0.01, result.Reviews[2].Cost, 0.001)
+		assert.Contains(t, result.Reviews[2].Error, "timeout")
+	})
+
+	t.Run("a failed reviewer does not hide requested changes", func(t *testing.T) {
+		t.Parallel()
+		result, err := run(t, &answerClient{output: approve}, &answerClient{output: requestChanges}, &answerClient{err: errors.New("timeout")})
+		require.NoError(t, err)
+		assert.Equal(t, VerdictRequestChanges, result.Verdict)
+	})
+
+	t.Run("every reviewer failed", func(t *testing.T) {
+		t.Parallel()
+		failed := &answerClient{err: errors.New("timeout")}
+		_, err := run(t, failed, failed, failed)
+		require.Error(t, err)
+		assert.True(t, IsReviewerError(err))
+	})
+
+	t.Run("every reviewer failed after spending", func(t *testing.T) {
+		t.Parallel()
+		failed := &answerClient{err: errors.New("timeout"), partial: true}
+		result, err := run(t, failed, failed, failed)
+		require.Error(t, err)
+		require.NotNil(t, result)
+		assert.InDelta(t, 0.03, result.Cost, 0.001)
+		assert.Empty(t, result.Verdict)
+	})
+}
@@ -0,0 +1,74 @@
+package reviewer
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is where specialised reviewers are configured.
+const DefaultFile = ".claude/reviewers.yaml"
+
+// DefaultName is the name of the reviewer that runs the -r instructions.
+const DefaultName = "default"
+
+// DefaultMaxDiffBytes is the most diff shown to a reviewer when the file sets no limit.
+const DefaultMaxDiffBytes = 20000
+
+// Spec is a specialised reviewer: instructions for one concern, such as tests,
+// security or API compatibility.
+type Spec struct {
+	Name   string `yaml:"name"`
+	Prompt string `yaml:"prompt"`
+	Model  string `yaml:"model,omitempty"` // Model for this reviewer (empty = the reviewer model)
+}
+
+// Settings is the reviewers file: who reviews each iteration and how much of
+// the diff they are shown.
+type Settings struct {
+	Reviewers    []Spec `yaml:"reviewers"`
+	MaxDiffBytes int    `yaml:"max_diff_bytes,omitempty"` // Diff size cap in bytes (0 = DefaultMaxDiffBytes)
+}
+
+// LoadSettings reads the reviewers file at path. A missing file yields empty
+// Settings: only the -r reviewer runs.
+func LoadSettings(path string) (*Settings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Settings{}, nil
+		}
+		return nil, &ReviewerError{Phase: "config", Message: "failed to read " + path, Err: err}
+	}
+	var s Settings
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, &ReviewerError{Phase: "config", Message: "invalid YAML in " + path, Err: err}
+	}
+	if err := s.Validate(); err != nil {
+		return nil, &ReviewerError{Phase: "config", Message: path, Err: err}
+	}
+	return &s, nil
+}
+
+// Validate checks reviewer names and prompts and the diff size cap.
+func (s *Settings) Validate() error {
+	if s.MaxDiffBytes < 0 {
+		return fmt.Errorf("max_diff_bytes cannot be negative")
+	}
+	seen := make(map[string]bool)
+	for i, r := range s.Reviewers {
+		switch {
+		case r.Name == "":
+			return fmt.Errorf("reviewer %d has no name", i+1)
+		case r.Name == DefaultName:
+			return fmt.Errorf("reviewer name %q is reserved", DefaultName)
+		case seen[r.Name]:
+			return fmt.Errorf("duplicate reviewer %q", r.Name)
+		case r.Prompt == "":
+			return fmt.Errorf("reviewer %s has no prompt", r.Name)
+		}
+		seen[r.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package reviewer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSettings(t *testing.T) {
+	t.Run("missing file has no reviewers", func(t *testing.T) {
+		s, err := LoadSettings(filepath.Join(t.TempDir(), "reviewers.yaml"))
+		require.NoError(t, err)
+		assert.Empty(t, s.Reviewers)
+	})
+
+	t.Run("reads reviewers and the diff cap", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "reviewers.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`max_diff_bytes: 8000
+reviewers:
+  - name: tests
+    prompt: Check that every change is tested.
+    model: haiku
+  - name: security
+    prompt: Look for injection and leaked secrets.
+`), 0644))
+
+		s, err := LoadSettings(path)
+		require.NoError(t, err)
+		assert.Equal(t, 8000, s.MaxDiffBytes)
+		assert.Equal(t, []Spec{
+			{Name: "tests", Prompt: "Check that every change is tested.", Model: "haiku"},
+			{Name: "security", Prompt: "Look for injection and leaked secrets."},
+		}, s.Reviewers)
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		tests := []struct {
+			name, content, wantErr string
+		}{
+			{"yaml", "reviewers: [", "invalid YAML"},
+			{"unnamed reviewer", "reviewers:\n  - prompt: x\n", "reviewer 1 has no name"},
+			{"reserved name", "reviewers:\n  - name: default\n    prompt: x\n", `reviewer name "default" is reserved`},
+			{"duplicate", "reviewers:\n  - name: a\n    prompt: x\n  - name: a\n    prompt: y\n", `duplicate reviewer "a"`},
+			{"no prompt", "reviewers:\n  - name: a\n", "reviewer a has no prompt"},
+			{"negative cap", "max_diff_bytes: -1\n", "max_diff_bytes cannot be negative"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "reviewers.yaml")
+				require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+				_, err := LoadSettings(path)
+				require.Error(t, err)
+				assert.True(t, IsReviewerError(err))
+				assert.Contains(t, err.Error(), tt.wantErr)
+			})
+		}
+	})
+}
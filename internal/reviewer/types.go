@@ -5,6 +5,8 @@ package reviewer
 import (
 	"context"
 	"time"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/prompt"
 )
 
 // ClaudeClient executes Claude Code iterations.
@@ -24,13 +26,41 @@ type IterationResult struct {
 
 // Reviewer runs review passes.
 type Reviewer interface {
-	Run(ctx context.Context) (*Result, error)
+	Run(ctx context.Context, changes *Changes) (*Result, error)
 }
 
 // Config holds reviewer configuration.
 type Config struct {
 	ReviewPrompt         string // User's review instructions from -r flag
 	MaxConsecutiveErrors int    // Threshold for aborting on repeated failures
+
+	// Reviewers are specialised reviewers that run in parallel with the -r reviewer.
+	Reviewers []Spec
+
+	// MaxDiffBytes caps the diff shown to reviewers (0 = DefaultMaxDiffBytes).
+	MaxDiffBytes int
+
+	// Clients holds clients for reviewers that use their own model or permissions,
+	// keyed by reviewer name. Others use the reviewer client.
+	Clients map[string]ClaudeClient
+
+	Templates *prompt.TemplateSet // Optional template overrides (nil = built-in)
+}
+
+// Changes is what the reviewed iteration changed, shown to every reviewer.
+type Changes struct {
+	Summary string   // What the iteration reported doing
+	Files   []string // Changed files, one per item, e.g. "a.go (+10 -2)"
+	Diff    string   // Unified diff of the changes
+}
+
+// Review is one reviewer's part of a pass.
+type Review struct {
+	Name     string   `json:"name"`
+	Verdict  Verdict  `json:"verdict,omitempty"` // Empty when the reviewer failed or gave none
+	Findings []string `json:"findings,omitempty"`
+	Cost     float64  `json:"cost"`
+	Error    string   `json:"error,omitempty"`
 }
 
 // Result represents the outcome of a reviewer pass.
@@ -39,6 +69,9 @@ type Result struct {
 	Cost                  float64
 	Duration              time.Duration
 	CompletionSignalFound bool
+	Verdict               Verdict  // Empty when the output has no verdict
+	Findings              []string // What the reviewer found, one per item
+	Reviews               []Review // Each reviewer's verdict, in configuration order
 }
 
 // DefaultConfig returns a Config with default values.
@@ -48,7 +81,16 @@ func DefaultConfig() *Config {
 	}
 }
 
-// IsEnabled returns true if reviewer is configured with a prompt.
+// IsEnabled returns true if reviewer is configured with a prompt or specialised reviewers.
 func (c *Config) IsEnabled() bool {
-	return c != nil && c.ReviewPrompt != ""
+	return c != nil && (c.ReviewPrompt != "" || len(c.Reviewers) > 0)
+}
+
+// specs returns every reviewer that runs: the -r reviewer first, then the specialised ones.
+func (c *Config) specs() []Spec {
+	var specs []Spec
+	if c.ReviewPrompt != "" {
+		specs = append(specs, Spec{Name: DefaultName, Prompt: c.ReviewPrompt})
+	}
+	return append(specs, c.Reviewers...)
 }
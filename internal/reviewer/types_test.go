@@ -39,6 +39,11 @@ func TestConfig_IsEnabled(t *testing.T) {
 			config:   &Config{ReviewPrompt: "run tests"},
 			expected: true,
 		},
+		{
+			name:     "specialised reviewers only",
+			config:   &Config{Reviewers: []Spec{{Name: "tests", Prompt: "check the tests"}}},
+			expected: true,
+		},
 		{
 			name:     "whitespace-only prompt is enabled",
 			config:   &Config{ReviewPrompt: "   "},
@@ -0,0 +1,122 @@
+package reviewer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Verdict is the reviewer's judgement of an iteration's changes.
+type Verdict string
+
+const (
+	VerdictApprove        Verdict = "APPROVE"         // The changes may be committed
+	VerdictRequestChanges Verdict = "REQUEST_CHANGES" // The changes need the fixes in the findings first
+	VerdictBlock          Verdict = "BLOCK"           // The changes must be reverted
+)
+
+// VerdictBlockPattern matches the block a reviewer ends its review with:
+//
+//	<review_verdict>
+//	verdict: REQUEST_CHANGES
+//	findings:
+//	- parseConfig ignores the error from os.ReadFile
+//	- TestParseConfig does not cover an empty file
+//	</review_verdict>
+var VerdictBlockPattern = regexp.MustCompile(`(?is)<review_verdict>(.*?)</review_verdict>`)
+
+// VerdictLinePattern matches a one-line "VERDICT: <verdict>" at the start of a line.
+var VerdictLinePattern = regexp.MustCompile("(?im)^[ \\t>*`-]*VERDICT[*`]*:[ \\t]*(.+)$")
+
+// verdictField matches a field line of a verdict block, such as "findings:".
+var verdictField = regexp.MustCompile(`(?i)^(verdict|findings):\s*(.*)$`)
+
+// findingItem matches a list item: "- finding", "* finding", "1. finding" or "1) finding".
+var findingItem = regexp.MustCompile(`^(?:[-*]|\d+[.)])\s+(.*)$`)
+
+// verdictNames maps the spellings a reviewer may use to verdicts.
+var verdictNames = map[string]Verdict{
+	"APPROVE":           VerdictApprove,
+	"APPROVED":          VerdictApprove,
+	"REQUEST_CHANGES":   VerdictRequestChanges,
+	"CHANGES_REQUESTED": VerdictRequestChanges,
+	"BLOCK":             VerdictBlock,
+	"BLOCKED":           VerdictBlock,
+}
+
+// ParseVerdict extracts the verdict and findings from reviewer output. The last
+// verdict block is used; without one, the last VERDICT line, with the list items
+// of its paragraph as findings. ok is false when output has no known verdict.
+func ParseVerdict(output string) (verdict Verdict, findings []string, ok bool) {
+	if blocks := VerdictBlockPattern.FindAllStringSubmatch(output, -1); blocks != nil {
+		verdict, findings = parseVerdictFields(strings.Split(blocks[len(blocks)-1][1], "\n"), "")
+		return verdict, findings, verdict != ""
+	}
+
+	lines := VerdictLinePattern.FindAllStringSubmatchIndex(output, -1)
+	if lines == nil {
+		return "", nil, false
+	}
+	loc := lines[len(lines)-1]
+	rest := output[loc[1]:]
+	if end := strings.Index(rest, "\n\n"); end >= 0 {
+		rest = rest[:end]
+	}
+	_, findings = parseVerdictFields(strings.Split(rest, "\n"), "findings")
+	verdict = parseVerdictName(output[loc[2]:loc[3]])
+	return verdict, findings, verdict != ""
+}
+
+// maxOutputFinding is the most characters of reviewer output used as a finding.
+const maxOutputFinding = 1000
+
+// outputFinding returns the reviewer output outside the verdict block on one line,
+// shortened to maxOutputFinding characters, for verdicts that list no findings.
+func outputFinding(output string) string {
+	text := strings.Join(strings.Fields(VerdictBlockPattern.ReplaceAllString(output, "")), " ")
+	if runes := []rune(text); len(runes) > maxOutputFinding {
+		text = strings.TrimSpace(string(runes[:maxOutputFinding-3])) + "..."
+	}
+	return text
+}
+
+// parseVerdictFields reads the fields of a verdict block. Lines before the first
+// field belong to field; under findings, list items start a finding and other
+// lines continue it.
+func parseVerdictFields(lines []string, field string) (verdict Verdict, findings []string) {
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := verdictField.FindStringSubmatch(line); m != nil {
+			field, line = strings.ToLower(m[1]), strings.TrimSpace(m[2])
+			if line == "" {
+				continue
+			}
+		}
+		switch field {
+		case "verdict":
+			if verdict == "" {
+				verdict = parseVerdictName(line)
+			}
+		case "findings":
+			if m := findingItem.FindStringSubmatch(line); m != nil {
+				findings = append(findings, strings.TrimSpace(m[1]))
+			} else if n := len(findings); n > 0 {
+				findings[n-1] += " " + line
+			} else {
+				findings = append(findings, line)
+			}
+		}
+	}
+	return verdict, findings
+}
+
+// parseVerdictName returns the verdict text names, ignoring case, markdown
+// emphasis and whether words are joined by spaces, hyphens or underscores.
+// Returns "" for anything else.
+func parseVerdictName(text string) Verdict {
+	name := strings.ToUpper(strings.Trim(strings.TrimSpace(text), "*`_ .!"))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	return verdictNames[name]
+}
@@ -0,0 +1,78 @@
+package reviewer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVerdict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		output   string
+		verdict  Verdict
+		findings []string
+		ok       bool
+	}{
+		{
+			name:    "approve block",
+			output:  "All tests pass.\n\n<review_verdict>\nverdict: APPROVE\n</review_verdict>",
+			verdict: VerdictApprove,
+			ok:      true,
+		},
+		{
+			name: "request changes with findings",
+			output: "<review_verdict>\nverdict: REQUEST_CHANGES\nfindings:\n" +
+				"- parseConfig ignores the error\n  from os.ReadFile\n* TestParseConfig misses an empty file\n</review_verdict>",
+			verdict:  VerdictRequestChanges,
+			findings: []string{"parseConfig ignores the error from os.ReadFile", "TestParseConfig misses an empty file"},
+			ok:       true,
+		},
+		{
+			name:     "block with inline finding",
+			output:   "<REVIEW_VERDICT>\nVerdict: **blocked**\nFindings: deletes the migrations directory\n</REVIEW_VERDICT>",
+			verdict:  VerdictBlock,
+			findings: []string{"deletes the migrations directory"},
+			ok:       true,
+		},
+		{
+			name:    "spelled with spaces",
+			output:  "<review_verdict>verdict: request changes</review_verdict>",
+			verdict: VerdictRequestChanges,
+			ok:      true,
+		},
+		{
+			name:    "last block wins",
+			output:  "<review_verdict>verdict: BLOCK</review_verdict>\nOn second thought:\n<review_verdict>verdict: APPROVE</review_verdict>",
+			verdict: VerdictApprove,
+			ok:      true,
+		},
+		{
+			name:   "unknown verdict",
+			output: "<review_verdict>\nverdict: <APPROVE, REQUEST_CHANGES or BLOCK>\n</review_verdict>",
+		},
+		{
+			name:     "verdict line",
+			output:   "Review done.\n\n**VERDICT**: REQUEST_CHANGES\n- Handle the nil config\n- Add a test\n\nThanks.",
+			verdict:  VerdictRequestChanges,
+			findings: []string{"Handle the nil config", "Add a test"},
+			ok:       true,
+		},
+		{
+			name:   "no verdict",
+			output: "Looks good to me. I approve of these changes.",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			verdict, findings, ok := ParseVerdict(tt.output)
+			assert.Equal(t, tt.verdict, verdict)
+			assert.Equal(t, tt.findings, findings)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package secrets
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// Allowlist holds fingerprints of findings that are known not to be secrets.
+type Allowlist struct {
+	fingerprints map[string]bool
+}
+
+// NewAllowlist creates an Allowlist from fingerprints.
+func NewAllowlist(fingerprints ...string) *Allowlist {
+	a := &Allowlist{fingerprints: make(map[string]bool)}
+	for _, fp := range fingerprints {
+		a.fingerprints[fp] = true
+	}
+	return a
+}
+
+// LoadAllowlist reads an allowlist file: one fingerprint per line, optionally followed by
+// a note, with "#" starting a comment. A missing file yields an empty allowlist.
+func LoadAllowlist(path string) (*Allowlist, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return NewAllowlist(), nil
+	}
+	if err != nil {
+		return nil, &ScanError{Message: "failed to read allowlist " + path, Err: err}
+	}
+	defer f.Close()
+
+	a := NewAllowlist()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		a.fingerprints[strings.Fields(line)[0]] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &ScanError{Message: "failed to read allowlist " + path, Err: err}
+	}
+	return a, nil
+}
+
+// Allows reports whether fingerprint is allowlisted.
+func (a *Allowlist) Allows(fingerprint string) bool {
+	return a != nil && a.fingerprints[fingerprint]
+}
+
+// Len returns the number of allowlisted fingerprints.
+func (a *Allowlist) Len() int {
+	if a == nil {
+		return 0
+	}
+	return len(a.fingerprints)
+}
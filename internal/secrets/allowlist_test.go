@@ -0,0 +1,43 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAllowlist(t *testing.T) {
+	t.Run("fingerprints with notes and comments", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "secrets-allowlist")
+		content := "# Test fixtures\n\n0123456789abcdef  testdata/keys.pem:1 private-key\nfedcba9876543210\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		a, err := LoadAllowlist(path)
+		require.NoError(t, err)
+		assert.Equal(t, 2, a.Len())
+		assert.True(t, a.Allows("0123456789abcdef"))
+		assert.True(t, a.Allows("fedcba9876543210"))
+		assert.False(t, a.Allows("testdata/keys.pem:1"))
+	})
+
+	t.Run("missing file is empty", func(t *testing.T) {
+		a, err := LoadAllowlist(filepath.Join(t.TempDir(), "missing"))
+		require.NoError(t, err)
+		assert.Equal(t, 0, a.Len())
+	})
+
+	t.Run("unreadable path", func(t *testing.T) {
+		_, err := LoadAllowlist(t.TempDir())
+		require.Error(t, err)
+		assert.True(t, IsScanError(err))
+	})
+
+	t.Run("nil allowlist allows nothing", func(t *testing.T) {
+		var a *Allowlist
+		assert.False(t, a.Allows("0123456789abcdef"))
+		assert.Equal(t, 0, a.Len())
+	})
+}
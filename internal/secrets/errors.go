@@ -0,0 +1,39 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ScanError represents a secret scanning failure or a blocked commit.
+type ScanError struct {
+	Message  string
+	Findings []Finding // Set when a commit was blocked
+	Err      error
+}
+
+func (e *ScanError) Error() string {
+	msg := "secrets: " + e.Message
+	if len(e.Findings) > 0 {
+		locations := make([]string, len(e.Findings))
+		for i, f := range e.Findings {
+			locations[i] = fmt.Sprintf("%s:%d (%s)", f.File, f.Line, f.Rule)
+		}
+		msg += ": " + strings.Join(locations, ", ")
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// IsScanError checks if an error is a ScanError.
+func IsScanError(err error) bool {
+	var se *ScanError
+	return errors.As(err, &se)
+}
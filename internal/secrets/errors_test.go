@@ -0,0 +1,50 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanError_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *ScanError
+		expected string
+	}{
+		{
+			name:     "message only",
+			err:      &ScanError{Message: "unknown secrets action"},
+			expected: "secrets: unknown secrets action",
+		},
+		{
+			name: "with findings",
+			err: &ScanError{Message: "commit blocked", Findings: []Finding{
+				{File: ".env", Line: 2, Rule: "aws-access-key"},
+				{File: "main.go", Line: 10, Rule: "jwt"},
+			}},
+			expected: "secrets: commit blocked: .env:2 (aws-access-key), main.go:10 (jwt)",
+		},
+		{
+			name:     "with wrapped error",
+			err:      &ScanError{Message: "failed to read allowlist", Err: errors.New("permission denied")},
+			expected: "secrets: failed to read allowlist: permission denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.err.Error())
+		})
+	}
+}
+
+func TestIsScanError(t *testing.T) {
+	inner := errors.New("inner")
+	err := &ScanError{Message: "x", Err: inner}
+	assert.True(t, IsScanError(err))
+	assert.True(t, IsScanError(fmt.Errorf("wrapped: %w", err)))
+	assert.False(t, IsScanError(inner))
+	assert.ErrorIs(t, err, inner)
+}
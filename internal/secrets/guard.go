@@ -0,0 +1,140 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+)
+
+// Action is what a Guard does when staged changes contain secrets.
+type Action string
+
+const (
+	// ActionBlock aborts the commit (default).
+	ActionBlock Action = "block"
+	// ActionUnstage removes the affected files from the commit and keeps them in the working tree.
+	ActionUnstage Action = "unstage"
+	// ActionRedact replaces each secret with RedactedText in the working tree and restages the file.
+	ActionRedact Action = "redact"
+)
+
+// RedactedText replaces secrets with ActionRedact.
+const RedactedText = "REDACTED"
+
+// Actions lists the valid actions.
+var Actions = []Action{ActionBlock, ActionUnstage, ActionRedact}
+
+// ParseAction parses an action name. An empty name is ActionBlock.
+func ParseAction(s string) (Action, error) {
+	if s == "" {
+		return ActionBlock, nil
+	}
+	for _, a := range Actions {
+		if string(a) == s {
+			return a, nil
+		}
+	}
+	return "", &ScanError{Message: fmt.Sprintf("unknown secrets action %q (valid: block, unstage, redact)", s)}
+}
+
+// Guard scans staged changes before a commit and applies an Action to findings.
+// Its Check method is a git.StagedCheck.
+type Guard struct {
+	scanner  *Scanner
+	action   Action
+	root     string
+	findings []Finding
+}
+
+// NewGuard creates a Guard. root is the repository root used to rewrite files with
+// ActionRedact; empty means the working directory.
+func NewGuard(scanner *Scanner, action Action, root string) *Guard {
+	if scanner == nil {
+		scanner = NewScanner(nil)
+	}
+	if action == "" {
+		action = ActionBlock
+	}
+	return &Guard{scanner: scanner, action: action, root: root}
+}
+
+// Findings returns the findings of the most recent Check.
+func (g *Guard) Findings() []Finding {
+	return g.findings
+}
+
+// Check scans the staged diff. With ActionBlock, findings are returned as a *ScanError;
+// the other actions clean up the index and let the commit proceed.
+func (g *Guard) Check(ctx context.Context, commits *git.CommitManager) error {
+	diff, err := commits.StagedDiff(ctx)
+	if err != nil {
+		return &ScanError{Message: "failed to read staged changes", Err: err}
+	}
+	g.findings = g.scanner.ScanDiff(diff)
+	if len(g.findings) == 0 {
+		return nil
+	}
+
+	files := findingFiles(g.findings)
+	switch g.action {
+	case ActionUnstage:
+		if err := commits.Unstage(ctx, files); err != nil {
+			return &ScanError{Message: "failed to unstage files with secrets", Findings: g.findings, Err: err}
+		}
+		return nil
+	case ActionRedact:
+		if err := g.redactFiles(); err != nil {
+			return err
+		}
+		if err := commits.Stage(ctx, files); err != nil {
+			return &ScanError{Message: "failed to stage redacted files", Findings: g.findings, Err: err}
+		}
+		return nil
+	default:
+		return &ScanError{Message: "commit blocked, staged changes contain secrets", Findings: g.findings}
+	}
+}
+
+// redactFiles replaces every found secret in the working tree.
+func (g *Guard) redactFiles() error {
+	secretsByFile := make(map[string][]string)
+	for _, f := range g.findings {
+		secretsByFile[f.File] = append(secretsByFile[f.File], f.Secret)
+	}
+	for file, found := range secretsByFile {
+		path := filepath.Join(g.root, filepath.FromSlash(file))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return &ScanError{Message: "failed to redact " + file, Findings: g.findings, Err: err}
+		}
+		content := string(data)
+		for _, secret := range found {
+			content = strings.ReplaceAll(content, secret, RedactedText)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return &ScanError{Message: "failed to redact " + file, Findings: g.findings, Err: err}
+		}
+		if err := os.WriteFile(path, []byte(content), info.Mode().Perm()); err != nil {
+			return &ScanError{Message: "failed to redact " + file, Findings: g.findings, Err: err}
+		}
+	}
+	return nil
+}
+
+// findingFiles returns the distinct files with findings, in order.
+func findingFiles(findings []Finding) []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		if !seen[f.File] {
+			seen[f.File] = true
+			files = append(files, f.File)
+		}
+	}
+	return files
+}
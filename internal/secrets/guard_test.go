@@ -0,0 +1,125 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DeukWoongWoo/claude-loop/internal/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dirExecutor runs git commands in a fixed directory.
+type dirExecutor struct {
+	dir string
+}
+
+func (e *dirExecutor) CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = e.dir
+	return cmd
+}
+
+// newStagedRepo creates a repository with one commit, then stages a leaked key in .env
+// and an ordinary change in README.md.
+func newStagedRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitRun(t, dir, "init", "-q")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# app\n"), 0644))
+	gitRun(t, dir, "add", "-A")
+	gitRun(t, dir, "commit", "-q", "-m", "initial")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# app\n\nUsage\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("AWS_ACCESS_KEY_ID="+awsKey+"\n"), 0600))
+	gitRun(t, dir, "add", "-A")
+	return dir
+}
+
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(out)
+}
+
+func TestParseAction(t *testing.T) {
+	action, err := ParseAction("")
+	require.NoError(t, err)
+	assert.Equal(t, ActionBlock, action)
+
+	action, err = ParseAction("redact")
+	require.NoError(t, err)
+	assert.Equal(t, ActionRedact, action)
+
+	_, err = ParseAction("ignore")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown secrets action "ignore"`)
+}
+
+func TestGuard_Check(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("block", func(t *testing.T) {
+		dir := newStagedRepo(t)
+		commits := git.NewCommitManager(&dirExecutor{dir: dir})
+		guard := NewGuard(nil, ActionBlock, dir)
+
+		err := guard.Check(ctx, commits)
+		require.Error(t, err)
+		assert.True(t, IsScanError(err))
+		assert.Contains(t, err.Error(), ".env:1 (aws-access-key)")
+		require.Len(t, guard.Findings(), 1)
+		assert.Contains(t, gitRun(t, dir, "diff", "--cached", "--name-only"), ".env")
+	})
+
+	t.Run("unstage", func(t *testing.T) {
+		dir := newStagedRepo(t)
+		commits := git.NewCommitManager(&dirExecutor{dir: dir})
+
+		require.NoError(t, NewGuard(nil, ActionUnstage, dir).Check(ctx, commits))
+		assert.Equal(t, "README.md\n", gitRun(t, dir, "diff", "--cached", "--name-only"))
+		assert.FileExists(t, filepath.Join(dir, ".env"))
+	})
+
+	t.Run("redact", func(t *testing.T) {
+		dir := newStagedRepo(t)
+		commits := git.NewCommitManager(&dirExecutor{dir: dir})
+
+		require.NoError(t, NewGuard(nil, ActionRedact, dir).Check(ctx, commits))
+		staged := gitRun(t, dir, "show", ":.env")
+		assert.Equal(t, "AWS_ACCESS_KEY_ID=REDACTED\n", staged)
+		info, err := os.Stat(filepath.Join(dir, ".env"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("clean changes pass", func(t *testing.T) {
+		dir := newStagedRepo(t)
+		commits := git.NewCommitManager(&dirExecutor{dir: dir})
+		guard := NewGuard(NewScanner(&Options{Allowlist: NewAllowlist(Fingerprint(".env", "aws-access-key", awsKey))}), ActionBlock, dir)
+
+		require.NoError(t, guard.Check(ctx, commits))
+		assert.Empty(t, guard.Findings())
+	})
+
+	t.Run("blocks CommitAndPush before committing", func(t *testing.T) {
+		dir := newStagedRepo(t)
+		guard := NewGuard(nil, ActionBlock, dir)
+		commits := git.NewCommitManagerWithCheck(&dirExecutor{dir: dir}, guard.Check)
+
+		err := commits.CommitAndPush(ctx, "leak")
+		require.Error(t, err)
+		assert.True(t, IsScanError(err))
+		assert.Equal(t, "1", strings.TrimSpace(gitRun(t, dir, "rev-list", "--count", "HEAD")))
+	})
+}
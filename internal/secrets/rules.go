@@ -0,0 +1,115 @@
+// Package secrets detects credentials in diffs before they are committed.
+package secrets
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// Rule is a secret detector.
+// When Pattern has a capture group, the first group is the secret; otherwise the whole match is.
+type Rule struct {
+	ID      string
+	Pattern *regexp.Regexp
+	// MinEntropy, when positive, is the minimum Shannon entropy in bits per character
+	// the secret must reach. Such secrets must also mix letters and digits.
+	MinEntropy float64
+}
+
+// DefaultRules are the built-in detectors: common key formats, private key headers,
+// credential assignments and high-entropy strings.
+var DefaultRules = []Rule{
+	{ID: "private-key", Pattern: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY(?: BLOCK)?-----`)},
+	{ID: "aws-access-key", Pattern: regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{ID: "github-token", Pattern: regexp.MustCompile(`\b((?:gh[pousr]_[A-Za-z0-9]{36,})|(?:github_pat_[A-Za-z0-9_]{60,}))\b`)},
+	{ID: "gitlab-token", Pattern: regexp.MustCompile(`\b(glpat-[A-Za-z0-9_-]{20,})\b`)},
+	{ID: "slack-token", Pattern: regexp.MustCompile(`\b(xox[abprs]-[A-Za-z0-9-]{10,})\b`)},
+	{ID: "stripe-key", Pattern: regexp.MustCompile(`\b((?:sk|rk)_live_[A-Za-z0-9]{20,})\b`)},
+	{ID: "google-api-key", Pattern: regexp.MustCompile(`\b(AIza[0-9A-Za-z_-]{35})\b`)},
+	{ID: "anthropic-api-key", Pattern: regexp.MustCompile(`\b(sk-ant-[A-Za-z0-9_-]{20,})`)},
+	{ID: "openai-api-key", Pattern: regexp.MustCompile(`\b(sk-(?:proj-)?[A-Za-z0-9_-]{20,}T3BlbkFJ[A-Za-z0-9_-]{20,})`)},
+	{ID: "jwt", Pattern: regexp.MustCompile(`\b(eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,})`)},
+	{
+		ID:         "credential-assignment",
+		Pattern:    regexp.MustCompile(`(?i)(?:api[_-]?key|secret|token|passw(?:or)?d|credentials?)[\w-]*["']?\s*[:=]\s*["']([^"'\s]{8,})["']`),
+		MinEntropy: 3.0,
+	},
+	{ID: "high-entropy-string", Pattern: regexp.MustCompile(`[A-Za-z0-9+/_=-]{32,}`), MinEntropy: 4.3},
+}
+
+// ParseRule parses a custom rule given as "id=regex" or a bare regex.
+// Bare regexes get the ID "custom-<n>", where n is the rule's 1-based position.
+func ParseRule(spec string, n int) (Rule, error) {
+	id, expr := fmt.Sprintf("custom-%d", n), spec
+	if i := strings.Index(spec, "="); i > 0 && isRuleID(spec[:i]) {
+		id, expr = spec[:i], spec[i+1:]
+	}
+	if expr == "" {
+		return Rule{}, &ScanError{Message: fmt.Sprintf("secret pattern %q is empty", id)}
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return Rule{}, &ScanError{Message: fmt.Sprintf("invalid secret pattern %q", id), Err: err}
+	}
+	return Rule{ID: id, Pattern: re}, nil
+}
+
+// isRuleID reports whether s is a plain identifier such as "internal-token".
+func isRuleID(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// secret returns the secret in a match.
+func (r Rule) secret(match []string) string {
+	if len(match) > 1 && match[1] != "" {
+		return match[1]
+	}
+	return match[0]
+}
+
+// accepts applies the rule's entropy requirement to a candidate secret.
+func (r Rule) accepts(secret string) bool {
+	if r.MinEntropy <= 0 {
+		return true
+	}
+	return hasLetterAndDigit(secret) && Entropy(secret) >= r.MinEntropy
+}
+
+// Entropy returns the Shannon entropy of s in bits per character.
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+func hasLetterAndDigit(s string) bool {
+	var letter, digit bool
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digit = true
+		case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+			letter = true
+		}
+	}
+	return letter && digit
+}
@@ -0,0 +1,175 @@
+package secrets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Finding is a possible secret in a diff.
+type Finding struct {
+	File        string `json:"file"`
+	Line        int    `json:"line"` // Line in the new version of File (0 if unknown)
+	Rule        string `json:"rule"`
+	Redacted    string `json:"redacted"`    // The secret with all but its first characters masked
+	Fingerprint string `json:"fingerprint"` // Stable ID for allowlisting
+	Secret      string `json:"-"`           // The matched secret; never serialized
+}
+
+// String formats the finding for display.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s %s (fingerprint %s)", f.File, f.Line, f.Rule, f.Redacted, f.Fingerprint)
+}
+
+// Options configures a Scanner.
+type Options struct {
+	// Rules are added to DefaultRules.
+	Rules []Rule
+
+	// Allowlist suppresses known findings (nil = none).
+	Allowlist *Allowlist
+
+	// SkipFiles are base names never scanned, such as lock files full of hashes.
+	// nil means DefaultSkipFiles.
+	SkipFiles []string
+}
+
+// DefaultSkipFiles are dependency lock files whose checksums look like secrets.
+var DefaultSkipFiles = []string{
+	"go.sum", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "Cargo.lock",
+	"poetry.lock", "Pipfile.lock", "composer.lock", "Gemfile.lock",
+}
+
+// Scanner finds secrets in unified diffs.
+type Scanner struct {
+	rules     []Rule
+	allowlist *Allowlist
+	skip      map[string]bool
+}
+
+// NewScanner creates a Scanner. If opts is nil, the default rules are used.
+func NewScanner(opts *Options) *Scanner {
+	if opts == nil {
+		opts = &Options{}
+	}
+	skipFiles := opts.SkipFiles
+	if skipFiles == nil {
+		skipFiles = DefaultSkipFiles
+	}
+	s := &Scanner{
+		rules:     append(append([]Rule{}, DefaultRules...), opts.Rules...),
+		allowlist: opts.Allowlist,
+		skip:      make(map[string]bool),
+	}
+	for _, name := range skipFiles {
+		s.skip[name] = true
+	}
+	return s
+}
+
+// ScanDiff returns the secrets in the lines a unified diff adds, in diff order.
+// Allowlisted findings and skipped files are left out.
+func (s *Scanner) ScanDiff(diff string) []Finding {
+	var findings []Finding
+	file, line, prev := "", 0, ""
+	for _, text := range strings.Split(diff, "\n") {
+		header := strings.HasPrefix(prev, "--- ")
+		prev = text
+		switch {
+		case header && strings.HasPrefix(text, "+++ "):
+			file = diffPath(strings.TrimPrefix(text, "+++ "))
+		case strings.HasPrefix(text, "@@ "):
+			line = hunkStart(text)
+		case strings.HasPrefix(text, "+"):
+			if file != "" && !s.skip[path.Base(file)] {
+				findings = append(findings, s.ScanLine(file, line, text[1:])...)
+			}
+			line++
+		case strings.HasPrefix(text, " "):
+			line++
+		}
+	}
+	return findings
+}
+
+// ScanLine returns the secrets in one line of file.
+// A line matched by several rules is reported once per distinct secret.
+func (s *Scanner) ScanLine(file string, line int, text string) []Finding {
+	var findings []Finding
+	seen := make(map[string]bool)
+	for _, rule := range s.rules {
+		for _, match := range rule.Pattern.FindAllStringSubmatch(text, -1) {
+			secret := rule.secret(match)
+			if !rule.accepts(secret) || overlapsSeen(secret, seen) {
+				continue
+			}
+			seen[secret] = true
+			f := Finding{
+				File:        file,
+				Line:        line,
+				Rule:        rule.ID,
+				Redacted:    redact(secret),
+				Fingerprint: Fingerprint(file, rule.ID, secret),
+				Secret:      secret,
+			}
+			if !s.allowlist.Allows(f.Fingerprint) {
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings
+}
+
+// Fingerprint identifies a secret in a file independently of its line number.
+func Fingerprint(file, rule, secret string) string {
+	sum := sha256.Sum256([]byte(file + "\x00" + rule + "\x00" + secret))
+	return hex.EncodeToString(sum[:8])
+}
+
+// overlapsSeen reports whether secret is part of, or contains, a secret already found on the line,
+// so the generic rules do not repeat what a specific rule reported.
+func overlapsSeen(secret string, seen map[string]bool) bool {
+	for s := range seen {
+		if strings.Contains(s, secret) || strings.Contains(secret, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact keeps the first four characters of a secret and masks the rest.
+func redact(secret string) string {
+	const keep = 4
+	if len(secret) <= keep*2 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:keep] + strings.Repeat("*", len(secret)-keep)
+}
+
+// diffPath extracts the path from a "+++" header; deleted files yield "".
+func diffPath(header string) string {
+	header = strings.TrimRight(header, "\t")
+	if strings.HasPrefix(header, `"`) {
+		if unquoted, err := strconv.Unquote(header); err == nil {
+			header = unquoted
+		}
+	}
+	if header == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(header, "b/")
+}
+
+// hunkStart returns the first new-file line number of a "@@ -a,b +c,d @@" header.
+func hunkStart(header string) int {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return 0
+	}
+	start := strings.SplitN(strings.TrimPrefix(fields[2], "+"), ",", 2)[0]
+	n, _ := strconv.Atoi(start)
+	return n
+}
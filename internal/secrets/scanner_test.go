@@ -0,0 +1,95 @@
+package secrets
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var awsKey = "AKIA" + "IOSFODNN7EXAMPLE"
+
+func sampleDiff() string {
+	return "diff --git a/config/app.env b/config/app.env\n" +
+		"new file mode 100644\n" +
+		"--- /dev/null\n" +
+		"+++ b/config/app.env\n" +
+		"@@ -0,0 +1,3 @@\n" +
+		"+DEBUG=true\n" +
+		"+AWS_ACCESS_KEY_ID=" + awsKey + "\n" +
+		"+REGION=eu-west-1\n" +
+		"diff --git a/main.go b/main.go\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -10 +10,2 @@ func main() {\n" +
+		"-\tkey := \"\"\n" +
+		"+\tkey := \"" + awsKey + "\"\n" +
+		"+\t// ++ not a header\n" +
+		"diff --git a/go.sum b/go.sum\n" +
+		"--- a/go.sum\n" +
+		"+++ b/go.sum\n" +
+		"@@ -1 +1 @@\n" +
+		"+example.com/mod v1.0.0 h1:Zk9xQ2p3UjdMbVh2TjFiWTRrQThzRDZ1bm9wcXJzdA=\n" +
+		"diff --git a/old.env b/old.env\n" +
+		"--- a/old.env\n" +
+		"+++ /dev/null\n" +
+		"@@ -1 +0,0 @@\n" +
+		"-AWS_ACCESS_KEY_ID=" + awsKey + "\n"
+}
+
+func TestScanner_ScanDiff(t *testing.T) {
+	findings := NewScanner(nil).ScanDiff(sampleDiff())
+
+	require.Len(t, findings, 2)
+	assert.Equal(t, "config/app.env", findings[0].File)
+	assert.Equal(t, 2, findings[0].Line)
+	assert.Equal(t, "aws-access-key", findings[0].Rule)
+	assert.Equal(t, "AKIA****************", findings[0].Redacted)
+	assert.Equal(t, awsKey, findings[0].Secret)
+	assert.Equal(t, Fingerprint("config/app.env", "aws-access-key", awsKey), findings[0].Fingerprint)
+
+	assert.Equal(t, "main.go", findings[1].File)
+	assert.Equal(t, 10, findings[1].Line)
+	assert.NotEqual(t, findings[0].Fingerprint, findings[1].Fingerprint, "fingerprints include the file")
+}
+
+func TestScanner_Options(t *testing.T) {
+	t.Run("allowlist suppresses findings", func(t *testing.T) {
+		allow := NewAllowlist(Fingerprint("main.go", "aws-access-key", awsKey))
+		findings := NewScanner(&Options{Allowlist: allow}).ScanDiff(sampleDiff())
+		require.Len(t, findings, 1)
+		assert.Equal(t, "config/app.env", findings[0].File)
+	})
+
+	t.Run("custom rules", func(t *testing.T) {
+		rule := Rule{ID: "region", Pattern: regexp.MustCompile(`REGION=(\S+)`)}
+		findings := NewScanner(&Options{Rules: []Rule{rule}}).ScanDiff(sampleDiff())
+		require.Len(t, findings, 3)
+		assert.Equal(t, "region", findings[1].Rule)
+		assert.Equal(t, "eu-w*****", findings[1].Redacted)
+	})
+
+	t.Run("empty skip list scans lock files", func(t *testing.T) {
+		findings := NewScanner(&Options{SkipFiles: []string{}}).ScanDiff(sampleDiff())
+		require.Len(t, findings, 3)
+		assert.Equal(t, "go.sum", findings[2].File)
+		assert.Equal(t, "high-entropy-string", findings[2].Rule)
+	})
+}
+
+func TestScanner_ScanLine_ReportsEachSecretOnce(t *testing.T) {
+	// The key matches aws-access-key; the generic rules must not report it again
+	findings := NewScanner(nil).ScanLine("a.py", 3, `api_key = "`+awsKey+`"`)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "aws-access-key", findings[0].Rule)
+	assert.Equal(t, "a.py:3: aws-access-key AKIA**************** (fingerprint "+findings[0].Fingerprint+")", findings[0].String())
+}
+
+func TestDiffHelpers(t *testing.T) {
+	assert.Equal(t, "dir/a b.txt", diffPath(`"b/dir/a b.txt"`))
+	assert.Equal(t, "", diffPath("/dev/null"))
+	assert.Equal(t, 12, hunkStart("@@ -10,2 +12,3 @@ func x()"))
+	assert.Equal(t, 0, hunkStart("@@ broken"))
+	assert.Equal(t, "****", redact("abcd"))
+}
@@ -0,0 +1,48 @@
+package verifier
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// makeTargetPattern matches the build and test targets of a Makefile.
+var makeTargetPattern = regexp.MustCompile(`(?m)^(build|test)\s*:`)
+
+// DetectCriteria returns the build and test criteria for the project in dir:
+// "go build" and "go test" for a Go module, "npm run build" and "npm test" for
+// the scripts a package.json defines, or "make build" and "make test" for the
+// targets a Makefile defines. Returns nil when the project type is not recognized.
+func DetectCriteria(dir string) []string {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+		return []string{"go build", "go test"}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var pkg struct {
+			Scripts map[string]string `json:"scripts"`
+		}
+		if json.Unmarshal(data, &pkg) == nil {
+			var criteria []string
+			if pkg.Scripts["build"] != "" {
+				criteria = append(criteria, "npm run build")
+			}
+			if pkg.Scripts["test"] != "" {
+				criteria = append(criteria, "npm test")
+			}
+			if len(criteria) > 0 {
+				return criteria
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "Makefile")); err == nil {
+		var criteria []string
+		for _, match := range makeTargetPattern.FindAllStringSubmatch(string(data), -1) {
+			criteria = append(criteria, "make "+match[1])
+		}
+		return criteria
+	}
+	return nil
+}
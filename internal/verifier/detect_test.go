@@ -0,0 +1,54 @@
+package verifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectCriteria(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "go module",
+			files: map[string]string{"go.mod": "module example.com/app\n", "Makefile": "test:\n\tgo test ./...\n"},
+			want:  []string{"go build", "go test"},
+		},
+		{
+			name:  "package.json scripts",
+			files: map[string]string{"package.json": `{"scripts": {"test": "jest"}}`},
+			want:  []string{"npm test"},
+		},
+		{
+			name:  "package.json without scripts falls back to the Makefile",
+			files: map[string]string{"package.json": `{"name": "app"}`, "Makefile": "build:\n\tcc main.c\n"},
+			want:  []string{"make build"},
+		},
+		{
+			name:  "Makefile targets",
+			files: map[string]string{"Makefile": "build: deps\n\tcc main.c\n\ntest :\n\t./run-tests\n\nlint:\n"},
+			want:  []string{"make build", "make test"},
+		},
+		{
+			name:  "unknown project",
+			files: map[string]string{"README.md": "# app\n"},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+			}
+			assert.Equal(t, tt.want, DetectCriteria(dir))
+		})
+	}
+}
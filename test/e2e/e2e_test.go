@@ -75,11 +75,20 @@ func buildBinary(t *testing.T) string {
 	return cachedBinary
 }
 
+// command runs the binary in a fresh temporary directory, so the run history and
+// reports it writes do not end up in the source tree.
+func command(t *testing.T, binPath string, args ...string) *exec.Cmd {
+	t.Helper()
+	cmd := exec.Command(binPath, args...)
+	cmd.Dir = t.TempDir()
+	return cmd
+}
+
 func TestE2E_HelpOutput(t *testing.T) {
 	skipIfShort(t)
 	binPath := buildBinary(t)
 
-	output, err := exec.Command(binPath, "--help").Output()
+	output, err := command(t, binPath, "--help").Output()
 	if err != nil {
 		t.Fatalf("--help failed: %v", err)
 	}
@@ -107,7 +116,7 @@ func TestE2E_VersionOutput(t *testing.T) {
 	skipIfShort(t)
 	binPath := buildBinary(t)
 
-	output, err := exec.Command(binPath, "--version").Output()
+	output, err := command(t, binPath, "--version").Output()
 	if err != nil {
 		t.Fatalf("--version failed: %v", err)
 	}
@@ -134,7 +143,7 @@ func TestE2E_ValidationErrors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			output, err := exec.Command(binPath, tt.args...).CombinedOutput()
+			output, err := command(t, binPath, tt.args...).CombinedOutput()
 			if err == nil {
 				t.Error("expected error but got success")
 				return
@@ -150,7 +159,7 @@ func TestE2E_DryRunMode(t *testing.T) {
 	skipIfShort(t)
 	binPath := buildBinary(t)
 
-	cmd := exec.Command(binPath,
+	cmd := command(t, binPath,
 		"-p", "Test prompt for dry run",
 		"-m", "3",
 		"--dry-run",
@@ -182,7 +191,7 @@ func TestE2E_ListWorktrees(t *testing.T) {
 	skipIfShort(t)
 	binPath := buildBinary(t)
 
-	output, err := exec.Command(binPath, "--list-worktrees").CombinedOutput()
+	output, err := command(t, binPath, "--list-worktrees").CombinedOutput()
 	outputStr := string(output)
 
 	// Should not require other flags
@@ -231,7 +240,7 @@ func TestE2E_FlagCombinations(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			output, err := exec.Command(binPath, tt.args...).CombinedOutput()
+			output, err := command(t, binPath, tt.args...).CombinedOutput()
 			if err != nil {
 				outputStr := string(output)
 				for _, ve := range validationErrors {
@@ -250,7 +259,7 @@ func TestE2E_MergeStrategies(t *testing.T) {
 
 	for _, strategy := range []string{"squash", "merge", "rebase"} {
 		t.Run(strategy, func(t *testing.T) {
-			output, err := exec.Command(binPath, "-p", "test", "-m", "1", "--merge-strategy", strategy, "--dry-run", "--disable-updates").CombinedOutput()
+			output, err := command(t, binPath, "-p", "test", "-m", "1", "--merge-strategy", strategy, "--dry-run", "--disable-updates").CombinedOutput()
 			if err != nil && strings.Contains(string(output), "merge-strategy must be") {
 				t.Errorf("valid merge strategy %q was rejected", strategy)
 			}
@@ -265,7 +274,7 @@ func TestE2E_DurationFormats(t *testing.T) {
 	// Note: -m 3 is used as a safety limit to prevent infinite loops in dry-run mode
 	for _, duration := range []string{"30s", "5m", "2h", "1h30m", "2h30m15s"} {
 		t.Run(duration, func(t *testing.T) {
-			output, err := exec.Command(binPath, "-p", "test", "--max-duration", duration, "-m", "3", "--dry-run", "--disable-updates").CombinedOutput()
+			output, err := command(t, binPath, "-p", "test", "--max-duration", duration, "-m", "3", "--dry-run", "--disable-updates").CombinedOutput()
 			if err != nil && strings.Contains(string(output), "invalid duration") {
 				t.Errorf("valid duration %q was rejected", duration)
 			}
@@ -287,7 +296,7 @@ func TestE2E_DryRunStopReasons(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			output, err := exec.Command(binPath, tt.args...).CombinedOutput()
+			output, err := command(t, binPath, tt.args...).CombinedOutput()
 			if err != nil {
 				t.Fatalf("unexpected error: %v\noutput: %s", err, output)
 			}